@@ -5,10 +5,13 @@ package admin
 
 import (
 	"context"
+	"net/netip"
+	"time"
 
 	"github.com/MetalBlockchain/metalgo/api"
 	"github.com/MetalBlockchain/metalgo/database/rpcdb"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/utils/formatting"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/rpc"
@@ -26,11 +29,19 @@ type Client interface {
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
 	Stacktrace(context.Context, ...rpc.Option) error
+	ReloadAPITokens(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
 	DBGet(ctx context.Context, key []byte, options ...rpc.Option) ([]byte, error)
+	AddPeer(ctx context.Context, nodeID ids.NodeID, ip netip.AddrPort, options ...rpc.Option) error
+	RemovePeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error
+	BanNode(ctx context.Context, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error
+	ListBans(ctx context.Context, options ...rpc.Option) ([]peer.Ban, error)
+	StopChain(ctx context.Context, chain string, options ...rpc.Option) error
+	RestartChain(ctx context.Context, chain string, options ...rpc.Option) error
+	ResyncChain(ctx context.Context, chain string, options ...rpc.Option) error
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -87,6 +98,10 @@ func (c *client) Stacktrace(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "admin.stacktrace", struct{}{}, &api.EmptyReply{}, options...)
 }
 
+func (c *client) ReloadAPITokens(ctx context.Context, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.reloadAPITokens", struct{}{}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) LoadVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error) {
 	res := &LoadVMsReply{}
 	err := c.requester.SendRequest(ctx, "admin.loadVMs", struct{}{}, res, options...)
@@ -163,3 +178,47 @@ func (c *client) DBGet(ctx context.Context, key []byte, options ...rpc.Option) (
 	}
 	return formatting.Decode(formatting.HexNC, res.Value)
 }
+
+func (c *client) AddPeer(ctx context.Context, nodeID ids.NodeID, ip netip.AddrPort, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.addPeer", &AddPeerArgs{
+		NodeID: nodeID,
+		IP:     ip.String(),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) RemovePeer(ctx context.Context, nodeID ids.NodeID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.removePeer", &RemovePeerArgs{
+		NodeID: nodeID,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) BanNode(ctx context.Context, nodeID ids.NodeID, duration time.Duration, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.banNode", &BanNodeArgs{
+		NodeID:   nodeID,
+		Duration: duration.String(),
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ListBans(ctx context.Context, options ...rpc.Option) ([]peer.Ban, error) {
+	res := &ListBansReply{}
+	err := c.requester.SendRequest(ctx, "admin.listBans", struct{}{}, res, options...)
+	return res.Bans, err
+}
+
+func (c *client) StopChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.stopChain", &ChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) RestartChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.restartChain", &ChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) ResyncChain(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "admin.resyncChain", &ChainArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
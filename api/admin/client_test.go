@@ -6,12 +6,15 @@ package admin
 import (
 	"context"
 	"errors"
+	"net/netip"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/api"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/rpc"
 )
@@ -65,6 +68,9 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *LoggerLevelReply:
 		response := mc.response.(*LoggerLevelReply)
 		*p = *response
+	case *ListBansReply:
+		response := mc.response.(*ListBansReply)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -165,6 +171,16 @@ func TestStacktrace(t *testing.T) {
 	}
 }
 
+func TestReloadAPITokens(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.ReloadAPITokens(context.Background())
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
 func TestReloadInstalledVMs(t *testing.T) {
 	t.Run("successful", func(t *testing.T) {
 		require := require.New(t)
@@ -356,3 +372,80 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestAddPeer(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.AddPeer(context.Background(), ids.GenerateTestNodeID(), netip.MustParseAddrPort("127.0.0.1:9651"))
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRemovePeer(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.RemovePeer(context.Background(), ids.GenerateTestNodeID())
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestBanNode(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.BanNode(context.Background(), ids.GenerateTestNodeID(), time.Hour)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestListBans(t *testing.T) {
+	require := require.New(t)
+
+	expectedBans := []peer.Ban{
+		{
+			NodeID: ids.GenerateTestNodeID(),
+			Expiry: time.Unix(1, 0),
+		},
+	}
+	mockClient := client{requester: NewMockClient(&ListBansReply{
+		Bans: expectedBans,
+	}, nil)}
+	bans, err := mockClient.ListBans(context.Background())
+	require.NoError(err)
+	require.Equal(expectedBans, bans)
+}
+
+func TestStopChain(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.StopChain(context.Background(), "chain")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRestartChain(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.RestartChain(context.Background(), "chain")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestResyncChain(t *testing.T) {
+	for _, test := range SuccessResponseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.expectedErr)}
+			err := mockClient.ResyncChain(context.Background(), "chain")
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -5,9 +5,12 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"net/netip"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -18,6 +21,8 @@ import (
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/rpcdb"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/formatting"
@@ -41,6 +46,9 @@ const (
 var (
 	errAliasTooLong = errors.New("alias length is too long")
 	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+
+	errAuthorizationDisabled = errors.New("API authorization is disabled")
+	errInvalidBanDuration    = errors.New("invalid ban duration")
 )
 
 type Config struct {
@@ -51,8 +59,10 @@ type Config struct {
 	DB           database.Database
 	ChainManager chains.Manager
 	HTTPServer   server.PathAdderWithReadLock
+	Authorizer   server.Authorizer
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	Network      network.Network
 }
 
 // Admin is the API service for node admin management
@@ -229,6 +239,19 @@ func (a *Admin) Stacktrace(_ *http.Request, _ *struct{}, _ *api.EmptyReply) erro
 	return perms.WriteFile(stacktraceFile, stacktrace, perms.ReadWrite)
 }
 
+// ReloadAPITokens reloads the API authorization config from disk
+func (a *Admin) ReloadAPITokens(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "reloadAPITokens"),
+	)
+
+	if a.Authorizer == nil {
+		return errAuthorizationDisabled
+	}
+	return a.Authorizer.Reload()
+}
+
 type SetLoggerLevelArgs struct {
 	LoggerName   string         `json:"loggerName"`
 	LogLevel     *logging.Level `json:"logLevel"`
@@ -413,3 +436,142 @@ func (a *Admin) DbGet(_ *http.Request, args *DBGetArgs, reply *DBGetReply) error
 	reply.Value, err = formatting.Encode(formatting.HexNC, value)
 	return err
 }
+
+// AddPeerArgs are the arguments for calling AddPeer
+type AddPeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	IP     string     `json:"ip"`
+}
+
+// AddPeer pins a peer. The node will persistently attempt to connect to the
+// peer at the provided IP.
+func (a *Admin) AddPeer(_ *http.Request, args *AddPeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "addPeer"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("ip", args.IP),
+	)
+
+	ip, err := netip.ParseAddrPort(args.IP)
+	if err != nil {
+		return err
+	}
+
+	a.Network.ManuallyTrack(args.NodeID, ip)
+	return nil
+}
+
+// RemovePeerArgs are the arguments for calling RemovePeer
+type RemovePeerArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+}
+
+// RemovePeer unpins a peer and closes any connection to it. If the peer is a
+// validator, the node may reconnect to it.
+func (a *Admin) RemovePeer(_ *http.Request, args *RemovePeerArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "removePeer"),
+		zap.Stringer("nodeID", args.NodeID),
+	)
+
+	a.Network.StopManuallyTracking(args.NodeID)
+	a.Network.Disconnect(args.NodeID)
+	return nil
+}
+
+// BanNodeArgs are the arguments for calling BanNode
+type BanNodeArgs struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Duration is formatted as a Go duration string, such as "24h".
+	Duration string `json:"duration"`
+}
+
+// BanNode disconnects from a node and rejects connections with it until the
+// ban expires. Bans are persisted across restarts.
+func (a *Admin) BanNode(_ *http.Request, args *BanNodeArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "banNode"),
+		zap.Stringer("nodeID", args.NodeID),
+		logging.UserString("duration", args.Duration),
+	)
+
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidBanDuration, err)
+	}
+	return a.Network.Ban(args.NodeID, duration)
+}
+
+// ListBansReply are the bans that have not yet expired
+type ListBansReply struct {
+	Bans []peer.Ban `json:"bans"`
+}
+
+// ListBans returns the bans that have not yet expired
+func (a *Admin) ListBans(_ *http.Request, _ *struct{}, reply *ListBansReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "listBans"),
+	)
+
+	reply.Bans = a.Network.Bans()
+	return nil
+}
+
+// ChainArgs are the arguments for calling StopChain, RestartChain and
+// ResyncChain
+type ChainArgs struct {
+	Chain string `json:"chain"`
+}
+
+// StopChain shuts down a chain until it is restarted. Chains validated by the
+// primary network can't be stopped.
+func (a *Admin) StopChain(r *http.Request, args *ChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "stopChain"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.StopChain(r.Context(), chainID)
+}
+
+// RestartChain stops a chain, if it is running, and starts it again. Chains
+// validated by the primary network can't be restarted.
+func (a *Admin) RestartChain(r *http.Request, args *ChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "restartChain"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.RestartChain(r.Context(), chainID)
+}
+
+// ResyncChain stops a chain, if it is running, deletes its state and starts it
+// again, bootstrapping it from scratch. Chains validated by the primary network
+// can't be re-synced.
+func (a *Admin) ResyncChain(r *http.Request, args *ChainArgs, _ *api.EmptyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "resyncChain"),
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := a.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+	return a.ChainManager.ResyncChain(r.Context(), chainID)
+}
@@ -4,14 +4,21 @@
 package admin
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"net/netip"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/MetalBlockchain/metalgo/chains"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/utils/formatting"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/vms/registry/registrymock"
@@ -20,6 +27,8 @@ import (
 	rpcdbpb "github.com/MetalBlockchain/metalgo/proto/pb/rpcdb"
 )
 
+var errUnknownAlias = errors.New("unknown alias")
+
 type loadVMsTest struct {
 	admin          *Admin
 	ctrl           *gomock.Controller
@@ -167,3 +176,210 @@ func TestServiceDBGet(t *testing.T) {
 		})
 	}
 }
+
+func TestReloadAPITokensDisabled(t *testing.T) {
+	admin := &Admin{Config: Config{
+		Log: logging.NoLog{},
+	}}
+
+	err := admin.ReloadAPITokens(&http.Request{}, nil, nil)
+	require.ErrorIs(t, err, errAuthorizationDisabled)
+}
+
+// testNetwork implements the peer management methods of network.Network on
+// top of a real ban list.
+type testNetwork struct {
+	network.Network
+
+	banList      peer.BanList
+	tracked      map[ids.NodeID]netip.AddrPort
+	disconnected []ids.NodeID
+}
+
+func newTestNetwork(t *testing.T) *testNetwork {
+	banList, err := peer.NewBanList(memdb.New())
+	require.NoError(t, err)
+	return &testNetwork{
+		banList: banList,
+		tracked: make(map[ids.NodeID]netip.AddrPort),
+	}
+}
+
+func (n *testNetwork) ManuallyTrack(nodeID ids.NodeID, ip netip.AddrPort) {
+	n.tracked[nodeID] = ip
+}
+
+func (n *testNetwork) StopManuallyTracking(nodeID ids.NodeID) {
+	delete(n.tracked, nodeID)
+}
+
+func (n *testNetwork) Disconnect(nodeID ids.NodeID) {
+	n.disconnected = append(n.disconnected, nodeID)
+}
+
+func (n *testNetwork) Ban(nodeID ids.NodeID, duration time.Duration) error {
+	if err := n.banList.Ban(nodeID, duration); err != nil {
+		return err
+	}
+	n.Disconnect(nodeID)
+	return nil
+}
+
+func (n *testNetwork) Bans() []peer.Ban {
+	return n.banList.Bans()
+}
+
+func TestServiceAddRemovePeer(t *testing.T) {
+	require := require.New(t)
+
+	network := newTestNetwork(t)
+	admin := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		Network: network,
+	}}
+
+	nodeID := ids.GenerateTestNodeID()
+	require.Error(admin.AddPeer(&http.Request{}, &AddPeerArgs{ //nolint:forbidigo // error is from netip
+		NodeID: nodeID,
+		IP:     "not an ip",
+	}, nil))
+	require.Empty(network.tracked)
+
+	require.NoError(admin.AddPeer(&http.Request{}, &AddPeerArgs{
+		NodeID: nodeID,
+		IP:     "127.0.0.1:9651",
+	}, nil))
+	require.Equal(
+		map[ids.NodeID]netip.AddrPort{
+			nodeID: netip.MustParseAddrPort("127.0.0.1:9651"),
+		},
+		network.tracked,
+	)
+
+	require.NoError(admin.RemovePeer(&http.Request{}, &RemovePeerArgs{
+		NodeID: nodeID,
+	}, nil))
+	require.Empty(network.tracked)
+	require.Equal([]ids.NodeID{nodeID}, network.disconnected)
+}
+
+func TestServiceBanNode(t *testing.T) {
+	tests := []struct {
+		name        string
+		duration    string
+		expectedErr error
+	}{
+		{
+			name:        "valid duration",
+			duration:    "1h",
+			expectedErr: nil,
+		},
+		{
+			name:        "unparsable duration",
+			duration:    "forever",
+			expectedErr: errInvalidBanDuration,
+		},
+		{
+			name:        "non-positive duration",
+			duration:    "-1h",
+			expectedErr: peer.ErrNonPositiveBanDuration,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			network := newTestNetwork(t)
+			admin := &Admin{Config: Config{
+				Log:     logging.NoLog{},
+				Network: network,
+			}}
+
+			nodeID := ids.GenerateTestNodeID()
+			err := admin.BanNode(&http.Request{}, &BanNodeArgs{
+				NodeID:   nodeID,
+				Duration: test.duration,
+			}, nil)
+			require.ErrorIs(err, test.expectedErr)
+
+			reply := ListBansReply{}
+			require.NoError(admin.ListBans(&http.Request{}, nil, &reply))
+			if test.expectedErr != nil {
+				require.Empty(reply.Bans)
+				return
+			}
+			require.Len(reply.Bans, 1)
+			require.Equal(nodeID, reply.Bans[0].NodeID)
+			require.Equal([]ids.NodeID{nodeID}, network.disconnected)
+		})
+	}
+}
+
+// testChainManager records the chains that were stopped, restarted and
+// re-synced.
+type testChainManager struct {
+	chains.Manager
+
+	aliases   map[string]ids.ID
+	stopped   []ids.ID
+	restarted []ids.ID
+	resynced  []ids.ID
+}
+
+func (m *testChainManager) Lookup(alias string) (ids.ID, error) {
+	chainID, ok := m.aliases[alias]
+	if !ok {
+		return ids.Empty, errUnknownAlias
+	}
+	return chainID, nil
+}
+
+func (m *testChainManager) StopChain(_ context.Context, chainID ids.ID) error {
+	m.stopped = append(m.stopped, chainID)
+	return nil
+}
+
+func (m *testChainManager) RestartChain(_ context.Context, chainID ids.ID) error {
+	m.restarted = append(m.restarted, chainID)
+	return nil
+}
+
+func (m *testChainManager) ResyncChain(_ context.Context, chainID ids.ID) error {
+	m.resynced = append(m.resynced, chainID)
+	return nil
+}
+
+func TestServiceChainLifecycle(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	chainManager := &testChainManager{
+		aliases: map[string]ids.ID{
+			"chain": chainID,
+		},
+	}
+	admin := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chainManager,
+	}}
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/", nil)
+	require.NoError(err)
+
+	require.NoError(admin.StopChain(request, &ChainArgs{Chain: "chain"}, nil))
+	require.NoError(admin.RestartChain(request, &ChainArgs{Chain: "chain"}, nil))
+	require.NoError(admin.ResyncChain(request, &ChainArgs{Chain: "chain"}, nil))
+	require.Equal([]ids.ID{chainID}, chainManager.stopped)
+	require.Equal([]ids.ID{chainID}, chainManager.restarted)
+	require.Equal([]ids.ID{chainID}, chainManager.resynced)
+
+	err = admin.StopChain(request, &ChainArgs{Chain: "unknown"}, nil)
+	require.ErrorIs(err, errUnknownAlias)
+	err = admin.RestartChain(request, &ChainArgs{Chain: "unknown"}, nil)
+	require.ErrorIs(err, errUnknownAlias)
+	err = admin.ResyncChain(request, &ChainArgs{Chain: "unknown"}, nil)
+	require.ErrorIs(err, errUnknownAlias)
+	require.Len(chainManager.stopped, 1)
+	require.Len(chainManager.restarted, 1)
+	require.Len(chainManager.resynced, 1)
+}
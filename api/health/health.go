@@ -65,7 +65,11 @@ type health struct {
 	liveness  *worker
 }
 
-func New(log logging.Logger, registerer prometheus.Registerer) (Health, error) {
+func New(log logging.Logger, registerer prometheus.Registerer, config Config) (Health, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
 	failingChecks := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "checks_failing",
@@ -75,9 +79,9 @@ func New(log logging.Logger, registerer prometheus.Registerer) (Health, error) {
 	)
 	return &health{
 		log:       log,
-		readiness: newWorker(log, "readiness", failingChecks),
-		health:    newWorker(log, "health", failingChecks),
-		liveness:  newWorker(log, "liveness", failingChecks),
+		readiness: newWorker(log, "readiness", config, failingChecks),
+		health:    newWorker(log, "health", config, failingChecks),
+		liveness:  newWorker(log, "liveness", config, failingChecks),
 	}, registerer.Register(failingChecks)
 }
 
@@ -54,7 +54,7 @@ func TestDuplicatedRegistations(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	require.NoError(h.RegisterReadinessCheck("check", check))
@@ -77,7 +77,7 @@ func TestDefaultFailing(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	{
@@ -118,7 +118,7 @@ func TestPassingChecks(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	require.NoError(h.RegisterReadinessCheck("check", check))
@@ -182,7 +182,7 @@ func TestPassingThenFailingChecks(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	require.NoError(h.RegisterReadinessCheck("check", check))
@@ -229,7 +229,7 @@ func TestPassingThenFailingChecks(t *testing.T) {
 func TestDeadlockRegression(t *testing.T) {
 	require := require.New(t)
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 
 	var lock sync.Mutex
@@ -259,7 +259,7 @@ func TestTags(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), DefaultConfig)
 	require.NoError(err)
 	require.NoError(h.RegisterHealthCheck("check1", check))
 	require.NoError(h.RegisterHealthCheck("check2", check, "tag1"))
@@ -390,3 +390,48 @@ func TestTags(t *testing.T) {
 		require.False(health)
 	}
 }
+
+func TestFlappingCheck(t *testing.T) {
+	require := require.New(t)
+
+	var shouldFail utils.Atomic[bool]
+	check := CheckerFunc(func(context.Context) (interface{}, error) {
+		// Alternate between failing and passing on every run.
+		failing := !shouldFail.Get()
+		shouldFail.Set(failing)
+		if failing {
+			return nil, errUnhealthy
+		}
+		return nil, nil
+	})
+
+	h, err := New(logging.NoLog{}, prometheus.NewRegistry(), Config{
+		HistorySize:   4,
+		FlapWindow:    time.Hour,
+		FlapThreshold: 2,
+	})
+	require.NoError(err)
+
+	require.NoError(h.RegisterHealthCheck("check", check))
+
+	h.Start(context.Background(), checkFreq)
+	defer h.Stop()
+
+	require.Eventually(func() bool {
+		results, _ := h.Health()
+		return results["check"].Flapping
+	}, awaitTimeout, awaitFreq)
+
+	results, _ := h.Health()
+	result := results["check"]
+	require.True(result.Flapping)
+	require.LessOrEqual(len(result.History), 4)
+	require.Positive(result.FailuresInWindow)
+	require.NotNil(result.TimeOfLastSuccess)
+	require.NotNil(result.TimeSinceLastSuccess)
+	require.GreaterOrEqual(*result.TimeSinceLastSuccess, time.Duration(0))
+
+	newest := result.History[len(result.History)-1]
+	require.Equal(result.Timestamp, newest.Timestamp)
+	require.Equal(result.Error, newest.Error)
+}
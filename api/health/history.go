@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	errInvalidHistorySize   = errors.New("history size must be positive")
+	errInvalidFlapWindow    = errors.New("flap window must be positive")
+	errInvalidFlapThreshold = errors.New("flap threshold must be non-negative")
+
+	DefaultConfig = Config{
+		HistorySize:   32,
+		FlapWindow:    10 * time.Minute,
+		FlapThreshold: 4,
+	}
+)
+
+// Config of the history kept for every registered check.
+type Config struct {
+	// HistorySize is the maximum number of results kept for every check.
+	HistorySize int `json:"historySize"`
+
+	// FlapWindow is the duration of history used to count failures and to
+	// detect flapping.
+	FlapWindow time.Duration `json:"flapWindow"`
+
+	// FlapThreshold is the number of transitions between passing and failing
+	// within [FlapWindow] after which a check is reported as flapping. If 0,
+	// flapping detection is disabled.
+	FlapThreshold int `json:"flapThreshold"`
+}
+
+func (c Config) Verify() error {
+	switch {
+	case c.HistorySize <= 0:
+		return fmt.Errorf("%w: %d", errInvalidHistorySize, c.HistorySize)
+	case c.FlapWindow <= 0:
+		return fmt.Errorf("%w: %s", errInvalidFlapWindow, c.FlapWindow)
+	case c.FlapThreshold < 0:
+		return fmt.Errorf("%w: %d", errInvalidFlapThreshold, c.FlapThreshold)
+	default:
+		return nil
+	}
+}
+
+// HistoryEntry is a previous execution of a HealthCheck.
+type HistoryEntry struct {
+	// Error is the string representation of the error returned by the
+	// HealthCheck. The value is nil if the check passed.
+	Error *string `json:"error,omitempty"`
+
+	// Timestamp of the HealthCheck.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Duration is the amount of time the HealthCheck took to evaluate.
+	Duration time.Duration `json:"duration"`
+}
+
+// appendHistory appends [entry] to [history], evicting the oldest entries to
+// keep at most [maxSize] entries.
+//
+// A new slice is always returned so that previously returned histories are
+// never modified.
+func appendHistory(history []HistoryEntry, entry HistoryEntry, maxSize int) []HistoryEntry {
+	if numToEvict := len(history) + 1 - maxSize; numToEvict > 0 {
+		history = history[numToEvict:]
+	}
+	newHistory := make([]HistoryEntry, len(history), len(history)+1)
+	copy(newHistory, history)
+	return append(newHistory, entry)
+}
+
+// summarizeHistory returns the number of failures and the number of
+// transitions between passing and failing that occurred within [window] of
+// [now].
+func summarizeHistory(history []HistoryEntry, now time.Time, window time.Duration) (int, int) {
+	var (
+		start       = now.Add(-window)
+		failures    int
+		transitions int
+		prevFailed  bool
+		hasPrevious bool
+	)
+	for _, entry := range history {
+		if entry.Timestamp.Before(start) {
+			continue
+		}
+
+		failed := entry.Error != nil
+		if failed {
+			failures++
+		}
+		if hasPrevious && failed != prevFailed {
+			transitions++
+		}
+		prevFailed = failed
+		hasPrevious = true
+	}
+	return failures, transitions
+}
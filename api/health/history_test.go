@@ -0,0 +1,158 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name:   "default",
+			config: DefaultConfig,
+		},
+		{
+			name: "flapping disabled",
+			config: Config{
+				HistorySize: 1,
+				FlapWindow:  time.Second,
+			},
+		},
+		{
+			name: "zero history size",
+			config: Config{
+				FlapWindow: time.Second,
+			},
+			expectedErr: errInvalidHistorySize,
+		},
+		{
+			name: "zero flap window",
+			config: Config{
+				HistorySize: 1,
+			},
+			expectedErr: errInvalidFlapWindow,
+		},
+		{
+			name: "negative flap threshold",
+			config: Config{
+				HistorySize:   1,
+				FlapWindow:    time.Second,
+				FlapThreshold: -1,
+			},
+			expectedErr: errInvalidFlapThreshold,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestAppendHistory(t *testing.T) {
+	require := require.New(t)
+
+	var history []HistoryEntry
+	for i := 0; i < 5; i++ {
+		prevHistory := history
+		history = appendHistory(history, HistoryEntry{
+			Timestamp: time.Unix(int64(i), 0),
+		}, 3)
+
+		// Previously returned histories must not be modified.
+		if len(prevHistory) > 0 {
+			require.Equal(time.Unix(int64(max(i-3, 0)), 0), prevHistory[0].Timestamp)
+		}
+	}
+
+	require.Equal(
+		[]HistoryEntry{
+			{Timestamp: time.Unix(2, 0)},
+			{Timestamp: time.Unix(3, 0)},
+			{Timestamp: time.Unix(4, 0)},
+		},
+		history,
+	)
+}
+
+func TestSummarizeHistory(t *testing.T) {
+	failure := "failure"
+	now := time.Unix(100, 0)
+	entry := func(secondsAgo int64, failed bool) HistoryEntry {
+		entry := HistoryEntry{
+			Timestamp: now.Add(-time.Duration(secondsAgo) * time.Second),
+		}
+		if failed {
+			entry.Error = &failure
+		}
+		return entry
+	}
+
+	tests := []struct {
+		name                string
+		history             []HistoryEntry
+		expectedFailures    int
+		expectedTransitions int
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "always passing",
+			history: []HistoryEntry{
+				entry(3, false),
+				entry(2, false),
+				entry(1, false),
+			},
+		},
+		{
+			name: "always failing",
+			history: []HistoryEntry{
+				entry(3, true),
+				entry(2, true),
+				entry(1, true),
+			},
+			expectedFailures: 3,
+		},
+		{
+			name: "alternating",
+			history: []HistoryEntry{
+				entry(4, true),
+				entry(3, false),
+				entry(2, true),
+				entry(1, false),
+			},
+			expectedFailures:    2,
+			expectedTransitions: 3,
+		},
+		{
+			name: "ignores entries outside of the window",
+			history: []HistoryEntry{
+				entry(20, true),
+				entry(15, false),
+				entry(5, true),
+				entry(1, true),
+			},
+			expectedFailures: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			failures, transitions := summarizeHistory(test.history, now, 10*time.Second)
+			require.Equal(test.expectedFailures, failures)
+			require.Equal(test.expectedTransitions, transitions)
+		})
+	}
+}
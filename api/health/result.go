@@ -35,4 +35,25 @@ type Result struct {
 
 	// TimeOfFirstFailure of the HealthCheck,
 	TimeOfFirstFailure *time.Time `json:"timeOfFirstFailure,omitempty"`
+
+	// TimeOfLastSuccess of the HealthCheck. The value is nil if the
+	// HealthCheck has never passed.
+	TimeOfLastSuccess *time.Time `json:"timeOfLastSuccess,omitempty"`
+
+	// TimeSinceLastSuccess is the amount of time since the HealthCheck last
+	// passed, evaluated when the result was requested. The value is nil if the
+	// HealthCheck has never passed.
+	TimeSinceLastSuccess *time.Duration `json:"timeSinceLastSuccess,omitempty"`
+
+	// FailuresInWindow is the number of recorded failures of the HealthCheck
+	// within the configured flap window.
+	FailuresInWindow int `json:"failuresInWindow,omitempty"`
+
+	// Flapping is true if the HealthCheck has alternated between passing and
+	// failing at least the configured number of times within the flap window.
+	Flapping bool `json:"flapping,omitempty"`
+
+	// History of the most recent executions of the HealthCheck, from oldest to
+	// newest.
+	History []HistoryEntry `json:"history,omitempty"`
 }
@@ -30,12 +30,14 @@ var (
 type worker struct {
 	log           logging.Logger
 	name          string
+	config        Config
 	failingChecks *prometheus.GaugeVec
 	checksLock    sync.RWMutex
 	checks        map[string]*taggedChecker
 
 	resultsLock                 sync.RWMutex
 	results                     map[string]Result
+	histories                   map[string][]HistoryEntry // check name -> history
 	numFailingApplicationChecks int
 	tags                        map[string]set.Set[string] // tag -> set of check names
 
@@ -54,6 +56,7 @@ type taggedChecker struct {
 func newWorker(
 	log logging.Logger,
 	name string,
+	config Config,
 	failingChecks *prometheus.GaugeVec,
 ) *worker {
 	// Initialize the number of failing checks to 0 for all checks
@@ -66,9 +69,11 @@ func newWorker(
 	return &worker{
 		log:           log,
 		name:          name,
+		config:        config,
 		failingChecks: failingChecks,
 		checks:        make(map[string]*taggedChecker),
 		results:       make(map[string]Result),
+		histories:     make(map[string][]HistoryEntry),
 		closer:        make(chan struct{}),
 		tags:          make(map[string]set.Set[string]),
 	}
@@ -158,10 +163,18 @@ func (w *worker) Results(tags ...string) (map[string]Result, bool) {
 		}
 	}
 
-	results := make(map[string]Result, names.Len())
-	healthy := true
+	var (
+		now     = time.Now()
+		results = make(map[string]Result, names.Len())
+		healthy = true
+	)
 	for name := range names {
 		if result, ok := w.results[name]; ok {
+			if result.TimeOfLastSuccess != nil {
+				timeSinceLastSuccess := now.Sub(*result.TimeOfLastSuccess)
+				result.TimeSinceLastSuccess = &timeSinceLastSuccess
+			}
+			result.History = w.histories[name]
 			results[name] = result
 			healthy = healthy && result.Error == nil
 		}
@@ -265,6 +278,39 @@ func (w *worker) runCheck(ctx context.Context, wg *sync.WaitGroup, name string,
 		)
 		w.updateMetrics(check, true /*=healthy*/, false /*=register*/)
 	}
+
+	if err == nil {
+		result.TimeOfLastSuccess = &end
+	} else {
+		result.TimeOfLastSuccess = prevResult.TimeOfLastSuccess
+	}
+
+	history := appendHistory(w.histories[name], HistoryEntry{
+		Error:     result.Error,
+		Timestamp: end,
+		Duration:  result.Duration,
+	}, w.config.HistorySize)
+	w.histories[name] = history
+
+	failures, transitions := summarizeHistory(history, end, w.config.FlapWindow)
+	result.FailuresInWindow = failures
+	result.Flapping = w.config.FlapThreshold > 0 && transitions >= w.config.FlapThreshold
+	switch {
+	case result.Flapping && !prevResult.Flapping:
+		w.log.Warn("check started flapping",
+			zap.String("name", w.name),
+			zap.String("name", name),
+			zap.Strings("tags", check.tags),
+			zap.Int("failures", failures),
+			zap.Int("transitions", transitions),
+		)
+	case !result.Flapping && prevResult.Flapping:
+		w.log.Info("check stopped flapping",
+			zap.String("name", w.name),
+			zap.String("name", name),
+			zap.Strings("tags", check.tags),
+		)
+	}
 	w.results[name] = result
 }
 
@@ -215,3 +215,33 @@ func TestLabelGatherer_Register(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelGatherer_Deregister(t *testing.T) {
+	require := require.New(t)
+
+	gatherer := NewLabelGatherer("chain")
+	require.NoError(gatherer.Register("first", &testGatherer{}))
+	require.NoError(gatherer.Register("second", &testGatherer{}))
+
+	require.True(gatherer.Deregister("first"))
+	require.False(gatherer.Deregister("first"))
+	require.Equal(
+		&labelGatherer{
+			multiGatherer: multiGatherer{
+				names: []string{"second"},
+				gatherers: prometheus.Gatherers{
+					&labeledGatherer{
+						labelName:  "chain",
+						labelValue: "second",
+						gatherer:   &testGatherer{},
+					},
+				},
+			},
+			labelName: "chain",
+		},
+		gatherer,
+	)
+
+	// A deregistered name can be registered again.
+	require.NoError(gatherer.Register("first", &testGatherer{}))
+}
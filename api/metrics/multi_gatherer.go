@@ -5,6 +5,7 @@ package metrics
 
 import (
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,6 +21,11 @@ type MultiGatherer interface {
 	// Register adds the outputs of [gatherer] to the results of future calls to
 	// Gather with the provided [name] added to the metrics.
 	Register(name string, gatherer prometheus.Gatherer) error
+
+	// Deregister removes the outputs of a gatherer with [name] from the results
+	// of future calls to Gather. Returns true if a gatherer with [name] was
+	// found.
+	Deregister(name string) bool
 }
 
 // Deprecated: Use NewPrefixGatherer instead.
@@ -42,6 +48,20 @@ func (g *multiGatherer) Gather() ([]*dto.MetricFamily, error) {
 	return g.gatherers.Gather()
 }
 
+func (g *multiGatherer) Deregister(name string) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	index := slices.Index(g.names, name)
+	if index == -1 {
+		return false
+	}
+
+	g.names = slices.Delete(g.names, index, index+1)
+	g.gatherers = slices.Delete(g.gatherers, index, index+1)
+	return true
+}
+
 func MakeAndRegister(gatherer MultiGatherer, name string) (*prometheus.Registry, error) {
 	reg := prometheus.NewRegistry()
 	if err := gatherer.Register(name, reg); err != nil {
@@ -0,0 +1,245 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+
+	dto "github.com/prometheus/client_model/go"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+const otlpExportTimeout = 10 * time.Second
+
+// MetricExporter exports metrics to an OpenTelemetry collector.
+type MetricExporter interface {
+	ExportMetrics(ctx context.Context, metrics []*metricspb.Metric) error
+}
+
+// OTLPExporter periodically exports the metrics of a gatherer.
+type OTLPExporter struct {
+	log       logging.Logger
+	gatherer  prometheus.Gatherer
+	exporter  MetricExporter
+	frequency time.Duration
+	// Start time of cumulative metrics
+	startTime time.Time
+
+	closeOnce sync.Once
+	closing   chan struct{}
+	done      chan struct{}
+}
+
+// NewOTLPExporter returns an exporter that exports the metrics of [gatherer]
+// to [exporter] every [frequency] once started.
+func NewOTLPExporter(
+	log logging.Logger,
+	gatherer prometheus.Gatherer,
+	exporter MetricExporter,
+	frequency time.Duration,
+) *OTLPExporter {
+	return &OTLPExporter{
+		log:       log,
+		gatherer:  gatherer,
+		exporter:  exporter,
+		frequency: frequency,
+		startTime: time.Now(),
+		closing:   make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Dispatch exports the metrics until Close is called.
+func (e *OTLPExporter) Dispatch() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.export(); err != nil {
+				e.log.Debug("failed to export metrics",
+					zap.Error(err),
+				)
+			}
+		case <-e.closing:
+			return
+		}
+	}
+}
+
+// Close stops exporting metrics. Close must only be called after Dispatch.
+func (e *OTLPExporter) Close() {
+	e.closeOnce.Do(func() {
+		close(e.closing)
+	})
+	<-e.done
+}
+
+func (e *OTLPExporter) export() error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+	defer cancel()
+	return e.exporter.ExportMetrics(ctx, toOTLPMetrics(families, e.startTime, time.Now()))
+}
+
+// toOTLPMetrics converts prometheus metric families into OTLP metrics.
+//
+// Counters are exported as cumulative sums starting at [startTime]. Gauges
+// and untyped metrics are exported as gauges.
+func toOTLPMetrics(families []*dto.MetricFamily, startTime time.Time, now time.Time) []*metricspb.Metric {
+	var (
+		start     = uint64(startTime.UnixNano())
+		timestamp = uint64(now.UnixNano())
+		metrics   = make([]*metricspb.Metric, 0, len(families))
+	)
+	for _, family := range families {
+		metric := &metricspb.Metric{
+			Name:        family.GetName(),
+			Description: family.GetHelp(),
+		}
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			dataPoints := make([]*metricspb.NumberDataPoint, len(family.Metric))
+			for i, m := range family.Metric {
+				dataPoints[i] = newNumberDataPoint(m, m.GetCounter().GetValue(), start, timestamp)
+			}
+			metric.Data = &metricspb.Metric_Sum{
+				Sum: &metricspb.Sum{
+					DataPoints:             dataPoints,
+					AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+					IsMonotonic:            true,
+				},
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			dataPoints := make([]*metricspb.NumberDataPoint, len(family.Metric))
+			for i, m := range family.Metric {
+				value := m.GetGauge().GetValue()
+				if family.GetType() == dto.MetricType_UNTYPED {
+					value = m.GetUntyped().GetValue()
+				}
+				dataPoints[i] = newNumberDataPoint(m, value, 0, timestamp)
+			}
+			metric.Data = &metricspb.Metric_Gauge{
+				Gauge: &metricspb.Gauge{
+					DataPoints: dataPoints,
+				},
+			}
+		case dto.MetricType_HISTOGRAM:
+			dataPoints := make([]*metricspb.HistogramDataPoint, len(family.Metric))
+			for i, m := range family.Metric {
+				dataPoints[i] = newHistogramDataPoint(m, start, timestamp)
+			}
+			metric.Data = &metricspb.Metric_Histogram{
+				Histogram: &metricspb.Histogram{
+					DataPoints:             dataPoints,
+					AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				},
+			}
+		case dto.MetricType_SUMMARY:
+			dataPoints := make([]*metricspb.SummaryDataPoint, len(family.Metric))
+			for i, m := range family.Metric {
+				dataPoints[i] = newSummaryDataPoint(m, start, timestamp)
+			}
+			metric.Data = &metricspb.Metric_Summary{
+				Summary: &metricspb.Summary{
+					DataPoints: dataPoints,
+				},
+			}
+		default:
+			// Other metric types aren't supported
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+func newNumberDataPoint(m *dto.Metric, value float64, start, timestamp uint64) *metricspb.NumberDataPoint {
+	return &metricspb.NumberDataPoint{
+		Attributes:        toOTLPAttributes(m.Label),
+		StartTimeUnixNano: start,
+		TimeUnixNano:      timestamp,
+		Value: &metricspb.NumberDataPoint_AsDouble{
+			AsDouble: value,
+		},
+	}
+}
+
+func newHistogramDataPoint(m *dto.Metric, start, timestamp uint64) *metricspb.HistogramDataPoint {
+	var (
+		histogram = m.GetHistogram()
+		sum       = histogram.GetSampleSum()
+		count     = histogram.GetSampleCount()
+		// Prometheus buckets are cumulative, OTLP buckets aren't. OTLP has an
+		// additional bucket for the values above the last bound.
+		bounds       = make([]float64, 0, len(histogram.Bucket))
+		bucketCounts = make([]uint64, 0, len(histogram.Bucket)+1)
+		previous     uint64
+	)
+	for _, bucket := range histogram.Bucket {
+		bounds = append(bounds, bucket.GetUpperBound())
+		bucketCounts = append(bucketCounts, bucket.GetCumulativeCount()-previous)
+		previous = bucket.GetCumulativeCount()
+	}
+	bucketCounts = append(bucketCounts, count-previous)
+
+	return &metricspb.HistogramDataPoint{
+		Attributes:        toOTLPAttributes(m.Label),
+		StartTimeUnixNano: start,
+		TimeUnixNano:      timestamp,
+		Count:             count,
+		Sum:               &sum,
+		BucketCounts:      bucketCounts,
+		ExplicitBounds:    bounds,
+	}
+}
+
+func newSummaryDataPoint(m *dto.Metric, start, timestamp uint64) *metricspb.SummaryDataPoint {
+	summary := m.GetSummary()
+	quantiles := make([]*metricspb.SummaryDataPoint_ValueAtQuantile, len(summary.Quantile))
+	for i, quantile := range summary.Quantile {
+		quantiles[i] = &metricspb.SummaryDataPoint_ValueAtQuantile{
+			Quantile: quantile.GetQuantile(),
+			Value:    quantile.GetValue(),
+		}
+	}
+	return &metricspb.SummaryDataPoint{
+		Attributes:        toOTLPAttributes(m.Label),
+		StartTimeUnixNano: start,
+		TimeUnixNano:      timestamp,
+		Count:             summary.GetSampleCount(),
+		Sum:               summary.GetSampleSum(),
+		QuantileValues:    quantiles,
+	}
+}
+
+func toOTLPAttributes(labels []*dto.LabelPair) []*commonpb.KeyValue {
+	attributes := make([]*commonpb.KeyValue, len(labels))
+	for i, label := range labels {
+		attributes[i] = &commonpb.KeyValue{
+			Key: label.GetName(),
+			Value: &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_StringValue{
+					StringValue: label.GetValue(),
+				},
+			},
+		}
+	}
+	return attributes
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+func TestToOTLPMetrics(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(counterOpts, []string{"label"})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "gauge",
+		Help: "help",
+	})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "histogram",
+		Help:    "help",
+		Buckets: []float64{1, 10},
+	})
+	require.NoError(registry.Register(counter))
+	require.NoError(registry.Register(gauge))
+	require.NoError(registry.Register(histogram))
+
+	counter.WithLabelValues("value").Add(2)
+	gauge.Set(3)
+	histogram.Observe(0.5)
+	histogram.Observe(5)
+	histogram.Observe(50)
+	histogram.Observe(500)
+
+	families, err := registry.Gather()
+	require.NoError(err)
+
+	var (
+		startTime = time.Unix(1, 0)
+		now       = time.Unix(2, 0)
+	)
+	metrics := toOTLPMetrics(families, startTime, now)
+	require.Len(metrics, 3)
+
+	metricsByName := make(map[string]*metricspb.Metric)
+	for _, metric := range metrics {
+		metricsByName[metric.Name] = metric
+	}
+
+	sum := metricsByName["counter"].GetSum()
+	require.NotNil(sum)
+	require.True(sum.IsMonotonic)
+	require.Equal(metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, sum.AggregationTemporality)
+	require.Len(sum.DataPoints, 1)
+	require.Equal(2.0, sum.DataPoints[0].GetAsDouble())
+	require.Equal(uint64(startTime.UnixNano()), sum.DataPoints[0].StartTimeUnixNano)
+	require.Equal(uint64(now.UnixNano()), sum.DataPoints[0].TimeUnixNano)
+	require.Len(sum.DataPoints[0].Attributes, 1)
+	require.Equal("label", sum.DataPoints[0].Attributes[0].Key)
+	require.Equal("value", sum.DataPoints[0].Attributes[0].Value.GetStringValue())
+
+	gaugeMetric := metricsByName["gauge"].GetGauge()
+	require.NotNil(gaugeMetric)
+	require.Len(gaugeMetric.DataPoints, 1)
+	require.Equal(3.0, gaugeMetric.DataPoints[0].GetAsDouble())
+
+	histogramMetric := metricsByName["histogram"].GetHistogram()
+	require.NotNil(histogramMetric)
+	require.Len(histogramMetric.DataPoints, 1)
+	dataPoint := histogramMetric.DataPoints[0]
+	require.Equal(uint64(4), dataPoint.Count)
+	require.Equal(555.5, dataPoint.GetSum())
+	require.Equal([]float64{1, 10}, dataPoint.ExplicitBounds)
+	require.Equal([]uint64{1, 1, 2}, dataPoint.BucketCounts)
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+// originPattern matches the origins that start with [prefix] and end with
+// [suffix].
+type originPattern struct {
+	prefix string
+	suffix string
+}
+
+func (p originPattern) match(origin string) bool {
+	return len(origin) >= len(p.prefix)+len(p.suffix) &&
+		strings.HasPrefix(origin, p.prefix) &&
+		strings.HasSuffix(origin, p.suffix)
+}
+
+// NewOriginChecker returns a function that reports whether a websocket
+// upgrade request may be accepted. Browsers don't apply CORS to websockets, so
+// the origin of the request is validated against [allowed], which is
+// interpreted the same way as the allowed origins of the HTTP CORS handler:
+//
+//   - An empty list or a list containing "*" allows all origins.
+//   - An origin containing a "*" matches any origin that it matches when the
+//     "*" is replaced by any string.
+//   - Origins are matched case-insensitively.
+//
+// Requests without an origin, which aren't sent by browsers, and requests
+// from the same origin as the server are always allowed.
+func NewOriginChecker(allowed []string) func(*http.Request) bool {
+	var (
+		origins  set.Set[string]
+		patterns []originPattern
+	)
+	for _, origin := range allowed {
+		origin = strings.ToLower(origin)
+		if origin == wildcard {
+			return allowAllOrigins
+		}
+		if i := strings.Index(origin, wildcard); i >= 0 {
+			patterns = append(patterns, originPattern{
+				prefix: origin[:i],
+				suffix: origin[i+1:],
+			})
+			continue
+		}
+		origins.Add(origin)
+	}
+	if origins.Len() == 0 && len(patterns) == 0 {
+		return allowAllOrigins
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+
+		origin = strings.ToLower(origin)
+		if origins.Contains(origin) {
+			return true
+		}
+		for _, pattern := range patterns {
+			if pattern.match(origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func allowAllOrigins(*http.Request) bool {
+	return true
+}
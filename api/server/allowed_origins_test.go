@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOriginChecker(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		host    string
+		origin  string
+		allow   bool
+	}{
+		{
+			name:    "no origin header",
+			allowed: []string{"https://www.foobar.com"},
+			host:    "localhost:9650",
+			origin:  "",
+			allow:   true,
+		},
+		{
+			name:    "same origin",
+			allowed: []string{"https://www.foobar.com"},
+			host:    "localhost:9650",
+			origin:  "http://localhost:9650",
+			allow:   true,
+		},
+		{
+			name:    "origin not allowed",
+			allowed: []string{"https://www.foobar.com"},
+			host:    "localhost:9650",
+			origin:  "https://www.evil.com",
+		},
+		{
+			name:    "origin allowed",
+			allowed: []string{"https://www.foobar.com"},
+			host:    "localhost:9650",
+			origin:  "https://WWW.foobar.com",
+			allow:   true,
+		},
+		{
+			name:    "origin pattern allowed",
+			allowed: []string{"https://*.foobar.com"},
+			host:    "localhost:9650",
+			origin:  "https://api.foobar.com",
+			allow:   true,
+		},
+		{
+			name:    "origin pattern not allowed",
+			allowed: []string{"https://*.foobar.com"},
+			host:    "localhost:9650",
+			origin:  "https://foobar.com.evil.com",
+		},
+		{
+			name:    "wildcard",
+			allowed: []string{"https://www.foobar.com", "*"},
+			host:    "localhost:9650",
+			origin:  "https://www.evil.com",
+			allow:   true,
+		},
+		{
+			name:    "no allowed origins",
+			allowed: nil,
+			host:    "localhost:9650",
+			origin:  "https://www.evil.com",
+			allow:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = test.host
+			if test.origin != "" {
+				req.Header.Set("Origin", test.origin)
+			}
+
+			checkOrigin := NewOriginChecker(test.allowed)
+			require.Equal(t, test.allow, checkOrigin(req))
+		})
+	}
+}
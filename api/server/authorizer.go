@@ -0,0 +1,326 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+const (
+	bearerPrefix = "Bearer "
+
+	// unauthorizedErrorCode is the JSON-RPC error code returned to calls
+	// received over a websocket that aren't authorized.
+	unauthorizedErrorCode = -32001
+
+	missingTokenReason = "missing_token"
+	invalidTokenReason = "invalid_token"
+	forbiddenReason    = "forbidden"
+)
+
+var (
+	_ Authorizer = (*authorizer)(nil)
+
+	errEmptyToken     = errors.New("empty token")
+	errDuplicateToken = errors.New("duplicate token")
+)
+
+// Authorizer restricts the routes and JSON-RPC methods that can be called on
+// the API server.
+type Authorizer interface {
+	// WrapHandler returns a handler that only forwards authorized requests to
+	// [handler].
+	WrapHandler(handler http.Handler) http.Handler
+
+	// Reload the authorization config from disk. If the config fails to load,
+	// the previous config is kept.
+	Reload() error
+}
+
+// callAuthorizerKey is the context key of the function that authorizes the
+// calls received over a websocket.
+type callAuthorizerKey struct{}
+
+// callAuthorizer returns the function that authorizes the calls received over
+// the websocket opened by [r]. If [r] wasn't authorized by an [Authorizer],
+// nil is returned.
+func callAuthorizer(r *http.Request) func(*http.Request) (int, string) {
+	authorize, _ := r.Context().Value(callAuthorizerKey{}).(func(*http.Request) (int, string))
+	return authorize
+}
+
+// AuthConfig is the format of the authorization config file.
+type AuthConfig struct {
+	// Public permissions are granted to every request, including requests
+	// that don't provide a token.
+	Public Permissions `json:"public"`
+
+	// Tokens that can be provided with the "Authorization: Bearer <token>"
+	// header to be granted additional permissions.
+	Tokens []TokenConfig `json:"tokens"`
+}
+
+// TokenConfig describes the permissions granted to a token.
+type TokenConfig struct {
+	// Name of the token, used to identify it in logs and errors.
+	Name string `json:"name"`
+	// Token that must be provided by the client.
+	Token string `json:"token"`
+	Permissions
+}
+
+// Permissions is a set of allow lists. A request is authorized if its route
+// matches any of [Routes], or if it is a JSON-RPC request and every method it
+// calls matches any of [Methods].
+//
+// Patterns are matched case-insensitively. A pattern ending in "*" matches
+// every value that starts with the pattern's prefix, so "*" matches
+// everything, "/ext/bc/*" matches every chain route and "info.*" matches every
+// method of the info API.
+type Permissions struct {
+	Routes  []string `json:"routes"`
+	Methods []string `json:"methods"`
+}
+
+func (p *Permissions) allowsRoute(route string) bool {
+	return matchesAny(p.Routes, route)
+}
+
+func (p *Permissions) allowsMethods(methods []string) bool {
+	if len(methods) == 0 {
+		return false
+	}
+	for _, method := range methods {
+		if !matchesAny(p.Methods, method) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+				return true
+			}
+			continue
+		}
+		if strings.EqualFold(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+type authorizer struct {
+	log      logging.Logger
+	path     string
+	failures *prometheus.CounterVec
+
+	lock   sync.RWMutex
+	public Permissions
+	// Tokens are indexed by their hash to avoid leaking the tokens through the
+	// timing of the lookups.
+	tokens map[[sha256.Size]byte]Permissions
+}
+
+// NewAuthorizer returns an Authorizer that loads its config from the JSON
+// file at [path].
+func NewAuthorizer(
+	log logging.Logger,
+	path string,
+	registerer prometheus.Registerer,
+) (Authorizer, error) {
+	a := &authorizer{
+		log:  log,
+		path: path,
+		failures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "auth_failures",
+				Help: "The number of API calls that were rejected because they weren't authorized",
+			},
+			[]string{"reason"},
+		),
+	}
+	if err := registerer.Register(a.failures); err != nil {
+		return nil, err
+	}
+	return a, a.Reload()
+}
+
+func (a *authorizer) Reload() error {
+	configBytes, err := os.ReadFile(a.path)
+	if err != nil {
+		return fmt.Errorf("failed to read auth config: %w", err)
+	}
+
+	var config AuthConfig
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return fmt.Errorf("failed to parse auth config: %w", err)
+	}
+
+	var (
+		tokens = make(map[[sha256.Size]byte]Permissions, len(config.Tokens))
+		names  = make([]string, len(config.Tokens))
+	)
+	for i, tokenConfig := range config.Tokens {
+		if tokenConfig.Token == "" {
+			return fmt.Errorf("%w: %q", errEmptyToken, tokenConfig.Name)
+		}
+		tokenHash := sha256.Sum256([]byte(tokenConfig.Token))
+		if _, ok := tokens[tokenHash]; ok {
+			return fmt.Errorf("%w: %q", errDuplicateToken, tokenConfig.Name)
+		}
+		tokens[tokenHash] = tokenConfig.Permissions
+		names[i] = tokenConfig.Name
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.public = config.Public
+	a.tokens = tokens
+
+	a.log.Info("loaded API authorization config",
+		zap.String("path", a.path),
+		zap.Strings("tokens", names),
+	)
+	return nil
+}
+
+func (a *authorizer) WrapHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var status int
+		var reason string
+		if isWebsocketRequest(r) {
+			// The calls made over a websocket are only sent once it is
+			// opened, so opening it only requires a valid token and every
+			// call is authorized when it is received.
+			status, reason = a.authorizeToken(r)
+			r = r.WithContext(context.WithValue(r.Context(), callAuthorizerKey{}, a.authorizeCall))
+		} else {
+			r, _, _ = withJSONRPCCalls(r)
+			status, reason = a.authorize(r)
+		}
+		if status == http.StatusOK {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		a.rejected(r, reason)
+		http.Error(w, http.StatusText(status), status)
+	})
+}
+
+// isWebsocketRequest returns true if [r] opens a websocket served by a
+// websocket endpoint. Upgrade requests to other routes are authorized like
+// any other request.
+func isWebsocketRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		strings.HasSuffix(r.URL.Path, websocketEndpoint) &&
+		websocket.IsWebSocketUpgrade(r)
+}
+
+// authorizeCall authorizes a call that was received over a websocket. The
+// call is authorized as if it was sent in [r].
+func (a *authorizer) authorizeCall(r *http.Request) (int, string) {
+	status, reason := a.authorize(r)
+	if status != http.StatusOK {
+		a.rejected(r, reason)
+	}
+	return status, reason
+}
+
+func (a *authorizer) rejected(r *http.Request, reason string) {
+	a.failures.WithLabelValues(reason).Inc()
+	a.log.Debug("rejected unauthorized API call",
+		zap.String("path", r.URL.Path),
+		zap.String("reason", reason),
+	)
+}
+
+// authorizeToken returns http.StatusOK if [r] doesn't provide a token or
+// provides a valid token.
+func (a *authorizer) authorizeToken(r *http.Request) (int, string) {
+	if _, ok := a.permissions(r.Header.Get("Authorization")); !ok {
+		return http.StatusUnauthorized, invalidTokenReason
+	}
+	return http.StatusOK, ""
+}
+
+// authorize returns http.StatusOK if [r] is authorized. Otherwise, the status
+// code to respond with and the reason of the failure are returned.
+//
+// The calls of [r] should have been stored with [withJSONRPCCalls] so that
+// they aren't parsed again.
+func (a *authorizer) authorize(r *http.Request) (int, string) {
+	header := r.Header.Get("Authorization")
+	hasToken := header != ""
+	permissions, ok := a.permissions(header)
+	if !ok {
+		return http.StatusUnauthorized, invalidTokenReason
+	}
+
+	route := r.URL.Path
+	for _, p := range permissions {
+		if p.allowsRoute(route) {
+			return http.StatusOK, ""
+		}
+	}
+
+	_, calls, _ := withJSONRPCCalls(r)
+	methods := make([]string, len(calls))
+	for i, call := range calls {
+		methods[i] = call.Method
+	}
+	for _, p := range permissions {
+		if p.allowsMethods(methods) {
+			return http.StatusOK, ""
+		}
+	}
+
+	if !hasToken {
+		return http.StatusUnauthorized, missingTokenReason
+	}
+	return http.StatusForbidden, forbiddenReason
+}
+
+// permissions returns the permissions granted to a request with the provided
+// Authorization [header]. If the header doesn't contain a valid bearer token,
+// false is returned.
+func (a *authorizer) permissions(header string) ([]*Permissions, bool) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	public := a.public
+	if header == "" {
+		return []*Permissions{&public}, true
+	}
+
+	// The authentication scheme is case-insensitive.
+	if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return nil, false
+	}
+	tokenHash := sha256.Sum256([]byte(header[len(bearerPrefix):]))
+	permissions, ok := a.tokens[tokenHash]
+	if !ok {
+		return nil, false
+	}
+	return []*Permissions{&public, &permissions}, true
+}
@@ -0,0 +1,364 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+func writeAuthConfig(t *testing.T, path string, config AuthConfig) {
+	configBytes, err := json.Marshal(config)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, configBytes, 0o600))
+}
+
+func TestAuthorizer(t *testing.T) {
+	config := AuthConfig{
+		Public: Permissions{
+			Routes:  []string{"/ext/health"},
+			Methods: []string{"info.*"},
+		},
+		Tokens: []TokenConfig{
+			{
+				Name:  "admin",
+				Token: "admin-token",
+				Permissions: Permissions{
+					Methods: []string{"admin.*"},
+				},
+			},
+			{
+				Name:  "chains",
+				Token: "chains-token",
+				Permissions: Permissions{
+					Routes: []string{"/ext/bc/*"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		method             string
+		path               string
+		authorization      string
+		upgrade            bool
+		body               string
+		expectedStatusCode int
+		expectedReason     string
+	}{
+		{
+			name:               "public route",
+			method:             http.MethodGet,
+			path:               "/ext/health",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "public method",
+			method:             http.MethodPost,
+			path:               "/ext/info",
+			body:               `{"jsonrpc":"2.0","id":1,"method":"info.peers"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "public methods in batch",
+			method:             http.MethodPost,
+			path:               "/ext/info",
+			body:               `[{"method":"info.peers"},{"method":"info.getNodeID"}]`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "batch with private method",
+			method:             http.MethodPost,
+			path:               "/ext/info",
+			body:               `[{"method":"info.peers"},{"method":"admin.stacktrace"}]`,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedReason:     missingTokenReason,
+		},
+		{
+			name:               "private method without token",
+			method:             http.MethodPost,
+			path:               "/ext/admin",
+			body:               `{"jsonrpc":"2.0","id":1,"method":"admin.stacktrace"}`,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedReason:     missingTokenReason,
+		},
+		{
+			name:               "private method with invalid token",
+			method:             http.MethodPost,
+			path:               "/ext/admin",
+			authorization:      bearerPrefix + "invalid-token",
+			body:               `{"jsonrpc":"2.0","id":1,"method":"admin.stacktrace"}`,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedReason:     invalidTokenReason,
+		},
+		{
+			name:               "private method with token",
+			method:             http.MethodPost,
+			path:               "/ext/admin",
+			authorization:      bearerPrefix + "admin-token",
+			body:               `{"jsonrpc":"2.0","id":1,"method":"Admin.Stacktrace"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "private method with other token",
+			method:             http.MethodPost,
+			path:               "/ext/admin",
+			authorization:      bearerPrefix + "chains-token",
+			body:               `{"jsonrpc":"2.0","id":1,"method":"admin.stacktrace"}`,
+			expectedStatusCode: http.StatusForbidden,
+			expectedReason:     forbiddenReason,
+		},
+		{
+			name:               "private route with token",
+			method:             http.MethodPost,
+			path:               "/ext/bc/X",
+			authorization:      bearerPrefix + "chains-token",
+			body:               `{"jsonrpc":"2.0","id":1,"method":"avm.getHeight"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "token also has public permissions",
+			method:             http.MethodGet,
+			path:               "/ext/health",
+			authorization:      bearerPrefix + "admin-token",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "token without bearer scheme",
+			method:             http.MethodPost,
+			path:               "/ext/admin",
+			authorization:      "admin-token",
+			body:               `{"jsonrpc":"2.0","id":1,"method":"admin.stacktrace"}`,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedReason:     invalidTokenReason,
+		},
+		{
+			name:               "lowercase bearer scheme",
+			method:             http.MethodPost,
+			path:               "/ext/admin",
+			authorization:      "bearer admin-token",
+			body:               `{"jsonrpc":"2.0","id":1,"method":"admin.stacktrace"}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "upgrade to private route without token",
+			method:             http.MethodPost,
+			path:               "/ext/admin",
+			upgrade:            true,
+			body:               `{"jsonrpc":"2.0","id":1,"method":"admin.stacktrace"}`,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedReason:     missingTokenReason,
+		},
+		{
+			name:               "websocket upgrade to private route without token",
+			method:             http.MethodGet,
+			path:               "/ext/admin",
+			upgrade:            true,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedReason:     missingTokenReason,
+		},
+		{
+			name:               "websocket upgrade to websocket endpoint without token",
+			method:             http.MethodGet,
+			path:               "/ext/bc/X" + websocketEndpoint,
+			upgrade:            true,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "invalid body",
+			method:             http.MethodPost,
+			path:               "/ext/info",
+			body:               `info.peers`,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedReason:     missingTokenReason,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			path := filepath.Join(t.TempDir(), "auth.json")
+			writeAuthConfig(t, path, config)
+
+			a, err := NewAuthorizer(logging.NoLog{}, path, prometheus.NewRegistry())
+			require.NoError(err)
+
+			handler := a.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// The body must still be readable by the wrapped handler.
+				body, err := io.ReadAll(r.Body)
+				require.NoError(err)
+				require.Equal(test.body, string(body))
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			if test.authorization != "" {
+				r.Header.Set("Authorization", test.authorization)
+			}
+			if test.upgrade {
+				r.Header.Set("Connection", "Upgrade")
+				r.Header.Set("Upgrade", "websocket")
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			require.Equal(test.expectedStatusCode, w.Code)
+
+			failures := a.(*authorizer).failures
+			if test.expectedReason == "" {
+				require.Zero(testutil.CollectAndCount(failures))
+			} else {
+				require.Equal(1.0, testutil.ToFloat64(failures.WithLabelValues(test.expectedReason)))
+			}
+		})
+	}
+}
+
+func TestAuthorizerReload(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "auth.json")
+	writeAuthConfig(t, path, AuthConfig{})
+
+	authorizer, err := NewAuthorizer(logging.NoLog{}, path, prometheus.NewRegistry())
+	require.NoError(err)
+
+	handler := authorizer.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func() int {
+		r := httptest.NewRequest(http.MethodGet, "/ext/health", nil)
+		r.Header.Set("Authorization", bearerPrefix+"token")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+	require.Equal(http.StatusUnauthorized, serve())
+
+	writeAuthConfig(t, path, AuthConfig{
+		Tokens: []TokenConfig{
+			{
+				Name:  "token",
+				Token: "token",
+				Permissions: Permissions{
+					Routes: []string{"*"},
+				},
+			},
+		},
+	})
+	require.NoError(authorizer.Reload())
+	require.Equal(http.StatusOK, serve())
+
+	// Failing to reload the config keeps the previous config.
+	writeAuthConfig(t, path, AuthConfig{
+		Tokens: []TokenConfig{
+			{Name: "first", Token: "token"},
+			{Name: "second", Token: "token"},
+		},
+	})
+	err = authorizer.Reload()
+	require.ErrorIs(err, errDuplicateToken)
+	require.Equal(http.StatusOK, serve())
+
+	writeAuthConfig(t, path, AuthConfig{
+		Tokens: []TokenConfig{
+			{Name: "empty"},
+		},
+	})
+	err = authorizer.Reload()
+	require.ErrorIs(err, errEmptyToken)
+	require.Equal(http.StatusOK, serve())
+}
+
+func TestAuthorizerWebsocket(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "auth.json")
+	writeAuthConfig(t, path, AuthConfig{
+		Tokens: []TokenConfig{
+			{
+				Name:  "methods",
+				Token: "methods-token",
+				Permissions: Permissions{
+					Methods: []string{"avm.*"},
+				},
+			},
+			{
+				Name:  "websocket",
+				Token: "websocket-token",
+				Permissions: Permissions{
+					Routes: []string{"/ext/bc/X/ws"},
+				},
+			},
+		},
+	})
+	a, err := NewAuthorizer(logging.NoLog{}, path, prometheus.NewRegistry())
+	require.NoError(err)
+
+	server := httptest.NewServer(a.WrapHandler(
+		newWebsocketHandler(logging.NoLog{}, newBatchHandler(echoHandler), allowAllOrigins, true),
+	))
+	defer server.Close()
+
+	uri := "ws" + strings.TrimPrefix(server.URL, "http") + "/ext/bc/X" + websocketEndpoint
+	dial := func(authorization string) (*websocket.Conn, *http.Response, error) {
+		return websocket.DefaultDialer.Dial(uri, http.Header{
+			"Authorization": []string{authorization},
+		})
+	}
+	call := func(conn *websocket.Conn, message string) map[string]interface{} {
+		require.NoError(conn.WriteMessage(websocket.TextMessage, []byte(message)))
+		var response map[string]interface{}
+		require.NoError(conn.ReadJSON(&response))
+		return response
+	}
+
+	// Opening a websocket requires a valid token.
+	_, resp, err := dial(bearerPrefix + "invalid-token")
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+	require.Equal(http.StatusUnauthorized, resp.StatusCode)
+	require.NoError(resp.Body.Close())
+
+	_, resp, err = dial("methods-token")
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+	require.Equal(http.StatusUnauthorized, resp.StatusCode)
+	require.NoError(resp.Body.Close())
+
+	// Every call is authorized against the methods of the token.
+	conn, _, err := dial(bearerPrefix + "methods-token")
+	require.NoError(err)
+	defer conn.Close()
+
+	response := call(conn, `{"jsonrpc":"2.0","id":1,"method":"avm.getHeight"}`)
+	require.Equal("avm.getHeight", response["result"])
+
+	response = call(conn, `{"jsonrpc":"2.0","id":2,"method":"admin.stacktrace"}`)
+	require.Contains(response, "error")
+	require.Equal(float64(unauthorizedErrorCode), response["error"].(map[string]interface{})["code"])
+
+	// Being allowed to open a websocket doesn't allow any calls.
+	conn, _, err = dial(bearerPrefix + "websocket-token")
+	require.NoError(err)
+	defer conn.Close()
+
+	response = call(conn, `{"jsonrpc":"2.0","id":3,"method":"avm.getHeight"}`)
+	require.Contains(response, "error")
+
+	failures := a.(*authorizer).failures
+	require.Equal(2.0, testutil.ToFloat64(failures.WithLabelValues(invalidTokenReason)))
+	require.Equal(2.0, testutil.ToFloat64(failures.WithLabelValues(forbiddenReason)))
+}
@@ -0,0 +1,208 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// maxBatchSize is the maximum number of calls in a JSON-RPC batch.
+	maxBatchSize = 1000
+
+	invalidRequestErrorCode = -32600
+	internalErrorCode       = -32603
+)
+
+var (
+	_ http.Handler = (*batchHandler)(nil)
+
+	errBodyTooLarge = errors.New("body too large")
+)
+
+// batchHandler adds support for JSON-RPC 2.0 batches to a handler that only
+// supports individual calls.
+//
+// Every call of a batch is forwarded to the handler in order, and the
+// responses are returned as an array. Requests that aren't batches are
+// forwarded unmodified. Requests whose body is larger than
+// [maxParsedBodySize] are rejected.
+type batchHandler struct {
+	handler http.Handler
+}
+
+func newBatchHandler(handler http.Handler) http.Handler {
+	return &batchHandler{
+		handler: handler,
+	}
+}
+
+func (b *batchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	calls, ok, err := readBatch(r)
+	if err != nil {
+		// The calls of the request couldn't be parsed, so the request
+		// couldn't be authorized or rate limited by its calls.
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !ok {
+		b.handler.ServeHTTP(w, r)
+		return
+	}
+
+	switch {
+	case len(calls) == 0:
+		writeJSONRPCError(w, http.StatusOK, []jsonRPCCall{{}}, false, invalidRequestErrorCode, "empty batch")
+		return
+	case len(calls) > maxBatchSize:
+		writeJSONRPCError(w, http.StatusOK, []jsonRPCCall{{}}, false, invalidRequestErrorCode, "batch too large")
+		return
+	}
+
+	// The calls are only served if they are the calls that the request was
+	// authorized and rate limited for, which requires every call of the batch
+	// to be valid.
+	r, _, isBatch := withJSONRPCCalls(r)
+	responses := make([]json.RawMessage, 0, len(calls))
+	for _, call := range calls {
+		var response json.RawMessage
+		if isBatch {
+			response = serveCall(b.handler, r, call)
+		} else {
+			response = newJSONRPCErrorResponse(nil, invalidRequestErrorCode, "invalid request")
+		}
+		if len(response) > 0 {
+			responses = append(responses, response)
+		}
+	}
+
+	// If every call of the batch was a notification, nothing is returned.
+	if len(responses) == 0 {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(responses)
+}
+
+// readBatch returns the calls of [r] if it is a JSON-RPC batch. The body of
+// [r] is preserved if [r] isn't a batch. An error is returned if the body of
+// [r] is larger than [maxParsedBodySize].
+func readBatch(r *http.Request) ([]json.RawMessage, bool, error) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		return nil, false, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxParsedBodySize+1))
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil, false, nil
+	}
+	if len(body) > maxParsedBodySize {
+		return nil, false, errBodyTooLarge
+	}
+
+	body = bytes.TrimSpace(body)
+	if !bytes.HasPrefix(body, []byte("[")) {
+		return nil, false, nil
+	}
+
+	var calls []json.RawMessage
+	if err := json.Unmarshal(body, &calls); err != nil {
+		return nil, false, nil
+	}
+	return calls, true, nil
+}
+
+// serveCall forwards a single JSON-RPC [call] to [handler] and returns the
+// response. [r] is the request that the call was received in.
+//
+// If [call] is a notification, no response is returned.
+func serveCall(handler http.Handler, r *http.Request, call json.RawMessage) json.RawMessage {
+	var parsedCall jsonRPCCall
+	if err := json.Unmarshal(call, &parsedCall); err != nil {
+		return newJSONRPCErrorResponse(nil, invalidRequestErrorCode, "invalid request")
+	}
+
+	callRequest := r.Clone(r.Context())
+	callRequest.Method = http.MethodPost
+	callRequest.Body = io.NopCloser(bytes.NewReader(call))
+	callRequest.ContentLength = int64(len(call))
+	callRequest.Header.Set("Content-Type", "application/json")
+
+	// The calls stored in the context of [r] are the calls of the whole batch,
+	// so they are replaced by [call].
+	var calls []jsonRPCCall
+	if parsedCall.Method != "" {
+		calls = []jsonRPCCall{parsedCall}
+	}
+	callRequest = storeJSONRPCCalls(callRequest, calls, false)
+
+	recorder := newResponseRecorder()
+	handler.ServeHTTP(recorder, callRequest)
+
+	// Notifications don't have responses.
+	if len(parsedCall.ID) == 0 {
+		return nil
+	}
+
+	response := bytes.TrimSpace(recorder.body.Bytes())
+	if json.Valid(response) && len(response) > 0 {
+		return response
+	}
+
+	// The handler didn't reply with a JSON-RPC response, so the reply is
+	// converted into an error.
+	message := strings.TrimSpace(string(response))
+	if message == "" {
+		message = http.StatusText(recorder.status)
+	}
+	return newJSONRPCErrorResponse(parsedCall.ID, internalErrorCode, message)
+}
+
+func newJSONRPCErrorResponse(id json.RawMessage, code int, message string) json.RawMessage {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	response, _ := json.Marshal(jsonRPCErrorResponse{
+		Version: "2.0",
+		Error: jsonRPCError{
+			Code:    code,
+			Message: message,
+		},
+		ID: id,
+	})
+	return response
+}
+
+// responseRecorder buffers the response of a handler in memory.
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
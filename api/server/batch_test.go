@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// echoHandler replies to every call with its method as the result. Calls to
+// the "fail" method are rejected with a plain text error.
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var call jsonRPCCall
+	if err := json.Unmarshal(body, &call); err != nil {
+		http.Error(w, "not a single call", http.StatusBadRequest)
+		return
+	}
+	if call.Method == "fail" {
+		http.Error(w, "call failed", http.StatusServiceUnavailable)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"result":  call.Method,
+		"id":      call.ID,
+	})
+})
+
+func TestBatchHandler(t *testing.T) {
+	tests := []struct {
+		name             string
+		body             string
+		expectedResponse string
+	}{
+		{
+			name:             "single call",
+			body:             `{"jsonrpc":"2.0","id":1,"method":"a"}`,
+			expectedResponse: `{"id":1,"jsonrpc":"2.0","result":"a"}`,
+		},
+		{
+			name:             "batch",
+			body:             ` [{"jsonrpc":"2.0","id":1,"method":"a"},{"jsonrpc":"2.0","id":"2","method":"b"}]`,
+			expectedResponse: `[{"id":1,"jsonrpc":"2.0","result":"a"},{"id":"2","jsonrpc":"2.0","result":"b"}]`,
+		},
+		{
+			name:             "notifications are omitted",
+			body:             `[{"jsonrpc":"2.0","method":"a"},{"jsonrpc":"2.0","id":2,"method":"b"}]`,
+			expectedResponse: `[{"id":2,"jsonrpc":"2.0","result":"b"}]`,
+		},
+		{
+			name:             "only notifications",
+			body:             `[{"jsonrpc":"2.0","method":"a"}]`,
+			expectedResponse: ``,
+		},
+		{
+			name:             "failed call",
+			body:             `[{"jsonrpc":"2.0","id":1,"method":"fail"},{"jsonrpc":"2.0","id":2,"method":"b"}]`,
+			expectedResponse: `[{"jsonrpc":"2.0","error":{"code":-32603,"message":"call failed"},"id":1},{"id":2,"jsonrpc":"2.0","result":"b"}]`,
+		},
+		{
+			name:             "invalid call",
+			body:             `[1]`,
+			expectedResponse: `[{"jsonrpc":"2.0","error":{"code":-32600,"message":"invalid request"},"id":null}]`,
+		},
+		{
+			name:             "batch with invalid call",
+			body:             `[{"jsonrpc":"2.0","id":1,"method":"a"},{"jsonrpc":"2.0","id":2}]`,
+			expectedResponse: `[{"jsonrpc":"2.0","error":{"code":-32600,"message":"invalid request"},"id":null},{"jsonrpc":"2.0","error":{"code":-32600,"message":"invalid request"},"id":null}]`,
+		},
+		{
+			name:             "empty batch",
+			body:             `[]`,
+			expectedResponse: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"empty batch"},"id":null}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			handler := newBatchHandler(echoHandler)
+			r := httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			require.Equal(http.StatusOK, w.Code)
+			require.Equal(test.expectedResponse, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestBatchHandlerTooLarge(t *testing.T) {
+	require := require.New(t)
+
+	calls := make([]string, maxBatchSize+1)
+	for i := range calls {
+		calls[i] = `{"jsonrpc":"2.0","id":1,"method":"a"}`
+	}
+	body := "[" + strings.Join(calls, ",") + "]"
+
+	handler := newBatchHandler(echoHandler)
+	r := httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	var response jsonRPCErrorResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(invalidRequestErrorCode, response.Error.Code)
+}
+
+func TestBatchHandlerBodyTooLarge(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "single call",
+			body: `{"jsonrpc":"2.0","id":1,"method":"a"}` + strings.Repeat(" ", maxParsedBodySize),
+		},
+		{
+			name: "batch",
+			body: `[{"jsonrpc":"2.0","id":1,"method":"a"}]` + strings.Repeat(" ", maxParsedBodySize),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			handler := newBatchHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				require.FailNow("oversized request reached the handler")
+			}))
+			r := httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			require.Equal(http.StatusRequestEntityTooLarge, w.Code)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// maxParsedBodySize is the maximum number of bytes of a request body that are
+// parsed to find the JSON-RPC calls of a request. Larger requests are treated
+// as if they weren't JSON-RPC requests, and are rejected before they reach the
+// handler of their route.
+const maxParsedBodySize = 4 * 1024 * 1024
+
+type jsonRPCCall struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+type jsonRPCCallsKey struct{}
+
+// parsedJSONRPCCalls are the calls of a request that are stored in its
+// context.
+type parsedJSONRPCCalls struct {
+	calls   []jsonRPCCall
+	isBatch bool
+}
+
+// withJSONRPCCalls returns the calls made by [r], as returned by
+// [parseJSONRPCCalls], along with a request that stores them in its context.
+// The middlewares that inspect the calls of a request forward the returned
+// request so that the body of the request is only parsed once.
+func withJSONRPCCalls(r *http.Request) (*http.Request, []jsonRPCCall, bool) {
+	if parsed, ok := r.Context().Value(jsonRPCCallsKey{}).(parsedJSONRPCCalls); ok {
+		return r, parsed.calls, parsed.isBatch
+	}
+
+	calls, isBatch := parseJSONRPCCalls(r)
+	return storeJSONRPCCalls(r, calls, isBatch), calls, isBatch
+}
+
+// storeJSONRPCCalls returns a copy of [r] whose context stores [calls] as the
+// calls made by [r].
+func storeJSONRPCCalls(r *http.Request, calls []jsonRPCCall, isBatch bool) *http.Request {
+	ctx := context.WithValue(r.Context(), jsonRPCCallsKey{}, parsedJSONRPCCalls{
+		calls:   calls,
+		isBatch: isBatch,
+	})
+	return r.WithContext(ctx)
+}
+
+// parseJSONRPCCalls returns the calls made by [r] if it is a JSON-RPC request
+// and whether the calls were sent as a batch. If [r] isn't a JSON-RPC request,
+// no calls are returned.
+//
+// The body of [r] is preserved so that it can still be handled.
+func parseJSONRPCCalls(r *http.Request) ([]jsonRPCCall, bool) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		return nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxParsedBodySize+1))
+	r.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(body), r.Body),
+		Closer: r.Body,
+	}
+	if err != nil || len(body) > maxParsedBodySize {
+		return nil, false
+	}
+
+	body = bytes.TrimSpace(body)
+	var (
+		calls   []jsonRPCCall
+		isBatch = bytes.HasPrefix(body, []byte("["))
+	)
+	if isBatch {
+		if err := json.Unmarshal(body, &calls); err != nil {
+			return nil, false
+		}
+	} else {
+		var call jsonRPCCall
+		if err := json.Unmarshal(body, &call); err != nil {
+			return nil, false
+		}
+		calls = []jsonRPCCall{call}
+	}
+
+	for _, call := range calls {
+		if call.Method == "" {
+			return nil, false
+		}
+	}
+	return calls, isBatch
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type jsonRPCErrorResponse struct {
+	Version string          `json:"jsonrpc"`
+	Error   jsonRPCError    `json:"error"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// writeJSONRPCError responds to every call in [calls] with the provided
+// error.
+func writeJSONRPCError(
+	w http.ResponseWriter,
+	status int,
+	calls []jsonRPCCall,
+	isBatch bool,
+	code int,
+	message string,
+) {
+	responses := make([]jsonRPCErrorResponse, len(calls))
+	for i, call := range calls {
+		id := call.ID
+		if len(id) == 0 {
+			id = json.RawMessage("null")
+		}
+		responses[i] = jsonRPCErrorResponse{
+			Version: "2.0",
+			Error: jsonRPCError{
+				Code:    code,
+				Message: message,
+			},
+			ID: id,
+		}
+	}
+
+	var response interface{} = responses
+	if !isBatch && len(responses) == 1 {
+		response = responses[0]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithJSONRPCCalls(t *testing.T) {
+	require := require.New(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(`[{"id":1,"method":"a"},{"id":2,"method":"b"}]`))
+	r, calls, isBatch := withJSONRPCCalls(r)
+	require.True(isBatch)
+	require.Len(calls, 2)
+
+	// The body isn't consumed by the parsing.
+	body, err := io.ReadAll(r.Body)
+	require.NoError(err)
+	require.JSONEq(`[{"id":1,"method":"a"},{"id":2,"method":"b"}]`, string(body))
+
+	// The stored calls are returned without parsing the body again.
+	r.Body = io.NopCloser(strings.NewReader(`{"id":3,"method":"c"}`))
+	storedRequest, storedCalls, storedIsBatch := withJSONRPCCalls(r)
+	require.Equal(r, storedRequest)
+	require.Equal(calls, storedCalls)
+	require.True(storedIsBatch)
+}
+
+func TestBatchHandlerStoresCallOfSubRequests(t *testing.T) {
+	require := require.New(t)
+
+	var methods []string
+	handler := newBatchHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, calls, isBatch := withJSONRPCCalls(r)
+		require.False(isBatch)
+		require.Len(calls, 1)
+		methods = append(methods, calls[0].Method)
+		echoHandler.ServeHTTP(w, r)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(`[{"id":1,"method":"a"},{"id":2,"method":"b"}]`))
+	r, _, _ = withJSONRPCCalls(r)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	require.Equal(http.StatusOK, w.Code)
+	require.Equal([]string{"a", "b"}, methods)
+}
@@ -12,9 +12,10 @@ import (
 )
 
 type metrics struct {
-	numProcessing *prometheus.GaugeVec
-	numCalls      *prometheus.CounterVec
-	totalDuration *prometheus.GaugeVec
+	numProcessing  *prometheus.GaugeVec
+	numCalls       *prometheus.CounterVec
+	totalDuration  *prometheus.GaugeVec
+	numRateLimited *prometheus.CounterVec
 }
 
 func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
@@ -40,12 +41,20 @@ func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
 			},
 			[]string{"base"},
 		),
+		numRateLimited: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "calls_rate_limited",
+				Help: "The number of API calls that were rejected because they exceeded a rate limit",
+			},
+			[]string{"base", "limit"},
+		),
 	}
 
 	err := errors.Join(
 		registerer.Register(m.numProcessing),
 		registerer.Register(m.numCalls),
 		registerer.Register(m.totalDuration),
+		registerer.Register(m.numRateLimited),
 	)
 	return m, err
 }
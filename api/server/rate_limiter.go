@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/MetalBlockchain/metalgo/cache"
+)
+
+const (
+	// maxRateLimitedClients is the maximum number of token buckets tracked per
+	// API. If more clients call an API, the least recently seen clients have
+	// their buckets reset.
+	maxRateLimitedClients = 16 * 1024
+
+	// rateLimitedErrorCode is the JSON-RPC error code returned to rate limited
+	// calls.
+	rateLimitedErrorCode = -32005
+
+	clientLimit = "client"
+)
+
+var (
+	errNegativeRate     = errors.New("rate must be non-negative")
+	errNonPositiveBurst = errors.New("burst must be positive")
+)
+
+// RateLimitConfig describes the token bucket limits applied to API calls.
+type RateLimitConfig struct {
+	// Default limits of every API that isn't specified in [APIs].
+	Default APIRateLimits `json:"default"`
+
+	// APIs maps a chain alias, such as "X", or an API name, such as "info", to
+	// the limits of the API.
+	APIs map[string]APIRateLimits `json:"apis"`
+}
+
+func (c *RateLimitConfig) Verify() error {
+	if err := c.Default.Verify(); err != nil {
+		return fmt.Errorf("invalid default rate limits: %w", err)
+	}
+	for name, limits := range c.APIs {
+		if err := limits.Verify(); err != nil {
+			return fmt.Errorf("invalid rate limits of %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// limits returns the limits of the API named [name].
+func (c *RateLimitConfig) limits(name string) APIRateLimits {
+	if limits, ok := c.APIs[name]; ok {
+		return limits
+	}
+	return c.Default
+}
+
+// APIRateLimits are the limits applied to every client of an API.
+type APIRateLimits struct {
+	// Client limits the calls of each client IP to the API.
+	Client RateLimit `json:"client"`
+
+	// Methods limits the calls of each client IP to specific JSON-RPC
+	// methods. Method names are case-insensitive.
+	Methods map[string]RateLimit `json:"methods"`
+}
+
+func (l *APIRateLimits) Verify() error {
+	if err := l.Client.Verify(); err != nil {
+		return fmt.Errorf("invalid client rate limit: %w", err)
+	}
+	for method, limit := range l.Methods {
+		if err := limit.Verify(); err != nil {
+			return fmt.Errorf("invalid rate limit of %q: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// RateLimit is a token bucket that is refilled with [Rate] calls per second
+// and that holds at most [Burst] calls. If [Rate] is 0, calls are unlimited.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+func (l *RateLimit) Verify() error {
+	switch {
+	case l.Rate < 0:
+		return fmt.Errorf("%w: %f", errNegativeRate, l.Rate)
+	case l.Rate > 0 && l.Burst <= 0:
+		return fmt.Errorf("%w: %d", errNonPositiveBurst, l.Burst)
+	default:
+		return nil
+	}
+}
+
+func (l *RateLimit) enabled() bool {
+	return l.Rate > 0
+}
+
+// newRateLimiter returns a handler that enforces the limits of the API named
+// [name] before forwarding requests to [handler]. If the API has no limits,
+// [handler] is returned.
+func newRateLimiter(
+	config *RateLimitConfig,
+	name string,
+	numRateLimited *prometheus.CounterVec,
+	handler http.Handler,
+) http.Handler {
+	limits := config.limits(name)
+	methods := make(map[string]RateLimit, len(limits.Methods))
+	for method, limit := range limits.Methods {
+		if limit.enabled() {
+			methods[strings.ToLower(method)] = limit
+		}
+	}
+	if !limits.Client.enabled() && len(methods) == 0 {
+		return handler
+	}
+
+	return &rateLimiter{
+		name:    name,
+		client:  limits.Client,
+		methods: methods,
+		buckets: &cache.LRU[rateLimiterKey, *rate.Limiter]{
+			Size: maxRateLimitedClients,
+		},
+		numRateLimited: numRateLimited,
+		handler:        handler,
+	}
+}
+
+type rateLimiterKey struct {
+	ip     string
+	method string
+}
+
+// rateLimiter enforces per client token bucket limits on the calls to an API.
+type rateLimiter struct {
+	name    string
+	client  RateLimit
+	methods map[string]RateLimit // lowercase method -> limit
+	buckets cache.Cacher[rateLimiterKey, *rate.Limiter]
+
+	numRateLimited *prometheus.CounterVec
+	handler        http.Handler
+}
+
+func (l *rateLimiter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	r, calls, isBatch := withJSONRPCCalls(r)
+	var (
+		now = time.Now()
+		// Every call of a batch consumes a token so that batches can't be used
+		// to bypass the limits.
+		numCalls = max(len(calls), 1)
+	)
+	if l.client.enabled() && !l.allow(now, rateLimiterKey{ip: ip}, l.client, numCalls) {
+		l.reject(w, calls, isBatch, clientLimit)
+		return
+	}
+	for _, call := range calls {
+		method := strings.ToLower(call.Method)
+		limit, ok := l.methods[method]
+		if !ok {
+			continue
+		}
+		key := rateLimiterKey{
+			ip:     ip,
+			method: method,
+		}
+		if !l.allow(now, key, limit, 1) {
+			l.reject(w, calls, isBatch, method)
+			return
+		}
+	}
+
+	l.handler.ServeHTTP(w, r)
+}
+
+func (l *rateLimiter) allow(now time.Time, key rateLimiterKey, limit RateLimit, n int) bool {
+	bucket, ok := l.buckets.Get(key)
+	if !ok {
+		bucket = rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+		l.buckets.Put(key, bucket)
+	}
+	return bucket.AllowN(now, n)
+}
+
+// reject responds with a rate limit error. [limit] is the name of the limit
+// that was exceeded, which is either [clientLimit] or a configured method.
+func (l *rateLimiter) reject(w http.ResponseWriter, calls []jsonRPCCall, isBatch bool, limit string) {
+	l.numRateLimited.WithLabelValues(l.name, limit).Inc()
+
+	const message = "rate limit exceeded"
+	if len(calls) == 0 {
+		http.Error(w, message, http.StatusTooManyRequests)
+		return
+	}
+	writeJSONRPCError(w, http.StatusTooManyRequests, calls, isBatch, rateLimitedErrorCode, message)
+}
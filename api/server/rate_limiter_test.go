@@ -0,0 +1,204 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      RateLimitConfig
+		expectedErr error
+	}{
+		{
+			name: "unlimited",
+		},
+		{
+			name: "valid",
+			config: RateLimitConfig{
+				Default: APIRateLimits{
+					Client: RateLimit{Rate: 1, Burst: 1},
+				},
+				APIs: map[string]APIRateLimits{
+					"X": {
+						Methods: map[string]RateLimit{
+							"avm.getUTXOs": {Rate: 0.5, Burst: 2},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "negative rate",
+			config: RateLimitConfig{
+				Default: APIRateLimits{
+					Client: RateLimit{Rate: -1, Burst: 1},
+				},
+			},
+			expectedErr: errNegativeRate,
+		},
+		{
+			name: "no burst",
+			config: RateLimitConfig{
+				APIs: map[string]APIRateLimits{
+					"X": {
+						Methods: map[string]RateLimit{
+							"avm.getUTXOs": {Rate: 1},
+						},
+					},
+				},
+			},
+			expectedErr: errNonPositiveBurst,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	require := require.New(t)
+
+	config := RateLimitConfig{
+		Default: APIRateLimits{
+			Client: RateLimit{Rate: 0.001, Burst: 100},
+		},
+		APIs: map[string]APIRateLimits{
+			"X": {
+				Client: RateLimit{Rate: 0.001, Burst: 3},
+				Methods: map[string]RateLimit{
+					"avm.getUTXOs": {Rate: 0.001, Burst: 1},
+				},
+			},
+		},
+	}
+	numRateLimited := prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: "calls_rate_limited"},
+		[]string{"base", "limit"},
+	)
+	handler := newRateLimiter(&config, "X", numRateLimited, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(remoteAddr string, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(body))
+		r.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	// The method limit is enforced.
+	getUTXOs := `{"jsonrpc":"2.0","id":1,"method":"avm.getUTXOs"}`
+	require.Equal(http.StatusOK, serve("1.1.1.1:1", getUTXOs).Code)
+	w := serve("1.1.1.1:2", getUTXOs)
+	require.Equal(http.StatusTooManyRequests, w.Code)
+
+	var response jsonRPCErrorResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(rateLimitedErrorCode, response.Error.Code)
+	require.Equal(json.RawMessage("1"), response.ID)
+	require.Equal(1.0, testutil.ToFloat64(numRateLimited.WithLabelValues("X", "avm.getutxos")))
+
+	// Other clients are limited independently.
+	require.Equal(http.StatusOK, serve("2.2.2.2:1", getUTXOs).Code)
+
+	// The client limit counts every call in a batch.
+	w = serve("1.1.1.1:3", `[{"id":2,"method":"avm.getHeight"},{"id":3,"method":"avm.getHeight"}]`)
+	require.Equal(http.StatusTooManyRequests, w.Code)
+
+	var responses []jsonRPCErrorResponse
+	require.NoError(json.Unmarshal(w.Body.Bytes(), &responses))
+	require.Len(responses, 2)
+	require.Equal(json.RawMessage("2"), responses[0].ID)
+	require.Equal(json.RawMessage("3"), responses[1].ID)
+	require.Equal(1.0, testutil.ToFloat64(numRateLimited.WithLabelValues("X", clientLimit)))
+
+	// A rejected batch doesn't consume tokens of the client limit.
+	require.Equal(http.StatusOK, serve("1.1.1.1:4", `{"id":4,"method":"avm.getHeight"}`).Code)
+
+	// Requests that aren't JSON-RPC requests are limited by the client limit.
+	w = serve("1.1.1.1:5", "")
+	require.Equal(http.StatusTooManyRequests, w.Code)
+	require.Equal(2.0, testutil.ToFloat64(numRateLimited.WithLabelValues("X", clientLimit)))
+}
+
+func TestRateLimiterBatches(t *testing.T) {
+	config := RateLimitConfig{
+		APIs: map[string]APIRateLimits{
+			"X": {
+				Methods: map[string]RateLimit{
+					"avm.getUTXOs": {Rate: 0.001, Burst: 1},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{
+			name: "padded batch",
+			body: `[{"id":1,"method":"avm.getUTXOs"},{"id":2,"method":"avm.getUTXOs"}]` + strings.Repeat(" ", maxParsedBodySize),
+		},
+		{
+			name: "batch with invalid call",
+			body: `[{"id":1,"method":"avm.getUTXOs"},{"id":2,"method":"avm.getUTXOs"},{"id":3}]`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var numServed int
+			numRateLimited := prometheus.NewCounterVec(
+				prometheus.CounterOpts{Name: "calls_rate_limited"},
+				[]string{"base", "limit"},
+			)
+			handler := newRateLimiter(&config, "X", numRateLimited, newBatchHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				numServed++
+				echoHandler.ServeHTTP(w, r)
+			})))
+
+			// Calls that aren't counted by the rate limiter must not be
+			// served.
+			for i := 0; i < 3; i++ {
+				r := httptest.NewRequest(http.MethodPost, "/ext/bc/X", strings.NewReader(test.body))
+				handler.ServeHTTP(httptest.NewRecorder(), r)
+			}
+			require.Zero(numServed)
+		})
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	handler := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	config := RateLimitConfig{
+		APIs: map[string]APIRateLimits{
+			"X": {
+				Client: RateLimit{Rate: 1, Burst: 1},
+			},
+		},
+	}
+	numRateLimited := prometheus.NewCounterVec(
+		prometheus.CounterOpts{Name: "calls_rate_limited"},
+		[]string{"base", "limit"},
+	)
+	wrapped := newRateLimiter(&config, "P", numRateLimited, handler)
+	require.IsType(t, handler, wrapped)
+}
@@ -109,6 +109,41 @@ func (r *router) forceAddRouter(base, endpoint string, handler http.Handler) err
 	return err
 }
 
+// ReplaceRouter registers [handler] at [base]+[endpoint], replacing any handler
+// that was previously registered there.
+func (r *router) ReplaceRouter(base, endpoint string, handler http.Handler) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.routeLock.Lock()
+	defer r.routeLock.Unlock()
+
+	if r.reservedRoutes.Contains(base) {
+		return fmt.Errorf("%w: %s", errAlreadyReserved, base)
+	}
+	if _, exists := r.routes[base][endpoint]; !exists {
+		return r.forceAddRouter(base, endpoint, handler)
+	}
+	return r.forceReplaceRouter(base, endpoint, handler)
+}
+
+func (r *router) forceReplaceRouter(base, endpoint string, handler http.Handler) error {
+	url := base + endpoint
+	route := r.router.Get(url)
+	if route == nil {
+		return fmt.Errorf("failed to find route for %s", url)
+	}
+	route.Handler(handler)
+	r.routes[base][endpoint] = handler
+
+	var err error
+	for _, alias := range r.aliases[base] {
+		if innerErr := r.forceReplaceRouter(alias, endpoint, handler); err == nil {
+			err = innerErr
+		}
+	}
+	return err
+}
+
 func (r *router) AddAlias(base string, aliases ...string) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
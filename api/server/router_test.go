@@ -58,3 +58,35 @@ func TestBlock(t *testing.T) {
 	err := r.AddRouter("1", "", handler1)
 	require.ErrorIs(err, errAlreadyReserved)
 }
+
+func TestReplaceRouter(t *testing.T) {
+	require := require.New(t)
+	r := newRouter()
+
+	require.NoError(r.AddAlias("/1", "/2"))
+
+	handler1 := &testHandler{}
+	require.NoError(r.ReplaceRouter("/1", "", handler1))
+
+	handler, err := r.GetHandler("/2", "")
+	require.NoError(err)
+	require.Equal(handler1, handler)
+
+	handler2 := &testHandler{}
+	require.NoError(r.ReplaceRouter("/1", "", handler2))
+
+	for _, base := range []string{"/1", "/2"} {
+		handler, err := r.GetHandler(base, "")
+		require.NoError(err)
+		require.Same(handler2, handler)
+	}
+
+	request, err := http.NewRequest(http.MethodGet, "/2", nil)
+	require.NoError(err)
+	r.ServeHTTP(nil, request)
+	require.False(handler1.called)
+	require.True(handler2.called)
+
+	err = r.ReplaceRouter("/2", "", handler1)
+	require.ErrorIs(err, errAlreadyReserved)
+}
@@ -87,7 +87,14 @@ type server struct {
 	tracingEnabled bool
 	tracer         trace.Tracer
 
-	metrics *metrics
+	metrics    *metrics
+	rateLimits RateLimitConfig
+
+	// checkOrigin reports whether a websocket may be opened from the origin
+	// of a request.
+	checkOrigin func(*http.Request) bool
+
+	authorizationEnabled bool
 
 	// Maps endpoints to handlers
 	router *router
@@ -111,6 +118,8 @@ func New(
 	registerer prometheus.Registerer,
 	httpConfig HTTPConfig,
 	allowedHosts []string,
+	authorizer Authorizer,
+	rateLimits RateLimitConfig,
 ) (Server, error) {
 	m, err := newMetrics(registerer)
 	if err != nil {
@@ -118,7 +127,11 @@ func New(
 	}
 
 	router := newRouter()
-	allowedHostsHandler := filterInvalidHosts(router, allowedHosts)
+	var routerHandler http.Handler = router
+	if authorizer != nil {
+		routerHandler = authorizer.WrapHandler(router)
+	}
+	allowedHostsHandler := filterInvalidHosts(routerHandler, allowedHosts)
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   allowedOrigins,
 		AllowCredentials: true,
@@ -148,6 +161,7 @@ func New(
 
 	log.Info("API created",
 		zap.Strings("allowedOrigins", allowedOrigins),
+		zap.Bool("authorizationEnabled", authorizer != nil),
 	)
 
 	return &server{
@@ -157,9 +171,13 @@ func New(
 		tracingEnabled:  tracingEnabled,
 		tracer:          tracer,
 		metrics:         m,
+		rateLimits:      rateLimits,
+		checkOrigin:     NewOriginChecker(allowedOrigins),
 		router:          router,
 		srv:             httpServer,
 		listener:        listener,
+
+		authorizationEnabled: authorizer != nil,
 	}, nil
 }
 
@@ -186,6 +204,7 @@ func (s *server) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 	defaultEndpoint := path.Join(constants.ChainAliasPrefix, ctx.ChainID.String())
 
 	// Register each endpoint
+	var defaultHandler http.Handler
 	for extension, handler := range handlers {
 		// Validate that the route being added is valid
 		// e.g. "/foo" and "" are ok but "\n" is not
@@ -197,27 +216,51 @@ func (s *server) RegisterChain(chainName string, ctx *snow.ConsensusContext, vm
 			)
 			continue
 		}
-		if err := s.addChainRoute(chainName, handler, ctx, defaultEndpoint, extension); err != nil {
+		handler = s.wrapChainHandler(chainName, handler, ctx)
+		if extension == "" {
+			defaultHandler = handler
+		}
+		if err := s.addChainRoute(handler, defaultEndpoint, extension); err != nil {
 			s.log.Error("error adding route",
 				zap.Error(err),
 			)
 		}
 	}
+
+	// Serve the default endpoint of the chain over a websocket, unless the
+	// chain already provides its own websocket endpoint.
+	if _, ok := handlers[websocketEndpoint]; defaultHandler == nil || ok {
+		return
+	}
+	handler := newWebsocketHandler(s.log, defaultHandler, s.checkOrigin, s.authorizationEnabled)
+	if err := s.addChainRoute(handler, defaultEndpoint, websocketEndpoint); err != nil {
+		s.log.Error("error adding route",
+			zap.Error(err),
+		)
+	}
 }
 
-func (s *server) addChainRoute(chainName string, handler http.Handler, ctx *snow.ConsensusContext, base, endpoint string) error {
+func (s *server) addChainRoute(handler http.Handler, base, endpoint string) error {
 	url := fmt.Sprintf("%s/%s", baseURL, base)
 	s.log.Info("adding route",
 		zap.String("url", url),
 		zap.String("endpoint", endpoint),
 	)
+	// A chain that is restarted registers its handlers again, which replace
+	// the handlers of the stopped chain.
+	return s.router.ReplaceRouter(url, endpoint, handler)
+}
+
+// wrapChainHandler applies the middleware of the chain's routes to [handler].
+func (s *server) wrapChainHandler(chainName string, handler http.Handler, ctx *snow.ConsensusContext) http.Handler {
 	if s.tracingEnabled {
 		handler = api.TraceHandler(handler, chainName, s.tracer)
 	}
 	// Apply middleware to reject calls to the handler before the chain finishes bootstrapping
 	handler = rejectMiddleware(handler, ctx)
-	handler = s.metrics.wrapHandler(chainName, handler)
-	return s.router.AddRouter(url, endpoint, handler)
+	handler = newBatchHandler(handler)
+	handler = newRateLimiter(&s.rateLimits, chainName, s.metrics.numRateLimited, handler)
+	return s.metrics.wrapHandler(chainName, handler)
 }
 
 func (s *server) AddRoute(handler http.Handler, base, endpoint string) error {
@@ -241,6 +284,8 @@ func (s *server) addRoute(handler http.Handler, base, endpoint string) error {
 		handler = api.TraceHandler(handler, url, s.tracer)
 	}
 
+	handler = newBatchHandler(handler)
+	handler = newRateLimiter(&s.rateLimits, base, s.metrics.numRateLimited, handler)
 	handler = s.metrics.wrapHandler(base, handler)
 	return s.router.AddRouter(url, endpoint, handler)
 }
@@ -0,0 +1,262 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+const (
+	websocketEndpoint = "/ws"
+
+	// Time allowed to write a message to the client.
+	websocketWriteWait = 10 * time.Second
+
+	// Time allowed to read the next message or pong from the client.
+	websocketPongWait = 60 * time.Second
+
+	// Send pings to the client with this period. Must be less than
+	// [websocketPongWait].
+	websocketPingPeriod = (websocketPongWait * 9) / 10
+
+	// Maximum message size allowed from the client.
+	websocketMaxMessageSize = 4 * units.MiB
+
+	// Maximum number of messages of a connection that are handled
+	// concurrently.
+	websocketMaxConcurrentMessages = 16
+)
+
+var _ http.Handler = (*websocketHandler)(nil)
+
+// websocketHandler serves JSON-RPC calls over a persistent websocket
+// connection.
+//
+// Every text message sent by the client must be a JSON-RPC call or batch,
+// which is forwarded to the handler as if it was sent in an HTTP POST to the
+// handler. The handler must support batches. Responses are sent back as text messages. Messages are handled
+// concurrently, so responses may be sent in a different order than the
+// calls were received in.
+type websocketHandler struct {
+	log      logging.Logger
+	handler  http.Handler
+	upgrader websocket.Upgrader
+	// If true, websockets are only opened if the request was authorized by an
+	// [Authorizer] that provided the function authorizing their calls.
+	authorizationEnabled bool
+}
+
+// newWebsocketHandler returns a handler that serves [handler] over websockets
+// opened from the origins allowed by [checkOrigin].
+func newWebsocketHandler(
+	log logging.Logger,
+	handler http.Handler,
+	checkOrigin func(*http.Request) bool,
+	authorizationEnabled bool,
+) http.Handler {
+	return &websocketHandler{
+		log:                  log,
+		handler:              handler,
+		authorizationEnabled: authorizationEnabled,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  units.KiB,
+			WriteBufferSize: units.KiB,
+			CheckOrigin:     checkOrigin,
+		},
+	}
+}
+
+func (h *websocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	authorize := callAuthorizer(r)
+	if h.authorizationEnabled && authorize == nil {
+		// The calls received over the websocket couldn't be authorized.
+		h.log.Debug("rejected websocket without call authorizer",
+			zap.String("path", r.URL.Path),
+		)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handler := h.handler
+	if authorize != nil {
+		handler = authorizeCalls(handler, authorize)
+	}
+
+	c := &websocketConn{
+		conn:    conn,
+		handler: handler,
+		request: newWebsocketCallRequest(r),
+		sem:     make(chan struct{}, websocketMaxConcurrentMessages),
+	}
+	go c.pingPump(ctx, cancel)
+
+	err = c.readPump(ctx)
+	cancel()
+	c.wg.Wait()
+
+	h.log.Debug("websocket connection closed",
+		zap.Error(err),
+	)
+}
+
+// authorizeCalls only forwards the calls that are authorized by [authorize] to
+// [handler]. Unauthorized calls are responded to with an error.
+func authorizeCalls(handler http.Handler, authorize func(*http.Request) (int, string)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, calls, isBatch := withJSONRPCCalls(r)
+		status, _ := authorize(r)
+		if status == http.StatusOK {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		if len(calls) == 0 {
+			calls = []jsonRPCCall{{}}
+		}
+		writeJSONRPCError(w, status, calls, isBatch, unauthorizedErrorCode, http.StatusText(status))
+	})
+}
+
+// newWebsocketCallRequest returns the template of the requests that the calls
+// received over a websocket are forwarded with.
+func newWebsocketCallRequest(r *http.Request) *http.Request {
+	request := r.Clone(context.Background())
+	request.Method = http.MethodPost
+	request.URL.Path = strings.TrimSuffix(request.URL.Path, websocketEndpoint)
+	request.URL.RawPath = ""
+	for _, header := range []string{
+		"Connection",
+		"Upgrade",
+		"Sec-Websocket-Key",
+		"Sec-Websocket-Version",
+		"Sec-Websocket-Extensions",
+		"Sec-Websocket-Protocol",
+	} {
+		request.Header.Del(header)
+	}
+	return request
+}
+
+type websocketConn struct {
+	conn    *websocket.Conn
+	handler http.Handler
+	request *http.Request
+
+	// Limits the number of messages handled concurrently.
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	// Serializes writes to [conn].
+	writeLock sync.Mutex
+}
+
+// readPump handles the messages from the client until the connection is
+// closed or [ctx] is cancelled.
+func (c *websocketConn) readPump(ctx context.Context) error {
+	c.conn.SetReadLimit(websocketMaxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(websocketPongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(websocketPongWait))
+	})
+
+	for {
+		messageType, message, err := c.conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		_ = c.conn.SetReadDeadline(time.Now().Add(websocketPongWait))
+		if messageType != websocket.TextMessage {
+			continue
+		}
+
+		select {
+		case c.sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		c.wg.Add(1)
+		go func() {
+			defer func() {
+				<-c.sem
+				c.wg.Done()
+			}()
+
+			c.handle(ctx, message)
+		}()
+	}
+}
+
+func (c *websocketConn) handle(ctx context.Context, message []byte) {
+	request := c.request.Clone(ctx)
+
+	var response []byte
+	if bytes.HasPrefix(bytes.TrimSpace(message), []byte("[")) {
+		request.Body = io.NopCloser(bytes.NewReader(message))
+		request.ContentLength = int64(len(message))
+
+		recorder := newResponseRecorder()
+		c.handler.ServeHTTP(recorder, request)
+		response = recorder.body.Bytes()
+	} else {
+		response = serveCall(c.handler, request, message)
+	}
+
+	// Notifications don't have responses.
+	if len(response) == 0 {
+		return
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(websocketWriteWait))
+	_ = c.conn.WriteMessage(websocket.TextMessage, response)
+}
+
+// pingPump periodically pings the client to detect dead connections.
+func (c *websocketConn) pingPump(ctx context.Context, cancel context.CancelFunc) {
+	ticker := time.NewTicker(websocketPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// WriteControl is safe to call concurrently with the writes in
+			// [handle].
+			err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(websocketWriteWait))
+			if err != nil {
+				cancel()
+				_ = c.conn.Close()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+func TestWebsocketHandler(t *testing.T) {
+	require := require.New(t)
+
+	server := httptest.NewServer(newWebsocketHandler(logging.NoLog{}, newBatchHandler(echoHandler), allowAllOrigins, false))
+	defer server.Close()
+
+	uri := "ws" + strings.TrimPrefix(server.URL, "http") + websocketEndpoint
+	conn, _, err := websocket.DefaultDialer.Dial(uri, nil)
+	require.NoError(err)
+	defer conn.Close()
+
+	// Notifications don't receive a response, so the next response must be
+	// the response of the batch.
+	require.NoError(conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","method":"a"}`)))
+	require.NoError(conn.WriteMessage(websocket.TextMessage, []byte(`[{"jsonrpc":"2.0","id":1,"method":"b"},{"jsonrpc":"2.0","id":2,"method":"c"}]`)))
+
+	var responses []map[string]interface{}
+	require.NoError(conn.ReadJSON(&responses))
+	require.Len(responses, 2)
+	require.Equal("b", responses[0]["result"])
+	require.Equal("c", responses[1]["result"])
+
+	require.NoError(conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":3,"method":"fail"}`)))
+
+	var response jsonRPCErrorResponse
+	require.NoError(conn.ReadJSON(&response))
+	require.Equal(json.RawMessage("3"), response.ID)
+	require.Equal(internalErrorCode, response.Error.Code)
+	require.Equal("call failed", response.Error.Message)
+}
+
+func TestWebsocketHandlerRejectsOrigin(t *testing.T) {
+	require := require.New(t)
+
+	checkOrigin := NewOriginChecker([]string{"https://www.foobar.com"})
+	server := httptest.NewServer(newWebsocketHandler(logging.NoLog{}, newBatchHandler(echoHandler), checkOrigin, false))
+	defer server.Close()
+
+	uri := "ws" + strings.TrimPrefix(server.URL, "http") + websocketEndpoint
+	_, resp, err := websocket.DefaultDialer.Dial(uri, http.Header{
+		"Origin": []string{"https://www.evil.com"},
+	})
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+	require.Equal(http.StatusForbidden, resp.StatusCode)
+	require.NoError(resp.Body.Close())
+
+	conn, _, err := websocket.DefaultDialer.Dial(uri, http.Header{
+		"Origin": []string{"https://www.foobar.com"},
+	})
+	require.NoError(err)
+	require.NoError(conn.Close())
+}
+
+func TestWebsocketHandlerRequiresCallAuthorizer(t *testing.T) {
+	require := require.New(t)
+
+	server := httptest.NewServer(newWebsocketHandler(logging.NoLog{}, newBatchHandler(echoHandler), allowAllOrigins, true))
+	defer server.Close()
+
+	uri := "ws" + strings.TrimPrefix(server.URL, "http") + websocketEndpoint
+	_, resp, err := websocket.DefaultDialer.Dial(uri, nil)
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+	require.Equal(http.StatusUnauthorized, resp.StatusCode)
+	require.NoError(resp.Body.Close())
+}
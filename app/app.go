@@ -5,6 +5,7 @@ package app
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"sync"
@@ -14,6 +15,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/MetalBlockchain/metalgo/node"
+	"github.com/MetalBlockchain/metalgo/trace"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/perms"
@@ -52,10 +54,22 @@ func New(config node.Config) (App, error) {
 		return nil, fmt.Errorf("failed to restrict the permissions of the log directory with: %w", err)
 	}
 
+	// Export logs to the same endpoint as traces
+	var logExporter io.Closer = nopCloser{}
+	if config.TraceConfig.Enabled && config.TraceConfig.ExportLogs {
+		client, err := trace.NewOTLPClient(config.TraceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize log exporter: %w", err)
+		}
+		config.LoggingConfig.Exporter = client
+		logExporter = client
+	}
+
 	logFactory := logging.NewFactory(config.LoggingConfig)
 	log, err := logFactory.Make("main")
 	if err != nil {
 		logFactory.Close()
+		_ = logExporter.Close()
 		return nil, fmt.Errorf("failed to initialize log: %w", err)
 	}
 
@@ -66,6 +80,7 @@ func New(config node.Config) (App, error) {
 			zap.Error(err),
 		)
 		logFactory.Close()
+		_ = logExporter.Close()
 		return nil, err
 	}
 
@@ -74,13 +89,15 @@ func New(config node.Config) (App, error) {
 		log.Fatal("failed to initialize node", zap.Error(err))
 		log.Stop()
 		logFactory.Close()
+		_ = logExporter.Close()
 		return nil, fmt.Errorf("failed to initialize node: %w", err)
 	}
 
 	return &app{
-		node:       n,
-		log:        log,
-		logFactory: logFactory,
+		node:        n,
+		log:         log,
+		logFactory:  logFactory,
+		logExporter: logExporter,
 	}, nil
 }
 
@@ -136,10 +153,17 @@ func Run(app App) int {
 
 // app is a wrapper around a node that runs in this process
 type app struct {
-	node       *node.Node
-	log        logging.Logger
-	logFactory logging.Factory
-	exitWG     sync.WaitGroup
+	node        *node.Node
+	log         logging.Logger
+	logFactory  logging.Factory
+	logExporter io.Closer
+	exitWG      sync.WaitGroup
+}
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error {
+	return nil
 }
 
 // Start the business logic of the node (as opposed to config reading, etc).
@@ -155,6 +179,7 @@ func (a *app) Start() error {
 			}
 			a.log.Stop()
 			a.logFactory.Close()
+			_ = a.logExporter.Close()
 			a.exitWG.Done()
 		}()
 		defer func() {
@@ -0,0 +1,42 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MetalBlockchain/metalgo/api/health"
+)
+
+var _ health.Checker = (*chainHealthCheck)(nil)
+
+// chainHealthCheck reports the health of the most recently created instance of
+// a chain. Health checks can't be deregistered, so a chain that is stopped or
+// restarted replaces the checker of its previous instance.
+type chainHealthCheck struct {
+	lock    sync.RWMutex
+	checker health.Checker
+}
+
+func newChainHealthCheck(checker health.Checker) *chainHealthCheck {
+	return &chainHealthCheck{
+		checker: checker,
+	}
+}
+
+func (c *chainHealthCheck) HealthCheck(ctx context.Context) (interface{}, error) {
+	c.lock.RLock()
+	checker := c.checker
+	c.lock.RUnlock()
+
+	return checker.HealthCheck(ctx)
+}
+
+func (c *chainHealthCheck) set(checker health.Checker) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.checker = checker
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/api/health"
+)
+
+func TestChainHealthCheck(t *testing.T) {
+	require := require.New(t)
+
+	healthCheck := newChainHealthCheck(health.CheckerFunc(func(context.Context) (interface{}, error) {
+		return "first", nil
+	}))
+
+	details, err := healthCheck.HealthCheck(context.Background())
+	require.NoError(err)
+	require.Equal("first", details)
+
+	errTest := errors.New("non-nil error")
+	healthCheck.set(health.CheckerFunc(func(context.Context) (interface{}, error) {
+		return "second", errTest
+	}))
+
+	details, err = healthCheck.HealthCheck(context.Background())
+	require.ErrorIs(err, errTest)
+	require.Equal("second", details)
+}
@@ -23,10 +23,12 @@ import (
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/meterdb"
 	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/network"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/avalanche/bootstrap/queue"
 	"github.com/MetalBlockchain/metalgo/snow/engine/avalanche/state"
@@ -51,6 +53,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/metric"
 	"github.com/MetalBlockchain/metalgo/utils/perms"
 	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/units"
 	"github.com/MetalBlockchain/metalgo/vms"
 	"github.com/MetalBlockchain/metalgo/vms/fx"
 	"github.com/MetalBlockchain/metalgo/vms/metervm"
@@ -78,6 +81,8 @@ const (
 	defaultChannelSize = 1
 	initialQueueSize   = 3
 
+	resyncDeletionWriteSize = 4 * units.MiB
+
 	avalancheNamespace    = constants.PlatformName + metric.NamespaceSeparator + "avalanche"
 	handlerNamespace      = constants.PlatformName + metric.NamespaceSeparator + "handler"
 	meterchainvmNamespace = constants.PlatformName + metric.NamespaceSeparator + "meterchainvm"
@@ -105,6 +110,11 @@ var (
 	errCreatePlatformVM        = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped         = errors.New("subnets not bootstrapped")
 	errPartialSyncAsAValidator = errors.New("partial sync should not be configured for a validator")
+	errUnknownChain            = errors.New("unknown chain")
+	errPrimaryNetworkChain     = errors.New("chain is validated by the primary network")
+	errChainNotRunning         = errors.New("chain is not running")
+	errChainStopped            = errors.New("chain was stopped")
+	errQueueClosed             = errors.New("chain creation queue is closed")
 
 	fxs = map[ids.ID]fx.Factory{
 		secp256k1fx.ID: &secp256k1fx.Factory{},
@@ -121,6 +131,8 @@ var (
 //   - Add a registrant. When a chain is created, each registrant calls
 //     RegisterChain with the new chain as the argument.
 //   - Manage the aliases of chains
+//   - Stop, restart and re-sync chains that aren't validated by the primary
+//     network
 type Manager interface {
 	ids.Aliaser
 
@@ -147,6 +159,20 @@ type Manager interface {
 	// be called once.
 	StartChainCreator(platformChain ChainParameters) error
 
+	// StopChain shuts down the chain's handler and VM and stops routing
+	// messages to it. The chain is not restarted until RestartChain or
+	// ResyncChain is called.
+	StopChain(ctx context.Context, chainID ids.ID) error
+
+	// RestartChain stops the chain, if it is running, and queues it to be
+	// created again.
+	RestartChain(ctx context.Context, chainID ids.ID) error
+
+	// ResyncChain stops the chain, if it is running, deletes its database and
+	// data directory, and queues it to be created again. The chain is
+	// bootstrapped from scratch.
+	ResyncChain(ctx context.Context, chainID ids.ID) error
+
 	Shutdown()
 }
 
@@ -232,12 +258,18 @@ type ManagerConfig struct {
 	// This node will only consider the first [AncestorsMaxContainersReceived]
 	// containers in an ancestors message it receives.
 	BootstrapAncestorsMaxContainersReceived int
+	// Trusted checkpoints of each chain, sorted by height.
+	BootstrapCheckpoints map[ids.ID][]genesis.Checkpoint
 
 	Upgrades upgrade.Config
 
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
 
+	// Tracks the reputation of peers, which is used to select the peers that
+	// are sent requests.
+	Reputation reputation.Tracker
+
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
@@ -263,6 +295,16 @@ type manager struct {
 	chainCreatorShutdownCh chan struct{}
 	chainCreatorExited     sync.WaitGroup
 
+	// Serializes the creation and stopping of chains. Fields below that aren't
+	// protected by [chainsLock] are only accessed while holding this lock.
+	lifecycleLock sync.Mutex
+	// Key: Chain's ID
+	// Value: The parameters the chain was last created with
+	chainParams map[ids.ID]ChainParameters
+	// Key: Chain's ID
+	// Value: The health check of the chain
+	healthChecks map[ids.ID]*chainHealthCheck
+
 	chainsLock sync.Mutex
 	// Key: Chain's ID
 	// Value: The chain
@@ -327,6 +369,8 @@ func New(config *ManagerConfig) (Manager, error) {
 	return &manager{
 		Aliaser:                ids.NewAliaser(),
 		ManagerConfig:          *config,
+		chainParams:            make(map[ids.ID]ChainParameters),
+		healthChecks:           make(map[ids.ID]*chainHealthCheck),
 		chains:                 make(map[ids.ID]handler.Handler),
 		chainsQueue:            buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
 		unblockChainCreatorCh:  make(chan struct{}),
@@ -372,11 +416,28 @@ func (m *manager) QueueChainCreation(chainParams ChainParameters) {
 // Note: it is expected for the subnet to already have the chain registered as
 // bootstrapping before this function is called
 func (m *manager) createChain(chainParams ChainParameters) {
+	m.lifecycleLock.Lock()
+	defer m.lifecycleLock.Unlock()
+
+	m.chainsLock.Lock()
+	_, running := m.chains[chainParams.ID]
+	m.chainsLock.Unlock()
+	if running {
+		m.Log.Debug("skipping chain creation",
+			zap.String("reason", "chain already running"),
+			zap.Stringer("subnetID", chainParams.SubnetID),
+			zap.Stringer("chainID", chainParams.ID),
+			zap.Stringer("vmID", chainParams.VMID),
+		)
+		return
+	}
+
 	m.Log.Info("creating chain",
 		zap.Stringer("subnetID", chainParams.SubnetID),
 		zap.Stringer("chainID", chainParams.ID),
 		zap.Stringer("vmID", chainParams.VMID),
 	)
+	m.chainParams[chainParams.ID] = chainParams
 
 	sb, _ := m.Subnets.GetOrCreate(chainParams.SubnetID)
 
@@ -408,17 +469,22 @@ func (m *manager) createChain(chainParams ChainParameters) {
 			zap.Error(err),
 		)
 
+		// Remove any metrics registered before the failure so that the chain
+		// can be restarted.
+		m.deregisterMetrics(chainAlias, chainParams.VMID)
+
 		// Register the health check for this chain regardless of if it was
 		// created or not. This attempts to notify the node operator that their
 		// node may not be properly validating the subnet they expect to be
 		// validating.
 		healthCheckErr := fmt.Errorf("failed to create chain on subnet %s: %w", chainParams.SubnetID, err)
-		err := m.Health.RegisterHealthCheck(
+		err := m.setHealthCheck(
+			chainParams.ID,
 			chainAlias,
+			chainParams.SubnetID,
 			health.CheckerFunc(func(context.Context) (interface{}, error) {
 				return nil, healthCheckErr
 			}),
-			chainParams.SubnetID.String(),
 		)
 		if err != nil {
 			m.Log.Error("failed to register failing health check",
@@ -436,8 +502,14 @@ func (m *manager) createChain(chainParams ChainParameters) {
 	m.chains[chainParams.ID] = chain.Handler
 	m.chainsLock.Unlock()
 
-	// Associate the newly created chain with its default alias
-	if err := m.Alias(chainParams.ID, chainParams.ID.String()); err != nil {
+	// Associate the newly created chain with its default alias, unless the
+	// chain was previously created and is being restarted.
+	if _, err := m.Lookup(chainParams.ID.String()); err == nil {
+		m.Log.Debug("skipping chain aliasing",
+			zap.String("reason", "chain already aliased"),
+			zap.Stringer("chainID", chainParams.ID),
+		)
+	} else if err := m.Alias(chainParams.ID, chainParams.ID.String()); err != nil {
 		m.Log.Error("failed to alias the new chain with itself",
 			zap.Stringer("subnetID", chainParams.SubnetID),
 			zap.Stringer("chainID", chainParams.ID),
@@ -875,6 +947,7 @@ func (m *manager) createAvalancheChain(
 		p2pReg,
 		set.Of(ctx.NodeID),
 		nil,
+		m.Reputation,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating peer tracker: %w", err)
@@ -965,6 +1038,7 @@ func (m *manager) createAvalancheChain(
 		Timer:                          h,
 		PeerTracker:                    peerTracker,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
+		Checkpoints:                    m.BootstrapCheckpoints[ctx.ChainID],
 		DB:                             blockBootstrappingDB,
 		VM:                             vmWrappingProposerVM,
 	}
@@ -1044,7 +1118,7 @@ func (m *manager) createAvalancheChain(
 	})
 
 	// Register health check for this chain
-	if err := m.Health.RegisterHealthCheck(primaryAlias, h, ctx.SubnetID.String()); err != nil {
+	if err := m.setHealthCheck(ctx.ChainID, primaryAlias, ctx.SubnetID, h); err != nil {
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", primaryAlias, err)
 	}
 
@@ -1269,6 +1343,7 @@ func (m *manager) createSnowmanChain(
 		p2pReg,
 		set.Of(ctx.NodeID),
 		nil,
+		m.Reputation,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating peer tracker: %w", err)
@@ -1360,6 +1435,7 @@ func (m *manager) createSnowmanChain(
 		Timer:                          h,
 		PeerTracker:                    peerTracker,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
+		Checkpoints:                    m.BootstrapCheckpoints[ctx.ChainID],
 		DB:                             bootstrappingDB,
 		VM:                             vm,
 		Bootstrapped:                   bootstrapFunc,
@@ -1411,7 +1487,7 @@ func (m *manager) createSnowmanChain(
 	})
 
 	// Register health checks
-	if err := m.Health.RegisterHealthCheck(primaryAlias, h, ctx.SubnetID.String()); err != nil {
+	if err := m.setHealthCheck(ctx.ChainID, primaryAlias, ctx.SubnetID, h); err != nil {
 		return nil, fmt.Errorf("couldn't add health check for chain %s: %w", primaryAlias, err)
 	}
 
@@ -1434,6 +1510,171 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Context().State.Get().State == snow.NormalOp
 }
 
+func (m *manager) StopChain(ctx context.Context, chainID ids.ID) error {
+	m.lifecycleLock.Lock()
+	defer m.lifecycleLock.Unlock()
+
+	if err := m.verifyStoppable(chainID); err != nil {
+		return err
+	}
+	return m.stopChain(ctx, chainID)
+}
+
+func (m *manager) RestartChain(ctx context.Context, chainID ids.ID) error {
+	m.lifecycleLock.Lock()
+	defer m.lifecycleLock.Unlock()
+
+	if err := m.verifyStoppable(chainID); err != nil {
+		return err
+	}
+	if err := m.stopChain(ctx, chainID); err != nil && !errors.Is(err, errChainNotRunning) {
+		return err
+	}
+	return m.queueRestart(chainID)
+}
+
+func (m *manager) ResyncChain(ctx context.Context, chainID ids.ID) error {
+	m.lifecycleLock.Lock()
+	defer m.lifecycleLock.Unlock()
+
+	if err := m.verifyStoppable(chainID); err != nil {
+		return err
+	}
+	if err := m.stopChain(ctx, chainID); err != nil && !errors.Is(err, errChainNotRunning) {
+		return err
+	}
+
+	// Only the chains validated by the primary network are indexed, so the
+	// chain doesn't have an index that would need to be deleted.
+	m.Log.Info("deleting chain state",
+		zap.Stringer("chainID", chainID),
+	)
+	chainDB := prefixdb.New(chainID[:], m.DB)
+	if err := database.Clear(chainDB, resyncDeletionWriteSize); err != nil {
+		return fmt.Errorf("couldn't delete database of chain %s: %w", chainID, err)
+	}
+	chainDataDir := filepath.Join(m.ChainDataDir, chainID.String())
+	if err := os.RemoveAll(chainDataDir); err != nil {
+		return fmt.Errorf("couldn't delete data directory of chain %s: %w", chainID, err)
+	}
+	return m.queueRestart(chainID)
+}
+
+// verifyStoppable returns an error if [chainID] was never created or is
+// validated by the primary network, whose chains the node can't run without.
+//
+// Invariant: [m.lifecycleLock] must be held.
+func (m *manager) verifyStoppable(chainID ids.ID) error {
+	chainParams, ok := m.chainParams[chainID]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	if chainParams.SubnetID == constants.PrimaryNetworkID {
+		return fmt.Errorf("%w: %s", errPrimaryNetworkChain, chainID)
+	}
+	return nil
+}
+
+// stopChain shuts down the chain and removes everything that was registered
+// when the chain was created and isn't reused when it is created again.
+//
+// Invariant: [m.lifecycleLock] must be held.
+func (m *manager) stopChain(ctx context.Context, chainID ids.ID) error {
+	m.chainsLock.Lock()
+	chain, ok := m.chains[chainID]
+	m.chainsLock.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", errChainNotRunning, chainID)
+	}
+
+	chainAlias := m.PrimaryAliasOrDefault(chainID)
+	m.Log.Info("stopping chain",
+		zap.Stringer("chainID", chainID),
+		zap.String("chainAlias", chainAlias),
+	)
+
+	// Stopping the handler shuts down the engine and the VM. Once the handler
+	// has stopped, the router stops routing messages to it.
+	chain.Stop(ctx)
+	if _, err := chain.AwaitStopped(ctx); err != nil {
+		return fmt.Errorf("couldn't stop chain %s: %w", chainID, err)
+	}
+
+	m.chainsLock.Lock()
+	delete(m.chains, chainID)
+	m.chainsLock.Unlock()
+
+	// The API handlers of the chain remain registered until the chain is
+	// created again, so calls to them must be rejected.
+	chainCtx := chain.Context()
+	state := chainCtx.State.Get()
+	chainCtx.State.Set(snow.EngineState{
+		Type:  state.Type,
+		State: snow.Initializing,
+	})
+
+	m.deregisterMetrics(chainAlias, m.chainParams[chainID].VMID)
+
+	healthCheckErr := fmt.Errorf("%w on subnet %s", errChainStopped, chainCtx.SubnetID)
+	m.healthChecks[chainID].set(health.CheckerFunc(func(context.Context) (interface{}, error) {
+		return nil, healthCheckErr
+	}))
+	return nil
+}
+
+// queueRestart queues the chain to be created with the parameters it was last
+// created with.
+//
+// Invariant: [m.lifecycleLock] must be held.
+func (m *manager) queueRestart(chainID ids.ID) error {
+	if ok := m.chainsQueue.PushRight(m.chainParams[chainID]); !ok {
+		return fmt.Errorf("%w: couldn't restart chain %s", errQueueClosed, chainID)
+	}
+	return nil
+}
+
+// setHealthCheck reports the health of the chain using [checker]. The health
+// check of the chain is registered the first time the chain is created.
+//
+// Invariant: [m.lifecycleLock] must be held.
+func (m *manager) setHealthCheck(chainID ids.ID, chainAlias string, subnetID ids.ID, checker health.Checker) error {
+	if healthCheck, ok := m.healthChecks[chainID]; ok {
+		healthCheck.set(checker)
+		return nil
+	}
+
+	healthCheck := newChainHealthCheck(checker)
+	if err := m.Health.RegisterHealthCheck(chainAlias, healthCheck, subnetID.String()); err != nil {
+		return err
+	}
+	m.healthChecks[chainID] = healthCheck
+	return nil
+}
+
+// deregisterMetrics removes the metrics of the chain so that they can be
+// registered again when the chain is created again.
+//
+// Invariant: [m.lifecycleLock] must be held.
+func (m *manager) deregisterMetrics(chainAlias string, vmID ids.ID) {
+	gatherers := []metrics.MultiGatherer{
+		m.avalancheGatherer,
+		m.handlerGatherer,
+		m.meterChainVMGatherer,
+		m.meterDAGVMGatherer,
+		m.proposervmGatherer,
+		m.p2pGatherer,
+		m.snowmanGatherer,
+		m.stakeGatherer,
+		m.MeterDBMetrics,
+	}
+	if vmGatherer, ok := m.vmGatherer[vmID]; ok {
+		gatherers = append(gatherers, vmGatherer)
+	}
+	for _, gatherer := range gatherers {
+		gatherer.Deregister(chainAlias)
+	}
+}
+
 func (m *manager) registerBootstrappedHealthChecks() error {
 	bootstrappedCheck := health.CheckerFunc(func(context.Context) (interface{}, error) {
 		if subnetIDs := m.Subnets.Bootstrapping(); len(subnetIDs) != 0 {
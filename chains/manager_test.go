@@ -0,0 +1,121 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+	"github.com/MetalBlockchain/metalgo/utils/buffer"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+)
+
+func TestResyncChain(t *testing.T) {
+	var (
+		subnetChain = ChainParameters{
+			ID:       ids.GenerateTestID(),
+			SubnetID: ids.GenerateTestID(),
+			VMID:     ids.GenerateTestID(),
+		}
+		otherChain = ChainParameters{
+			ID:       ids.GenerateTestID(),
+			SubnetID: subnetChain.SubnetID,
+			VMID:     subnetChain.VMID,
+		}
+		primaryChain = ChainParameters{
+			ID:       ids.GenerateTestID(),
+			SubnetID: constants.PrimaryNetworkID,
+			VMID:     constants.PlatformVMID,
+		}
+		key   = []byte("key")
+		value = []byte("value")
+	)
+
+	tests := []struct {
+		name        string
+		chainID     ids.ID
+		expectedErr error
+	}{
+		{
+			name:    "subnet chain",
+			chainID: subnetChain.ID,
+		},
+		{
+			name:        "primary network chain",
+			chainID:     primaryChain.ID,
+			expectedErr: errPrimaryNetworkChain,
+		},
+		{
+			name:        "unknown chain",
+			chainID:     ids.GenerateTestID(),
+			expectedErr: errUnknownChain,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := memdb.New()
+			chainDataDir := t.TempDir()
+			chains := []ChainParameters{subnetChain, otherChain, primaryChain}
+			for _, chain := range chains {
+				require.NoError(prefixdb.New(chain.ID[:], db).Put(key, value))
+				require.NoError(os.Mkdir(filepath.Join(chainDataDir, chain.ID.String()), perms.ReadWriteExecute))
+			}
+
+			m := &manager{
+				ManagerConfig: ManagerConfig{
+					Log:          logging.NoLog{},
+					DB:           db,
+					ChainDataDir: chainDataDir,
+				},
+				chainsQueue: buffer.NewUnboundedBlockingDeque[ChainParameters](initialQueueSize),
+				chainParams: make(map[ids.ID]ChainParameters),
+				chains:      make(map[ids.ID]handler.Handler),
+			}
+			for _, chain := range chains {
+				m.chainParams[chain.ID] = chain
+			}
+
+			err := m.ResyncChain(context.Background(), test.chainID)
+			require.ErrorIs(err, test.expectedErr)
+
+			// Only the state of the re-synced chain is deleted.
+			for _, chain := range chains {
+				resynced := test.expectedErr == nil && chain.ID == test.chainID
+
+				has, err := prefixdb.New(chain.ID[:], db).Has(key)
+				require.NoError(err)
+				require.Equal(!resynced, has)
+
+				_, err = os.Stat(filepath.Join(chainDataDir, chain.ID.String()))
+				if resynced {
+					require.ErrorIs(err, os.ErrNotExist)
+				} else {
+					require.NoError(err)
+				}
+			}
+
+			// The re-synced chain is queued to be created again.
+			if test.expectedErr != nil {
+				require.Zero(m.chainsQueue.Len())
+				return
+			}
+			require.Equal(1, m.chainsQueue.Len())
+			chain, ok := m.chainsQueue.PopLeft()
+			require.True(ok)
+			require.Equal(subnetChain, chain)
+		})
+	}
+}
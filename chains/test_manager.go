@@ -3,7 +3,11 @@
 
 package chains
 
-import "github.com/MetalBlockchain/metalgo/ids"
+import (
+	"context"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
 
 // TestManager implements Manager but does nothing. Always returns nil error.
 // To be used only in tests
@@ -41,6 +45,18 @@ func (testManager) StartChainCreator(ChainParameters) error {
 	return nil
 }
 
+func (testManager) StopChain(context.Context, ids.ID) error {
+	return nil
+}
+
+func (testManager) RestartChain(context.Context, ids.ID) error {
+	return nil
+}
+
+func (testManager) ResyncChain(context.Context, ids.ID) error {
+	return nil
+}
+
 func (testManager) SubnetID(ids.ID) (ids.ID, error) {
 	return ids.Empty, nil
 }
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io/fs"
 	"math"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,12 +19,16 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/MetalBlockchain/metalgo/api/health"
 	"github.com/MetalBlockchain/metalgo/api/server"
 	"github.com/MetalBlockchain/metalgo/chains"
 	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/indexer"
 	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/network/capture"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/node"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
@@ -48,6 +53,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/components/gas"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/reward"
 	"github.com/MetalBlockchain/metalgo/vms/proposervm"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime/subprocess"
 
 	txfee "github.com/MetalBlockchain/metalgo/vms/platformvm/txs/fee"
 	validatorfee "github.com/MetalBlockchain/metalgo/vms/platformvm/validators/fee"
@@ -86,6 +92,9 @@ var (
 	errMissingStakingSigningKeyFile           = errors.New("missing staking signing key file")
 	errTracingEndpointEmpty                   = fmt.Errorf("%s cannot be empty", TracingEndpointKey)
 	errPluginDirNotADirectory                 = errors.New("plugin dir is not a directory")
+	errNegativePluginSupervisionBackoff       = fmt.Errorf("%s must be >= 0", PluginSupervisionInitialBackoffKey)
+	errPluginSupervisionBackoffAboveMax       = fmt.Errorf("%s can't be greater than %s", PluginSupervisionInitialBackoffKey, PluginSupervisionMaxBackoffKey)
+	errNonPositivePluginRestartTimeout        = fmt.Errorf("%s must be > 0", PluginSupervisionRestartTimeoutKey)
 	errCannotReadDirectory                    = errors.New("cannot read directory")
 	errUnmarshalling                          = errors.New("unmarshalling failed")
 	errFileDoesNotExist                       = errors.New("file does not exist")
@@ -171,6 +180,32 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		}
 	}
 
+	var rateLimitConfigBytes []byte
+	switch {
+	case v.IsSet(HTTPRateLimitContentKey):
+		rawContent := v.GetString(HTTPRateLimitContentKey)
+		rateLimitConfigBytes, err = base64.StdEncoding.DecodeString(rawContent)
+		if err != nil {
+			return node.HTTPConfig{}, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(HTTPRateLimitFileKey):
+		rateLimitFilepath := GetExpandedArg(v, HTTPRateLimitFileKey)
+		rateLimitConfigBytes, err = os.ReadFile(filepath.Clean(rateLimitFilepath))
+		if err != nil {
+			return node.HTTPConfig{}, err
+		}
+	}
+
+	var rateLimitConfig server.RateLimitConfig
+	if len(rateLimitConfigBytes) > 0 {
+		if err := json.Unmarshal(rateLimitConfigBytes, &rateLimitConfig); err != nil {
+			return node.HTTPConfig{}, fmt.Errorf("unable to parse rate limit config: %w", err)
+		}
+		if err := rateLimitConfig.Verify(); err != nil {
+			return node.HTTPConfig{}, err
+		}
+	}
+
 	return node.HTTPConfig{
 		HTTPConfig: server.HTTPConfig{
 			ReadTimeout:       v.GetDuration(HTTPReadTimeoutKey),
@@ -182,6 +217,12 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 			APIIndexerConfig: node.APIIndexerConfig{
 				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
 				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexWebhooks: indexer.WebhookConfig{
+					URLs:       v.GetStringSlice(IndexWebhookURLsKey),
+					MaxRetries: int(v.GetUint(IndexWebhookMaxRetriesKey)),
+					RetryDelay: v.GetDuration(IndexWebhookRetryDelayKey),
+					Timeout:    v.GetDuration(IndexWebhookTimeoutKey),
+				},
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
@@ -196,6 +237,8 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		HTTPSCert:          httpsCert,
 		HTTPAllowedOrigins: v.GetStringSlice(HTTPAllowedOrigins),
 		HTTPAllowedHosts:   v.GetStringSlice(HTTPAllowedHostsKey),
+		HTTPAuthConfigFile: GetExpandedArg(v, HTTPAuthConfigFileKey),
+		HTTPRateLimits:     rateLimitConfig,
 		ShutdownTimeout:    v.GetDuration(HTTPShutdownTimeoutKey),
 		ShutdownWait:       v.GetDuration(HTTPShutdownWaitKey),
 	}, nil
@@ -267,6 +310,37 @@ func getNetworkConfig(
 		allowPrivateIPs = v.GetBool(NetworkAllowPrivateIPsKey)
 	}
 
+	proxyURL := v.GetString(NetworkOutboundConnectionProxyURLKey)
+	if proxyURL != "" {
+		if _, err := dialer.ParseProxyURL(proxyURL); err != nil {
+			return network.Config{}, fmt.Errorf("invalid %s: %w", NetworkOutboundConnectionProxyURLKey, err)
+		}
+	}
+
+	captureOps, err := capture.ParseOps(v.GetStringSlice(NetworkCaptureOpsKey))
+	if err != nil {
+		return network.Config{}, fmt.Errorf("invalid %s: %w", NetworkCaptureOpsKey, err)
+	}
+
+	rawCaptureChainIDs := v.GetStringSlice(NetworkCaptureChainIDsKey)
+	captureChainIDs := set.NewSet[ids.ID](len(rawCaptureChainIDs))
+	for _, rawChainID := range rawCaptureChainIDs {
+		chainID, err := ids.FromString(rawChainID)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("invalid %s: %w", NetworkCaptureChainIDsKey, err)
+		}
+		captureChainIDs.Add(chainID)
+	}
+
+	rawProxyBypass := v.GetStringSlice(NetworkOutboundConnectionProxyBypassKey)
+	proxyBypass := make([]netip.Prefix, len(rawProxyBypass))
+	for i, rawPrefix := range rawProxyBypass {
+		proxyBypass[i], err = netip.ParsePrefix(rawPrefix)
+		if err != nil {
+			return network.Config{}, fmt.Errorf("invalid %s: %w", NetworkOutboundConnectionProxyBypassKey, err)
+		}
+	}
+
 	var supportedACPs set.Set[uint32]
 	for _, acp := range v.GetIntSlice(ACPSupportKey) {
 		if acp < 0 || acp > math.MaxInt32 {
@@ -323,6 +397,9 @@ func getNetworkConfig(
 				DiskThrottlerConfig: throttling.SystemThrottlerConfig{
 					MaxRecheckDelay: v.GetDuration(InboundThrottlerDiskMaxRecheckDelayKey),
 				},
+				SubnetThrottlerConfig: throttling.SubnetThrottlerConfig{
+					PrimaryNetworkReserve: v.GetFloat64(InboundThrottlerPrimaryNetworkReserveKey),
+				},
 			},
 
 			OutboundMsgThrottlerConfig: throttling.MsgByteThrottlerConfig{
@@ -348,10 +425,27 @@ func getNetworkConfig(
 		DialerConfig: dialer.Config{
 			ThrottleRps:       v.GetUint32(NetworkOutboundConnectionThrottlingRpsKey),
 			ConnectionTimeout: v.GetDuration(NetworkOutboundConnectionTimeoutKey),
+			ProxyURL:          proxyURL,
+			ProxyBypass:       proxyBypass,
 		},
 
 		TLSKeyLogFile: v.GetString(NetworkTLSKeyLogFileKey),
 
+		ReputationConfig: reputation.Config{
+			Halflife:   v.GetDuration(NetworkReputationHalflifeKey),
+			Threshold:  v.GetFloat64(NetworkReputationThresholdKey),
+			MaxTracked: v.GetInt(NetworkReputationMaxTrackedKey),
+		},
+
+		CaptureConfig: capture.Config{
+			Enabled:   v.GetBool(NetworkCaptureEnabledKey),
+			Directory: GetExpandedArg(v, NetworkCaptureDirKey),
+			MaxSize:   v.GetInt(NetworkCaptureMaxSizeKey),
+			MaxFiles:  v.GetInt(NetworkCaptureMaxFilesKey),
+			Ops:       captureOps,
+			ChainIDs:  captureChainIDs,
+		},
+
 		TimeoutConfig: network.TimeoutConfig{
 			PingPongTimeout:      v.GetDuration(NetworkPingTimeoutKey),
 			ReadHandshakeTimeout: v.GetDuration(NetworkReadHandshakeTimeoutKey),
@@ -402,6 +496,8 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= %d", InboundThrottlerCPUMaxRecheckDelayKey, constants.MinInboundThrottlerMaxRecheckDelay)
 	case config.ThrottlerConfig.InboundMsgThrottlerConfig.DiskThrottlerConfig.MaxRecheckDelay < constants.MinInboundThrottlerMaxRecheckDelay:
 		return network.Config{}, fmt.Errorf("%s must be >= %d", InboundThrottlerDiskMaxRecheckDelayKey, constants.MinInboundThrottlerMaxRecheckDelay)
+	case config.ThrottlerConfig.InboundMsgThrottlerConfig.SubnetThrottlerConfig.PrimaryNetworkReserve < 0 || config.ThrottlerConfig.InboundMsgThrottlerConfig.SubnetThrottlerConfig.PrimaryNetworkReserve > 1:
+		return network.Config{}, fmt.Errorf("%s must be in [0,1]", InboundThrottlerPrimaryNetworkReserveKey)
 	case config.MaxReconnectDelay < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxReconnectDelayKey)
 	case config.InitialReconnectDelay < 0:
@@ -419,6 +515,13 @@ func getNetworkConfig(
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
 	}
+
+	if err := config.ReputationConfig.Verify(); err != nil {
+		return network.Config{}, fmt.Errorf("invalid reputation config: %w", err)
+	}
+	if err := config.CaptureConfig.Verify(); err != nil {
+		return network.Config{}, fmt.Errorf("invalid capture config: %w", err)
+	}
 	return config, nil
 }
 
@@ -489,6 +592,12 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		BootstrapAncestorsMaxContainersReceived: int(v.GetUint(BootstrapAncestorsMaxContainersReceivedKey)),
 	}
 
+	checkpoints, err := getBootstrapCheckpoints(v)
+	if err != nil {
+		return node.BootstrapConfig{}, err
+	}
+	config.BootstrapCheckpoints = checkpoints
+
 	// TODO: Add a "BootstrappersKey" flag to more clearly enforce ID and IP
 	// length equality.
 	ipsSet := v.IsSet(BootstrapIPsKey)
@@ -545,9 +654,34 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 	return config, nil
 }
 
+func getBootstrapCheckpoints(v *viper.Viper) (map[ids.ID][]genesis.Checkpoint, error) {
+	var (
+		checkpointsBytes []byte
+		err              error
+	)
+	switch {
+	case v.IsSet(BootstrapCheckpointsFileContentKey):
+		checkpointsContent := v.GetString(BootstrapCheckpointsFileContentKey)
+		checkpointsBytes, err = base64.StdEncoding.DecodeString(checkpointsContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode bootstrap checkpoints base64 content: %w", err)
+		}
+	case v.IsSet(BootstrapCheckpointsFileKey):
+		checkpointsFileName := GetExpandedArg(v, BootstrapCheckpointsFileKey)
+		checkpointsBytes, err = os.ReadFile(checkpointsFileName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read bootstrap checkpoints file: %w", err)
+		}
+	default:
+		return nil, nil
+	}
+	return genesis.ParseCheckpoints(checkpointsBytes)
+}
+
 func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 	ipConfig := node.IPConfig{
 		PublicIP:                  v.GetString(PublicIPKey),
+		PublicSecondaryIP:         v.GetString(PublicSecondaryIPKey),
 		PublicIPResolutionService: v.GetString(PublicIPResolutionServiceKey),
 		PublicIPResolutionFreq:    v.GetDuration(PublicIPResolutionFreqKey),
 		ListenHost:                v.GetString(StakingHostKey),
@@ -1149,6 +1283,7 @@ func getDefaultSubnetConfig(v *viper.Viper) subnets.Config {
 		ValidatorOnly:               false,
 		ProposerMinBlockDelay:       proposervm.DefaultMinBlockDelay,
 		ProposerNumHistoricalBlocks: proposervm.DefaultNumHistoricalBlocks,
+		ThrottlerWeight:             subnets.DefaultThrottlerWeight,
 	}
 }
 
@@ -1222,6 +1357,12 @@ func getTraceConfig(v *viper.Viper) (trace.Config, error) {
 		return trace.Config{}, errTracingEndpointEmpty
 	}
 
+	exportMetrics := v.GetBool(TracingExportMetricsKey)
+	metricsExportFrequency := v.GetDuration(TracingMetricsExportFrequencyKey)
+	if exportMetrics && metricsExportFrequency <= 0 {
+		return trace.Config{}, fmt.Errorf("%q must be > 0", TracingMetricsExportFrequencyKey)
+	}
+
 	return trace.Config{
 		ExporterConfig: trace.ExporterConfig{
 			Type:     exporterType,
@@ -1233,6 +1374,10 @@ func getTraceConfig(v *viper.Viper) (trace.Config, error) {
 		TraceSampleRate: v.GetFloat64(TracingSampleRateKey),
 		AppName:         constants.AppName,
 		Version:         version.Current.String(),
+
+		ExportLogs:             v.GetBool(TracingExportLogsKey),
+		ExportMetrics:          exportMetrics,
+		MetricsExportFrequency: metricsExportFrequency,
 	}, nil
 }
 
@@ -1259,6 +1404,25 @@ func getPluginDir(v *viper.Viper) (string, error) {
 	return pluginDir, nil
 }
 
+func getPluginSupervisionConfig(v *viper.Viper) (subprocess.SupervisorConfig, error) {
+	config := subprocess.SupervisorConfig{
+		Enabled:        v.GetBool(PluginSupervisionEnabledKey),
+		MaxRestarts:    int(v.GetUint(PluginSupervisionMaxRestartsKey)),
+		InitialBackoff: v.GetDuration(PluginSupervisionInitialBackoffKey),
+		MaxBackoff:     v.GetDuration(PluginSupervisionMaxBackoffKey),
+		RestartTimeout: v.GetDuration(PluginSupervisionRestartTimeoutKey),
+	}
+	switch {
+	case config.InitialBackoff < 0:
+		return subprocess.SupervisorConfig{}, errNegativePluginSupervisionBackoff
+	case config.InitialBackoff > config.MaxBackoff:
+		return subprocess.SupervisorConfig{}, errPluginSupervisionBackoffAboveMax
+	case config.RestartTimeout <= 0:
+		return subprocess.SupervisorConfig{}, errNonPositivePluginRestartTimeout
+	}
+	return config, nil
+}
+
 func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	var (
 		nodeConfig node.Config
@@ -1270,6 +1434,11 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	nodeConfig.PluginSupervisionConfig, err = getPluginSupervisionConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	nodeConfig.ConsensusShutdownTimeout = v.GetDuration(ConsensusShutdownTimeoutKey)
 	if nodeConfig.ConsensusShutdownTimeout < 0 {
 		return node.Config{}, fmt.Errorf("%q must be >= 0", ConsensusShutdownTimeoutKey)
@@ -1336,6 +1505,14 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 	if nodeConfig.HealthCheckFreq < 0 {
 		return node.Config{}, fmt.Errorf("%s must be positive", HealthCheckFreqKey)
 	}
+	nodeConfig.HealthCheckConfig = health.Config{
+		HistorySize:   v.GetInt(HealthCheckHistorySizeKey),
+		FlapWindow:    v.GetDuration(HealthCheckFlapWindowKey),
+		FlapThreshold: v.GetInt(HealthCheckFlapThresholdKey),
+	}
+	if err := nodeConfig.HealthCheckConfig.Verify(); err != nil {
+		return node.Config{}, err
+	}
 	// Halflife of continuous averager used in health checks
 	healthCheckAveragerHalflife := v.GetDuration(HealthCheckAveragerHalflifeKey)
 	if healthCheckAveragerHalflife <= 0 {
@@ -1388,6 +1565,17 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 
 	nodeConfig.SubnetConfigs = subnetConfigs
 
+	// Subnets without a config use the primary network's config.
+	subnetWeights := make(map[ids.ID]uint64, nodeConfig.TrackedSubnets.Len())
+	for subnetID := range nodeConfig.TrackedSubnets {
+		subnetConfig, ok := subnetConfigs[subnetID]
+		if !ok {
+			subnetConfig = primaryNetworkConfig
+		}
+		subnetWeights[subnetID] = subnetConfig.ThrottlerWeight
+	}
+	nodeConfig.NetworkConfig.ThrottlerConfig.InboundMsgThrottlerConfig.SubnetThrottlerConfig.Weights = subnetWeights
+
 	// Benchlist
 	nodeConfig.BenchlistConfig, err = getBenchlistConfig(v, primaryNetworkConfig.ConsensusParameters)
 	if err != nil {
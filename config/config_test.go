@@ -11,15 +11,18 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/chains"
+	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
 	"github.com/MetalBlockchain/metalgo/subnets"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime/subprocess"
 )
 
 const chainConfigFilenameExtention = ".ex"
@@ -549,6 +552,108 @@ func setupConfigJSON(t *testing.T, rootPath string, value string) string {
 }
 
 // setups file creates necessary path and writes value to it.
+func TestGetBootstrapCheckpoints(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	checkpoints := []genesis.Checkpoint{
+		{
+			ChainID: chainID,
+			Height:  10,
+			BlockID: ids.GenerateTestID(),
+		},
+	}
+	checkpointsJSON, err := json.Marshal(checkpoints)
+	require.NoError(err)
+	expected := map[ids.ID][]genesis.Checkpoint{
+		chainID: checkpoints,
+	}
+
+	// No checkpoints are configured by default.
+	v := setupViperFlags()
+	parsedCheckpoints, err := getBootstrapCheckpoints(v)
+	require.NoError(err)
+	require.Empty(parsedCheckpoints)
+
+	// Checkpoints can be provided in a file.
+	root := t.TempDir()
+	setupFile(t, root, "checkpoints.json", string(checkpointsJSON))
+	v.Set(BootstrapCheckpointsFileKey, filepath.Join(root, "checkpoints.json"))
+	parsedCheckpoints, err = getBootstrapCheckpoints(v)
+	require.NoError(err)
+	require.Equal(expected, parsedCheckpoints)
+
+	// Checkpoints can be provided as base64 encoded content.
+	v = setupViperFlags()
+	v.Set(BootstrapCheckpointsFileContentKey, base64.StdEncoding.EncodeToString(checkpointsJSON))
+	parsedCheckpoints, err = getBootstrapCheckpoints(v)
+	require.NoError(err)
+	require.Equal(expected, parsedCheckpoints)
+}
+
+func TestGetPluginSupervisionConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		initialBackoff time.Duration
+		maxBackoff     time.Duration
+		restartTimeout time.Duration
+		expectedErr    error
+	}{
+		{
+			name:           "valid",
+			initialBackoff: time.Second,
+			maxBackoff:     time.Minute,
+			restartTimeout: time.Minute,
+		},
+		{
+			name:           "negative initial backoff",
+			initialBackoff: -time.Second,
+			maxBackoff:     time.Minute,
+			restartTimeout: time.Minute,
+			expectedErr:    errNegativePluginSupervisionBackoff,
+		},
+		{
+			name:           "initial backoff above max",
+			initialBackoff: time.Minute,
+			maxBackoff:     time.Second,
+			restartTimeout: time.Minute,
+			expectedErr:    errPluginSupervisionBackoffAboveMax,
+		},
+		{
+			name:           "zero restart timeout",
+			initialBackoff: time.Second,
+			maxBackoff:     time.Minute,
+			restartTimeout: 0,
+			expectedErr:    errNonPositivePluginRestartTimeout,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(PluginSupervisionEnabledKey, true)
+			v.Set(PluginSupervisionMaxRestartsKey, 3)
+			v.Set(PluginSupervisionInitialBackoffKey, test.initialBackoff)
+			v.Set(PluginSupervisionMaxBackoffKey, test.maxBackoff)
+			v.Set(PluginSupervisionRestartTimeoutKey, test.restartTimeout)
+
+			config, err := getPluginSupervisionConfig(v)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(subprocess.SupervisorConfig{
+				Enabled:        true,
+				MaxRestarts:    3,
+				InitialBackoff: test.initialBackoff,
+				MaxBackoff:     test.maxBackoff,
+				RestartTimeout: test.restartTimeout,
+			}, config)
+		})
+	}
+}
+
 func setupFile(t *testing.T, path string, fileName string, value string) {
 	require := require.New(t)
 
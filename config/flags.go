@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/MetalBlockchain/metalgo/api/health"
 	"github.com/MetalBlockchain/metalgo/database/leveldb"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/database/pebbledb"
@@ -43,6 +44,7 @@ var (
 	defaultDBDir                = filepath.Join(defaultUnexpandedDataDir, "db")
 	defaultLogDir               = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir           = filepath.Join(defaultUnexpandedDataDir, "profiles")
+	defaultCaptureDir           = filepath.Join(defaultUnexpandedDataDir, "captures")
 	defaultStakingPath          = filepath.Join(defaultUnexpandedDataDir, "staking")
 	defaultStakingTLSKeyPath    = filepath.Join(defaultStakingPath, "staker.key")
 	defaultStakingCertPath      = filepath.Join(defaultStakingPath, "staker.crt")
@@ -82,6 +84,13 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Plugin directory
 	fs.String(PluginDirKey, defaultPluginDir, "Path to the plugin directory")
 
+	// Plugin supervision
+	fs.Bool(PluginSupervisionEnabledKey, false, "If true, VM plugins that exit unexpectedly are restarted")
+	fs.Uint(PluginSupervisionMaxRestartsKey, 5, fmt.Sprintf("Maximum number of consecutive times a VM plugin is restarted before its chain is considered failed. The count is reset once a plugin has been running for %s", PluginSupervisionMaxBackoffKey))
+	fs.Duration(PluginSupervisionInitialBackoffKey, time.Second, "Time to wait before restarting a VM plugin for the first time. Doubles with each consecutive restart")
+	fs.Duration(PluginSupervisionMaxBackoffKey, time.Minute, "Maximum time to wait before restarting a VM plugin")
+	fs.Duration(PluginSupervisionRestartTimeoutKey, 5*time.Minute, "Maximum time a VM plugin restart may take, including re-initializing the plugin. A restart that times out counts as a failed restart")
+
 	// Config File
 	fs.String(ConfigFileKey, "", fmt.Sprintf("Specifies a config file. Ignored if %s is specified", ConfigContentKey))
 	fs.String(ConfigContentKey, "", "Specifies base64 encoded config content")
@@ -156,6 +165,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 
 	// Public IP Resolution
 	fs.String(PublicIPKey, "", "Public IP of this node for P2P communication")
+	fs.String(PublicSecondaryIPKey, "", "Public IP of this node for P2P communication in the other IP family of the public IP. If provided, both IPs are advertised to peers")
 	fs.Duration(PublicIPResolutionFreqKey, 5*time.Minute, "Frequency at which this node resolves/updates its public IP and renew NAT mappings, if applicable")
 	fs.String(PublicIPResolutionServiceKey, "", fmt.Sprintf("Only acceptable values are %q, %q or %q. When provided, the node will use that service to periodically resolve/update its public IP", dynamicip.OpenDNSName, dynamicip.IFConfigCoName, dynamicip.IFConfigMeName))
 
@@ -165,6 +175,8 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Outbound Connection Throttling
 	fs.Uint(NetworkOutboundConnectionThrottlingRpsKey, constants.DefaultOutboundConnectionThrottlingRps, "Make at most this number of outgoing peer connection attempts per second")
 	fs.Duration(NetworkOutboundConnectionTimeoutKey, constants.DefaultOutboundConnectionTimeout, "Timeout when dialing a peer")
+	fs.String(NetworkOutboundConnectionProxyURLKey, "", "SOCKS5 or HTTP CONNECT proxy that peers are dialed through, formatted as scheme://[user:password@]host:port, where scheme is socks5, socks5h, http or https. If empty, peers are dialed directly")
+	fs.StringSlice(NetworkOutboundConnectionProxyBypassKey, nil, "List of subnets, in CIDR notation, of peers that are dialed directly rather than through the proxy")
 	// Timeouts
 	fs.Duration(NetworkInitialTimeoutKey, constants.DefaultNetworkInitialTimeout, "Initial timeout value of the adaptive timeout manager")
 	fs.Duration(NetworkMinimumTimeoutKey, constants.DefaultNetworkMinimumTimeout, "Minimum timeout value of the adaptive timeout manager")
@@ -176,7 +188,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(NetworkPingTimeoutKey, constants.DefaultPingPongTimeout, "Timeout value for Ping-Pong with a peer")
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
-	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s]", compression.TypeZstd, compression.TypeNone))
+	fs.String(NetworkCompressionTypeKey, constants.DefaultNetworkCompressionType.String(), fmt.Sprintf("Compression type for outbound messages. Must be one of [%s, %s, %s, %s]", compression.TypeZstd, compression.TypeBrotli, compression.TypeLZ4, compression.TypeNone))
 
 	fs.Duration(NetworkMaxClockDifferenceKey, constants.DefaultNetworkMaxClockDifference, "Max allowed clock difference value between this node and peers")
 	// Note: The default value is set to false here because the default
@@ -197,6 +209,19 @@ func addNodeFlags(fs *pflag.FlagSet) {
 
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
 
+	// Reputation
+	fs.Duration(NetworkReputationHalflifeKey, constants.DefaultNetworkReputationHalflife, "Halflife of the decay of peer reputation scores back to 0")
+	fs.Float64(NetworkReputationThresholdKey, constants.DefaultNetworkReputationThreshold, "Reputation score below which peers are avoided, unless they are manually tracked. Must be in [-1000, 0]")
+	fs.Int(NetworkReputationMaxTrackedKey, constants.DefaultNetworkReputationMaxTracked, "Maximum number of peers whose reputation scores are tracked. Once reached, the least recently updated score is forgotten")
+
+	// Capture
+	fs.Bool(NetworkCaptureEnabledKey, false, "If true, records every p2p message sent and received to disk. Should only be enabled for debugging")
+	fs.String(NetworkCaptureDirKey, defaultCaptureDir, "Directory that p2p message captures are written to")
+	fs.Int(NetworkCaptureMaxSizeKey, constants.DefaultNetworkCaptureMaxSize, "The maximum file size in megabytes of a capture file before it is rotated")
+	fs.Int(NetworkCaptureMaxFilesKey, constants.DefaultNetworkCaptureMaxFiles, "The maximum number of rotated capture files to keep. If 0, all rotated files are kept")
+	fs.StringSlice(NetworkCaptureOpsKey, nil, "List of message ops, such as push_query or chits, to capture. If empty, all ops are captured")
+	fs.StringSlice(NetworkCaptureChainIDsKey, nil, "List of chainIDs to capture messages for. If empty, messages for all chains, including messages that aren't chain specific, are captured")
+
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, constants.DefaultBenchlistFailThreshold, "Number of consecutive failed queries before benchlisting a node")
 	fs.Duration(BenchlistDurationKey, constants.DefaultBenchlistDuration, "Max amount of time a peer is benchlisted after surpassing the threshold")
@@ -216,6 +241,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Uint64(InboundThrottlerBandwidthMaxBurstSizeKey, constants.DefaultInboundThrottlerBandwidthMaxBurstSize, "Max inbound bandwidth a node can use at once. Must be at least the max message size. See BandwidthThrottler")
 	fs.Duration(InboundThrottlerCPUMaxRecheckDelayKey, constants.DefaultInboundThrottlerCPUMaxRecheckDelay, "In the CPU-based network throttler, check at least this often whether the node's CPU usage has fallen to an acceptable level")
 	fs.Duration(InboundThrottlerDiskMaxRecheckDelayKey, constants.DefaultInboundThrottlerDiskMaxRecheckDelay, "In the disk-based network throttler, check at least this often whether the node's disk usage has fallen to an acceptable level")
+	fs.Float64(InboundThrottlerPrimaryNetworkReserveKey, constants.DefaultInboundThrottlerPrimaryNetworkReserve, "Portion, in [0, 1], of the inbound byte and bandwidth allocations that can only be used by primary network messages. The rest is divided between tracked subnets by their throttlerWeight")
 
 	// Outbound Throttling
 	fs.Uint64(OutboundThrottlerAtLargeAllocSizeKey, constants.DefaultOutboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in outbound message throttler")
@@ -232,6 +258,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(HTTPSCertContentKey, "", "Specifies base64 encoded TLS certificate for the HTTPs server")
 	fs.String(HTTPAllowedOrigins, "*", "Origins to allow on the HTTP port. Defaults to * which allows all origins. Example: https://*.avax.network https://*.avax-test.network")
 	fs.StringSlice(HTTPAllowedHostsKey, []string{"localhost"}, "List of acceptable host names in API requests. Provide the wildcard ('*') to accept requests from all hosts. API requests where the Host field is empty or an IP address will always be accepted. An API call whose HTTP Host field isn't acceptable will receive a 403 error code")
+	fs.String(HTTPAuthConfigFileKey, "", "Path to a JSON file describing the API tokens and the routes and JSON-RPC methods they are allowed to call. If empty, every API call is authorized")
+	fs.String(HTTPRateLimitFileKey, "", fmt.Sprintf("Path to a JSON file describing the rate limits of API calls. Ignored if %s is specified", HTTPRateLimitContentKey))
+	fs.String(HTTPRateLimitContentKey, "", "Specifies base64 encoded JSON describing the rate limits of API calls")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
 	fs.Duration(HTTPShutdownTimeoutKey, 10*time.Second, "Maximum duration to wait for existing connections to complete during node shutdown")
 	fs.Duration(HTTPReadTimeoutKey, 30*time.Second, "Maximum duration for reading the entire request, including the body. A zero or negative value means there will be no timeout")
@@ -249,6 +278,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Health Checks
 	fs.Duration(HealthCheckFreqKey, 30*time.Second, "Time between health checks")
 	fs.Duration(HealthCheckAveragerHalflifeKey, constants.DefaultHealthCheckAveragerHalflife, "Halflife of averager when calculating a running average in a health check")
+	fs.Int(HealthCheckHistorySizeKey, health.DefaultConfig.HistorySize, "Number of results to keep in the history of each health check")
+	fs.Duration(HealthCheckFlapWindowKey, health.DefaultConfig.FlapWindow, "Window of health check history used to count failures and detect flapping")
+	fs.Int(HealthCheckFlapThresholdKey, health.DefaultConfig.FlapThreshold, "Number of transitions between passing and failing within the flap window after which a health check is reported as flapping. If 0, flapping detection is disabled")
 	// Network Layer Health
 	fs.Duration(NetworkHealthMaxTimeSinceMsgSentKey, constants.DefaultNetworkHealthMaxTimeSinceMsgSent, "Network layer returns unhealthy if haven't sent a message for at least this much time")
 	fs.Duration(NetworkHealthMaxTimeSinceMsgReceivedKey, constants.DefaultNetworkHealthMaxTimeSinceMsgReceived, "Network layer returns unhealthy if haven't received a message for at least this much time")
@@ -307,6 +339,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Duration(BootstrapMaxTimeGetAncestorsKey, 50*time.Millisecond, "Max Time to spend fetching a container and its ancestors when responding to a GetAncestors")
 	fs.Uint(BootstrapAncestorsMaxContainersSentKey, 2000, "Max number of containers in an Ancestors message sent by this node")
 	fs.Uint(BootstrapAncestorsMaxContainersReceivedKey, 2000, "This node reads at most this many containers from an incoming Ancestors message")
+	fs.String(BootstrapCheckpointsFileKey, "", fmt.Sprintf("Specifies a file containing a JSON list of trusted checkpoints with the fields chainID, height, and blockID. Bootstrapping fails if an accepted block conflicts with a checkpoint. Ignored if %s is specified",
+		BootstrapCheckpointsFileContentKey))
+	fs.String(BootstrapCheckpointsFileContentKey, "", "Specifies base64 encoded trusted checkpoints content")
 
 	// Consensus
 	fs.Int(SnowSampleSizeKey, snowball.DefaultParameters.K, "Number of nodes to query for each network poll")
@@ -331,6 +366,10 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// Indexer
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
+	fs.StringSlice(IndexWebhookURLsKey, nil, "List of URLs that every indexed container is POSTed to. Ignored if index is disabled")
+	fs.Uint(IndexWebhookMaxRetriesKey, 5, "Number of times a failed webhook delivery is retried before it is dropped")
+	fs.Duration(IndexWebhookRetryDelayKey, time.Second, "Delay before the first retry of a failed webhook delivery. The delay doubles after every failed retry")
+	fs.Duration(IndexWebhookTimeoutKey, 10*time.Second, "Timeout of a single webhook delivery attempt")
 
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
@@ -382,6 +421,9 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.Bool(TracingInsecureKey, true, "If true, don't use TLS when sending trace data")
 	fs.Float64(TracingSampleRateKey, 0.1, "The fraction of traces to sample. If >= 1, always sample. If <= 0, never sample")
 	fs.StringToString(TracingHeadersKey, map[string]string{}, "The headers to provide the trace indexer")
+	fs.Bool(TracingExportLogsKey, false, "If true, export logs to the tracing endpoint. Ignored if tracing is disabled")
+	fs.Bool(TracingExportMetricsKey, false, "If true, export metrics to the tracing endpoint. Ignored if tracing is disabled")
+	fs.Duration(TracingMetricsExportFrequencyKey, 10*time.Second, "Frequency at which metrics are exported to the tracing endpoint")
 
 	fs.String(ProcessContextFileKey, defaultProcessContextPath, "The path to write process context to (including PID, API URI, and staking address).")
 }
@@ -60,6 +60,7 @@ const (
 	DBConfigFileKey                          = "db-config-file"
 	DBConfigContentKey                       = "db-config-file-content"
 	PublicIPKey                              = "public-ip"
+	PublicSecondaryIPKey                     = "public-secondary-ip"
 	PublicIPResolutionFreqKey                = "public-ip-resolution-frequency"
 	PublicIPResolutionServiceKey             = "public-ip-resolution-service"
 	HTTPHostKey                              = "http-host"
@@ -72,6 +73,9 @@ const (
 
 	HTTPAllowedOrigins       = "http-allowed-origins"
 	HTTPAllowedHostsKey      = "http-allowed-hosts"
+	HTTPAuthConfigFileKey    = "http-auth-config-file"
+	HTTPRateLimitFileKey     = "http-rate-limit-file"
+	HTTPRateLimitContentKey  = "http-rate-limit-file-content"
 	HTTPShutdownTimeoutKey   = "http-shutdown-timeout"
 	HTTPShutdownWaitKey      = "http-shutdown-wait"
 	HTTPReadTimeoutKey       = "http-read-timeout"
@@ -127,6 +131,17 @@ const (
 	NetworkInboundThrottlerMaxConnsPerSecKey           = "network-inbound-connection-throttling-max-conns-per-sec"
 	NetworkOutboundConnectionThrottlingRpsKey          = "network-outbound-connection-throttling-rps"
 	NetworkOutboundConnectionTimeoutKey                = "network-outbound-connection-timeout"
+	NetworkOutboundConnectionProxyURLKey               = "network-outbound-connection-proxy-url"
+	NetworkOutboundConnectionProxyBypassKey            = "network-outbound-connection-proxy-bypass"
+	NetworkReputationHalflifeKey                       = "network-reputation-halflife"
+	NetworkReputationThresholdKey                      = "network-reputation-threshold"
+	NetworkReputationMaxTrackedKey                     = "network-reputation-max-tracked-peers"
+	NetworkCaptureEnabledKey                           = "network-capture-enabled"
+	NetworkCaptureDirKey                               = "network-capture-dir"
+	NetworkCaptureMaxSizeKey                           = "network-capture-max-size"
+	NetworkCaptureMaxFilesKey                          = "network-capture-max-files"
+	NetworkCaptureOpsKey                               = "network-capture-ops"
+	NetworkCaptureChainIDsKey                          = "network-capture-chain-ids"
 	BenchlistFailThresholdKey                          = "benchlist-fail-threshold"
 	BenchlistDurationKey                               = "benchlist-duration"
 	BenchlistMinFailingDurationKey                     = "benchlist-min-failing-duration"
@@ -163,15 +178,29 @@ const (
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	IndexWebhookURLsKey                                = "index-webhook-urls"
+	IndexWebhookMaxRetriesKey                          = "index-webhook-max-retries"
+	IndexWebhookRetryDelayKey                          = "index-webhook-retry-delay"
+	IndexWebhookTimeoutKey                             = "index-webhook-timeout"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
 	HealthCheckAveragerHalflifeKey                     = "health-check-averager-halflife"
+	HealthCheckHistorySizeKey                          = "health-check-history-size"
+	HealthCheckFlapWindowKey                           = "health-check-flap-window"
+	HealthCheckFlapThresholdKey                        = "health-check-flap-threshold"
 	PluginDirKey                                       = "plugin-dir"
+	PluginSupervisionEnabledKey                        = "plugin-supervision-enabled"
+	PluginSupervisionMaxRestartsKey                    = "plugin-supervision-max-restarts"
+	PluginSupervisionInitialBackoffKey                 = "plugin-supervision-initial-backoff"
+	PluginSupervisionMaxBackoffKey                     = "plugin-supervision-max-backoff"
+	PluginSupervisionRestartTimeoutKey                 = "plugin-supervision-restart-timeout"
 	BootstrapBeaconConnectionTimeoutKey                = "bootstrap-beacon-connection-timeout"
 	BootstrapMaxTimeGetAncestorsKey                    = "bootstrap-max-time-get-ancestors"
 	BootstrapAncestorsMaxContainersSentKey             = "bootstrap-ancestors-max-containers-sent"
 	BootstrapAncestorsMaxContainersReceivedKey         = "bootstrap-ancestors-max-containers-received"
+	BootstrapCheckpointsFileKey                        = "bootstrap-checkpoints-file"
+	BootstrapCheckpointsFileContentKey                 = "bootstrap-checkpoints-file-content"
 	ChainDataDirKey                                    = "chain-data-dir"
 	ChainConfigDirKey                                  = "chain-config-dir"
 	ChainConfigContentKey                              = "chain-config-content"
@@ -189,6 +218,7 @@ const (
 	InboundThrottlerBandwidthMaxBurstSizeKey           = "throttler-inbound-bandwidth-max-burst-size"
 	InboundThrottlerCPUMaxRecheckDelayKey              = "throttler-inbound-cpu-max-recheck-delay"
 	InboundThrottlerDiskMaxRecheckDelayKey             = "throttler-inbound-disk-max-recheck-delay"
+	InboundThrottlerPrimaryNetworkReserveKey           = "throttler-inbound-primary-network-reserve"
 	CPUVdrAllocKey                                     = "throttler-inbound-cpu-validator-alloc"
 	CPUMaxNonVdrUsageKey                               = "throttler-inbound-cpu-max-non-validator-usage"
 	CPUMaxNonVdrNodeUsageKey                           = "throttler-inbound-cpu-max-non-validator-node-usage"
@@ -215,5 +245,8 @@ const (
 	TracingSampleRateKey                               = "tracing-sample-rate"
 	TracingExporterTypeKey                             = "tracing-exporter-type"
 	TracingHeadersKey                                  = "tracing-headers"
+	TracingExportLogsKey                               = "tracing-export-logs"
+	TracingExportMetricsKey                            = "tracing-export-metrics"
+	TracingMetricsExportFrequencyKey                   = "tracing-metrics-export-frequency"
 	ProcessContextFileKey                              = "process-context-file"
 )
@@ -16,10 +16,12 @@ const (
 )
 
 var (
-	headKey = []byte{0x01}
+	headKey    = []byte{0x01}
+	nodePrefix = []byte{0x00}
 
 	_ LinkedDB          = (*linkedDB)(nil)
 	_ database.Iterator = (*iterator)(nil)
+	_ database.Iterator = (*unorderedIterator)(nil)
 )
 
 // LinkedDB provides a key value interface while allowing iteration.
@@ -429,6 +431,62 @@ func (it *iterator) Value() []byte {
 
 func (*iterator) Release() {}
 
+// NewUnorderedIterator returns an iterator over the key-value pairs of the list
+// stored in [db], ordered by key rather than by their position in the list.
+//
+// Unlike the iterators of a LinkedDB, the returned iterator reads directly from
+// [db], so it iterates over a snapshot of the list if the iterators of [db] do.
+// The list must not have any unwritten changes.
+func NewUnorderedIterator(db database.Iteratee) database.Iterator {
+	return &unorderedIterator{
+		it: db.NewIteratorWithPrefix(nodePrefix),
+	}
+}
+
+type unorderedIterator struct {
+	it         database.Iterator
+	key, value []byte
+	err        error
+}
+
+func (it *unorderedIterator) Next() bool {
+	if it.err != nil || !it.it.Next() {
+		it.key = nil
+		it.value = nil
+		return false
+	}
+
+	n := node{}
+	if _, err := Codec.Unmarshal(it.it.Value(), &n); err != nil {
+		it.key = nil
+		it.value = nil
+		it.err = err
+		return false
+	}
+	it.key = it.it.Key()[len(nodePrefix):]
+	it.value = n.Value
+	return true
+}
+
+func (it *unorderedIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.it.Error()
+}
+
+func (it *unorderedIterator) Key() []byte {
+	return it.key
+}
+
+func (it *unorderedIterator) Value() []byte {
+	return it.value
+}
+
+func (it *unorderedIterator) Release() {
+	it.it.Release()
+}
+
 func nodeKey(key []byte) []byte {
 	newKey := make([]byte, len(key)+1)
 	copy(newKey[1:], key)
@@ -460,3 +460,39 @@ func TestLinkedDBHead(t *testing.T) {
 	require.Equal(key0, headKey)
 	require.Equal(value0, headVal)
 }
+
+func TestUnorderedIterator(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	ldb := NewDefault(db)
+
+	key0 := []byte("hello0")
+	key1 := []byte("hello1")
+	key2 := []byte("hello2")
+	value0 := []byte("world0")
+	value1 := []byte("world1")
+	value2 := []byte("world2")
+
+	require.NoError(ldb.Put(key1, value1))
+	require.NoError(ldb.Put(key0, value0))
+
+	it := NewUnorderedIterator(db)
+	defer it.Release()
+
+	// Changes made after the iterator was created aren't included, as the
+	// iterators of [db] are snapshots.
+	require.NoError(ldb.Put(key2, value2))
+	require.NoError(ldb.Delete(key1))
+
+	require.True(it.Next())
+	require.Equal(key0, it.Key())
+	require.Equal(value0, it.Value())
+
+	require.True(it.Next())
+	require.Equal(key1, it.Key())
+	require.Equal(value1, it.Value())
+
+	require.False(it.Next())
+	require.NoError(it.Error())
+}
@@ -4,8 +4,11 @@
 package genesis
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 
 	_ "embed"
 
@@ -15,6 +18,8 @@ import (
 )
 
 var (
+	errConflictingCheckpoints = errors.New("conflicting checkpoints")
+
 	//go:embed checkpoints.json
 	checkpointsPerNetworkJSON []byte
 
@@ -33,3 +38,60 @@ func GetCheckpoints(networkID uint32, chainID ids.ID) set.Set[ids.ID] {
 	networkName := constants.NetworkIDToNetworkName[networkID]
 	return checkpointsPerNetwork[networkName][chainID]
 }
+
+// Checkpoint is a block that the operator of this node trusts to have been
+// accepted by the network.
+type Checkpoint struct {
+	ChainID ids.ID `json:"chainID"`
+	Height  uint64 `json:"height"`
+	BlockID ids.ID `json:"blockID"`
+}
+
+// ParseCheckpoints parses a JSON list of checkpoints. The checkpoints are
+// returned grouped by chain and sorted by height.
+func ParseCheckpoints(checkpointsJSON []byte) (map[ids.ID][]Checkpoint, error) {
+	var checkpoints []Checkpoint
+	if err := json.Unmarshal(checkpointsJSON, &checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoints: %w", err)
+	}
+
+	checkpointsPerChain := make(map[ids.ID][]Checkpoint)
+	for _, checkpoint := range checkpoints {
+		checkpointsPerChain[checkpoint.ChainID] = append(checkpointsPerChain[checkpoint.ChainID], checkpoint)
+	}
+	for chainID, checkpoints := range checkpointsPerChain {
+		slices.SortFunc(checkpoints, func(a, b Checkpoint) int {
+			return cmp.Compare(a.Height, b.Height)
+		})
+
+		var (
+			compacted = checkpoints[:0]
+			blockIDs  set.Set[ids.ID]
+		)
+		for i, checkpoint := range checkpoints {
+			if i > 0 && checkpoints[i-1].Height == checkpoint.Height {
+				if checkpoints[i-1].BlockID != checkpoint.BlockID {
+					return nil, fmt.Errorf("%w: chain %s has blocks %s and %s at height %d",
+						errConflictingCheckpoints,
+						chainID,
+						checkpoints[i-1].BlockID,
+						checkpoint.BlockID,
+						checkpoint.Height,
+					)
+				}
+				continue
+			}
+			if blockIDs.Contains(checkpoint.BlockID) {
+				return nil, fmt.Errorf("%w: chain %s has block %s at multiple heights",
+					errConflictingCheckpoints,
+					chainID,
+					checkpoint.BlockID,
+				)
+			}
+			blockIDs.Add(checkpoint.BlockID)
+			compacted = append(compacted, checkpoint)
+		}
+		checkpointsPerChain[chainID] = compacted
+	}
+	return checkpointsPerChain, nil
+}
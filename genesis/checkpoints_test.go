@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func TestParseCheckpoints(t *testing.T) {
+	var (
+		chainID0 = ids.GenerateTestID()
+		chainID1 = ids.GenerateTestID()
+		blkID0   = ids.GenerateTestID()
+		blkID1   = ids.GenerateTestID()
+	)
+	tests := []struct {
+		name        string
+		checkpoints []Checkpoint
+		expected    map[ids.ID][]Checkpoint
+		expectedErr error
+	}{
+		{
+			name:        "empty",
+			checkpoints: []Checkpoint{},
+			expected:    map[ids.ID][]Checkpoint{},
+		},
+		{
+			name: "sorted by height per chain",
+			checkpoints: []Checkpoint{
+				{ChainID: chainID0, Height: 10, BlockID: blkID1},
+				{ChainID: chainID1, Height: 5, BlockID: blkID0},
+				{ChainID: chainID0, Height: 5, BlockID: blkID0},
+			},
+			expected: map[ids.ID][]Checkpoint{
+				chainID0: {
+					{ChainID: chainID0, Height: 5, BlockID: blkID0},
+					{ChainID: chainID0, Height: 10, BlockID: blkID1},
+				},
+				chainID1: {
+					{ChainID: chainID1, Height: 5, BlockID: blkID0},
+				},
+			},
+		},
+		{
+			name: "duplicate checkpoint",
+			checkpoints: []Checkpoint{
+				{ChainID: chainID0, Height: 5, BlockID: blkID0},
+				{ChainID: chainID0, Height: 5, BlockID: blkID0},
+			},
+			expected: map[ids.ID][]Checkpoint{
+				chainID0: {
+					{ChainID: chainID0, Height: 5, BlockID: blkID0},
+				},
+			},
+		},
+		{
+			name: "conflicting blocks at height",
+			checkpoints: []Checkpoint{
+				{ChainID: chainID0, Height: 5, BlockID: blkID0},
+				{ChainID: chainID0, Height: 5, BlockID: blkID1},
+			},
+			expectedErr: errConflictingCheckpoints,
+		},
+		{
+			name: "block at multiple heights",
+			checkpoints: []Checkpoint{
+				{ChainID: chainID0, Height: 5, BlockID: blkID0},
+				{ChainID: chainID0, Height: 6, BlockID: blkID0},
+			},
+			expectedErr: errConflictingCheckpoints,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			checkpointsJSON, err := json.Marshal(test.checkpoints)
+			require.NoError(err)
+
+			checkpoints, err := ParseCheckpoints(checkpointsJSON)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expected, checkpoints)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/MetalBlockchain/metalgo/api/info"
+	"github.com/MetalBlockchain/metalgo/genesis"
+	"github.com/MetalBlockchain/metalgo/indexer"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+)
+
+const (
+	uriKey            = "uri"
+	chainsKey         = "chains"
+	numCheckpointsKey = "num-checkpoints"
+	outputKey         = "output"
+)
+
+// This fetches the IDs of blocks periodically accepted on the requested chains
+// of a running node and writes them as trusted checkpoints that can be passed
+// to --bootstrap-checkpoints-file.
+//
+// The node must have the index API enabled, and must have indexed the chains
+// since genesis. Because the genesis block isn't indexed, the block at index i
+// has height i+1.
+func main() {
+	fs := pflag.NewFlagSet("trustedcheckpoints", pflag.ExitOnError)
+	uri := fs.String(uriKey, "http://127.0.0.1:9650", "URI of the node to fetch checkpoints from")
+	chains := fs.String(chainsKey, "P,X,C", "Comma separated list of aliases or IDs of the chains to fetch checkpoints for")
+	numCheckpoints := fs.Uint64(numCheckpointsKey, 100, "Maximum number of checkpoints to fetch per chain")
+	output := fs.String(outputKey, "checkpoints.json", "File to write the checkpoints to")
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		log.Fatalf("failed to parse flags: %v", err)
+	}
+	if *numCheckpoints == 0 {
+		log.Fatalf("--%s must be positive", numCheckpointsKey)
+	}
+
+	var (
+		ctx         = context.Background()
+		infoClient  = info.NewClient(*uri)
+		checkpoints []genesis.Checkpoint
+	)
+	for _, alias := range strings.Split(*chains, ",") {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			continue
+		}
+
+		chainCheckpoints, err := getCheckpoints(ctx, infoClient, *uri, alias, *numCheckpoints)
+		if err != nil {
+			log.Fatalf("failed to fetch %s checkpoints: %v", alias, err)
+		}
+		checkpoints = append(checkpoints, chainCheckpoints...)
+	}
+
+	checkpointsJSON, err := json.MarshalIndent(checkpoints, "", "\t")
+	if err != nil {
+		log.Fatalf("failed to marshal checkpoints: %v", err)
+	}
+
+	if err := perms.WriteFile(*output, checkpointsJSON, perms.ReadWrite); err != nil {
+		log.Fatalf("failed to write checkpoints: %v", err)
+	}
+}
+
+func getCheckpoints(
+	ctx context.Context,
+	infoClient info.Client,
+	uri string,
+	chainAlias string,
+	maxNumCheckpoints uint64,
+) ([]genesis.Checkpoint, error) {
+	chainID, err := infoClient.GetBlockchainID(ctx, chainAlias)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chainID: %w", err)
+	}
+
+	var (
+		chainURI = fmt.Sprintf("%s/ext/index/%s/block", uri, chainAlias)
+		client   = indexer.NewClient(chainURI)
+	)
+
+	// If there haven't been any blocks accepted, this will return an error.
+	_, lastIndex, err := client.GetLastAccepted(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		numAccepted = lastIndex + 1
+		// interval is rounded up to ensure that the number of checkpoints
+		// fetched is at most maxNumCheckpoints.
+		interval    = (numAccepted + maxNumCheckpoints - 1) / maxNumCheckpoints
+		checkpoints []genesis.Checkpoint
+	)
+	for index := interval - 1; index <= lastIndex; index += interval {
+		container, err := client.GetContainerByIndex(ctx, index)
+		if err != nil {
+			return nil, err
+		}
+
+		checkpoints = append(checkpoints, genesis.Checkpoint{
+			ChainID: chainID,
+			Height:  index + 1,
+			BlockID: container.ID,
+		})
+	}
+	return checkpoints, nil
+}
@@ -4,6 +4,7 @@
 package indexer
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -31,6 +32,7 @@ var (
 	errNoneAccepted        = errors.New("no containers have been accepted")
 	errNumToFetchInvalid   = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
 	errNoContainerAtIndex  = errors.New("no container at index")
+	errIndexClosed         = errors.New("index closed")
 
 	_ snow.Acceptor = (*index)(nil)
 )
@@ -54,6 +56,11 @@ type index struct {
 	// Container ID --> Index
 	containerToIndex database.Database
 	log              logging.Logger
+	// Closed and replaced every time a container is accepted
+	accepted chan struct{}
+	// Closed when the index is closed
+	closed    chan struct{}
+	closeOnce sync.Once
 }
 
 // Create a new thread-safe index.
@@ -75,6 +82,8 @@ func newIndex(
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
 		log:              log,
+		accepted:         make(chan struct{}),
+		closed:           make(chan struct{}),
 	}
 
 	// Get next accepted index from db
@@ -96,14 +105,19 @@ func newIndex(
 	return i, nil
 }
 
-// Close this index
+// Close this index. Calls after the first one do nothing and return nil.
 func (i *index) Close() error {
-	return errors.Join(
-		i.indexToContainer.Close(),
-		i.containerToIndex.Close(),
-		i.vDB.Close(),
-		i.baseDB.Close(),
-	)
+	var err error
+	i.closeOnce.Do(func() {
+		close(i.closed)
+		err = errors.Join(
+			i.indexToContainer.Close(),
+			i.containerToIndex.Close(),
+			i.vDB.Close(),
+			i.baseDB.Close(),
+		)
+	})
+	return err
 }
 
 // Index that the given transaction is accepted
@@ -158,7 +172,14 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	}
 
 	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
-	return i.vDB.Commit()
+	if err := i.vDB.Commit(); err != nil {
+		return err
+	}
+
+	// Notify anyone waiting for a new container
+	close(i.accepted)
+	i.accepted = make(chan struct{})
+	return nil
 }
 
 // Returns the ID of the [index]th accepted container and the container itself.
@@ -270,6 +291,29 @@ func (i *index) GetLastAccepted() (Container, error) {
 	return i.getContainerByIndex(lastAcceptedIndex)
 }
 
+// waitForIndex blocks until a container has been accepted at [index], [ctx]
+// is cancelled or the index is closed.
+func (i *index) waitForIndex(ctx context.Context, index uint64) error {
+	for {
+		i.lock.RLock()
+		isAccepted := index < i.nextAcceptedIndex
+		accepted := i.accepted
+		i.lock.RUnlock()
+
+		if isAccepted {
+			return nil
+		}
+
+		select {
+		case <-accepted:
+		case <-i.closed:
+			return errIndexClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // Assumes i.lock is held
 // Returns:
 //
@@ -78,6 +78,8 @@ func TestIndex(t *testing.T) {
 	// Create a new index with the same database and ensure contents still there
 	require.NoError(db.Commit())
 	require.NoError(idx.Close())
+	// Closing the index again does nothing
+	require.NoError(idx.Close())
 	db = versiondb.New(baseDB)
 	idx, err = newIndex(db, logging.NoLog{}, mockable.Clock{})
 	require.NoError(err)
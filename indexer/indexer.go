@@ -4,8 +4,10 @@
 package indexer
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"sync"
 
 	"github.com/gorilla/rpc/v2"
@@ -53,6 +55,11 @@ type Config struct {
 	VertexAcceptorGroup  snow.AcceptorGroup
 	APIServer            server.PathAdder
 	ShutdownF            func()
+	// Optional HTTP endpoints that are notified of accepted containers
+	Webhooks WebhookConfig
+	// Origins that are allowed to open index streams. Interpreted the same way
+	// as the allowed origins of the API server.
+	AllowedOrigins []string
 }
 
 // Indexer causes accepted containers for a given chain
@@ -80,7 +87,11 @@ func NewIndexer(config Config) (Indexer, error) {
 		blockIndices:         map[ids.ID]*index{},
 		pathAdder:            config.APIServer,
 		shutdownF:            config.ShutdownF,
+		webhookConfig:        config.Webhooks,
+		webhookClient:        &http.Client{},
+		checkStreamOrigin:    server.NewOriginChecker(config.AllowedOrigins),
 	}
+	indexer.webhookCtx, indexer.webhookCancel = context.WithCancel(context.Background())
 
 	hasRun, err := indexer.hasRun()
 	if err != nil {
@@ -126,6 +137,18 @@ type indexer struct {
 	txAcceptorGroup snow.AcceptorGroup
 	// Notifies of newly accepted vertices
 	vertexAcceptorGroup snow.AcceptorGroup
+
+	webhookConfig WebhookConfig
+	webhookClient *http.Client
+	// Cancelled on close to stop delivering webhook notifications
+	webhookCtx    context.Context
+	webhookCancel context.CancelFunc
+	// Tracks running webhooks
+	webhookWG sync.WaitGroup
+
+	// Reports whether an index stream may be opened from the origin of the
+	// request
+	checkStreamOrigin func(*http.Request) bool
 }
 
 // Assumes [ctx.Lock] is not held
@@ -341,9 +364,45 @@ func (i *indexer) registerChainHelper(
 		_ = index.Close()
 		return nil, err
 	}
+
+	// Create a websocket endpoint to stream accepted containers
+	streamHandler := newStreamHandler(i.log, index, i.checkStreamOrigin)
+	if err := i.pathAdder.AddRoute(streamHandler, "index/"+name, "/"+endpoint+streamEndpointSuffix); err != nil {
+		_ = index.Close()
+		return nil, err
+	}
+
+	i.startWebhooks(chainID, endpoint, index)
 	return index, nil
 }
 
+// startWebhooks starts delivering containers accepted into [index] to the
+// configured webhooks.
+//
+// Assumes [i.lock] is held.
+func (i *indexer) startWebhooks(chainID ids.ID, endpoint string, index *index) {
+	index.lock.RLock()
+	nextIndex := index.nextAcceptedIndex
+	index.lock.RUnlock()
+
+	for _, url := range i.webhookConfig.URLs {
+		webhook := &webhook{
+			log:       i.log,
+			config:    i.webhookConfig,
+			client:    i.webhookClient,
+			url:       url,
+			chainID:   chainID,
+			indexName: endpoint,
+			index:     index,
+		}
+		i.webhookWG.Add(1)
+		go func() {
+			defer i.webhookWG.Done()
+			webhook.run(i.webhookCtx, nextIndex)
+		}()
+	}
+}
+
 // Close this indexer. Stops indexing all chains.
 // Closes [i.db]. Assumes Close is only called after
 // the node is done making decisions.
@@ -361,6 +420,10 @@ func (i *indexer) close() error {
 	}
 	i.closed = true
 
+	// Stop the webhooks before closing the indices they read from
+	i.webhookCancel()
+	i.webhookWG.Wait()
+
 	errs := &wrappers.Errs{}
 	for chainID, txIndex := range i.txIndices {
 		errs.Add(
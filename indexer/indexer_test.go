@@ -170,9 +170,11 @@ func TestIndexer(t *testing.T) {
 	previouslyIndexed, err = idxr.previouslyIndexed(chain1Ctx.ChainID)
 	require.NoError(err)
 	require.True(previouslyIndexed)
-	require.Equal(1, server.timesCalled)
+	require.Equal(2, server.timesCalled) // block index and block stream
 	require.Equal("index/chain1", server.bases[0])
 	require.Equal("/block", server.endpoints[0])
+	require.Equal("index/chain1", server.bases[1])
+	require.Equal("/block/stream", server.endpoints[1])
 	require.Len(idxr.blockIndices, 1)
 	require.Empty(idxr.txIndices)
 	require.Empty(idxr.vtxIndices)
@@ -253,7 +255,7 @@ func TestIndexer(t *testing.T) {
 	container, err = blkIdx.GetLastAccepted()
 	require.NoError(err)
 	require.Equal(blkID, container.ID)
-	require.Equal(1, server.timesCalled) // block index for chain
+	require.Equal(2, server.timesCalled) // block index and stream for chain
 	require.Contains(server.endpoints, "/block")
 
 	// Register a DAG chain
@@ -268,11 +270,12 @@ func TestIndexer(t *testing.T) {
 	dagVM := vertexmock.NewLinearizableVM(ctrl)
 	idxr.RegisterChain("chain2", chain2Ctx, dagVM)
 	require.NoError(err)
-	require.Equal(4, server.timesCalled) // block index for chain, block index for dag, vtx index, tx index
+	require.Equal(8, server.timesCalled) // block index for chain, block index for dag, vtx index, tx index and their streams
 	require.Contains(server.bases, "index/chain2")
 	require.Contains(server.endpoints, "/block")
 	require.Contains(server.endpoints, "/vtx")
 	require.Contains(server.endpoints, "/tx")
+	require.Contains(server.endpoints, "/tx/stream")
 	require.Len(idxr.blockIndices, 2)
 	require.Len(idxr.txIndices, 1)
 	require.Len(idxr.vtxIndices, 1)
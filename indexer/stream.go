@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/utils/formatting"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+const (
+	streamEndpointSuffix = "/stream"
+
+	// Time allowed to write a message to the subscriber.
+	streamWriteWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the subscriber.
+	streamPongWait = 60 * time.Second
+
+	// Send pings to the subscriber with this period. Must be less than
+	// [streamPongWait].
+	streamPingPeriod = (streamPongWait * 9) / 10
+
+	// Maximum message size allowed from the subscriber. Subscribers aren't
+	// expected to send anything other than control messages.
+	streamMaxMessageSize = units.KiB
+
+	startIndexParam = "startIndex"
+	encodingParam   = "encoding"
+)
+
+var errInvalidStartIndex = errors.New("invalid start index")
+
+// streamArgs are the query parameters of a stream subscription.
+type streamArgs struct {
+	// The index of the first container to send. If not provided, only
+	// containers accepted after the subscription was created are sent.
+	startIndex    uint64
+	hasStartIndex bool
+	encoding      formatting.Encoding
+}
+
+func parseStreamArgs(query url.Values) (streamArgs, error) {
+	args := streamArgs{
+		encoding: formatting.Hex,
+	}
+	if startIndexStr := query.Get(startIndexParam); startIndexStr != "" {
+		startIndex, err := strconv.ParseUint(startIndexStr, 10, 64)
+		if err != nil {
+			return args, fmt.Errorf("%w %q: %w", errInvalidStartIndex, startIndexStr, err)
+		}
+		args.startIndex = startIndex
+		args.hasStartIndex = true
+	}
+	if encodingStr := query.Get(encodingParam); encodingStr != "" {
+		if err := args.encoding.UnmarshalJSON([]byte(strconv.Quote(encodingStr))); err != nil {
+			return args, fmt.Errorf("%w: %q", err, encodingStr)
+		}
+	}
+	return args, nil
+}
+
+// streamHandler serves websocket subscriptions to an index.
+//
+// Every accepted container, starting from the requested index, is sent to the
+// subscriber as a JSON encoded FormattedContainer in the order it was
+// accepted. Subscribers that disconnect can resume from the index after the
+// last container they received.
+type streamHandler struct {
+	log      logging.Logger
+	index    *index
+	upgrader websocket.Upgrader
+}
+
+// newStreamHandler returns a handler of subscriptions to [index] that can be
+// opened from the origins allowed by [checkOrigin].
+func newStreamHandler(log logging.Logger, index *index, checkOrigin func(*http.Request) bool) *streamHandler {
+	return &streamHandler{
+		log:   log,
+		index: index,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  units.KiB,
+			WriteBufferSize: units.KiB,
+			CheckOrigin:     checkOrigin,
+		},
+	}
+}
+
+func (s *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	args, err := parseStreamArgs(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go s.readPump(ctx, cancel, conn)
+	go s.pingPump(ctx, cancel, conn)
+
+	err = s.stream(ctx, conn, args)
+	s.log.Debug("index stream closed",
+		zap.Error(err),
+	)
+}
+
+// readPump discards all messages from the subscriber and cancels [ctx] once
+// the connection is closed.
+func (*streamHandler) readPump(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn) {
+	defer cancel()
+
+	conn.SetReadLimit(streamMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	})
+	for ctx.Err() == nil {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
+// pingPump periodically pings the subscriber to detect dead connections.
+func (*streamHandler) pingPump(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn) {
+	ticker := time.NewTicker(streamPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// WriteControl is safe to call concurrently with the writes in
+			// [stream].
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait))
+			if err != nil {
+				cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// stream sends containers to the subscriber until [ctx] is cancelled, the
+// index is closed or writing to the subscriber fails.
+func (s *streamHandler) stream(ctx context.Context, conn *websocket.Conn, args streamArgs) error {
+	nextIndex := args.startIndex
+	if !args.hasStartIndex {
+		s.index.lock.RLock()
+		nextIndex = s.index.nextAcceptedIndex
+		s.index.lock.RUnlock()
+	}
+
+	for {
+		if err := s.index.waitForIndex(ctx, nextIndex); err != nil {
+			return err
+		}
+
+		containers, err := s.index.GetContainerRange(nextIndex, MaxFetchedByRange)
+		if err != nil {
+			return err
+		}
+		for _, container := range containers {
+			formattedContainer, err := newFormattedContainer(container, nextIndex, args.encoding)
+			if err != nil {
+				return err
+			}
+			if err := conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+				return err
+			}
+			if err := conn.WriteJSON(formattedContainer); err != nil {
+				return err
+			}
+			nextIndex++
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/api/server"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/snowtest"
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/formatting"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+func TestStream(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{})
+	require.NoError(err)
+
+	containerIDs := make([]ids.ID, 4)
+	containerBytes := make([][]byte, 4)
+	for i := range containerIDs {
+		containerIDs[i] = ids.GenerateTestID()
+		containerBytes[i] = utils.RandomBytes(32)
+	}
+	for i := 0; i < 3; i++ {
+		require.NoError(idx.Accept(ctx, containerIDs[i], containerBytes[i]))
+	}
+
+	checkOrigin := server.NewOriginChecker([]string{"https://allowed.example.com"})
+	server := httptest.NewServer(newStreamHandler(logging.NoLog{}, idx, checkOrigin))
+	defer server.Close()
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+
+	// Streams can't be opened from origins that aren't allowed
+	_, response, err := websocket.DefaultDialer.Dial(url, http.Header{
+		"Origin": []string{"https://evil.example.com"},
+	})
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+	require.Equal(http.StatusForbidden, response.StatusCode)
+	require.NoError(response.Body.Close())
+
+	// Invalid arguments are rejected before upgrading the connection
+	_, response, err = websocket.DefaultDialer.Dial(url+"?startIndex=-1", nil)
+	require.ErrorIs(err, websocket.ErrBadHandshake)
+	require.Equal(http.StatusBadRequest, response.StatusCode)
+	require.NoError(response.Body.Close())
+
+	conn, response, err := websocket.DefaultDialer.Dial(url+"?startIndex=1&encoding=hexnc", http.Header{
+		"Origin": []string{"https://allowed.example.com"},
+	})
+	require.NoError(err)
+	require.NoError(response.Body.Close())
+	defer conn.Close()
+
+	readContainer := func(expectedIndex int) {
+		var container FormattedContainer
+		require.NoError(conn.ReadJSON(&container))
+		require.Equal(containerIDs[expectedIndex], container.ID)
+		require.Equal(uint64(expectedIndex), uint64(container.Index))
+		require.Equal(formatting.HexNC, container.Encoding)
+
+		expectedBytes, err := formatting.Encode(formatting.HexNC, containerBytes[expectedIndex])
+		require.NoError(err)
+		require.Equal(expectedBytes, container.Bytes)
+	}
+
+	// Previously accepted containers are replayed from the requested index
+	readContainer(1)
+	readContainer(2)
+
+	// Newly accepted containers are streamed
+	require.NoError(idx.Accept(ctx, containerIDs[3], containerBytes[3]))
+	readContainer(3)
+
+	// Closing the index closes the stream
+	require.NoError(idx.Close())
+	_, _, err = conn.ReadMessage()
+	require.Error(err) //nolint:forbidigo // the websocket close error is not exported
+}
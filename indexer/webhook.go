@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/formatting"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+const (
+	maxWebhookRetryDelay = time.Minute
+	// minWebhookReadRetryDelay is the minimum delay before containers are
+	// read again after reading them from the index failed.
+	minWebhookReadRetryDelay = 100 * time.Millisecond
+)
+
+var errUnexpectedStatusCode = errors.New("unexpected status code")
+
+// WebhookConfig configures the delivery of accepted containers to HTTP
+// endpoints.
+type WebhookConfig struct {
+	// URLs that every accepted container is POSTed to as a JSON encoded
+	// WebhookNotification.
+	URLs []string `json:"urls"`
+	// Number of times a failed delivery is retried before the notification is
+	// dropped.
+	MaxRetries int `json:"maxRetries"`
+	// Delay before the first retry of a failed delivery. The delay doubles
+	// after every failed retry, up to one minute.
+	RetryDelay time.Duration `json:"retryDelay"`
+	// Timeout of a single delivery attempt. If 0, attempts don't time out.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// WebhookNotification is the body of a webhook delivery.
+type WebhookNotification struct {
+	ChainID ids.ID `json:"chainID"`
+	// Name of the index the container was accepted into. One of "block",
+	// "vtx" or "tx".
+	Index     string             `json:"index"`
+	Container FormattedContainer `json:"container"`
+}
+
+// webhook delivers every container accepted into [index] to [url], in the
+// order the containers were accepted.
+//
+// Notifications that can't be delivered after all retries are dropped.
+// Delivery starts at the first container accepted after the node started, so
+// containers accepted while the node was offline, or dropped, must be fetched
+// with GetContainerRange.
+type webhook struct {
+	log       logging.Logger
+	config    WebhookConfig
+	client    *http.Client
+	url       string
+	chainID   ids.ID
+	indexName string
+	index     *index
+}
+
+// run delivers the containers starting at [nextIndex] until [ctx] is
+// cancelled or the index is closed.
+//
+// If the containers can't be read from the index, they are read again after a
+// delay that doubles after every failed read, up to one minute.
+func (w *webhook) run(ctx context.Context, nextIndex uint64) {
+	initialReadDelay := max(w.config.RetryDelay, minWebhookReadRetryDelay)
+	readDelay := initialReadDelay
+	for {
+		if err := w.index.waitForIndex(ctx, nextIndex); err != nil {
+			return
+		}
+
+		containers, err := w.index.GetContainerRange(nextIndex, MaxFetchedByRange)
+		if err != nil {
+			w.log.Warn("failed to read containers for webhook",
+				zap.String("url", w.url),
+				zap.Uint64("index", nextIndex),
+				zap.Duration("retryDelay", readDelay),
+				zap.Error(err),
+			)
+			if !sleep(ctx, readDelay) {
+				return
+			}
+			readDelay = min(2*readDelay, maxWebhookRetryDelay)
+			continue
+		}
+		readDelay = initialReadDelay
+
+		for _, container := range containers {
+			if err := w.deliver(ctx, container, nextIndex); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				w.log.Warn("dropping webhook notification",
+					zap.String("url", w.url),
+					zap.Stringer("containerID", container.ID),
+					zap.Uint64("index", nextIndex),
+					zap.Error(err),
+				)
+			}
+			nextIndex++
+		}
+	}
+}
+
+// deliver sends [container] to the webhook, retrying failed attempts up to
+// [w.config.MaxRetries] times.
+func (w *webhook) deliver(ctx context.Context, container Container, index uint64) error {
+	formattedContainer, err := newFormattedContainer(container, index, formatting.Hex)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(WebhookNotification{
+		ChainID:   w.chainID,
+		Index:     w.indexName,
+		Container: formattedContainer,
+	})
+	if err != nil {
+		return err
+	}
+
+	delay := w.config.RetryDelay
+	for retries := 0; ; retries++ {
+		err := w.post(ctx, body)
+		if err == nil || retries >= w.config.MaxRetries {
+			return err
+		}
+
+		w.log.Debug("failed to deliver webhook notification",
+			zap.String("url", w.url),
+			zap.Uint64("index", index),
+			zap.Duration("retryDelay", delay),
+			zap.Error(err),
+		)
+
+		if !sleep(ctx, delay) {
+			return ctx.Err()
+		}
+		delay = min(2*delay, maxWebhookRetryDelay)
+	}
+}
+
+// sleep waits for [delay]. Returns false if [ctx] was cancelled first.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (w *webhook) post(ctx context.Context, body []byte) error {
+	if w.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.config.Timeout)
+		defer cancel()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %d", errUnexpectedStatusCode, response.StatusCode)
+	}
+	return nil
+}
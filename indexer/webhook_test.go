@@ -0,0 +1,223 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/snowtest"
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+func TestWebhook(t *testing.T) {
+	tests := []struct {
+		name string
+		// Status codes returned by the webhook server, in order. Once
+		// exhausted, 200 is returned.
+		statusCodes []int
+		maxRetries  int
+		// Indices of the containers that are expected to be delivered
+		expectedIndices []uint64
+		// Expected number of delivery attempts
+		expectedAttempts int
+	}{
+		{
+			name:             "delivered",
+			expectedIndices:  []uint64{0, 1},
+			expectedAttempts: 2,
+		},
+		{
+			name:             "delivered after retry",
+			statusCodes:      []int{http.StatusInternalServerError},
+			maxRetries:       1,
+			expectedIndices:  []uint64{0, 1},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "dropped after retries",
+			statusCodes:      []int{http.StatusInternalServerError, http.StatusServiceUnavailable},
+			maxRetries:       1,
+			expectedIndices:  []uint64{1},
+			expectedAttempts: 3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				lock          sync.Mutex
+				attempts      int
+				notifications = make(chan WebhookNotification, len(test.expectedIndices))
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				lock.Lock()
+				defer lock.Unlock()
+
+				attempts++
+				if attempts <= len(test.statusCodes) {
+					w.WriteHeader(test.statusCodes[attempts-1])
+					return
+				}
+
+				var notification WebhookNotification
+				if err := json.NewDecoder(r.Body).Decode(&notification); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				notifications <- notification
+			}))
+			defer server.Close()
+
+			snowCtx := snowtest.Context(t, snowtest.CChainID)
+			ctx := snowtest.ConsensusContext(snowCtx)
+			idx, err := newIndex(memdb.New(), logging.NoLog{}, mockable.Clock{})
+			require.NoError(err)
+
+			w := &webhook{
+				log: logging.NoLog{},
+				config: WebhookConfig{
+					MaxRetries: test.maxRetries,
+					RetryDelay: time.Millisecond,
+					Timeout:    time.Second,
+				},
+				client:    server.Client(),
+				url:       server.URL,
+				chainID:   ctx.ChainID,
+				indexName: "block",
+				index:     idx,
+			}
+			runCtx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				w.run(runCtx, 0)
+			}()
+
+			containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+			for _, containerID := range containerIDs {
+				require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+			}
+
+			for _, expectedIndex := range test.expectedIndices {
+				notification := <-notifications
+				require.Equal(ctx.ChainID, notification.ChainID)
+				require.Equal("block", notification.Index)
+				require.Equal(containerIDs[expectedIndex], notification.Container.ID)
+				require.Equal(expectedIndex, uint64(notification.Container.Index))
+			}
+
+			cancel()
+			<-done
+
+			lock.Lock()
+			defer lock.Unlock()
+			require.Equal(test.expectedAttempts, attempts)
+		})
+	}
+}
+
+// failingGetDB fails reads while [fail] is set. [failed] is closed after the
+// first failed read.
+type failingGetDB struct {
+	database.Database
+
+	lock   sync.Mutex
+	fail   bool
+	failed chan struct{}
+}
+
+func (db *failingGetDB) Get(key []byte) ([]byte, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.fail {
+		select {
+		case <-db.failed:
+		default:
+			close(db.failed)
+		}
+		return nil, errTestReadFailed
+	}
+	return db.Database.Get(key)
+}
+
+func (db *failingGetDB) setFail(fail bool) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.fail = fail
+}
+
+var errTestReadFailed = errors.New("read failed")
+
+// Containers that fail to be read from the index are read again
+func TestWebhookRetriesFailedRead(t *testing.T) {
+	require := require.New(t)
+
+	notifications := make(chan WebhookNotification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var notification WebhookNotification
+		if err := json.NewDecoder(r.Body).Decode(&notification); err == nil {
+			notifications <- notification
+		}
+	}))
+	defer server.Close()
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	db := &failingGetDB{
+		Database: memdb.New(),
+		failed:   make(chan struct{}),
+	}
+	idx, err := newIndex(db, logging.NoLog{}, mockable.Clock{})
+	require.NoError(err)
+
+	containerID := ids.GenerateTestID()
+	require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+	db.setFail(true)
+
+	w := &webhook{
+		log: logging.NoLog{},
+		config: WebhookConfig{
+			RetryDelay: time.Millisecond,
+			Timeout:    time.Second,
+		},
+		client:    server.Client(),
+		url:       server.URL,
+		chainID:   ctx.ChainID,
+		indexName: "block",
+		index:     idx,
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.run(runCtx, 0)
+	}()
+
+	// Once the index can be read again, the container is delivered
+	<-db.failed
+	db.setFail(false)
+
+	notification := <-notifications
+	require.Equal(containerID, notification.Container.ID)
+
+	cancel()
+	<-done
+}
@@ -12,7 +12,9 @@ package messagemock
 import (
 	reflect "reflect"
 
+	ids "github.com/MetalBlockchain/metalgo/ids"
 	message "github.com/MetalBlockchain/metalgo/message"
+	compression "github.com/MetalBlockchain/metalgo/utils/compression"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -81,6 +83,35 @@ func (mr *OutboundMessageMockRecorder) BytesSavedCompression() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesSavedCompression", reflect.TypeOf((*OutboundMessage)(nil).BytesSavedCompression))
 }
 
+// BytesWithCompression mocks base method.
+func (m *OutboundMessage) BytesWithCompression(arg0 compression.Type) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BytesWithCompression", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BytesWithCompression indicates an expected call of BytesWithCompression.
+func (mr *OutboundMessageMockRecorder) BytesWithCompression(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BytesWithCompression", reflect.TypeOf((*OutboundMessage)(nil).BytesWithCompression), arg0)
+}
+
+// ChainID mocks base method.
+func (m *OutboundMessage) ChainID() ids.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainID")
+	ret0, _ := ret[0].(ids.ID)
+	return ret0
+}
+
+// ChainID indicates an expected call of ChainID.
+func (mr *OutboundMessageMockRecorder) ChainID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainID", reflect.TypeOf((*OutboundMessage)(nil).ChainID))
+}
+
 // Op mocks base method.
 func (m *OutboundMessage) Op() message.Op {
 	m.ctrl.T.Helper()
@@ -6,6 +6,7 @@ package message
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -105,8 +106,15 @@ type OutboundMessage interface {
 	BypassThrottling() bool
 	// Op returns the op that describes this message type
 	Op() Op
+	// ChainID returns the chain this message is destined for. If the message
+	// isn't chain specific, [ids.Empty] is returned.
+	ChainID() ids.ID
 	// Bytes returns the bytes that will be sent
 	Bytes() []byte
+	// BytesWithCompression returns the bytes that will be sent to a peer that
+	// negotiated [compressionType]. If this message isn't compressed, the
+	// result of [Bytes] is returned regardless of [compressionType].
+	BytesWithCompression(compressionType compression.Type) ([]byte, error)
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
@@ -115,8 +123,19 @@ type OutboundMessage interface {
 type outboundMessage struct {
 	bypassThrottling      bool
 	op                    Op
+	chainID               ids.ID
 	bytes                 []byte
 	bytesSavedCompression int
+
+	// The following fields are only set if the message was compressed, so
+	// that it can be recompressed for peers that don't support
+	// [compressionType].
+	builder         *msgBuilder
+	msg             *p2p.Message
+	compressionType compression.Type
+
+	lock         sync.Mutex
+	recompressed map[compression.Type][]byte
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -127,21 +146,50 @@ func (m *outboundMessage) Op() Op {
 	return m.op
 }
 
+func (m *outboundMessage) ChainID() ids.ID {
+	return m.chainID
+}
+
 func (m *outboundMessage) Bytes() []byte {
 	return m.bytes
 }
 
+func (m *outboundMessage) BytesWithCompression(compressionType compression.Type) ([]byte, error) {
+	if m.msg == nil || compressionType == m.compressionType {
+		return m.bytes, nil
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if b, ok := m.recompressed[compressionType]; ok {
+		return b, nil
+	}
+
+	b, _, _, err := m.builder.marshal(m.msg, compressionType)
+	if err != nil {
+		return nil, err
+	}
+	if m.recompressed == nil {
+		m.recompressed = make(map[compression.Type][]byte)
+	}
+	m.recompressed[compressionType] = b
+	return b, nil
+}
+
 func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
-// TODO: add other compression algorithms with extended interface
 type msgBuilder struct {
 	log logging.Logger
 
-	zstdCompressor compression.Compressor
-	count          *prometheus.CounterVec // type + op + direction
-	duration       *prometheus.GaugeVec   // type + op + direction
+	compressors map[compression.Type]compression.Compressor
+
+	count             *prometheus.CounterVec // type + op + direction
+	duration          *prometheus.GaugeVec   // type + op + direction
+	uncompressedBytes *prometheus.CounterVec // type + op + direction
+	compressedBytes   *prometheus.CounterVec // type + op + direction
 
 	maxMessageTimeout time.Duration
 }
@@ -155,11 +203,23 @@ func newMsgBuilder(
 	if err != nil {
 		return nil, err
 	}
+	brotliCompressor, err := compression.NewBrotliCompressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	lz4Compressor, err := compression.NewLZ4Compressor(constants.DefaultMaxMessageSize)
+	if err != nil {
+		return nil, err
+	}
 
 	mb := &msgBuilder{
 		log: log,
 
-		zstdCompressor: zstdCompressor,
+		compressors: map[compression.Type]compression.Compressor{
+			compression.TypeZstd:   zstdCompressor,
+			compression.TypeBrotli: brotliCompressor,
+			compression.TypeLZ4:    lz4Compressor,
+		},
 		count: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Name: "codec_compressed_count",
@@ -174,12 +234,28 @@ func newMsgBuilder(
 			},
 			metricLabels,
 		),
+		uncompressedBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "codec_uncompressed_bytes",
+				Help: "number of bytes handled by the compressors before compression or after decompression",
+			},
+			metricLabels,
+		),
+		compressedBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "codec_compressed_bytes",
+				Help: "number of bytes handled by the compressors after compression or before decompression",
+			},
+			metricLabels,
+		),
 
 		maxMessageTimeout: maxMessageTimeout,
 	}
 	return mb, errors.Join(
 		metrics.Register(mb.count),
 		metrics.Register(mb.duration),
+		metrics.Register(mb.uncompressedBytes),
+		metrics.Register(mb.compressedBytes),
 	)
 }
 
@@ -207,21 +283,30 @@ func (mb *msgBuilder) marshal(
 		startTime     = time.Now()
 		compressedMsg p2p.Message
 	)
-	switch compressionType {
-	case compression.TypeNone:
+	if compressionType == compression.TypeNone {
 		return uncompressedMsgBytes, 0, op, nil
+	}
+	compressor, ok := mb.compressors[compressionType]
+	if !ok {
+		return nil, 0, 0, errUnknownCompressionType
+	}
+	compressedBytes, err := compressor.Compress(uncompressedMsgBytes)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	switch compressionType {
 	case compression.TypeZstd:
-		compressedBytes, err := mb.zstdCompressor.Compress(uncompressedMsgBytes)
-		if err != nil {
-			return nil, 0, 0, err
+		compressedMsg.Message = &p2p.Message_CompressedZstd{
+			CompressedZstd: compressedBytes,
 		}
-		compressedMsg = p2p.Message{
-			Message: &p2p.Message_CompressedZstd{
-				CompressedZstd: compressedBytes,
-			},
+	case compression.TypeBrotli:
+		compressedMsg.Message = &p2p.Message_CompressedBrotli{
+			CompressedBrotli: compressedBytes,
+		}
+	case compression.TypeLZ4:
+		compressedMsg.Message = &p2p.Message_CompressedLz4{
+			CompressedLz4: compressedBytes,
 		}
-	default:
-		return nil, 0, 0, errUnknownCompressionType
 	}
 
 	compressedMsgBytes, err := proto.Marshal(&compressedMsg)
@@ -237,6 +322,8 @@ func (mb *msgBuilder) marshal(
 	}
 	mb.count.With(labels).Inc()
 	mb.duration.With(labels).Add(float64(compressTook))
+	mb.uncompressedBytes.With(labels).Add(float64(len(uncompressedMsgBytes)))
+	mb.compressedBytes.With(labels).Add(float64(len(compressedMsgBytes)))
 
 	bytesSaved := len(uncompressedMsgBytes) - len(compressedMsgBytes)
 	return compressedMsgBytes, bytesSaved, op, nil
@@ -250,19 +337,25 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 
 	// Figure out what compression type, if any, was used to compress the message.
 	var (
-		compressor      compression.Compressor
+		compressionType compression.Type
 		compressedBytes []byte
-		zstdCompressed  = m.GetCompressedZstd()
 	)
 	switch {
-	case len(zstdCompressed) > 0:
-		compressor = mb.zstdCompressor
-		compressedBytes = zstdCompressed
+	case len(m.GetCompressedZstd()) > 0:
+		compressionType = compression.TypeZstd
+		compressedBytes = m.GetCompressedZstd()
+	case len(m.GetCompressedBrotli()) > 0:
+		compressionType = compression.TypeBrotli
+		compressedBytes = m.GetCompressedBrotli()
+	case len(m.GetCompressedLz4()) > 0:
+		compressionType = compression.TypeLZ4
+		compressedBytes = m.GetCompressedLz4()
 	default:
 		// The message wasn't compressed
 		op, err := ToOp(m)
 		return m, 0, op, err
 	}
+	compressor := mb.compressors[compressionType]
 
 	startTime := time.Now()
 
@@ -284,12 +377,14 @@ func (mb *msgBuilder) unmarshal(b []byte) (*p2p.Message, int, Op, error) {
 	}
 
 	labels := prometheus.Labels{
-		typeLabel:      compression.TypeZstd.String(),
+		typeLabel:      compressionType.String(),
 		opLabel:        op.String(),
 		directionLabel: decompressionLabel,
 	}
 	mb.count.With(labels).Inc()
 	mb.duration.With(labels).Add(float64(decompressTook))
+	mb.uncompressedBytes.With(labels).Add(float64(len(decompressed)))
+	mb.compressedBytes.With(labels).Add(float64(len(compressedBytes)))
 
 	return m, bytesSavedCompression, op, nil
 }
@@ -300,12 +395,27 @@ func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression
 		return nil, err
 	}
 
-	return &outboundMessage{
+	msg, err := Unwrap(m)
+	if err != nil {
+		return nil, err
+	}
+
+	// Messages that aren't chain specific, such as handshake messages, don't
+	// have a chainID.
+	chainID, _ := GetChainID(msg)
+	outMsg := &outboundMessage{
 		bypassThrottling:      bypassThrottling,
 		op:                    op,
+		chainID:               chainID,
 		bytes:                 b,
 		bytesSavedCompression: saved,
-	}, nil
+	}
+	if compressionType != compression.TypeNone {
+		outMsg.builder = mb
+		outMsg.msg = m
+		outMsg.compressionType = compressionType
+	}
+	return outMsg, nil
 }
 
 func (mb *msgBuilder) parseInbound(
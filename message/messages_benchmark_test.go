@@ -4,8 +4,13 @@
 package message
 
 import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
 	"net"
+	"net/netip"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,8 +20,11 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/staking"
 	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/ips"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/units"
 )
 
 var (
@@ -133,3 +141,199 @@ func BenchmarkUnmarshalHandshake(b *testing.B) {
 		}
 	}
 }
+
+type compressibleMessage struct {
+	op     Op
+	create func(mb OutboundMsgBuilder) (OutboundMessage, error)
+}
+
+// compressibleMessages returns, for each op that supports compression, a
+// function that builds a message of that op.
+func compressibleMessages(b *testing.B) []compressibleMessage {
+	var (
+		chainID  = ids.GenerateTestID()
+		payload  = benchmarkPayload(b, 32*units.KiB)
+		idsCount = 64
+		testIDs  = make([]ids.ID, idsCount)
+		heights  = make([]uint64, idsCount)
+	)
+	for i := range testIDs {
+		testIDs[i] = ids.GenerateTestID()
+		heights[i] = uint64(i)
+	}
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(b, err)
+	cert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+	require.NoError(b, err)
+	peers := make([]*ips.ClaimedIPPort, 15)
+	for i := range peers {
+		peers[i] = ips.NewClaimedIPPort(
+			cert,
+			netip.AddrPortFrom(netip.AddrFrom4([4]byte{10, 0, 0, byte(i)}), 9651),
+			uint64(time.Now().Unix()),
+			payload[:64],
+		)
+	}
+
+	return []compressibleMessage{
+		{
+			op: GetPeerListOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.GetPeerList(payload[:units.KiB], payload[:32], true)
+			},
+		},
+		{
+			op: PeerListOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.PeerList(peers, false)
+			},
+		},
+		{
+			op: StateSummaryFrontierOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.StateSummaryFrontier(chainID, 1, payload)
+			},
+		},
+		{
+			op: GetAcceptedStateSummaryOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.GetAcceptedStateSummary(chainID, 1, time.Second, heights)
+			},
+		},
+		{
+			op: AcceptedStateSummaryOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.AcceptedStateSummary(chainID, 1, testIDs)
+			},
+		},
+		{
+			op: AncestorsOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.Ancestors(chainID, 1, [][]byte{payload, payload, payload})
+			},
+		},
+		{
+			op: PutOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.Put(chainID, 1, payload)
+			},
+		},
+		{
+			op: PushQueryOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.PushQuery(chainID, 1, time.Second, payload, 1)
+			},
+		},
+		{
+			op: AppRequestOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.AppRequest(chainID, 1, time.Second, payload)
+			},
+		},
+		{
+			op: AppResponseOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.AppResponse(chainID, 1, payload)
+			},
+		},
+		{
+			op: AppErrorOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.AppError(chainID, 1, 1, strings.Repeat("error", 200))
+			},
+		},
+		{
+			op: AppGossipOp,
+			create: func(mb OutboundMsgBuilder) (OutboundMessage, error) {
+				return mb.AppGossip(chainID, payload)
+			},
+		},
+	}
+}
+
+// benchmarkPayload returns [size] bytes that are partially compressible, similar
+// to serialized containers that mix IDs with small integers and padding.
+func benchmarkPayload(b *testing.B, size int) []byte {
+	require := require.New(b)
+
+	rng := rand.New(rand.NewSource(0)) // #nosec G404
+	payload := make([]byte, 0, size)
+	for len(payload) < size {
+		id := make([]byte, ids.IDLen)
+		_, err := rng.Read(id)
+		require.NoError(err)
+
+		payload = append(payload, id...)
+		payload = binary.BigEndian.AppendUint64(payload, uint64(rng.Intn(1024)))
+		payload = append(payload, make([]byte, ids.IDLen)...)
+	}
+	return payload[:size]
+}
+
+// Benchmarks compressing each compressible op with each compression type.
+//
+// The "ratio" metric is the size of the uncompressed message divided by the
+// size of the compressed message.
+//
+// e.g.,
+//
+//	$ go test -run=NONE -bench=BenchmarkCompressOps -benchmem
+func BenchmarkCompressOps(b *testing.B) {
+	for _, compressionType := range []compression.Type{
+		compression.TypeZstd,
+		compression.TypeBrotli,
+		compression.TypeLZ4,
+	} {
+		mc, err := NewCreator(logging.NoLog{}, prometheus.NewRegistry(), compressionType, 10*time.Second)
+		require.NoError(b, err)
+
+		for _, test := range compressibleMessages(b) {
+			b.Run(fmt.Sprintf("%s_%s", compressionType, test.op), func(b *testing.B) {
+				require := require.New(b)
+
+				var msg OutboundMessage
+				for i := 0; i < b.N; i++ {
+					msg, err = test.create(mc)
+					require.NoError(err)
+				}
+
+				compressedLen := len(msg.Bytes())
+				uncompressedLen := compressedLen + msg.BytesSavedCompression()
+				b.ReportMetric(float64(uncompressedLen)/float64(compressedLen), "ratio")
+			})
+		}
+	}
+}
+
+// Benchmarks decompressing each compressible op with each compression type.
+//
+// e.g.,
+//
+//	$ go test -run=NONE -bench=BenchmarkDecompressOps -benchmem
+func BenchmarkDecompressOps(b *testing.B) {
+	for _, compressionType := range []compression.Type{
+		compression.TypeZstd,
+		compression.TypeBrotli,
+		compression.TypeLZ4,
+	} {
+		mc, err := NewCreator(logging.NoLog{}, prometheus.NewRegistry(), compressionType, 10*time.Second)
+		require.NoError(b, err)
+
+		for _, test := range compressibleMessages(b) {
+			b.Run(fmt.Sprintf("%s_%s", compressionType, test.op), func(b *testing.B) {
+				require := require.New(b)
+
+				msg, err := test.create(mc)
+				require.NoError(err)
+				msgBytes := msg.Bytes()
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, err := mc.Parse(msgBytes, dummyNodeID, dummyOnFinishedHandling)
+					require.NoError(err)
+				}
+			})
+		}
+	}
+}
@@ -654,6 +654,92 @@ func TestMessage(t *testing.T) {
 	}
 }
 
+func TestOutboundMessageBytesWithCompression(t *testing.T) {
+	compressionTypes := []compression.Type{
+		compression.TypeNone,
+		compression.TypeZstd,
+		compression.TypeBrotli,
+		compression.TypeLZ4,
+	}
+	for _, createdWith := range compressionTypes {
+		t.Run(createdWith.String(), func(t *testing.T) {
+			require := require.New(t)
+
+			mb, err := newMsgBuilder(
+				logging.NoLog{},
+				prometheus.NewRegistry(),
+				5*time.Second,
+			)
+			require.NoError(err)
+
+			chainID := ids.GenerateTestID()
+			gossip := bytes.Repeat([]byte{1}, 1024)
+			outMsg, err := mb.createOutbound(
+				&p2p.Message{
+					Message: &p2p.Message_AppGossip{
+						AppGossip: &p2p.AppGossip{
+							ChainId:  chainID[:],
+							AppBytes: gossip,
+						},
+					},
+				},
+				createdWith,
+				false,
+			)
+			require.NoError(err)
+
+			for _, sentWith := range compressionTypes {
+				msgBytes, err := outMsg.BytesWithCompression(sentWith)
+				require.NoError(err)
+				if sentWith == createdWith {
+					require.Equal(outMsg.Bytes(), msgBytes)
+				}
+
+				inMsg, err := mb.parseInbound(msgBytes, ids.EmptyNodeID, func() {})
+				require.NoError(err)
+				require.Equal(AppGossipOp, inMsg.Op())
+				require.Equal(gossip, inMsg.Message().(*p2p.AppGossip).AppBytes)
+
+				if createdWith == compression.TypeNone {
+					// Uncompressed messages are never recompressed.
+					require.Equal(outMsg.Bytes(), msgBytes)
+					require.Zero(inMsg.BytesSavedCompression())
+				} else if sentWith != compression.TypeNone {
+					require.Positive(inMsg.BytesSavedCompression())
+				}
+			}
+		})
+	}
+}
+
+func TestOutboundMessageBytesWithUnknownCompression(t *testing.T) {
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		5*time.Second,
+	)
+	require.NoError(err)
+
+	outMsg, err := mb.createOutbound(
+		&p2p.Message{
+			Message: &p2p.Message_AppGossip{
+				AppGossip: &p2p.AppGossip{
+					ChainId:  ids.Empty[:],
+					AppBytes: []byte("gossip"),
+				},
+			},
+		},
+		compression.TypeZstd,
+		false,
+	)
+	require.NoError(err)
+
+	_, err = outMsg.BytesWithCompression(compression.Type(0))
+	require.ErrorIs(err, errUnknownCompressionType)
+}
+
 // Tests the Stringer interface on inbound messages
 func TestInboundMessageToString(t *testing.T) {
 	t.Parallel()
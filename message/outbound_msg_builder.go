@@ -13,7 +13,17 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/ips"
 )
 
-var _ OutboundMsgBuilder = (*outMsgBuilder)(nil)
+var (
+	_ OutboundMsgBuilder = (*outMsgBuilder)(nil)
+
+	// supportedCompressionTypes are advertised in the Handshake message as the
+	// compression types that inbound messages can be compressed with.
+	supportedCompressionTypes = []uint32{
+		uint32(compression.TypeZstd),
+		uint32(compression.TypeBrotli),
+		uint32(compression.TypeLZ4),
+	}
+)
 
 // OutboundMsgBuilder builds outbound messages. Outbound messages are returned
 // with a reference count of 1. Once the reference count hits 0, the message
@@ -30,6 +40,8 @@ type OutboundMsgBuilder interface {
 		ipSigningTime uint64,
 		ipNodeIDSig []byte,
 		ipBLSSig []byte,
+		secondaryIP netip.AddrPort,
+		secondaryIPNodeIDSig []byte,
 		trackedSubnets []ids.ID,
 		supportedACPs []uint32,
 		objectedACPs []uint32,
@@ -237,6 +249,8 @@ func (b *outMsgBuilder) Handshake(
 	ipSigningTime uint64,
 	ipNodeIDSig []byte,
 	ipBLSSig []byte,
+	secondaryIP netip.AddrPort,
+	secondaryIPNodeIDSig []byte,
 	trackedSubnets []ids.ID,
 	supportedACPs []uint32,
 	objectedACPs []uint32,
@@ -248,32 +262,40 @@ func (b *outMsgBuilder) Handshake(
 	encodeIDs(trackedSubnets, subnetIDBytes)
 	// TODO: Use .AsSlice() after v1.12.x activates.
 	addr := ip.Addr().As16()
+	handshake := &p2p.Handshake{
+		NetworkId:      networkID,
+		MyTime:         myTime,
+		IpAddr:         addr[:],
+		IpPort:         uint32(ip.Port()),
+		IpSigningTime:  ipSigningTime,
+		IpNodeIdSig:    ipNodeIDSig,
+		TrackedSubnets: subnetIDBytes,
+		Client: &p2p.Client{
+			Name:  client,
+			Major: major,
+			Minor: minor,
+			Patch: patch,
+		},
+		SupportedAcps: supportedACPs,
+		ObjectedAcps:  objectedACPs,
+		KnownPeers: &p2p.BloomFilter{
+			Filter: knownPeersFilter,
+			Salt:   knownPeersSalt,
+		},
+		IpBlsSig:                  ipBLSSig,
+		AllSubnets:                requestAllSubnetIPs,
+		SupportedCompressionTypes: supportedCompressionTypes,
+	}
+	if secondaryIP.IsValid() {
+		secondaryAddr := secondaryIP.Addr().As16()
+		handshake.SecondaryIpAddr = secondaryAddr[:]
+		handshake.SecondaryIpPort = uint32(secondaryIP.Port())
+		handshake.SecondaryIpNodeIdSig = secondaryIPNodeIDSig
+	}
 	return b.builder.createOutbound(
 		&p2p.Message{
 			Message: &p2p.Message_Handshake{
-				Handshake: &p2p.Handshake{
-					NetworkId:      networkID,
-					MyTime:         myTime,
-					IpAddr:         addr[:],
-					IpPort:         uint32(ip.Port()),
-					IpSigningTime:  ipSigningTime,
-					IpNodeIdSig:    ipNodeIDSig,
-					TrackedSubnets: subnetIDBytes,
-					Client: &p2p.Client{
-						Name:  client,
-						Major: major,
-						Minor: minor,
-						Patch: patch,
-					},
-					SupportedAcps: supportedACPs,
-					ObjectedAcps:  objectedACPs,
-					KnownPeers: &p2p.BloomFilter{
-						Filter: knownPeersFilter,
-						Salt:   knownPeersSalt,
-					},
-					IpBlsSig:   ipBLSSig,
-					AllSubnets: requestAllSubnetIPs,
-				},
+				Handshake: handshake,
 			},
 		},
 		compression.TypeNone,
@@ -316,6 +338,12 @@ func (b *outMsgBuilder) PeerList(peers []*ips.ClaimedIPPort, bypassThrottling bo
 			Signature:       p.Signature,
 			TxId:            ids.Empty[:],
 		}
+		if p.SecondaryAddrPort.IsValid() {
+			secondaryIP := p.SecondaryAddrPort.Addr().As16()
+			claimIPPorts[i].SecondaryIpAddr = secondaryIP[:]
+			claimIPPorts[i].SecondaryIpPort = uint32(p.SecondaryAddrPort.Port())
+			claimIPPorts[i].SecondarySignature = p.SecondarySignature
+		}
 	}
 	return b.builder.createOutbound(
 		&p2p.Message{
@@ -4,6 +4,7 @@
 package message
 
 import (
+	"net/netip"
 	"testing"
 	"time"
 
@@ -11,7 +12,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 )
 
@@ -28,6 +31,8 @@ func Test_newOutboundBuilder(t *testing.T) {
 	for _, compressionType := range []compression.Type{
 		compression.TypeNone,
 		compression.TypeZstd,
+		compression.TypeBrotli,
+		compression.TypeLZ4,
 	} {
 		t.Run(compressionType.String(), func(t *testing.T) {
 			builder := newOutboundBuilder(compressionType, mb)
@@ -43,3 +48,69 @@ func Test_newOutboundBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestOutboundMessageChainID(t *testing.T) {
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		10*time.Second,
+	)
+	require.NoError(err)
+	builder := newOutboundBuilder(compression.TypeNone, mb)
+
+	chainID := ids.GenerateTestID()
+	outMsg, err := builder.Get(chainID, 1, time.Second, ids.GenerateTestID())
+	require.NoError(err)
+	require.Equal(chainID, outMsg.ChainID())
+
+	outMsg, err = builder.Ping(100)
+	require.NoError(err)
+	require.Equal(ids.Empty, outMsg.ChainID())
+}
+
+func TestHandshakeSupportedCompressionTypes(t *testing.T) {
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		10*time.Second,
+	)
+	require.NoError(err)
+	builder := newOutboundBuilder(compression.TypeZstd, mb)
+
+	outMsg, err := builder.Handshake(
+		constants.LocalID,
+		0,
+		netip.AddrPortFrom(netip.IPv6Loopback(), 9651),
+		"metalgo",
+		1,
+		2,
+		3,
+		0,
+		nil,
+		nil,
+		netip.AddrPort{},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		false,
+	)
+	require.NoError(err)
+
+	inMsg, err := mb.parseInbound(outMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.NoError(err)
+	require.Equal(
+		[]uint32{
+			uint32(compression.TypeZstd),
+			uint32(compression.TypeBrotli),
+			uint32(compression.TypeLZ4),
+		},
+		inMsg.Message().(*p2p.Handshake).SupportedCompressionTypes,
+	)
+}
@@ -0,0 +1,341 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package capture records the p2p messages sent and received by a node so that
+// they can later be inspected or replayed.
+package capture
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const (
+	// FileName is the name of the file that records are currently written
+	// to. Rotated files are named with the time they were rotated inserted
+	// before the extension.
+	FileName = "network.capture"
+
+	directionLabel = "direction"
+
+	// headerLen is the length of a record, excluding the message bytes.
+	headerLen = wrappers.ByteLen + // direction
+		wrappers.ByteLen + // op
+		wrappers.LongLen + // timestamp
+		ids.NodeIDLen + // nodeID
+		ids.IDLen // chainID
+)
+
+var (
+	_ Recorder = (*recorder)(nil)
+	_ Recorder = NoRecorder{}
+
+	errNoDirectory      = errors.New("capture directory must be provided")
+	errNonPositiveSize  = errors.New("max capture file size must be positive")
+	errUnknownOp        = errors.New("unknown op")
+	errRecordTooShort   = errors.New("record is too short")
+	errUnknownDirection = errors.New("unknown direction")
+)
+
+// Direction describes whether a message was sent or received.
+type Direction byte
+
+const (
+	Inbound Direction = iota
+	Outbound
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Inbound:
+		return "inbound"
+	case Outbound:
+		return "outbound"
+	default:
+		return "unknown"
+	}
+}
+
+// Record is a single captured message.
+type Record struct {
+	Direction Direction
+	Op        message.Op
+	// NodeID is the peer that sent an inbound message or that an outbound
+	// message was sent to.
+	NodeID ids.NodeID
+	// ChainID is [ids.Empty] if the message isn't chain specific.
+	ChainID   ids.ID
+	Timestamp time.Time
+	// Bytes are the message bytes as they were sent over the wire, which may
+	// be compressed.
+	Bytes []byte
+}
+
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// Directory that capture files are written to.
+	Directory string `json:"directory"`
+	// MaxSize is the size, in megabytes, a capture file can grow to before it
+	// is rotated.
+	MaxSize int `json:"maxSize"`
+	// MaxFiles is the number of rotated capture files to keep. If 0, all
+	// rotated files are kept.
+	MaxFiles int `json:"maxFiles"`
+	// Ops to record. If empty, all ops are recorded.
+	Ops set.Set[message.Op] `json:"ops"`
+	// ChainIDs to record. If empty, messages for all chains, including
+	// messages that aren't chain specific, are recorded.
+	ChainIDs set.Set[ids.ID] `json:"chainIDs"`
+}
+
+func (c *Config) Verify() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Directory == "" {
+		return errNoDirectory
+	}
+	if c.MaxSize <= 0 {
+		return errNonPositiveSize
+	}
+	return nil
+}
+
+// ShouldRecord returns true if a message with [op] for [chainID] passes the
+// configured filters.
+func (c *Config) ShouldRecord(op message.Op, chainID ids.ID) bool {
+	return (c.Ops.Len() == 0 || c.Ops.Contains(op)) &&
+		(c.ChainIDs.Len() == 0 || c.ChainIDs.Contains(chainID))
+}
+
+// ParseOps returns the ops with the provided names.
+func ParseOps(names []string) (set.Set[message.Op], error) {
+	ops := set.NewSet[message.Op](len(names))
+	for _, name := range names {
+		op, ok := parseOp(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errUnknownOp, name)
+		}
+		ops.Add(op)
+	}
+	return ops, nil
+}
+
+func parseOp(name string) (message.Op, bool) {
+	for _, op := range message.ExternalOps {
+		if op.String() == name {
+			return op, true
+		}
+	}
+	return 0, false
+}
+
+// Recorder records messages sent and received by the node.
+//
+// Must be thread safe.
+type Recorder interface {
+	// RecordInbound records [msg], which was parsed from [msgBytes].
+	RecordInbound(msg message.InboundMessage, msgBytes []byte)
+	// RecordOutbound records [msg], which was sent to [nodeID] as [msgBytes].
+	RecordOutbound(nodeID ids.NodeID, msg message.OutboundMessage, msgBytes []byte)
+	// Close flushes and closes the current capture file.
+	Close() error
+}
+
+type recorder struct {
+	config Config
+	log    logging.Logger
+	clock  mockable.Clock
+
+	records     *prometheus.CounterVec
+	writeErrors prometheus.Counter
+
+	lock   sync.Mutex
+	writer io.WriteCloser
+}
+
+// NewRecorder returns a Recorder that writes records to rotating files in
+// [config.Directory].
+//
+// Records are written synchronously, so enabling the recorder will slow down
+// the peers' read and write loops.
+func NewRecorder(config Config, log logging.Logger, registerer prometheus.Registerer) (Recorder, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	r := &recorder{
+		config: config,
+		log:    log,
+		records: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "capture_records",
+				Help: "number of messages written to the capture file",
+			},
+			[]string{directionLabel},
+		),
+		writeErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "capture_write_errors",
+			Help: "number of messages that failed to be written to the capture file",
+		}),
+		writer: &lumberjack.Logger{
+			Filename:   filepath.Join(config.Directory, FileName),
+			MaxSize:    config.MaxSize,  // megabytes
+			MaxBackups: config.MaxFiles, // files
+		},
+	}
+	err := errors.Join(
+		registerer.Register(r.records),
+		registerer.Register(r.writeErrors),
+	)
+	return r, err
+}
+
+func (r *recorder) RecordInbound(msg message.InboundMessage, msgBytes []byte) {
+	// Messages that aren't chain specific, such as handshake messages, don't
+	// have a chainID.
+	chainID, _ := message.GetChainID(msg.Message())
+	r.record(Record{
+		Direction: Inbound,
+		Op:        msg.Op(),
+		NodeID:    msg.NodeID(),
+		ChainID:   chainID,
+		Bytes:     msgBytes,
+	})
+}
+
+func (r *recorder) RecordOutbound(nodeID ids.NodeID, msg message.OutboundMessage, msgBytes []byte) {
+	r.record(Record{
+		Direction: Outbound,
+		Op:        msg.Op(),
+		NodeID:    nodeID,
+		ChainID:   msg.ChainID(),
+		Bytes:     msgBytes,
+	})
+}
+
+func (r *recorder) record(record Record) {
+	if !r.config.ShouldRecord(record.Op, record.ChainID) {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	record.Timestamp = r.clock.Time()
+
+	// Each record is written with a single call to Write so that a record is
+	// never split across rotated files.
+	if _, err := r.writer.Write(marshalRecord(record)); err != nil {
+		r.writeErrors.Inc()
+		r.log.Debug("failed to write capture record",
+			zap.Stringer("direction", record.Direction),
+			zap.Stringer("op", record.Op),
+			zap.Stringer("nodeID", record.NodeID),
+			zap.Error(err),
+		)
+		return
+	}
+	r.records.With(prometheus.Labels{
+		directionLabel: record.Direction.String(),
+	}).Inc()
+}
+
+func (r *recorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.writer.Close()
+}
+
+// marshalRecord returns [record] prefixed with its length.
+func marshalRecord(record Record) []byte {
+	recordLen := headerLen + len(record.Bytes)
+	p := wrappers.Packer{
+		Bytes: make([]byte, wrappers.IntLen+recordLen),
+	}
+	p.PackInt(uint32(recordLen))
+	p.PackByte(byte(record.Direction))
+	p.PackByte(byte(record.Op))
+	p.PackLong(uint64(record.Timestamp.UnixNano()))
+	p.PackFixedBytes(record.NodeID.Bytes())
+	p.PackFixedBytes(record.ChainID[:])
+	p.PackFixedBytes(record.Bytes)
+	return p.Bytes
+}
+
+func parseRecord(b []byte) (Record, error) {
+	if len(b) < headerLen {
+		return Record{}, errRecordTooShort
+	}
+
+	p := wrappers.Packer{Bytes: b}
+	record := Record{
+		Direction: Direction(p.UnpackByte()),
+		Op:        message.Op(p.UnpackByte()),
+		Timestamp: time.Unix(0, int64(p.UnpackLong())),
+	}
+	if record.Direction != Inbound && record.Direction != Outbound {
+		return Record{}, fmt.Errorf("%w: %d", errUnknownDirection, record.Direction)
+	}
+
+	nodeID, err := ids.ToNodeID(p.UnpackFixedBytes(ids.NodeIDLen))
+	if err != nil {
+		return Record{}, err
+	}
+	chainID, err := ids.ToID(p.UnpackFixedBytes(ids.IDLen))
+	if err != nil {
+		return Record{}, err
+	}
+	record.NodeID = nodeID
+	record.ChainID = chainID
+	record.Bytes = b[p.Offset:]
+	return record, p.Err
+}
+
+// Files returns the capture files in [dir] ordered from oldest to newest.
+func Files(dir string) ([]string, error) {
+	ext := filepath.Ext(FileName)
+	prefix := strings.TrimSuffix(FileName, ext)
+
+	// Rotated files are named with a sortable timestamp.
+	rotated, err := filepath.Glob(filepath.Join(dir, prefix+"-*"+ext))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(rotated)
+
+	current, err := filepath.Glob(filepath.Join(dir, FileName))
+	if err != nil {
+		return nil, err
+	}
+	return append(rotated, current...), nil
+}
+
+// NoRecorder is a Recorder that doesn't record any messages.
+type NoRecorder struct{}
+
+func (NoRecorder) RecordInbound(message.InboundMessage, []byte) {}
+
+func (NoRecorder) RecordOutbound(ids.NodeID, message.OutboundMessage, []byte) {}
+
+func (NoRecorder) Close() error {
+	return nil
+}
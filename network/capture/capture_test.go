@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+)
+
+func newTestCreator(t *testing.T) message.Creator {
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		compression.TypeZstd,
+		10*time.Second,
+	)
+	require.NoError(t, err)
+	return mc
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name:        "disabled",
+			config:      Config{},
+			expectedErr: nil,
+		},
+		{
+			name: "valid",
+			config: Config{
+				Enabled:   true,
+				Directory: "captures",
+				MaxSize:   1,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "no directory",
+			config: Config{
+				Enabled: true,
+				MaxSize: 1,
+			},
+			expectedErr: errNoDirectory,
+		},
+		{
+			name: "non-positive size",
+			config: Config{
+				Enabled:   true,
+				Directory: "captures",
+			},
+			expectedErr: errNonPositiveSize,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestParseOps(t *testing.T) {
+	require := require.New(t)
+
+	ops, err := ParseOps([]string{"push_query", "chits"})
+	require.NoError(err)
+	require.Equal(set.Of(message.PushQueryOp, message.ChitsOp), ops)
+
+	_, err = ParseOps([]string{"not_an_op"})
+	require.ErrorIs(err, errUnknownOp)
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	record := Record{
+		Direction: Outbound,
+		Op:        message.GetOp,
+		NodeID:    ids.GenerateTestNodeID(),
+		ChainID:   ids.GenerateTestID(),
+		Timestamp: time.Unix(123, 456),
+		Bytes:     []byte{1, 2, 3},
+	}
+
+	reader := NewReader(bytes.NewReader(marshalRecord(record)))
+	parsed, err := reader.Next()
+	require.NoError(err)
+	require.Equal(record.Direction, parsed.Direction)
+	require.Equal(record.Op, parsed.Op)
+	require.Equal(record.NodeID, parsed.NodeID)
+	require.Equal(record.ChainID, parsed.ChainID)
+	require.True(record.Timestamp.Equal(parsed.Timestamp))
+	require.Equal(record.Bytes, parsed.Bytes)
+
+	_, err = reader.Next()
+	require.ErrorIs(err, io.EOF)
+}
+
+func TestReaderTruncated(t *testing.T) {
+	recordBytes := marshalRecord(Record{
+		Bytes: []byte{1, 2, 3},
+	})
+
+	reader := NewReader(bytes.NewReader(recordBytes[:len(recordBytes)-1]))
+	_, err := reader.Next()
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestRecorder(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	chainID := ids.GenerateTestID()
+	r, err := NewRecorder(
+		Config{
+			Enabled:   true,
+			Directory: dir,
+			MaxSize:   1,
+			Ops:       set.Of(message.GetOp, message.AppGossipOp),
+			ChainIDs:  set.Of(chainID),
+		},
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	mc := newTestCreator(t)
+	nodeID := ids.GenerateTestNodeID()
+
+	// Filtered out because of the op.
+	ping, err := mc.Ping(100)
+	require.NoError(err)
+	r.RecordOutbound(nodeID, ping, ping.Bytes())
+
+	// Filtered out because of the chainID.
+	otherGet, err := mc.Get(ids.GenerateTestID(), 1, time.Second, ids.GenerateTestID())
+	require.NoError(err)
+	r.RecordOutbound(nodeID, otherGet, otherGet.Bytes())
+
+	get, err := mc.Get(chainID, 1, time.Second, ids.GenerateTestID())
+	require.NoError(err)
+	r.RecordOutbound(nodeID, get, get.Bytes())
+
+	gossip, err := mc.AppGossip(chainID, []byte("gossip"))
+	require.NoError(err)
+	inboundGossip, err := mc.Parse(gossip.Bytes(), nodeID, nil)
+	require.NoError(err)
+	r.RecordInbound(inboundGossip, gossip.Bytes())
+
+	require.NoError(r.Close())
+
+	files, err := Files(dir)
+	require.NoError(err)
+	require.Equal([]string{filepath.Join(dir, FileName)}, files)
+
+	f, err := os.Open(files[0])
+	require.NoError(err)
+	defer f.Close()
+
+	reader := NewReader(f)
+	record, err := reader.Next()
+	require.NoError(err)
+	require.Equal(Outbound, record.Direction)
+	require.Equal(message.GetOp, record.Op)
+	require.Equal(nodeID, record.NodeID)
+	require.Equal(chainID, record.ChainID)
+	require.Equal(get.Bytes(), record.Bytes)
+
+	record, err = reader.Next()
+	require.NoError(err)
+	require.Equal(Inbound, record.Direction)
+	require.Equal(message.AppGossipOp, record.Op)
+	require.Equal(nodeID, record.NodeID)
+	require.Equal(chainID, record.ChainID)
+	require.Equal(gossip.Bytes(), record.Bytes)
+
+	_, err = reader.Next()
+	require.ErrorIs(err, io.EOF)
+}
+
+func TestFilesOrder(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	for _, name := range []string{
+		FileName,
+		"network-2024-01-02T00-00-00.000.capture",
+		"network-2024-01-01T00-00-00.000.capture",
+		"unrelated.log",
+	} {
+		require.NoError(os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	files, err := Files(dir)
+	require.NoError(err)
+	require.Equal(
+		[]string{
+			filepath.Join(dir, "network-2024-01-01T00-00-00.000.capture"),
+			filepath.Join(dir, "network-2024-01-02T00-00-00.000.capture"),
+			filepath.Join(dir, FileName),
+		},
+		files,
+	)
+}
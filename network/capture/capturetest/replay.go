@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capturetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/capture"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+)
+
+// Replay parses the inbound records read from [reader] that pass [filter] and
+// pushes them into [h], in the order they were recorded. If [filter] is nil,
+// all inbound records are replayed.
+//
+// Records are pushed directly into the handler, bypassing the router, so
+// [filter] should typically only accept records for the handler's chain.
+//
+// Returns the number of messages that were pushed.
+func Replay(
+	ctx context.Context,
+	reader *capture.Reader,
+	creator message.Creator,
+	h handler.Handler,
+	filter func(capture.Record) bool,
+) (int, error) {
+	var numReplayed int
+	for {
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return numReplayed, nil
+		}
+		if err != nil {
+			return numReplayed, err
+		}
+
+		if record.Direction != capture.Inbound || (filter != nil && !filter(record)) {
+			continue
+		}
+
+		msg, err := creator.Parse(record.Bytes, record.NodeID, nil)
+		if err != nil {
+			return numReplayed, fmt.Errorf("failed to parse %s message from %s: %w", record.Op, record.NodeID, err)
+		}
+
+		// Note: engineType is not guaranteed to be one of the explicitly named
+		// enum values. If it was not specified it defaults to UNSPECIFIED.
+		engineType, _ := message.GetEngineType(msg.Message())
+		h.Push(ctx, handler.Message{
+			InboundMessage: msg,
+			EngineType:     engineType,
+		})
+		numReplayed++
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capturetest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/capture"
+	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler"
+	"github.com/MetalBlockchain/metalgo/snow/networking/handler/handlermock"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+func TestReplay(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		compression.TypeZstd,
+		10*time.Second,
+	)
+	require.NoError(err)
+
+	dir := t.TempDir()
+	recorder, err := capture.NewRecorder(
+		capture.Config{
+			Enabled:   true,
+			Directory: dir,
+			MaxSize:   1,
+		},
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	var (
+		nodeID       = ids.GenerateTestNodeID()
+		chainID      = ids.GenerateTestID()
+		otherChainID = ids.GenerateTestID()
+	)
+	record := func(chainID ids.ID, requestID uint32) {
+		outMsg, err := mc.GetAncestors(chainID, requestID, time.Second, ids.GenerateTestID(), p2p.EngineType_ENGINE_TYPE_SNOWMAN)
+		require.NoError(err)
+		inMsg, err := mc.Parse(outMsg.Bytes(), nodeID, nil)
+		require.NoError(err)
+		recorder.RecordInbound(inMsg, outMsg.Bytes())
+
+		// Outbound messages are never replayed.
+		recorder.RecordOutbound(nodeID, outMsg, outMsg.Bytes())
+	}
+	record(chainID, 1)
+	record(otherChainID, 2)
+	record(chainID, 3)
+	require.NoError(recorder.Close())
+
+	var pushed []uint32
+	h := handlermock.NewHandler(ctrl)
+	h.EXPECT().Push(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, msg handler.Message) {
+			require.Equal(nodeID, msg.NodeID())
+			require.Equal(message.GetAncestorsOp, msg.Op())
+			require.Equal(p2p.EngineType_ENGINE_TYPE_SNOWMAN, msg.EngineType)
+
+			requestID, ok := message.GetRequestID(msg.Message())
+			require.True(ok)
+			pushed = append(pushed, requestID)
+		},
+	).Times(2)
+
+	f, err := os.Open(filepath.Join(dir, capture.FileName))
+	require.NoError(err)
+	defer f.Close()
+
+	numReplayed, err := Replay(
+		context.Background(),
+		capture.NewReader(f),
+		mc,
+		h,
+		func(r capture.Record) bool {
+			return r.ChainID == chainID
+		},
+	)
+	require.NoError(err)
+	require.Equal(2, numReplayed)
+	require.Equal([]uint32{1, 3}, pushed)
+}
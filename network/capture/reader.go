@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+)
+
+var errRecordTooLarge = errors.New("record is too large")
+
+// maxRecordLen is the largest record that can be read. Records contain a
+// single p2p message, so they are bounded by the max message size.
+const maxRecordLen = headerLen + constants.DefaultMaxMessageSize
+
+// Reader reads records written by a Recorder.
+type Reader struct {
+	reader *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		reader: bufio.NewReader(r),
+	}
+}
+
+// Next returns the next record. If there are no more records, [io.EOF] is
+// returned. If the capture ends in the middle of a record,
+// [io.ErrUnexpectedEOF] is returned.
+func (r *Reader) Next() (Record, error) {
+	var recordLenBytes [4]byte
+	if _, err := io.ReadFull(r.reader, recordLenBytes[:]); err != nil {
+		return Record{}, err
+	}
+
+	recordLen := binary.BigEndian.Uint32(recordLenBytes[:])
+	if recordLen > maxRecordLen {
+		return Record{}, fmt.Errorf("%w: %d > %d", errRecordTooLarge, recordLen, maxRecordLen)
+	}
+
+	recordBytes := make([]byte, recordLen)
+	if _, err := io.ReadFull(r.reader, recordBytes); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Record{}, err
+	}
+	return parseRecord(recordBytes)
+}
@@ -10,7 +10,10 @@ import (
 	"time"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/capture"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
+	"github.com/MetalBlockchain/metalgo/network/peer"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/uptime"
@@ -111,18 +114,23 @@ type Config struct {
 
 	TLSKeyLogFile string `json:"tlsKeyLogFile"`
 
-	MyNodeID           ids.NodeID                    `json:"myNodeID"`
-	MyIPPort           *utils.Atomic[netip.AddrPort] `json:"myIP"`
-	NetworkID          uint32                        `json:"networkID"`
-	MaxClockDifference time.Duration                 `json:"maxClockDifference"`
-	PingFrequency      time.Duration                 `json:"pingFrequency"`
-	AllowPrivateIPs    bool                          `json:"allowPrivateIPs"`
+	MyNodeID ids.NodeID                    `json:"myNodeID"`
+	MyIPPort *utils.Atomic[netip.AddrPort] `json:"myIP"`
+	// MySecondaryIPPort is the optional address of this node in the other IP
+	// family of [MyIPPort]. If populated, both addresses are advertised and
+	// peers are dialed using the IP families this node is reachable on.
+	MySecondaryIPPort  netip.AddrPort `json:"mySecondaryIP"`
+	NetworkID          uint32         `json:"networkID"`
+	MaxClockDifference time.Duration  `json:"maxClockDifference"`
+	PingFrequency      time.Duration  `json:"pingFrequency"`
+	AllowPrivateIPs    bool           `json:"allowPrivateIPs"`
 
 	SupportedACPs set.Set[uint32] `json:"supportedACPs"`
 	ObjectedACPs  set.Set[uint32] `json:"objectedACPs"`
 
-	// The compression type to use when compressing outbound messages.
-	// Assumes all peers support this compression type.
+	// The compression type to use when compressing outbound messages. Peers
+	// that don't advertise support for this compression type during the
+	// handshake are sent zstd compressed messages instead.
 	CompressionType compression.Type `json:"compressionType"`
 
 	// TLSKey is this node's TLS key that is used to sign IPs.
@@ -177,4 +185,21 @@ type Config struct {
 	// Specifies how much disk usage each peer can cause before
 	// we rate-limit them.
 	DiskTargeter tracker.Targeter `json:"-"`
+
+	// ReputationConfig configures how peer reputations are scored.
+	ReputationConfig reputation.Config `json:"reputationConfig"`
+
+	// Tracks the reputation of peers. Peers with a poor reputation are not
+	// connected to unless they were manually tracked.
+	Reputation reputation.Tracker `json:"-"`
+
+	// Tracks the nodes that are banned. Connections with banned nodes are
+	// rejected during the TLS upgrade.
+	BanList peer.BanList `json:"-"`
+
+	// CaptureConfig configures which messages are recorded to disk.
+	CaptureConfig capture.Config `json:"captureConfig"`
+
+	// Records the messages sent to and received from peers.
+	Capture capture.Recorder `json:"-"`
 }
@@ -5,24 +5,42 @@ package dialer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/netip"
+	"net/url"
 	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/net/proxy"
 
+	"github.com/MetalBlockchain/metalgo/api/health"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 )
 
-var _ Dialer = (*dialer)(nil)
+const (
+	ProxyKey          = "proxy"
+	ProxyReachableKey = "proxyReachable"
+)
+
+var (
+	_ Dialer = (*dialer)(nil)
+
+	errUnsupportedProxyScheme = errors.New("unsupported proxy scheme")
+	errMissingProxyHost       = errors.New("missing proxy host")
+)
 
 // Dialer attempts to create a connection with the provided IP/port pair
 type Dialer interface {
 	// If [ctx] is canceled, gives up trying to connect to [ip]
 	// and returns an error.
 	Dial(ctx context.Context, ip netip.AddrPort) (net.Conn, error)
+
+	// HealthCheck reports whether the configured proxy, if any, is
+	// reachable.
+	health.Checker
 }
 
 type dialer struct {
@@ -30,11 +48,27 @@ type dialer struct {
 	log       logging.Logger
 	network   string
 	throttler throttling.DialThrottler
+
+	// proxyURL is nil if connections are dialed directly.
+	proxyURL    *url.URL
+	proxyDialer proxy.ContextDialer
+	proxyBypass []netip.Prefix
 }
 
 type Config struct {
 	ThrottleRps       uint32        `json:"throttleRps"`
 	ConnectionTimeout time.Duration `json:"connectionTimeout"`
+
+	// ProxyURL is the proxy that outbound connections are dialed through,
+	// formatted as "scheme://[user:password@]host:port". The socks5 and
+	// socks5h schemes use a SOCKS5 proxy. The http and https schemes use an
+	// HTTP proxy that supports the CONNECT method. If empty, connections are
+	// dialed directly.
+	ProxyURL string `json:"proxyURL"`
+
+	// ProxyBypass is the set of subnets that are dialed directly, even if
+	// [ProxyURL] is set.
+	ProxyBypass []netip.Prefix `json:"proxyBypass"`
 }
 
 // NewDialer returns a new Dialer that calls net.Dial with the provided network.
@@ -42,36 +76,159 @@ type Config struct {
 // [dialerConfig.connectionTimeout] gives the timeout when dialing an IP.
 // [dialerConfig.throttleRps] gives the max number of outgoing connection attempts/second.
 // If [dialerConfig.throttleRps] == 0, outgoing connections aren't rate-limited.
-func NewDialer(network string, dialerConfig Config, log logging.Logger) Dialer {
+// If [dialerConfig.ProxyURL] is set, connections to IPs outside of
+// [dialerConfig.ProxyBypass] are dialed through the proxy.
+func NewDialer(network string, dialerConfig Config, log logging.Logger) (Dialer, error) {
 	var throttler throttling.DialThrottler
 	if dialerConfig.ThrottleRps <= 0 {
 		throttler = throttling.NewNoDialThrottler()
 	} else {
 		throttler = throttling.NewDialThrottler(int(dialerConfig.ThrottleRps))
 	}
+	d := &dialer{
+		dialer:      net.Dialer{Timeout: dialerConfig.ConnectionTimeout},
+		log:         log,
+		network:     network,
+		throttler:   throttler,
+		proxyBypass: dialerConfig.ProxyBypass,
+	}
+
+	if dialerConfig.ProxyURL != "" {
+		proxyURL, err := ParseProxyURL(dialerConfig.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+
+		proxyDialer, err := newProxyDialer(network, proxyURL, &d.dialer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create proxy dialer: %w", err)
+		}
+		d.proxyURL = proxyURL
+		d.proxyDialer = proxyDialer
+	}
+
 	log.Debug(
 		"creating dialer",
 		zap.Uint32("throttleRPS", dialerConfig.ThrottleRps),
 		zap.Duration("dialTimeout", dialerConfig.ConnectionTimeout),
+		zap.String("proxy", d.redactedProxyURL()),
+		zap.Stringers("proxyBypass", dialerConfig.ProxyBypass),
 	)
-	return &dialer{
-		dialer:    net.Dialer{Timeout: dialerConfig.ConnectionTimeout},
-		log:       log,
-		network:   network,
-		throttler: throttler,
+	return d, nil
+}
+
+// ParseProxyURL parses and verifies a SOCKS5 or HTTP proxy URL. If an HTTP
+// proxy URL doesn't include a port, the default port of its scheme is used.
+func ParseProxyURL(rawURL string) (*url.URL, error) {
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proxy URL: %w", err)
+	}
+	var defaultPort string
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+	case "http":
+		defaultPort = "80"
+	case "https":
+		defaultPort = "443"
+	default:
+		return nil, fmt.Errorf("%w: %q", errUnsupportedProxyScheme, proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return nil, errMissingProxyHost
+	}
+	if defaultPort != "" && proxyURL.Port() == "" {
+		proxyURL.Host = net.JoinHostPort(proxyURL.Hostname(), defaultPort)
+	}
+	return proxyURL, nil
+}
+
+// newProxyDialer returns a dialer that dials connections through the proxy at
+// [proxyURL], which must have been verified by ParseProxyURL.
+func newProxyDialer(network string, proxyURL *url.URL, forward *net.Dialer) (proxy.ContextDialer, error) {
+	if proxyURL.Scheme == "http" || proxyURL.Scheme == "https" {
+		return newHTTPConnectDialer(proxyURL, forward), nil
+	}
+
+	var auth *proxy.Auth
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		auth = &proxy.Auth{
+			User:     proxyURL.User.Username(),
+			Password: password,
+		}
+	}
+	proxyDialer, err := proxy.SOCKS5(network, proxyURL.Host, auth, forward)
+	if err != nil {
+		return nil, err
 	}
+	// The returned dialer always implements ContextDialer.
+	return proxyDialer.(proxy.ContextDialer), nil
 }
 
 func (d *dialer) Dial(ctx context.Context, ip netip.AddrPort) (net.Conn, error) {
 	if err := d.throttler.Acquire(ctx); err != nil {
 		return nil, err
 	}
+
+	useProxy := d.useProxy(ip.Addr())
 	d.log.Verbo("dialing",
 		zap.Stringer("ip", ip),
+		zap.Bool("proxied", useProxy),
+	)
+
+	var (
+		conn net.Conn
+		err  error
 	)
-	conn, err := d.dialer.DialContext(ctx, d.network, ip.String())
+	if useProxy {
+		conn, err = d.proxyDialer.DialContext(ctx, d.network, ip.String())
+	} else {
+		conn, err = d.dialer.DialContext(ctx, d.network, ip.String())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error while dialing %s: %w", ip, err)
 	}
 	return conn, nil
 }
+
+// HealthCheck verifies that a connection can be opened to the proxy. If no
+// proxy is configured, the dialer is always healthy.
+func (d *dialer) HealthCheck(ctx context.Context) (interface{}, error) {
+	if d.proxyURL == nil {
+		return nil, nil
+	}
+
+	details := map[string]interface{}{
+		ProxyKey: d.redactedProxyURL(),
+	}
+	conn, err := d.dialer.DialContext(ctx, d.network, d.proxyURL.Host)
+	details[ProxyReachableKey] = err == nil
+	if err != nil {
+		return details, fmt.Errorf("proxy %s is unreachable: %w", d.proxyURL.Host, err)
+	}
+	_ = conn.Close()
+	return details, nil
+}
+
+// useProxy returns true if connections to [addr] should be dialed through the
+// proxy.
+func (d *dialer) useProxy(addr netip.Addr) bool {
+	if d.proxyDialer == nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range d.proxyBypass {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *dialer) redactedProxyURL() string {
+	if d.proxyURL == nil {
+		return ""
+	}
+	return d.proxyURL.Redacted()
+}
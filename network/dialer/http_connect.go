@@ -0,0 +1,111 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package dialer
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+var (
+	_ proxy.ContextDialer = (*httpConnectDialer)(nil)
+
+	errProxyConnectFailed = errors.New("proxy refused CONNECT request")
+)
+
+// httpConnectDialer dials connections through an HTTP proxy by tunnelling them
+// with the CONNECT method. If the proxy URL has the https scheme, the
+// connection to the proxy is secured with TLS.
+type httpConnectDialer struct {
+	proxyURL *url.URL
+	forward  *net.Dialer
+	// tlsConfig is nil if the connection to the proxy isn't secured with TLS.
+	tlsConfig *tls.Config
+}
+
+func newHTTPConnectDialer(proxyURL *url.URL, forward *net.Dialer) *httpConnectDialer {
+	d := &httpConnectDialer{
+		proxyURL: proxyURL,
+		forward:  forward,
+	}
+	if proxyURL.Scheme == "https" {
+		d.tlsConfig = &tls.Config{
+			ServerName: proxyURL.Hostname(),
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	return d
+}
+
+func (d *httpConnectDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := d.forward.DialContext(ctx, network, d.proxyURL.Host)
+	if err != nil {
+		return nil, err
+	}
+	if d.tlsConfig != nil {
+		tlsConn := tls.Client(conn, d.tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	if err := d.connect(ctx, conn, addr); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// connect requests the proxy on [conn] to tunnel the connection to [addr].
+func (d *httpConnectDialer) connect(ctx context.Context, conn net.Conn, addr string) error {
+	// Interrupt the request if [ctx] is cancelled.
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if user := d.proxyURL.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return err
+	}
+
+	// The peer doesn't send anything until it receives the handshake, so
+	// nothing after the response is buffered by the reader.
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", errProxyConnectFailed, resp.Status)
+	}
+
+	if !stop() {
+		// [ctx] was cancelled, so the deadline of [conn] may have been set.
+		return ctx.Err()
+	}
+	return nil
+}
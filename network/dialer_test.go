@@ -44,6 +44,10 @@ func (d *testDialer) AddListener(ip netip.AddrPort, listener *testListener) {
 	d.listeners[ip] = listener
 }
 
+func (*testDialer) HealthCheck(context.Context) (interface{}, error) {
+	return nil, nil
+}
+
 func (d *testDialer) Dial(ctx context.Context, ip netip.AddrPort) (net.Conn, error) {
 	listener, ok := d.listeners[ip]
 	if !ok {
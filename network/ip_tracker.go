@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/bloom"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
@@ -42,6 +43,7 @@ var _ validators.ManagerCallbackListener = (*ipTracker)(nil)
 
 func newIPTracker(
 	trackedSubnets set.Set[ids.ID],
+	reputation reputation.Tracker,
 	log logging.Logger,
 	registerer prometheus.Registerer,
 ) (*ipTracker, error) {
@@ -51,6 +53,7 @@ func newIPTracker(
 	}
 	tracker := &ipTracker{
 		trackedSubnets: trackedSubnets,
+		reputation:     reputation,
 		log:            log,
 		numTrackedPeers: prometheus.NewGauge(prometheus.GaugeOpts{
 			Name: "tracked_peers",
@@ -206,6 +209,7 @@ func (s *gossipableSubnet) canDelete() bool {
 type ipTracker struct {
 	// trackedSubnets does not include the primary network.
 	trackedSubnets    set.Set[ids.ID]
+	reputation        reputation.Tracker
 	log               logging.Logger
 	numTrackedPeers   prometheus.Gauge
 	numGossipableIPs  prometheus.Gauge // IPs are not deduplicated across subnets
@@ -246,6 +250,24 @@ func (i *ipTracker) ManuallyTrack(nodeID ids.NodeID) {
 	i.addTrackableID(nodeID, nil)
 }
 
+// StopManuallyTracking undoes a prior call to ManuallyTrack. The nodeID
+// remains tracked if it is a validator.
+func (i *ipTracker) StopManuallyTracking(nodeID ids.NodeID) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	trackedNode, ok := i.tracked[nodeID]
+	if !ok {
+		return
+	}
+
+	trackedNode.manuallyTracked = false
+	if trackedNode.canDelete() {
+		i.numTrackedPeers.Dec()
+		delete(i.tracked, nodeID)
+	}
+}
+
 // ManuallyGossip marks the provided nodeID as being desirable to connect to and
 // marks the IPs that this node provides as being valid to gossip.
 //
@@ -266,13 +288,14 @@ func (i *ipTracker) ManuallyGossip(subnetID ids.ID, nodeID ids.NodeID) {
 // WantsConnection returns true if any of the following conditions are met:
 //  1. The node has been manually tracked.
 //  2. The node has been manually gossiped on a tracked subnet.
-//  3. The node is currently a validator on a tracked subnet.
+//  3. The node is currently a validator on a tracked subnet and its
+//     reputation isn't poor enough to be avoided.
 func (i *ipTracker) WantsConnection(nodeID ids.NodeID) bool {
 	i.lock.RLock()
 	defer i.lock.RUnlock()
 
 	node, ok := i.tracked[nodeID]
-	return ok && node.wantsConnection()
+	return ok && i.wantsConnection(nodeID, node)
 }
 
 // ShouldVerifyIP is used as an optimization to avoid unnecessary IP
@@ -291,7 +314,7 @@ func (i *ipTracker) ShouldVerifyIP(
 		return false
 	}
 
-	if !trackAllSubnets && !node.wantsConnection() {
+	if !trackAllSubnets && !i.wantsConnection(ip.NodeID, node) {
 		return false
 	}
 
@@ -320,7 +343,7 @@ func (i *ipTracker) AddIP(ip *ips.ClaimedIPPort) bool {
 	if connectedNode, ok := i.connected[ip.NodeID]; ok {
 		i.setGossipableIP(trackedNode.ip, connectedNode.trackedSubnets)
 	}
-	return trackedNode.wantsConnection()
+	return i.wantsConnection(ip.NodeID, trackedNode)
 }
 
 // GetIP returns the most recent IP of the provided nodeID. Returns true if all
@@ -336,7 +359,7 @@ func (i *ipTracker) GetIP(nodeID ids.NodeID) (*ips.ClaimedIPPort, bool) {
 	if !ok || node.ip == nil {
 		return nil, false
 	}
-	return node.ip, node.wantsConnection()
+	return node.ip, i.wantsConnection(nodeID, node)
 }
 
 // Connected is called when a connection is established. The peer should have
@@ -356,6 +379,15 @@ func (i *ipTracker) Connected(ip *ips.ClaimedIPPort, trackedSubnets set.Set[ids.
 	}
 }
 
+// wantsConnection returns true if a connection to [node] is desired. Nodes with
+// a poor reputation are only connected to if they were manually tracked.
+func (i *ipTracker) wantsConnection(nodeID ids.NodeID, node *trackedNode) bool {
+	if !node.wantsConnection() {
+		return false
+	}
+	return node.manuallyTracked || !i.reputation.ShouldAvoid(nodeID)
+}
+
 func (i *ipTracker) addIP(ip *ips.ClaimedIPPort) (int, *trackedNode) {
 	node, ok := i.tracked[ip.NodeID]
 	if !ok {
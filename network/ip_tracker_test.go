@@ -5,12 +5,15 @@ package network
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/utils/bloom"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
@@ -21,6 +24,7 @@ import (
 func newTestIPTracker(t *testing.T) *ipTracker {
 	tracker, err := newIPTracker(
 		nil,
+		reputation.NoTracker{},
 		logging.NoLog{},
 		prometheus.NewRegistry(),
 	)
@@ -153,6 +157,56 @@ func TestIPTracker_ManuallyTrack(t *testing.T) {
 	}
 }
 
+func TestIPTracker_StopManuallyTracking(t *testing.T) {
+	tests := []struct {
+		name           string
+		initialState   func(t *testing.T) *ipTracker
+		expectedChange func(*ipTracker)
+	}{
+		{
+			name:           "untracked",
+			initialState:   newTestIPTracker,
+			expectedChange: func(*ipTracker) {},
+		},
+		{
+			name: "manually tracked non-validator",
+			initialState: func(t *testing.T) *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.ManuallyTrack(ip.NodeID)
+				return tracker
+			},
+			expectedChange: func(tracker *ipTracker) {
+				tracker.numTrackedPeers.Dec()
+				delete(tracker.tracked, ip.NodeID)
+			},
+		},
+		{
+			name: "manually tracked validator",
+			initialState: func(t *testing.T) *ipTracker {
+				tracker := newTestIPTracker(t)
+				tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
+				tracker.ManuallyTrack(ip.NodeID)
+				return tracker
+			},
+			expectedChange: func(tracker *ipTracker) {
+				tracker.tracked[ip.NodeID].manuallyTracked = false
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testState := test.initialState(t)
+			expectedState := test.initialState(t)
+
+			testState.StopManuallyTracking(ip.NodeID)
+			test.expectedChange(expectedState)
+
+			requireEqual(t, expectedState, testState)
+			requireMetricsConsistent(t, testState)
+		})
+	}
+}
+
 func TestIPTracker_ManuallyGossip(t *testing.T) {
 	subnetID := ids.GenerateTestID()
 	tests := []struct {
@@ -1248,6 +1302,51 @@ func TestIPTracker_PreventBloomFilterAddition(t *testing.T) {
 	requireMetricsConsistent(t, tracker)
 }
 
+func TestIPTracker_Reputation(t *testing.T) {
+	require := require.New(t)
+
+	peerReputation, err := reputation.NewTracker(
+		memdb.New(),
+		reputation.Config{
+			Halflife:   time.Hour,
+			Threshold:  -50,
+			MaxTracked: 1_000,
+		},
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	defer func() {
+		require.NoError(peerReputation.Close())
+	}()
+
+	tracker, err := newIPTracker(
+		nil,
+		peerReputation,
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	tracker.OnValidatorAdded(constants.PrimaryNetworkID, ip.NodeID, nil, ids.Empty, 0)
+	require.True(tracker.WantsConnection(ip.NodeID))
+	require.True(tracker.ShouldVerifyIP(ip, false))
+	require.True(tracker.AddIP(ip))
+
+	// Validators with a poor reputation are no longer connected to.
+	for i := 0; i < 10; i++ {
+		peerReputation.Record(ip.NodeID, reputation.InvalidMessage)
+	}
+	require.False(tracker.WantsConnection(ip.NodeID))
+	require.False(tracker.ShouldVerifyIP(newerTestIP(ip), false))
+	_, wantsConnection := tracker.GetIP(ip.NodeID)
+	require.False(wantsConnection)
+
+	// Manually tracked nodes are connected to regardless of their reputation.
+	tracker.ManuallyTrack(ip.NodeID)
+	require.True(tracker.WantsConnection(ip.NodeID))
+}
+
 func TestIPTracker_GetGossipableIPs(t *testing.T) {
 	subnetIDA := ids.GenerateTestID()
 	subnetIDB := ids.GenerateTestID()
@@ -26,6 +26,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/network/dialer"
 	"github.com/MetalBlockchain/metalgo/network/peer"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
+	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/sender"
@@ -46,6 +47,7 @@ const (
 	TimeSinceLastMsgReceivedKey = "timeSinceLastMsgReceived"
 	TimeSinceLastMsgSentKey     = "timeSinceLastMsgSent"
 	SendFailRateKey             = "sendFailRate"
+	DialerKey                   = "dialer"
 )
 
 var (
@@ -80,6 +82,22 @@ type Network interface {
 	// connect to this ID.
 	ManuallyTrack(nodeID ids.NodeID, ip netip.AddrPort)
 
+	// StopManuallyTracking undoes a prior call to ManuallyTrack. If a
+	// connection to this ID is no longer desired, the network will stop
+	// attempting to connect to it.
+	StopManuallyTracking(nodeID ids.NodeID)
+
+	// Disconnect closes any connection to this ID. The network may reconnect
+	// to this ID if a connection is still desired.
+	Disconnect(nodeID ids.NodeID)
+
+	// Ban disconnects from this ID and prevents connections with it until
+	// [duration] has passed.
+	Ban(nodeID ids.NodeID, duration time.Duration) error
+
+	// Bans returns the bans that have not yet expired.
+	Bans() []peer.Ban
+
 	// PeerInfo returns information about peers. If [nodeIDs] is empty, returns
 	// info about all peers that have finished the handshake. Otherwise, returns
 	// info about the peers in [nodeIDs] that have finished the handshake.
@@ -88,6 +106,11 @@ type Network interface {
 	// NodeUptime returns given node's primary network UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime() (UptimeResult, error)
+
+	// RegisterChain registers the subnet of this chain so that inbound
+	// messages for the chain are charged to the subnet's throttler
+	// allocation.
+	RegisterChain(chainName string, ctx *snow.ConsensusContext, vm common.VM)
 }
 
 type UptimeResult struct {
@@ -119,6 +142,7 @@ type network struct {
 	peerConfig *peer.Config
 	metrics    *metrics
 
+	inboundMsgThrottler  throttling.InboundMsgThrottler
 	outboundMsgThrottler throttling.OutboundMsgThrottler
 
 	// Limits the number of connection attempts based on IP.
@@ -239,7 +263,7 @@ func NewNetwork(
 		return nil, fmt.Errorf("initializing network metrics failed with: %w", err)
 	}
 
-	ipTracker, err := newIPTracker(config.TrackedSubnets, log, metricsRegisterer)
+	ipTracker, err := newIPTracker(config.TrackedSubnets, config.Reputation, log, metricsRegisterer)
 	if err != nil {
 		return nil, fmt.Errorf("initializing ip tracker failed with: %w", err)
 	}
@@ -277,9 +301,12 @@ func NewNetwork(
 		MaxClockDifference:   config.MaxClockDifference,
 		SupportedACPs:        config.SupportedACPs.List(),
 		ObjectedACPs:         config.ObjectedACPs.List(),
+		CompressionType:      config.CompressionType,
 		ResourceTracker:      config.ResourceTracker,
+		Reputation:           config.Reputation,
+		Capture:              config.Capture,
 		UptimeCalculator:     config.UptimeCalculator,
-		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
+		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.MySecondaryIPPort, config.TLSKey, config.BLSKey),
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -287,13 +314,14 @@ func NewNetwork(
 		config:               config,
 		peerConfig:           peerConfig,
 		metrics:              metrics,
+		inboundMsgThrottler:  inboundMsgThrottler,
 		outboundMsgThrottler: outboundMsgThrottler,
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		listener:                    listener,
 		dialer:                      dialer,
-		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected),
-		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected),
+		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig, metrics.tlsConnRejected, config.BanList),
+		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig, metrics.tlsConnRejected, config.BanList),
 
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
@@ -355,7 +383,7 @@ func (n *network) Send(
 // HealthCheck returns information about several network layer health checks.
 // 1) Information about health check results
 // 2) An error if the health check reports unhealthy
-func (n *network) HealthCheck(context.Context) (interface{}, error) {
+func (n *network) HealthCheck(ctx context.Context) (interface{}, error) {
 	n.peersLock.RLock()
 	connectedTo := n.connectedPeers.Len()
 	n.peersLock.RUnlock()
@@ -401,6 +429,13 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	details[SendFailRateKey] = sendFailRate
 	n.metrics.sendFailRate.Set(sendFailRate)
 
+	// Make sure the proxy that peers are dialed through is reachable
+	dialerDetails, dialerErr := n.dialer.HealthCheck(ctx)
+	if dialerDetails != nil {
+		details[DialerKey] = dialerDetails
+	}
+	healthy = healthy && dialerErr == nil
+
 	// emit metrics about the lifetime of peer connections
 	n.metrics.updatePeerConnectionLifetimeMetrics()
 
@@ -427,6 +462,9 @@ func (n *network) HealthCheck(context.Context) (interface{}, error) {
 	if !isMsgFailRate {
 		errorReasons = append(errorReasons, fmt.Sprintf("messages failure send rate %g > %g", sendFailRate, n.config.HealthConfig.MaxSendFailRate))
 	}
+	if dialerErr != nil {
+		errorReasons = append(errorReasons, dialerErr.Error())
+	}
 	return details, fmt.Errorf("network layer is unhealthy reason: %s", strings.Join(errorReasons, ", "))
 }
 
@@ -459,6 +497,8 @@ func (n *network) Connected(nodeID ids.NodeID) {
 		peerIP.Timestamp,
 		peerIP.TLSSignature,
 	)
+	newIP.SecondaryAddrPort = peerIP.SecondaryAddrPort
+	newIP.SecondarySignature = peerIP.SecondaryTLSSignature
 	trackedSubnets := peer.TrackedSubnets()
 	n.ipTracker.Connected(newIP, trackedSubnets)
 
@@ -678,6 +718,44 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip netip.AddrPort) {
 	}
 }
 
+func (n *network) StopManuallyTracking(nodeID ids.NodeID) {
+	n.ipTracker.StopManuallyTracking(nodeID)
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	if ip, isTracked := n.trackedIPs[nodeID]; isTracked && !n.ipTracker.WantsConnection(nodeID) {
+		ip.stopTracking()
+		delete(n.trackedIPs, nodeID)
+	}
+}
+
+func (n *network) Disconnect(nodeID ids.NodeID) {
+	n.peersLock.RLock()
+	connectingPeer, connecting := n.connectingPeers.GetByID(nodeID)
+	connectedPeer, connected := n.connectedPeers.GetByID(nodeID)
+	n.peersLock.RUnlock()
+
+	if connecting {
+		connectingPeer.StartClose()
+	}
+	if connected {
+		connectedPeer.StartClose()
+	}
+}
+
+func (n *network) Ban(nodeID ids.NodeID, duration time.Duration) error {
+	if err := n.config.BanList.Ban(nodeID, duration); err != nil {
+		return err
+	}
+	n.Disconnect(nodeID)
+	return nil
+}
+
+func (n *network) Bans() []peer.Ban {
+	return n.config.BanList.Bans()
+}
+
 func (n *network) track(ip *ips.ClaimedIPPort, trackAllSubnets bool) error {
 	// To avoid signature verification when the IP isn't needed, we
 	// optimistically filter out IPs. This can result in us not tracking an IP
@@ -696,10 +774,12 @@ func (n *network) track(ip *ips.ClaimedIPPort, trackAllSubnets bool) error {
 	// lock.
 	signedIP := peer.SignedIP{
 		UnsignedIP: peer.UnsignedIP{
-			AddrPort:  ip.AddrPort,
-			Timestamp: ip.Timestamp,
+			AddrPort:          ip.AddrPort,
+			SecondaryAddrPort: ip.SecondaryAddrPort,
+			Timestamp:         ip.Timestamp,
 		},
-		TLSSignature: ip.Signature,
+		TLSSignature:          ip.Signature,
+		SecondaryTLSSignature: ip.SecondarySignature,
 	}
 	maxTimestamp := n.peerConfig.Clock.Time().Add(n.peerConfig.MaxClockDifference)
 	if err := signedIP.Verify(ip.Cert, maxTimestamp); err != nil {
@@ -719,18 +799,42 @@ func (n *network) track(ip *ips.ClaimedIPPort, trackAllSubnets bool) error {
 		return nil
 	}
 
+	dialIP := n.dialAddrPort(ip)
 	tracked, isTracked := n.trackedIPs[ip.NodeID]
 	if isTracked {
 		// Stop tracking the old IP and start tracking the new one.
-		tracked = tracked.trackNewIP(ip.AddrPort)
+		tracked = tracked.trackNewIP(dialIP)
 	} else {
-		tracked = newTrackedIP(ip.AddrPort)
+		tracked = newTrackedIP(dialIP)
 	}
 	n.trackedIPs[ip.NodeID] = tracked
 	n.dial(ip.NodeID, tracked)
 	return nil
 }
 
+// dialAddrPort returns the address that should be dialed to connect to the
+// peer that claimed [ip]. The primary address is preferred unless this node
+// isn't reachable in its IP family and the peer provided a secondary address
+// that this node is reachable on.
+func (n *network) dialAddrPort(ip *ips.ClaimedIPPort) netip.AddrPort {
+	if !ip.SecondaryAddrPort.IsValid() ||
+		n.isReachable(ip.AddrPort.Addr()) ||
+		!n.isReachable(ip.SecondaryAddrPort.Addr()) {
+		return ip.AddrPort
+	}
+	return ip.SecondaryAddrPort
+}
+
+// isReachable returns true if this node advertises an address in the IP family
+// of [addr].
+func (n *network) isReachable(addr netip.Addr) bool {
+	if ips.IsSameFamily(n.config.MyIPPort.Get().Addr(), addr) {
+		return true
+	}
+	secondaryIP := n.config.MySecondaryIPPort
+	return secondaryIP.IsValid() && ips.IsSameFamily(secondaryIP.Addr(), addr)
+}
+
 // getPeers returns a slice of connected peers from a set of [nodeIDs].
 //
 //   - [nodeIDs] the IDs of the peers that should be returned if they are
@@ -853,7 +957,7 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 
 	// The peer that is disconnecting from us finished the handshake
 	if ip, wantsConnection := n.ipTracker.GetIP(nodeID); wantsConnection {
-		tracked := newTrackedIP(ip.AddrPort)
+		tracked := newTrackedIP(n.dialAddrPort(ip))
 		n.trackedIPs[nodeID] = tracked
 		n.dial(nodeID, tracked)
 	}
@@ -963,6 +1067,18 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				continue
 			}
 
+			// Banned nodes would be rejected during the upgrade, so we avoid
+			// dialing them until the ban expires.
+			if n.config.BanList.IsBanned(nodeID) {
+				n.peerConfig.Log.Verbo("skipping connection dial",
+					zap.String("reason", "node is banned"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("peerIP", ip.ip),
+					zap.Duration("delay", ip.delay),
+				)
+				continue
+			}
+
 			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
@@ -1199,6 +1315,10 @@ func (n *network) NodeUptime() (UptimeResult, error) {
 	}, nil
 }
 
+func (n *network) RegisterChain(_ string, ctx *snow.ConsensusContext, _ common.VM) {
+	n.inboundMsgThrottler.AddChain(ctx.ChainID, ctx.SubnetID)
+}
+
 func (n *network) runTimers() {
 	pullGossipPeerlists := time.NewTicker(n.config.PeerListPullGossipFreq)
 	resetPeerListBloom := time.NewTicker(n.config.PeerListBloomResetFreq)
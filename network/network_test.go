@@ -16,8 +16,10 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/capture"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
 	"github.com/MetalBlockchain/metalgo/network/peer"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
@@ -121,6 +123,9 @@ var (
 
 		MaximumInboundMessageTimeout: 30 * time.Second,
 		ResourceTracker:              newDefaultResourceTracker(),
+		Reputation:                   reputation.NoTracker{},
+		BanList:                      peer.NoBanList{},
+		Capture:                      capture.NoRecorder{},
 		CPUTargeter:                  nil, // Set in init
 		DiskTargeter:                 nil, // Set in init
 	}
@@ -567,7 +572,7 @@ func TestDialDeletesNonValidators(t *testing.T) {
 	}
 
 	config := configs[0]
-	signer := peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey)
+	signer := peer.NewIPSigner(config.MyIPPort, config.MySecondaryIPPort, config.TLSKey, config.BLSKey)
 	ip, err := signer.GetSignedIP()
 	require.NoError(err)
 
@@ -753,3 +758,80 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestDialAddrPort(t *testing.T) {
+	var (
+		ipv4 = netip.AddrPortFrom(
+			netip.AddrFrom4([4]byte{1, 2, 3, 4}),
+			1,
+		)
+		ipv6 = netip.AddrPortFrom(
+			netip.IPv6Loopback(),
+			1,
+		)
+	)
+	tests := []struct {
+		name        string
+		myIP        netip.AddrPort
+		mySecondary netip.AddrPort
+		ip          *ips.ClaimedIPPort
+		expected    netip.AddrPort
+	}{
+		{
+			name: "no secondary IP",
+			myIP: ipv6,
+			ip: &ips.ClaimedIPPort{
+				AddrPort: ipv4,
+			},
+			expected: ipv4,
+		},
+		{
+			name: "primary IP reachable",
+			myIP: ipv4,
+			ip: &ips.ClaimedIPPort{
+				AddrPort:          ipv4,
+				SecondaryAddrPort: ipv6,
+			},
+			expected: ipv4,
+		},
+		{
+			name: "only secondary IP reachable",
+			myIP: ipv6,
+			ip: &ips.ClaimedIPPort{
+				AddrPort:          ipv4,
+				SecondaryAddrPort: ipv6,
+			},
+			expected: ipv6,
+		},
+		{
+			name:        "both IPs reachable",
+			myIP:        ipv6,
+			mySecondary: ipv4,
+			ip: &ips.ClaimedIPPort{
+				AddrPort:          ipv4,
+				SecondaryAddrPort: ipv6,
+			},
+			expected: ipv4,
+		},
+		{
+			name: "neither IP reachable",
+			myIP: ipv6,
+			ip: &ips.ClaimedIPPort{
+				AddrPort:          ipv4,
+				SecondaryAddrPort: netip.AddrPortFrom(netip.AddrFrom4([4]byte{5, 6, 7, 8}), 1),
+			},
+			expected: ipv4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &network{
+				config: &Config{
+					MyIPPort:          utils.NewAtomic(test.myIP),
+					MySecondaryIPPort: test.mySecondary,
+				},
+			}
+			require.Equal(t, test.expected, n.dialAddrPort(test.ip))
+		})
+	}
+}
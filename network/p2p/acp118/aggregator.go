@@ -0,0 +1,219 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package acp118
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/proto/pb/sdk"
+	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp"
+)
+
+var (
+	ErrFailedAggregation = errors.New("failed aggregation")
+
+	errFailedVerification = errors.New("failed verification")
+)
+
+type indexedValidator struct {
+	*warp.Validator
+	Index int
+}
+
+type result struct {
+	NodeID    ids.NodeID
+	Validator indexedValidator
+	Signature *bls.Signature
+	Err       error
+}
+
+// NewSignatureAggregator returns an instance of SignatureAggregator
+func NewSignatureAggregator(log logging.Logger, client *p2p.Client) *SignatureAggregator {
+	return &SignatureAggregator{
+		log:    log,
+		client: client,
+	}
+}
+
+// SignatureAggregator aggregates validator signatures for warp messages
+type SignatureAggregator struct {
+	log    logging.Logger
+	client *p2p.Client
+}
+
+// AggregateSignatures requests signatures of [message] from [validators] and
+// blocks until the signers hold at least [quorumNum]/[quorumDen] of
+// [totalWeight], every validator has responded, or [ctx] is cancelled.
+//
+// [validators] and [totalWeight] must be the canonical validator set of the
+// source subnet of [message], as returned by [warp.GetCanonicalValidatorSet].
+// If a validator is registered under multiple nodeIDs, the first valid
+// signature returned by any of them is used.
+//
+// Returns the signed message and the weight of the validators that signed it.
+// If the quorum could not be reached, an error wrapping
+// [ErrFailedAggregation] is returned.
+func (s *SignatureAggregator) AggregateSignatures(
+	ctx context.Context,
+	message *warp.UnsignedMessage,
+	justification []byte,
+	validators []*warp.Validator,
+	totalWeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) (*warp.Message, uint64, error) {
+	request := &sdk.SignatureRequest{
+		Message:       message.Bytes(),
+		Justification: justification,
+	}
+	requestBytes, err := proto.Marshal(request)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal signature request: %w", err)
+	}
+
+	nodeIDsToValidator := make(map[ids.NodeID]indexedValidator)
+	for i, v := range validators {
+		for _, nodeID := range v.NodeIDs {
+			nodeIDsToValidator[nodeID] = indexedValidator{
+				Validator: v,
+				Index:     i,
+			}
+		}
+	}
+
+	var (
+		signers    = set.NewBits()
+		signatures = make([]*bls.Signature, 0, len(validators))
+		sigWeight  uint64
+	)
+	// If the quorum is already reached, for example because the quorum is 0,
+	// there is no need to request any signatures.
+	if err := warp.VerifyWeight(sigWeight, totalWeight, quorumNum, quorumDen); err == nil {
+		return newSignedMessage(message, signers, signatures, sigWeight)
+	}
+
+	// The results channel is buffered so that responses that arrive after
+	// this function returns never block the p2p router.
+	results := make(chan result, len(nodeIDsToValidator))
+	onResponse := func(
+		_ context.Context,
+		nodeID ids.NodeID,
+		responseBytes []byte,
+		err error,
+	) {
+		validator := nodeIDsToValidator[nodeID]
+		if err != nil {
+			results <- result{NodeID: nodeID, Validator: validator, Err: err}
+			return
+		}
+
+		signature, err := parseSignature(message, validator.Validator, responseBytes)
+		results <- result{
+			NodeID:    nodeID,
+			Validator: validator,
+			Signature: signature,
+			Err:       err,
+		}
+	}
+
+	nodeIDs := set.NewSet[ids.NodeID](len(nodeIDsToValidator))
+	for nodeID := range nodeIDsToValidator {
+		nodeIDs.Add(nodeID)
+	}
+	if err := s.client.AppRequest(ctx, nodeIDs, requestBytes, onResponse); err != nil {
+		return nil, 0, fmt.Errorf("failed to send signature request: %w", err)
+	}
+
+	for numPending := nodeIDs.Len(); numPending > 0; numPending-- {
+		select {
+		case <-ctx.Done():
+			return nil, sigWeight, fmt.Errorf("%w: %w", ErrFailedAggregation, ctx.Err())
+		case r := <-results:
+			if r.Err != nil {
+				s.log.Debug("dropping signature response",
+					zap.Stringer("nodeID", r.NodeID),
+					zap.Error(r.Err),
+				)
+				continue
+			}
+
+			// Only count the first signature from each validator.
+			if signers.Contains(r.Validator.Index) {
+				continue
+			}
+
+			signers.Add(r.Validator.Index)
+			signatures = append(signatures, r.Signature)
+			sigWeight += r.Validator.Weight // Impossible to overflow here
+
+			if err := warp.VerifyWeight(sigWeight, totalWeight, quorumNum, quorumDen); err == nil {
+				return newSignedMessage(message, signers, signatures, sigWeight)
+			}
+		}
+	}
+
+	err = warp.VerifyWeight(sigWeight, totalWeight, quorumNum, quorumDen)
+	return nil, sigWeight, fmt.Errorf("%w: %w", ErrFailedAggregation, err)
+}
+
+// parseSignature parses the signature in [responseBytes] and verifies that it
+// was produced by [validator] over [message].
+func parseSignature(
+	message *warp.UnsignedMessage,
+	validator *warp.Validator,
+	responseBytes []byte,
+) (*bls.Signature, error) {
+	if validator == nil {
+		return nil, fmt.Errorf("%w: unexpected response", errFailedVerification)
+	}
+
+	response := &sdk.SignatureResponse{}
+	if err := proto.Unmarshal(responseBytes, response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	signature, err := bls.SignatureFromBytes(response.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature: %w", err)
+	}
+
+	if !bls.Verify(validator.PublicKey, signature, message.Bytes()) {
+		return nil, fmt.Errorf("%w: invalid signature", errFailedVerification)
+	}
+	return signature, nil
+}
+
+func newSignedMessage(
+	message *warp.UnsignedMessage,
+	signers set.Bits,
+	signatures []*bls.Signature,
+	sigWeight uint64,
+) (*warp.Message, uint64, error) {
+	bitSetSignature := &warp.BitSetSignature{
+		Signers: signers.Bytes(),
+	}
+	if len(signatures) > 0 {
+		aggregateSignature, err := bls.AggregateSignatures(signatures)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %w", ErrFailedAggregation, err)
+		}
+		copy(bitSetSignature.Signature[:], bls.SignatureToBytes(aggregateSignature))
+	}
+
+	signedMessage, err := warp.NewMessage(message, bitSetSignature)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create signed message: %w", err)
+	}
+	return signedMessage, sigWeight, nil
+}
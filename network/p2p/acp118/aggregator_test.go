@@ -0,0 +1,229 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package acp118
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/network/p2p/p2ptest"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/validators"
+	"github.com/MetalBlockchain/metalgo/snow/validators/validatorstest"
+	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp"
+)
+
+type testPeer struct {
+	weight uint64
+	// offline peers never receive the request
+	offline bool
+	// err is returned by the peer's verifier
+	err *common.AppError
+	// wrongKey peers sign with a key that isn't registered to them
+	wrongKey bool
+	// sharedKey peers are registered with the key of the previous peer
+	sharedKey bool
+}
+
+func TestSignatureAggregator_AggregateSignatures(t *testing.T) {
+	tests := []struct {
+		name           string
+		peers          []testPeer
+		quorumNum      uint64
+		quorumDen      uint64
+		expectedWeight uint64
+		expectedErr    error
+	}{
+		{
+			name: "aggregates all signatures",
+			peers: []testPeer{
+				{weight: 1},
+				{weight: 1},
+				{weight: 1},
+			},
+			quorumNum:      1,
+			quorumDen:      1,
+			expectedWeight: 3,
+		},
+		{
+			name: "stops once quorum is reached",
+			peers: []testPeer{
+				{weight: 1},
+				{weight: 1},
+				{weight: 1},
+			},
+			quorumNum:      1,
+			quorumDen:      3,
+			expectedWeight: 1,
+		},
+		{
+			name: "ignores verification failures",
+			peers: []testPeer{
+				{weight: 1},
+				{weight: 1},
+				{weight: 1, err: &common.AppError{Code: 123}},
+			},
+			quorumNum:      2,
+			quorumDen:      3,
+			expectedWeight: 2,
+		},
+		{
+			name: "ignores offline validators",
+			peers: []testPeer{
+				{weight: 1},
+				{weight: 1},
+				{weight: 1, offline: true},
+			},
+			quorumNum:      2,
+			quorumDen:      3,
+			expectedWeight: 2,
+		},
+		{
+			name: "ignores invalid signatures",
+			peers: []testPeer{
+				{weight: 1},
+				{weight: 1},
+				{weight: 1, wrongKey: true},
+			},
+			quorumNum:      2,
+			quorumDen:      3,
+			expectedWeight: 2,
+		},
+		{
+			name: "counts validators with multiple nodeIDs once",
+			peers: []testPeer{
+				{weight: 1},
+				{weight: 1, sharedKey: true},
+				{weight: 2},
+			},
+			quorumNum:      1,
+			quorumDen:      1,
+			expectedWeight: 4,
+		},
+		{
+			name: "insufficient weight",
+			peers: []testPeer{
+				{weight: 1},
+				{weight: 1, err: &common.AppError{Code: 123}},
+				{weight: 1, offline: true},
+				{weight: 1, wrongKey: true},
+			},
+			quorumNum:   1,
+			quorumDen:   2,
+			expectedErr: warp.ErrInsufficientWeight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				ctx       = context.Background()
+				networkID = uint32(123)
+				chainID   = ids.GenerateTestID()
+				subnetID  = ids.GenerateTestID()
+
+				vdrSet   = make(map[ids.NodeID]*validators.GetValidatorOutput)
+				handlers = make(map[ids.NodeID]p2p.Handler)
+				sk       *bls.SecretKey
+			)
+			for _, peer := range tt.peers {
+				if !peer.sharedKey {
+					var err error
+					sk, err = bls.NewSecretKey()
+					require.NoError(err)
+				}
+
+				nodeID := ids.GenerateTestNodeID()
+				vdrSet[nodeID] = &validators.GetValidatorOutput{
+					NodeID:    nodeID,
+					PublicKey: bls.PublicFromSecretKey(sk),
+					Weight:    peer.weight,
+				}
+
+				if peer.offline {
+					continue
+				}
+
+				signingKey := sk
+				if peer.wrongKey {
+					var err error
+					signingKey, err = bls.NewSecretKey()
+					require.NoError(err)
+				}
+
+				var verifier testVerifier
+				if peer.err != nil {
+					verifier.Errs = []*common.AppError{peer.err}
+				}
+				handlers[nodeID] = NewHandler(
+					&verifier,
+					warp.NewSigner(signingKey, networkID, chainID),
+				)
+			}
+
+			client := p2ptest.NewClientWithPeers(
+				t,
+				ctx,
+				ids.GenerateTestNodeID(),
+				handlers,
+			)
+			aggregator := NewSignatureAggregator(logging.NoLog{}, client)
+
+			vdrs, totalWeight, err := warp.FlattenValidatorSet(vdrSet)
+			require.NoError(err)
+
+			unsignedMessage, err := warp.NewUnsignedMessage(
+				networkID,
+				chainID,
+				[]byte("payload"),
+			)
+			require.NoError(err)
+
+			signedMessage, weight, err := aggregator.AggregateSignatures(
+				ctx,
+				unsignedMessage,
+				[]byte("justification"),
+				vdrs,
+				totalWeight,
+				tt.quorumNum,
+				tt.quorumDen,
+			)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				require.ErrorIs(err, ErrFailedAggregation)
+				return
+			}
+			require.Equal(tt.expectedWeight, weight)
+
+			// The aggregated signature must be verifiable against the
+			// canonical validator set.
+			state := &validatorstest.State{
+				T: t,
+				GetSubnetIDF: func(context.Context, ids.ID) (ids.ID, error) {
+					return subnetID, nil
+				},
+				GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+					return vdrSet, nil
+				},
+			}
+			require.NoError(signedMessage.Signature.Verify(
+				ctx,
+				&signedMessage.UnsignedMessage,
+				networkID,
+				state,
+				0,
+				tt.quorumNum,
+				tt.quorumDen,
+			))
+		})
+	}
+}
@@ -21,6 +21,8 @@ const (
 	AtomicTxGossipHandlerID
 	// SignatureRequestHandlerID is specified in ACP-118: https://github.com/avalanche-foundation/ACPs/tree/main/ACPs/118-warp-signature-request
 	SignatureRequestHandlerID
+	// StateSyncHandlerID serves the syncable state of the P-chain
+	StateSyncHandlerID
 )
 
 var (
@@ -88,3 +88,92 @@ func NewClient(
 	require.NoError(t, serverNetwork.AddHandler(0, handler))
 	return clientNetwork.NewClient(0)
 }
+
+// NewClientWithPeers generates a client to communicate to a set of peers
+// running the specified handlers. Unlike NewClient, requests are only routed to
+// the requested peers.
+func NewClientWithPeers(
+	t *testing.T,
+	ctx context.Context,
+	clientNodeID ids.NodeID,
+	peers map[ids.NodeID]p2p.Handler,
+) *p2p.Client {
+	clientSender := &enginetest.Sender{}
+	clientNetwork, err := p2p.NewNetwork(logging.NoLog{}, clientSender, prometheus.NewRegistry(), "")
+	require.NoError(t, err)
+
+	peerNetworks := make(map[ids.NodeID]*p2p.Network, len(peers))
+	for nodeID, handler := range peers {
+		peerSender := &enginetest.Sender{}
+		peerNetwork, err := p2p.NewNetwork(logging.NoLog{}, peerSender, prometheus.NewRegistry(), "")
+		require.NoError(t, err)
+
+		peerSender.SendAppResponseF = func(ctx context.Context, _ ids.NodeID, requestID uint32, responseBytes []byte) error {
+			// Send the response asynchronously to avoid deadlock when the
+			// client is still sending requests
+			go func() {
+				require.NoError(t, clientNetwork.AppResponse(ctx, nodeID, requestID, responseBytes))
+			}()
+
+			return nil
+		}
+
+		peerSender.SendAppErrorF = func(ctx context.Context, _ ids.NodeID, requestID uint32, errorCode int32, errorMessage string) error {
+			// Send the response asynchronously to avoid deadlock when the
+			// client is still sending requests
+			go func() {
+				require.NoError(t, clientNetwork.AppRequestFailed(ctx, nodeID, requestID, &common.AppError{
+					Code:    errorCode,
+					Message: errorMessage,
+				}))
+			}()
+
+			return nil
+		}
+
+		require.NoError(t, peerNetwork.Connected(ctx, clientNodeID, nil))
+		require.NoError(t, peerNetwork.Connected(ctx, nodeID, nil))
+		require.NoError(t, peerNetwork.AddHandler(0, handler))
+		peerNetworks[nodeID] = peerNetwork
+	}
+
+	clientSender.SendAppGossipF = func(ctx context.Context, _ common.SendConfig, gossipBytes []byte) error {
+		// Send the gossip asynchronously to avoid deadlock when the peer
+		// responds to the client
+		for _, peerNetwork := range peerNetworks {
+			go func() {
+				require.NoError(t, peerNetwork.AppGossip(ctx, clientNodeID, gossipBytes))
+			}()
+		}
+
+		return nil
+	}
+
+	clientSender.SendAppRequestF = func(ctx context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, requestBytes []byte) error {
+		for nodeID := range nodeIDs {
+			peerNetwork, ok := peerNetworks[nodeID]
+			if !ok {
+				// Requests to unknown peers time out, as they would if the
+				// peer were not connected.
+				go func() {
+					require.NoError(t, clientNetwork.AppRequestFailed(ctx, nodeID, requestID, common.ErrTimeout))
+				}()
+				continue
+			}
+
+			// Send the request asynchronously to avoid deadlock when the peer
+			// sends the response back to the client
+			go func() {
+				require.NoError(t, peerNetwork.AppRequest(ctx, clientNodeID, requestID, time.Time{}, requestBytes))
+			}()
+		}
+
+		return nil
+	}
+
+	require.NoError(t, clientNetwork.Connected(ctx, clientNodeID, nil))
+	for nodeID := range peers {
+		require.NoError(t, clientNetwork.Connected(ctx, nodeID, nil))
+	}
+	return clientNetwork.NewClient(0)
+}
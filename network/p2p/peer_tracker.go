@@ -14,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/utils/heap"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/set"
@@ -38,6 +39,8 @@ const (
 // Tracks the bandwidth of responses coming from peers,
 // preferring to contact peers with known good bandwidth, connecting
 // to new peers with an exponentially decaying probability.
+//
+// Peers that should be avoided due to their reputation are never selected.
 type PeerTracker struct {
 	// Lock to protect concurrent access to the peer tracker
 	lock sync.RWMutex
@@ -63,6 +66,7 @@ type PeerTracker struct {
 	log          logging.Logger
 	ignoredNodes set.Set[ids.NodeID]
 	minVersion   *version.Application
+	reputation   reputation.Tracker
 	metrics      peerTrackerMetrics
 }
 
@@ -78,6 +82,7 @@ func NewPeerTracker(
 	registerer prometheus.Registerer,
 	ignoredNodes set.Set[ids.NodeID],
 	minVersion *version.Application,
+	reputation reputation.Tracker,
 ) (*PeerTracker, error) {
 	t := &PeerTracker{
 		peerBandwidth: make(map[ids.NodeID]safemath.Averager),
@@ -88,6 +93,7 @@ func NewPeerTracker(
 		log:              log,
 		ignoredNodes:     ignoredNodes,
 		minVersion:       minVersion,
+		reputation:       reputation,
 		metrics: peerTrackerMetrics{
 			numTrackedPeers: prometheus.NewGauge(
 				prometheus.GaugeOpts{
@@ -154,7 +160,8 @@ func (p *PeerTracker) shouldSelectUntrackedPeer() bool {
 
 // SelectPeer that we could send a request to.
 //
-// If we should track more peers, returns a random untracked peer, if any exist.
+// If we should track more peers, returns the untracked peer with the best
+// reputation, if any exist.
 // Otherwise, with probability [randomPeerProbability] returns a random peer
 // from [p.responsivePeers].
 // With probability [1-randomPeerProbability] returns the peer in
@@ -166,7 +173,7 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 	defer p.lock.RUnlock()
 
 	if p.shouldSelectUntrackedPeer() {
-		if nodeID, ok := p.untrackedPeers.Peek(); ok {
+		if nodeID, ok := p.bestReputable(p.untrackedPeers); ok {
 			p.log.Debug("selecting peer",
 				zap.String("reason", "untracked"),
 				zap.Stringer("nodeID", nodeID),
@@ -179,7 +186,7 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 
 	useBandwidthHeap := rand.Float64() > randomPeerProbability // #nosec G404
 	if useBandwidthHeap {
-		if nodeID, bandwidth, ok := p.bandwidthHeap.Peek(); ok {
+		if nodeID, bandwidth, ok := p.bandwidthHeap.Peek(); ok && !p.reputation.ShouldAvoid(nodeID) {
 			p.log.Debug("selecting peer",
 				zap.String("reason", "bandwidth"),
 				zap.Stringer("nodeID", nodeID),
@@ -188,7 +195,7 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 			return nodeID, true
 		}
 	} else {
-		if nodeID, ok := p.responsivePeers.Peek(); ok {
+		if nodeID, ok := p.anyReputable(p.responsivePeers); ok {
 			p.log.Debug("selecting peer",
 				zap.String("reason", "responsive"),
 				zap.Stringer("nodeID", nodeID),
@@ -197,7 +204,7 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 		}
 	}
 
-	if nodeID, ok := p.trackedPeers.Peek(); ok {
+	if nodeID, ok := p.anyReputable(p.trackedPeers); ok {
 		p.log.Debug("selecting peer",
 			zap.String("reason", "tracked"),
 			zap.Stringer("nodeID", nodeID),
@@ -210,6 +217,41 @@ func (p *PeerTracker) SelectPeer() (ids.NodeID, bool) {
 	return ids.EmptyNodeID, false
 }
 
+// bestReputable returns the peer in [peers] with the highest reputation score
+// that shouldn't be avoided.
+//
+// Assumes the read lock is held.
+func (p *PeerTracker) bestReputable(peers set.Set[ids.NodeID]) (ids.NodeID, bool) {
+	var (
+		bestNodeID ids.NodeID
+		bestScore  = math.Inf(-1)
+		found      bool
+	)
+	for nodeID := range peers {
+		if p.reputation.ShouldAvoid(nodeID) {
+			continue
+		}
+		if score := p.reputation.Score(nodeID); score > bestScore {
+			bestNodeID = nodeID
+			bestScore = score
+			found = true
+		}
+	}
+	return bestNodeID, found
+}
+
+// anyReputable returns an arbitrary peer in [peers] that shouldn't be avoided.
+//
+// Assumes the read lock is held.
+func (p *PeerTracker) anyReputable(peers set.Set[ids.NodeID]) (ids.NodeID, bool) {
+	for nodeID := range peers {
+		if !p.reputation.ShouldAvoid(nodeID) {
+			return nodeID, true
+		}
+	}
+	return ids.EmptyNodeID, false
+}
+
 // Record that we sent a request to [nodeID].
 //
 // Removes the peer's bandwidth averager from the bandwidth heap.
@@ -226,8 +268,10 @@ func (p *PeerTracker) RegisterRequest(nodeID ids.NodeID) {
 
 // Record that we observed that [nodeID]'s bandwidth is [bandwidth].
 //
-// Adds the peer's bandwidth averager to the bandwidth heap.
+// Adds the peer's bandwidth averager to the bandwidth heap and improves the
+// peer's reputation.
 func (p *PeerTracker) RegisterResponse(nodeID ids.NodeID, bandwidth float64) {
+	p.reputation.Record(nodeID, reputation.UsefulResponse)
 	p.updateBandwidth(nodeID, bandwidth, true)
 }
 
@@ -5,11 +5,14 @@ package p2p
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/version"
 )
@@ -22,6 +25,7 @@ func TestPeerTracker(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		nil,
+		reputation.NoTracker{},
 	)
 	require.NoError(err)
 
@@ -102,3 +106,67 @@ func TestPeerTracker(t *testing.T) {
 	require.True(ok)
 	require.Falsef(responsive, "expected connecting to a non-responsive peer, but got a peer that was responsive: peer %s", peer)
 }
+
+func TestPeerTrackerReputation(t *testing.T) {
+	require := require.New(t)
+
+	peerReputation, err := reputation.NewTracker(
+		memdb.New(),
+		reputation.Config{
+			Halflife:   time.Hour,
+			Threshold:  -50,
+			MaxTracked: 1_000,
+		},
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	defer func() {
+		require.NoError(peerReputation.Close())
+	}()
+
+	p, err := NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		nil,
+		peerReputation,
+	)
+	require.NoError(err)
+
+	var (
+		avoidedPeer = ids.GenerateTestNodeID()
+		neutralPeer = ids.GenerateTestNodeID()
+		goodPeer    = ids.GenerateTestNodeID()
+	)
+	for _, nodeID := range []ids.NodeID{avoidedPeer, neutralPeer, goodPeer} {
+		p.Connected(nodeID, version.CurrentApp)
+	}
+	for i := 0; i < 10; i++ {
+		peerReputation.Record(avoidedPeer, reputation.InvalidMessage)
+	}
+	peerReputation.Record(goodPeer, reputation.UsefulResponse)
+
+	// The untracked peer with the best reputation is selected first.
+	nodeID, ok := p.SelectPeer()
+	require.True(ok)
+	require.Equal(goodPeer, nodeID)
+	p.RegisterRequest(nodeID)
+
+	nodeID, ok = p.SelectPeer()
+	require.True(ok)
+	require.Equal(neutralPeer, nodeID)
+	p.RegisterRequest(nodeID)
+
+	// Peers that should be avoided are never selected.
+	for i := 0; i < 10; i++ {
+		nodeID, ok := p.SelectPeer()
+		require.True(ok)
+		require.NotEqual(avoidedPeer, nodeID)
+	}
+
+	// Useful responses improve the reputation of peers.
+	p.RegisterResponse(neutralPeer, 10)
+	require.Positive(peerReputation.Score(neutralPeer))
+}
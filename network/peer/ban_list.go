@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+var (
+	_ BanList = (*banList)(nil)
+	_ BanList = NoBanList{}
+
+	ErrNonPositiveBanDuration = errors.New("ban duration must be positive")
+)
+
+// Ban is a prohibition on connecting to a node until [Expiry].
+type Ban struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Expiry time.Time  `json:"expiry"`
+}
+
+func (b Ban) Compare(o Ban) int {
+	return b.NodeID.Compare(o.NodeID)
+}
+
+// BanList tracks nodes that should not be connected to.
+//
+// Must be thread safe.
+type BanList interface {
+	// Ban prevents connections with [nodeID] for [duration]. Banning a node
+	// that is already banned replaces the previous expiry.
+	Ban(nodeID ids.NodeID, duration time.Duration) error
+	// IsBanned returns true if connections with [nodeID] are prohibited.
+	IsBanned(nodeID ids.NodeID) bool
+	// Bans returns the bans that have not yet expired, sorted by nodeID.
+	Bans() []Ban
+}
+
+type banList struct {
+	clock mockable.Clock
+
+	lock sync.RWMutex
+	db   database.Database
+	bans map[ids.NodeID]time.Time
+}
+
+// NewBanList returns a BanList that persists its bans to [db]. Bans that
+// expired while the node was offline are removed from [db].
+func NewBanList(db database.Database) (BanList, error) {
+	b := &banList{
+		db:   db,
+		bans: make(map[ids.NodeID]time.Time),
+	}
+
+	now := b.clock.Time()
+	var expired []ids.NodeID
+	it := db.NewIterator()
+	for it.Next() {
+		nodeID, err := ids.ToNodeID(it.Key())
+		if err != nil {
+			it.Release()
+			return nil, err
+		}
+		expiry, err := database.ParseTimestamp(it.Value())
+		if err != nil {
+			it.Release()
+			return nil, err
+		}
+		if !now.Before(expiry) {
+			expired = append(expired, nodeID)
+			continue
+		}
+		b.bans[nodeID] = expiry
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, nodeID := range expired {
+		if err := db.Delete(nodeID.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func (b *banList) Ban(nodeID ids.NodeID, duration time.Duration) error {
+	if duration <= 0 {
+		return ErrNonPositiveBanDuration
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	expiry := b.clock.Time().Add(duration)
+	if err := database.PutTimestamp(b.db, nodeID.Bytes(), expiry); err != nil {
+		return err
+	}
+	b.bans[nodeID] = expiry
+	return nil
+}
+
+func (b *banList) IsBanned(nodeID ids.NodeID) bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	expiry, ok := b.bans[nodeID]
+	return ok && b.clock.Time().Before(expiry)
+}
+
+func (b *banList) Bans() []Ban {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.clock.Time()
+	bans := make([]Ban, 0, len(b.bans))
+	for nodeID, expiry := range b.bans {
+		if !now.Before(expiry) {
+			// The ban has expired. If the delete fails, the entry will be
+			// removed the next time the ban list is loaded.
+			delete(b.bans, nodeID)
+			_ = b.db.Delete(nodeID.Bytes())
+			continue
+		}
+		bans = append(bans, Ban{
+			NodeID: nodeID,
+			Expiry: expiry,
+		})
+	}
+	utils.Sort(bans)
+	return bans
+}
+
+// NoBanList is a BanList that never bans any node.
+type NoBanList struct{}
+
+func (NoBanList) Ban(ids.NodeID, time.Duration) error {
+	return nil
+}
+
+func (NoBanList) IsBanned(ids.NodeID) bool {
+	return false
+}
+
+func (NoBanList) Bans() []Ban {
+	return nil
+}
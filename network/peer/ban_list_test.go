@@ -0,0 +1,83 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+)
+
+func TestBanList(t *testing.T) {
+	require := require.New(t)
+
+	bl, err := NewBanList(memdb.New())
+	require.NoError(err)
+	b := bl.(*banList)
+
+	now := time.Unix(1_000, 0)
+	b.clock.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.False(b.IsBanned(nodeID))
+	require.ErrorIs(b.Ban(nodeID, 0), ErrNonPositiveBanDuration)
+	require.False(b.IsBanned(nodeID))
+
+	require.NoError(b.Ban(nodeID, time.Minute))
+	require.True(b.IsBanned(nodeID))
+	require.Equal(
+		[]Ban{
+			{
+				NodeID: nodeID,
+				Expiry: now.Add(time.Minute),
+			},
+		},
+		b.Bans(),
+	)
+
+	// Banning again replaces the previous expiry.
+	require.NoError(b.Ban(nodeID, time.Hour))
+	require.Equal(
+		[]Ban{
+			{
+				NodeID: nodeID,
+				Expiry: now.Add(time.Hour),
+			},
+		},
+		b.Bans(),
+	)
+
+	b.clock.Set(now.Add(time.Hour))
+	require.False(b.IsBanned(nodeID))
+	require.Empty(b.Bans())
+}
+
+func TestBanListPersistence(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	b, err := NewBanList(db)
+	require.NoError(err)
+
+	bannedNodeID := ids.GenerateTestNodeID()
+	require.NoError(b.Ban(bannedNodeID, time.Hour))
+
+	expiredNodeID := ids.GenerateTestNodeID()
+	require.NoError(database.PutTimestamp(db, expiredNodeID.Bytes(), time.Unix(1, 0)))
+
+	b, err = NewBanList(db)
+	require.NoError(err)
+	require.True(b.IsBanned(bannedNodeID))
+	require.False(b.IsBanned(expiredNodeID))
+
+	// Expired bans are removed from disk when the ban list is loaded.
+	has, err := db.Has(expiredNodeID.Bytes())
+	require.NoError(err)
+	require.False(has)
+}
@@ -8,11 +8,14 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/capture"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/uptime"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
@@ -46,6 +49,10 @@ type Config struct {
 	SupportedACPs []uint32
 	ObjectedACPs  []uint32
 
+	// CompressionType is the preferred compression type for messages sent to
+	// peers. Peers that don't support it are sent zstd compressed messages.
+	CompressionType compression.Type
+
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
 	LastSent, LastReceived int64
@@ -53,6 +60,12 @@ type Config struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker tracker.ResourceTracker
 
+	// Records invalid messages and failed handshakes of each peer.
+	Reputation reputation.Tracker
+
+	// Records the messages sent to and received from each peer.
+	Capture capture.Recorder
+
 	// Calculates uptime of peers
 	UptimeCalculator uptime.Calculator
 
@@ -18,17 +18,23 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 )
 
+const ipLen = net.IPv6len + wrappers.ShortLen + wrappers.LongLen
+
 var (
-	errTimestampTooFarInFuture = errors.New("timestamp too far in the future")
-	errInvalidTLSSignature     = errors.New("invalid TLS signature")
+	errTimestampTooFarInFuture      = errors.New("timestamp too far in the future")
+	errInvalidTLSSignature          = errors.New("invalid TLS signature")
+	errInvalidSecondaryTLSSignature = errors.New("invalid secondary TLS signature")
 )
 
 // UnsignedIP is used for a validator to claim an IP. The [Timestamp] is used to
 // ensure that the most updated IP claim is tracked by peers for a given
 // validator.
 type UnsignedIP struct {
-	AddrPort  netip.AddrPort
-	Timestamp uint64
+	AddrPort netip.AddrPort
+	// SecondaryAddrPort is optionally claimed by dual-stack nodes and should be
+	// in the other IP family of [AddrPort].
+	SecondaryAddrPort netip.AddrPort
+	Timestamp         uint64
 }
 
 // Sign this IP with the provided signer and return the signed IP.
@@ -39,18 +45,37 @@ func (ip *UnsignedIP) Sign(tlsSigner crypto.Signer, blsSigner *bls.SecretKey) (*
 		hashing.ComputeHash256(ipBytes),
 		crypto.SHA256,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The secondary signature covers both IPs so that the secondary IP can't
+	// be paired with a different primary IP.
+	var secondaryTLSSignature []byte
+	if ip.SecondaryAddrPort.IsValid() {
+		secondaryTLSSignature, err = tlsSigner.Sign(
+			rand.Reader,
+			hashing.ComputeHash256(ip.secondaryBytes()),
+			crypto.SHA256,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	blsSignature := bls.SignProofOfPossession(blsSigner, ipBytes)
 	return &SignedIP{
-		UnsignedIP:        *ip,
-		TLSSignature:      tlsSignature,
-		BLSSignature:      blsSignature,
-		BLSSignatureBytes: bls.SignatureToBytes(blsSignature),
-	}, err
+		UnsignedIP:            *ip,
+		TLSSignature:          tlsSignature,
+		SecondaryTLSSignature: secondaryTLSSignature,
+		BLSSignature:          blsSignature,
+		BLSSignatureBytes:     bls.SignatureToBytes(blsSignature),
+	}, nil
 }
 
 func (ip *UnsignedIP) bytes() []byte {
 	p := wrappers.Packer{
-		Bytes: make([]byte, net.IPv6len+wrappers.ShortLen+wrappers.LongLen),
+		Bytes: make([]byte, ipLen),
 	}
 	addrBytes := ip.AddrPort.Addr().As16()
 	p.PackFixedBytes(addrBytes[:])
@@ -59,17 +84,33 @@ func (ip *UnsignedIP) bytes() []byte {
 	return p.Bytes
 }
 
+// secondaryBytes returns the primary IP bytes followed by the secondary IP.
+func (ip *UnsignedIP) secondaryBytes() []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, ipLen+net.IPv6len+wrappers.ShortLen),
+	}
+	p.PackFixedBytes(ip.bytes())
+	addrBytes := ip.SecondaryAddrPort.Addr().As16()
+	p.PackFixedBytes(addrBytes[:])
+	p.PackShort(ip.SecondaryAddrPort.Port())
+	return p.Bytes
+}
+
 // SignedIP is a wrapper of an UnsignedIP with the signature from a signer.
 type SignedIP struct {
 	UnsignedIP
-	TLSSignature      []byte
-	BLSSignature      *bls.Signature
-	BLSSignatureBytes []byte
+	TLSSignature []byte
+	// SecondaryTLSSignature is only populated if [SecondaryAddrPort] is.
+	SecondaryTLSSignature []byte
+	BLSSignature          *bls.Signature
+	BLSSignatureBytes     []byte
 }
 
 // Returns nil if:
 // * [ip.Timestamp] is not after [maxTimestamp].
 // * [ip.TLSSignature] is a valid signature over [ip.UnsignedIP] from [cert].
+// * [ip.SecondaryTLSSignature] is a valid signature over both IPs from [cert],
+// if [ip.SecondaryAddrPort] is populated.
 func (ip *SignedIP) Verify(
 	cert *staking.Certificate,
 	maxTimestamp time.Time,
@@ -86,5 +127,16 @@ func (ip *SignedIP) Verify(
 	); err != nil {
 		return fmt.Errorf("%w: %w", errInvalidTLSSignature, err)
 	}
+
+	if !ip.SecondaryAddrPort.IsValid() {
+		return nil
+	}
+	if err := staking.CheckSignature(
+		cert,
+		ip.UnsignedIP.secondaryBytes(),
+		ip.SecondaryTLSSignature,
+	); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidSecondaryTLSSignature, err)
+	}
 	return nil
 }
@@ -20,6 +20,10 @@ type IPSigner struct {
 	tlsSigner crypto.Signer
 	blsSigner *bls.SecretKey
 
+	// Optional address in the other IP family of [ip] that is signed along
+	// with [ip].
+	secondaryIP netip.AddrPort
+
 	// Must be held while accessing [signedIP]
 	signedIPLock sync.RWMutex
 	// Note that the values in [*signedIP] are constants and can be inspected
@@ -29,13 +33,15 @@ type IPSigner struct {
 
 func NewIPSigner(
 	ip *utils.Atomic[netip.AddrPort],
+	secondaryIP netip.AddrPort,
 	tlsSigner crypto.Signer,
 	blsSigner *bls.SecretKey,
 ) *IPSigner {
 	return &IPSigner{
-		ip:        ip,
-		tlsSigner: tlsSigner,
-		blsSigner: blsSigner,
+		ip:          ip,
+		secondaryIP: secondaryIP,
+		tlsSigner:   tlsSigner,
+		blsSigner:   blsSigner,
 	}
 }
 
@@ -69,8 +75,9 @@ func (s *IPSigner) GetSignedIP() (*SignedIP, error) {
 
 	// We should now sign our new IP at the current timestamp.
 	unsignedIP := UnsignedIP{
-		AddrPort:  ip,
-		Timestamp: s.clock.Unix(),
+		AddrPort:          ip,
+		SecondaryAddrPort: s.secondaryIP,
+		Timestamp:         s.clock.Unix(),
 	}
 	signedIP, err := unsignedIP.Sign(s.tlsSigner, s.blsSigner)
 	if err != nil {
@@ -31,7 +31,7 @@ func TestIPSigner(t *testing.T) {
 	blsKey, err := bls.NewSecretKey()
 	require.NoError(err)
 
-	s := NewIPSigner(dynIP, tlsKey, blsKey)
+	s := NewIPSigner(dynIP, netip.AddrPort{}, tlsKey, blsKey)
 
 	s.clock.Set(time.Unix(10, 0))
 
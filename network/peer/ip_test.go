@@ -34,6 +34,10 @@ func TestSignedIpVerify(t *testing.T) {
 		netip.AddrFrom4([4]byte{1, 2, 3, 4}),
 		1,
 	)
+	secondaryAddrPort := netip.AddrPortFrom(
+		netip.IPv6Loopback(),
+		1,
+	)
 
 	type test struct {
 		name         string
@@ -70,6 +74,19 @@ func TestSignedIpVerify(t *testing.T) {
 			maxTimestamp: now,
 			expectedErr:  nil,
 		},
+		{
+			name:         "valid with secondary IP",
+			tlsSigner:    tlsKey1,
+			blsSigner:    blsKey1,
+			expectedCert: cert1,
+			ip: UnsignedIP{
+				AddrPort:          addrPort,
+				SecondaryAddrPort: secondaryAddrPort,
+				Timestamp:         uint64(now.Unix()),
+			},
+			maxTimestamp: now,
+			expectedErr:  nil,
+		},
 		{
 			name:         "timestamp too far ahead",
 			tlsSigner:    tlsKey1,
@@ -105,3 +122,59 @@ func TestSignedIpVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestSignedIPVerifySecondaryIP(t *testing.T) {
+	require := require.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	cert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+	require.NoError(err)
+	tlsKey := tlsCert.PrivateKey.(crypto.Signer)
+	blsKey, err := bls.NewSecretKey()
+	require.NoError(err)
+
+	now := time.Now()
+	unsignedIP := UnsignedIP{
+		AddrPort: netip.AddrPortFrom(
+			netip.AddrFrom4([4]byte{1, 2, 3, 4}),
+			1,
+		),
+		SecondaryAddrPort: netip.AddrPortFrom(
+			netip.IPv6Loopback(),
+			1,
+		),
+		Timestamp: uint64(now.Unix()),
+	}
+	signedIP, err := unsignedIP.Sign(tlsKey, blsKey)
+	require.NoError(err)
+	require.NoError(signedIP.Verify(cert, now))
+
+	// The secondary signature must not be valid for a different secondary IP.
+	modifiedIP := *signedIP
+	modifiedIP.SecondaryAddrPort = netip.AddrPortFrom(
+		netip.IPv6Loopback(),
+		2,
+	)
+	err = modifiedIP.Verify(cert, now)
+	require.ErrorIs(err, errInvalidSecondaryTLSSignature)
+
+	// The secondary signature must not be valid when paired with a different
+	// primary IP, even if the primary IP was signed.
+	otherIP := unsignedIP
+	otherIP.AddrPort = netip.AddrPortFrom(
+		netip.AddrFrom4([4]byte{5, 6, 7, 8}),
+		1,
+	)
+	otherSignedIP, err := otherIP.Sign(tlsKey, blsKey)
+	require.NoError(err)
+	otherSignedIP.SecondaryTLSSignature = signedIP.SecondaryTLSSignature
+	err = otherSignedIP.Verify(cert, now)
+	require.ErrorIs(err, errInvalidSecondaryTLSSignature)
+
+	// The secondary signature is required if a secondary IP is provided.
+	modifiedIP = *signedIP
+	modifiedIP.SecondaryTLSSignature = nil
+	err = modifiedIP.Verify(cert, now)
+	require.ErrorIs(err, errInvalidSecondaryTLSSignature)
+}
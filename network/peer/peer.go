@@ -19,10 +19,12 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/proto/pb/p2p"
 	"github.com/MetalBlockchain/metalgo/staking"
 	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/bloom"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
 	"github.com/MetalBlockchain/metalgo/utils/ips"
@@ -161,6 +163,10 @@ type peer struct {
 	// Our primary network uptime perceived by the peer
 	observedUptime utils.Atomic[uint32]
 
+	// compressionType is the compression type used for messages sent to the
+	// peer. It is negotiated once the peer's Handshake message is received.
+	compressionType utils.Atomic[compression.Type]
+
 	// True if this peer has sent us a valid Handshake message and
 	// is running a compatible version.
 	// Only modified on the connection's reader routine.
@@ -221,6 +227,9 @@ func Start(
 		onClosed:           make(chan struct{}),
 		getPeerListChan:    make(chan struct{}, 1),
 	}
+	// Until the peer advertises the compression types it supports, only
+	// compression types that all peers support are used.
+	p.compressionType.Set(negotiateCompressionType(config.CompressionType, nil))
 
 	go p.readMessages()
 	go p.writeMessages()
@@ -271,16 +280,17 @@ func (p *peer) Info() Info {
 
 	ip, _ := ips.ParseAddrPort(p.conn.RemoteAddr().String())
 	return Info{
-		IP:             ip,
-		PublicIP:       p.ip.AddrPort,
-		ID:             p.id,
-		Version:        p.version.String(),
-		LastSent:       p.LastSent(),
-		LastReceived:   p.LastReceived(),
-		ObservedUptime: json.Uint32(primaryUptime),
-		TrackedSubnets: p.trackedSubnets,
-		SupportedACPs:  p.supportedACPs,
-		ObjectedACPs:   p.objectedACPs,
+		IP:                ip,
+		PublicIP:          p.ip.AddrPort,
+		SecondaryPublicIP: p.ip.SecondaryAddrPort,
+		ID:                p.id,
+		Version:           p.version.String(),
+		LastSent:          p.LastSent(),
+		LastReceived:      p.LastReceived(),
+		ObservedUptime:    json.Uint32(primaryUptime),
+		TrackedSubnets:    p.trackedSubnets,
+		SupportedACPs:     p.supportedACPs,
+		ObjectedACPs:      p.objectedACPs,
 	}
 }
 
@@ -459,7 +469,14 @@ func (p *peer) readMessages() {
 		)
 
 		// Parse the message
-		msg, err := p.MessageCreator.Parse(msgBytes, p.id, onFinishedHandling)
+		//
+		// [releaseChain] is set once the message is charged to the allocation
+		// of its chain's subnet.
+		releaseChain := func() {}
+		msg, err := p.MessageCreator.Parse(msgBytes, p.id, func() {
+			onFinishedHandling()
+			releaseChain()
+		})
 		if err != nil {
 			p.Log.Verbo("failed to parse message",
 				zap.Stringer("nodeID", p.id),
@@ -468,6 +485,7 @@ func (p *peer) readMessages() {
 			)
 
 			p.Metrics.NumFailedToParse.Inc()
+			p.Reputation.Record(p.id, reputation.InvalidMessage)
 
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
@@ -478,6 +496,25 @@ func (p *peer) readMessages() {
 		now := p.Clock.Time()
 		p.storeLastReceived(now)
 		p.Metrics.Received(msg, msgLen)
+		p.Capture.RecordInbound(msg, msgBytes)
+
+		// Messages that aren't chain specific, such as handshake messages,
+		// aren't charged to any subnet.
+		if chainID, err := message.GetChainID(msg.Message()); err == nil {
+			release, ok := p.InboundMsgThrottler.AcquireChain(uint64(msgLen), p.id, chainID, msg.Op())
+			if !ok {
+				p.Log.Debug("dropping message",
+					zap.Stringer("nodeID", p.id),
+					zap.Stringer("messageOp", msg.Op()),
+					zap.Stringer("chainID", chainID),
+					zap.String("reason", "subnet allocation exhausted"),
+				)
+				msg.OnFinishedHandling()
+				p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
+				continue
+			}
+			releaseChain = release
+		}
 
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
@@ -526,6 +563,8 @@ func (p *peer) writeMessages() {
 		mySignedIP.Timestamp,
 		mySignedIP.TLSSignature,
 		mySignedIP.BLSSignatureBytes,
+		mySignedIP.SecondaryAddrPort,
+		mySignedIP.SecondaryTLSSignature,
 		p.MySubnets.List(),
 		p.SupportedACPs,
 		p.ObjectedACPs,
@@ -572,7 +611,15 @@ func (p *peer) writeMessages() {
 }
 
 func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
-	msgBytes := msg.Bytes()
+	msgBytes, err := msg.BytesWithCompression(p.compressionType.Get())
+	if err != nil {
+		p.Log.Error("failed to compress message",
+			zap.Stringer("op", msg.Op()),
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return
+	}
 	p.Log.Verbo("sending message",
 		zap.Stringer("op", msg.Op()),
 		zap.Stringer("nodeID", p.id),
@@ -611,6 +658,7 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	now := p.Clock.Time()
 	p.storeLastSent(now)
 	p.Metrics.Sent(msg)
+	p.Capture.RecordOutbound(p.id, msg, msgBytes)
 }
 
 func (p *peer) sendNetworkMessages() {
@@ -767,6 +815,7 @@ func (p *peer) handlePing(msg *p2p.Ping) {
 			zap.Stringer("subnetID", constants.PrimaryNetworkID),
 			zap.Uint32("uptime", msg.Uptime),
 		)
+		p.Reputation.Record(p.id, reputation.InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -812,10 +861,19 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 			zap.Stringer("messageOp", message.HandshakeOp),
 			zap.String("reason", "already received handshake"),
 		)
+		p.Reputation.Record(p.id, reputation.InvalidMessage)
 		p.StartClose()
 		return
 	}
 
+	defer func() {
+		// If the handshake wasn't accepted, the peer is penalized so that
+		// peers that repeatedly fail the handshake are eventually avoided.
+		if !p.gotHandshake.Get() {
+			p.Reputation.Record(p.id, reputation.HandshakeFailure)
+		}
+	}()
+
 	if msg.NetworkId != p.NetworkID {
 		p.Log.Debug(malformedMessageLog,
 			zap.Stringer("nodeID", p.id),
@@ -985,6 +1043,40 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 		},
 		TLSSignature: msg.IpNodeIdSig,
 	}
+
+	// The secondary IP is optional and only provided by dual-stack peers.
+	if len(msg.SecondaryIpAddr) > 0 {
+		secondaryAddr, ok := ips.AddrFromSlice(msg.SecondaryIpAddr)
+		if !ok {
+			p.Log.Debug(malformedMessageLog,
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", message.HandshakeOp),
+				zap.String("field", "secondaryIP"),
+				zap.Int("ipLen", len(msg.SecondaryIpAddr)),
+			)
+			p.StartClose()
+			return
+		}
+
+		secondaryPort := uint16(msg.SecondaryIpPort)
+		if secondaryPort == 0 {
+			p.Log.Debug(malformedMessageLog,
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", message.HandshakeOp),
+				zap.String("field", "secondaryPort"),
+				zap.Uint16("port", secondaryPort),
+			)
+			p.StartClose()
+			return
+		}
+
+		p.ip.SecondaryAddrPort = netip.AddrPortFrom(
+			secondaryAddr,
+			secondaryPort,
+		)
+		p.ip.SecondaryTLSSignature = msg.SecondaryIpNodeIdSig
+	}
+
 	maxTimestamp := localTime.Add(p.MaxClockDifference)
 	if err := p.ip.Verify(p.cert, maxTimestamp); err != nil {
 		log := p.Log.Debug
@@ -1027,6 +1119,7 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 		return
 	}
 
+	p.compressionType.Set(negotiateCompressionType(p.CompressionType, msg.SupportedCompressionTypes))
 	p.gotHandshake.Set(true)
 
 	peerIPs := p.Network.Peers(p.id, p.trackedSubnets, msg.AllSubnets, knownPeers, salt)
@@ -1075,6 +1168,7 @@ func (p *peer) handleGetPeerList(msg *p2p.GetPeerList) {
 			zap.String("field", "knownPeers.filter"),
 			zap.Error(err),
 		)
+		p.Reputation.Record(p.id, reputation.InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -1087,6 +1181,7 @@ func (p *peer) handleGetPeerList(msg *p2p.GetPeerList) {
 			zap.String("field", "knownPeers.salt"),
 			zap.Int("saltLen", saltLen),
 		)
+		p.Reputation.Record(p.id, reputation.InvalidMessage)
 		p.StartClose()
 		return
 	}
@@ -1135,6 +1230,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "cert"),
 				zap.Error(err),
 			)
+			p.Reputation.Record(p.id, reputation.InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -1147,6 +1243,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "ip"),
 				zap.Int("ipLen", len(claimedIPPort.IpAddr)),
 			)
+			p.Reputation.Record(p.id, reputation.InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -1159,6 +1256,7 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 				zap.String("field", "port"),
 				zap.Uint16("port", port),
 			)
+			p.Reputation.Record(p.id, reputation.InvalidMessage)
 			p.StartClose()
 			return
 		}
@@ -1172,6 +1270,43 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 			claimedIPPort.Timestamp,
 			claimedIPPort.Signature,
 		)
+
+		// The secondary IP is optional and only provided by dual-stack peers.
+		if len(claimedIPPort.SecondaryIpAddr) == 0 {
+			continue
+		}
+
+		secondaryAddr, ok := ips.AddrFromSlice(claimedIPPort.SecondaryIpAddr)
+		if !ok {
+			p.Log.Debug(malformedMessageLog,
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", message.PeerListOp),
+				zap.String("field", "secondaryIP"),
+				zap.Int("ipLen", len(claimedIPPort.SecondaryIpAddr)),
+			)
+			p.Reputation.Record(p.id, reputation.InvalidMessage)
+			p.StartClose()
+			return
+		}
+
+		secondaryPort := uint16(claimedIPPort.SecondaryIpPort)
+		if secondaryPort == 0 {
+			p.Log.Debug(malformedMessageLog,
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", message.PeerListOp),
+				zap.String("field", "secondaryPort"),
+				zap.Uint16("port", secondaryPort),
+			)
+			p.Reputation.Record(p.id, reputation.InvalidMessage)
+			p.StartClose()
+			return
+		}
+
+		discoveredIPs[i].SecondaryAddrPort = netip.AddrPortFrom(
+			secondaryAddr,
+			secondaryPort,
+		)
+		discoveredIPs[i].SecondarySignature = claimedIPPort.SecondarySignature
 	}
 
 	if err := p.Network.Track(discoveredIPs); err != nil {
@@ -1181,10 +1316,27 @@ func (p *peer) handlePeerList(msg *p2p.PeerList) {
 			zap.String("field", "claimedIP"),
 			zap.Error(err),
 		)
+		p.Reputation.Record(p.id, reputation.InvalidMessage)
 		p.StartClose()
 	}
 }
 
+// negotiateCompressionType returns the compression type to use for messages
+// sent to a peer that advertised [supported]. If the peer doesn't support
+// [preferred], zstd is used because all peers support it.
+func negotiateCompressionType(preferred compression.Type, supported []uint32) compression.Type {
+	switch preferred {
+	case compression.TypeNone, compression.TypeZstd:
+		return preferred
+	}
+	for _, compressionType := range supported {
+		if compression.Type(compressionType) == preferred {
+			return preferred
+		}
+	}
+	return compression.TypeZstd
+}
+
 func (p *peer) nextTimeout() time.Time {
 	return p.Clock.Time().Add(p.PongTimeout)
 }
@@ -4,6 +4,7 @@
 package peer
 
 import (
+	"bytes"
 	"context"
 	"crypto"
 	"net"
@@ -14,8 +15,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/capture"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
@@ -24,6 +28,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/staking"
 	"github.com/MetalBlockchain/metalgo/upgrade"
 	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/compression"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
@@ -91,6 +96,8 @@ func newConfig(t *testing.T) Config {
 		PongTimeout:          constants.DefaultPingPongTimeout,
 		MaxClockDifference:   time.Minute,
 		ResourceTracker:      resourceTracker,
+		Reputation:           reputation.NoTracker{},
+		Capture:              capture.NoRecorder{},
 		UptimeCalculator:     uptime.NoOpCalculator,
 		IPSigner:             nil,
 	}
@@ -114,7 +121,7 @@ func newRawTestPeer(t *testing.T, config Config) *rawTestPeer {
 	bls, err := bls.NewSecretKey()
 	require.NoError(err)
 
-	config.IPSigner = NewIPSigner(ip, tls, bls)
+	config.IPSigner = NewIPSigner(ip, netip.AddrPort{}, tls, bls)
 
 	inboundMsgChan := make(chan message.InboundMessage)
 	config.Router = router.InboundHandlerFunc(func(_ context.Context, msg message.InboundMessage) {
@@ -208,6 +215,117 @@ func TestSend(t *testing.T) {
 	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
+func TestSendNegotiatedCompression(t *testing.T) {
+	require := require.New(t)
+
+	sharedConfig := newConfig(t)
+	sharedConfig.CompressionType = compression.TypeBrotli
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		compression.TypeBrotli,
+		10*time.Second,
+	)
+	require.NoError(err)
+	sharedConfig.MessageCreator = mc
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	awaitReady(t, peer0, peer1)
+
+	require.Equal(compression.TypeBrotli, peer0.Peer.(*peer).compressionType.Get())
+
+	outboundGossipMsg, err := mc.AppGossip(ids.Empty, bytes.Repeat([]byte{1}, 1024))
+	require.NoError(err)
+
+	require.True(peer0.Send(context.Background(), outboundGossipMsg))
+
+	inboundGossipMsg := <-peer1.inboundMsgChan
+	require.Equal(message.AppGossipOp, inboundGossipMsg.Op())
+	require.Positive(inboundGossipMsg.BytesSavedCompression())
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestHandshakeSecondaryIP(t *testing.T) {
+	require := require.New(t)
+
+	sharedConfig := newConfig(t)
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	secondaryIP := netip.AddrPortFrom(
+		netip.AddrFrom4([4]byte{127, 0, 0, 1}),
+		1,
+	)
+	rawPeer0.config.IPSigner.secondaryIP = secondaryIP
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	awaitReady(t, peer0, peer1)
+
+	// peer1 should have verified both of peer0's IPs.
+	require.Equal(secondaryIP, peer1.IP().SecondaryAddrPort)
+	require.Equal(secondaryIP, peer1.Info().SecondaryPublicIP)
+	require.False(peer0.IP().SecondaryAddrPort.IsValid())
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestNegotiateCompressionType(t *testing.T) {
+	tests := []struct {
+		name      string
+		preferred compression.Type
+		supported []uint32
+		expected  compression.Type
+	}{
+		{
+			name:      "none",
+			preferred: compression.TypeNone,
+			expected:  compression.TypeNone,
+		},
+		{
+			name:      "zstd",
+			preferred: compression.TypeZstd,
+			expected:  compression.TypeZstd,
+		},
+		{
+			name:      "supported",
+			preferred: compression.TypeLZ4,
+			supported: []uint32{
+				uint32(compression.TypeZstd),
+				uint32(compression.TypeLZ4),
+			},
+			expected: compression.TypeLZ4,
+		},
+		{
+			name:      "unsupported",
+			preferred: compression.TypeBrotli,
+			supported: []uint32{
+				uint32(compression.TypeZstd),
+				uint32(compression.TypeLZ4),
+			},
+			expected: compression.TypeZstd,
+		},
+		{
+			name:      "peer didn't advertise",
+			preferred: compression.TypeBrotli,
+			expected:  compression.TypeZstd,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, negotiateCompressionType(test.preferred, test.supported))
+		})
+	}
+}
+
 func TestPingUptimes(t *testing.T) {
 	sharedConfig := newConfig(t)
 
@@ -345,6 +463,44 @@ func TestInvalidBLSKeyDisconnects(t *testing.T) {
 	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
+func TestHandshakeFailureLowersReputation(t *testing.T) {
+	require := require.New(t)
+
+	peerReputation, err := reputation.NewTracker(
+		memdb.New(),
+		reputation.Config{
+			Halflife:   time.Hour,
+			Threshold:  -50,
+			MaxTracked: 1_000,
+		},
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	defer func() {
+		require.NoError(peerReputation.Close())
+	}()
+
+	// peer1's clock is too far ahead of peer0's clock for peer0 to accept its
+	// handshake. peer1 tolerates the difference so that peer0 is guaranteed to
+	// process peer1's handshake.
+	config0 := newConfig(t)
+	config0.Reputation = peerReputation
+	config1 := newConfig(t)
+	config1.Clock.Set(time.Now().Add(time.Hour))
+	config1.MaxClockDifference = 2 * time.Hour
+
+	rawPeer0 := newRawTestPeer(t, config0)
+	rawPeer1 := newRawTestPeer(t, config1)
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+
+	require.Negative(peerReputation.Score(rawPeer1.config.MyNodeID))
+}
+
 func TestShouldDisconnect(t *testing.T) {
 	peerID := ids.GenerateTestNodeID()
 	txID := ids.GenerateTestID()
@@ -14,6 +14,8 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/capture"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
@@ -68,6 +70,7 @@ func StartTestPeer(
 	clientUpgrader := NewTLSClientUpgrader(
 		tlsConfg,
 		prometheus.NewCounter(prometheus.CounterOpts{}),
+		NoBanList{},
 	)
 
 	peerID, conn, cert, err := clientUpgrader.Upgrade(conn)
@@ -123,12 +126,15 @@ func StartTestPeer(
 			PongTimeout:          constants.DefaultPingPongTimeout,
 			MaxClockDifference:   time.Minute,
 			ResourceTracker:      resourceTracker,
+			Reputation:           reputation.NoTracker{},
+			Capture:              capture.NoRecorder{},
 			UptimeCalculator:     uptime.NoOpCalculator,
 			IPSigner: NewIPSigner(
 				utils.NewAtomic(netip.AddrPortFrom(
 					netip.IPv6Loopback(),
 					1,
 				)),
+				netip.AddrPort{},
 				tlsKey,
 				blsKey,
 			),
@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/staking"
+)
+
+func TestUpgraderRejectsBannedNode(t *testing.T) {
+	require := require.New(t)
+
+	serverCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	clientCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	parsedClientCert, err := staking.ParseCertificate(clientCert.Leaf.Raw)
+	require.NoError(err)
+	clientNodeID := ids.NodeIDFromCert(parsedClientCert)
+
+	banList, err := NewBanList(memdb.New())
+	require.NoError(err)
+
+	upgrade := func() error {
+		serverUpgrader := NewTLSServerUpgrader(
+			TLSConfig(*serverCert, nil),
+			prometheus.NewCounter(prometheus.CounterOpts{}),
+			banList,
+		)
+		clientUpgrader := NewTLSClientUpgrader(
+			TLSConfig(*clientCert, nil),
+			prometheus.NewCounter(prometheus.CounterOpts{}),
+			NoBanList{},
+		)
+
+		serverConn, clientConn := net.Pipe()
+		defer serverConn.Close()
+		defer clientConn.Close()
+
+		go func() {
+			_, _, _, _ = clientUpgrader.Upgrade(clientConn)
+		}()
+
+		nodeID, _, _, err := serverUpgrader.Upgrade(serverConn)
+		if err != nil {
+			return err
+		}
+		require.Equal(clientNodeID, nodeID)
+		return nil
+	}
+
+	require.NoError(upgrade())
+
+	require.NoError(banList.Ban(clientNodeID, time.Hour))
+	require.ErrorIs(upgrade(), errBannedNode)
+}
@@ -0,0 +1,462 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/linked"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+)
+
+const (
+	// MaxScore is the highest score a peer can reach. Bounding the score
+	// prevents a peer from banking enough goodwill to hide later misbehavior.
+	MaxScore = 100
+	// MinScore is the lowest score a peer can reach.
+	MinScore = -1_000
+
+	// Scores closer to 0 than [pruneScore] are removed from disk when the
+	// tracker is loaded.
+	pruneScore = 0.5
+
+	// flushInterval is how often updated scores are persisted.
+	flushInterval = 30 * time.Second
+
+	scoreLen = 2 * database.Uint64Size
+)
+
+var (
+	_ Tracker = (*tracker)(nil)
+	_ Tracker = NoTracker{}
+
+	errNonPositiveHalflife   = errors.New("halflife must be positive")
+	errInvalidThreshold      = errors.New("threshold must be in [MinScore, 0]")
+	errNonPositiveMaxTracked = errors.New("max tracked peers must be positive")
+	errInvalidScoreLen       = errors.New("invalid score length")
+)
+
+// Event is an observed behavior of a peer that affects its reputation.
+type Event uint8
+
+const (
+	// InvalidMessage is recorded when a peer sends a message that can't be
+	// parsed or that is malformed.
+	InvalidMessage Event = iota
+	// Timeout is recorded when a peer doesn't respond to a request in time.
+	Timeout
+	// HandshakeFailure is recorded when a peer's handshake is rejected.
+	HandshakeFailure
+	// UsefulResponse is recorded when a peer responds to a request with a
+	// valid response.
+	UsefulResponse
+)
+
+func (e Event) String() string {
+	switch e {
+	case InvalidMessage:
+		return "invalid_message"
+	case Timeout:
+		return "timeout"
+	case HandshakeFailure:
+		return "handshake_failure"
+	case UsefulResponse:
+		return "useful_response"
+	default:
+		return "unknown"
+	}
+}
+
+// weight returns the change in score caused by the event.
+func (e Event) weight() float64 {
+	switch e {
+	case InvalidMessage:
+		return -10
+	case Timeout:
+		return -2
+	case HandshakeFailure:
+		return -20
+	case UsefulResponse:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type Config struct {
+	// Halflife is the time it takes for a score to decay halfway back to 0.
+	Halflife time.Duration `json:"halflife"`
+	// Threshold is the score below which peers should be avoided.
+	Threshold float64 `json:"threshold"`
+	// MaxTracked is the maximum number of peers whose scores are tracked.
+	// Once reached, the score that was updated the least recently is
+	// forgotten.
+	MaxTracked int `json:"maxTracked"`
+}
+
+func (c *Config) Verify() error {
+	switch {
+	case c.Halflife <= 0:
+		return fmt.Errorf("%w: %s", errNonPositiveHalflife, c.Halflife)
+	case c.Threshold < MinScore || c.Threshold > 0:
+		return fmt.Errorf("%w: %f", errInvalidThreshold, c.Threshold)
+	case c.MaxTracked <= 0:
+		return fmt.Errorf("%w: %d", errNonPositiveMaxTracked, c.MaxTracked)
+	default:
+		return nil
+	}
+}
+
+// Tracker scores peers based on their observed behavior.
+//
+// Scores start at 0, are bounded by [MinScore] and [MaxScore], and decay back
+// towards 0 over time.
+type Tracker interface {
+	// Record that [event] was observed from [nodeID].
+	Record(nodeID ids.NodeID, event Event)
+	// Score returns the current score of [nodeID].
+	Score(nodeID ids.NodeID) float64
+	// ShouldAvoid returns true if [nodeID]'s score is low enough that it
+	// shouldn't be connected to or queried.
+	ShouldAvoid(nodeID ids.NodeID) bool
+	// Close persists the scores that haven't been persisted yet. Events
+	// must not be recorded after Close is called.
+	Close() error
+}
+
+type score struct {
+	value   float64
+	updated time.Time
+}
+
+type nodeScore struct {
+	nodeID ids.NodeID
+	score  score
+}
+
+// decayed returns the value of the score at [now].
+func (s score) decayed(now time.Time, halflife time.Duration) float64 {
+	elapsed := now.Sub(s.updated)
+	if elapsed <= 0 {
+		return s.value
+	}
+	return s.value * math.Exp2(-float64(elapsed)/float64(halflife))
+}
+
+type tracker struct {
+	log    logging.Logger
+	db     database.Database
+	config Config
+	clock  mockable.Clock
+
+	numEvents      *prometheus.CounterVec
+	numTracked     prometheus.Gauge
+	numWriteErrors prometheus.Counter
+
+	lock sync.RWMutex
+	// Scores ordered by the time they were last updated.
+	scores *linked.Hashmap[ids.NodeID, score]
+	// Peers whose scores were updated or forgotten since the scores were
+	// last persisted.
+	dirty set.Set[ids.NodeID]
+
+	// flushLock ensures that scores are persisted in the order they were
+	// updated in.
+	flushLock sync.Mutex
+	closed    chan struct{}
+	closeOnce sync.Once
+	done      sync.WaitGroup
+}
+
+// NewTracker returns a Tracker that persists scores to [db]. Scores that were
+// previously persisted are loaded so that peers keep their reputation across
+// restarts. Updated scores are persisted periodically and when the tracker is
+// closed.
+func NewTracker(
+	db database.Database,
+	config Config,
+	log logging.Logger,
+	registerer prometheus.Registerer,
+) (Tracker, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	t := &tracker{
+		log:    log,
+		db:     db,
+		config: config,
+		numEvents: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "reputation_events",
+				Help: "number of events that affected the reputation of peers",
+			},
+			[]string{"event"},
+		),
+		numTracked: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "reputation_tracked_peers",
+			Help: "number of peers with a non-zero reputation",
+		}),
+		numWriteErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "reputation_write_errors",
+			Help: "number of times reputation scores failed to be persisted",
+		}),
+		scores: linked.NewHashmap[ids.NodeID, score](),
+		closed: make(chan struct{}),
+	}
+	err := errors.Join(
+		registerer.Register(t.numEvents),
+		registerer.Register(t.numTracked),
+		registerer.Register(t.numWriteErrors),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+
+	t.done.Add(1)
+	go t.flushPeriodically()
+	return t, nil
+}
+
+// load the persisted scores, removing the scores that have decayed
+// to approximately 0 and the least recently updated scores that exceed
+// [Config.MaxTracked].
+func (t *tracker) load() error {
+	it := t.db.NewIterator()
+	defer it.Release()
+
+	var (
+		now    = t.clock.Time()
+		loaded []nodeScore
+		pruned [][]byte
+	)
+	for it.Next() {
+		key := it.Key()
+		nodeID, err := ids.ToNodeID(key)
+		if err != nil {
+			return fmt.Errorf("failed to parse nodeID: %w", err)
+		}
+		s, err := parseScore(it.Value())
+		if err != nil {
+			return fmt.Errorf("failed to parse score of %s: %w", nodeID, err)
+		}
+		if math.Abs(s.decayed(now, t.config.Halflife)) < pruneScore {
+			pruned = append(pruned, key)
+			continue
+		}
+		loaded = append(loaded, nodeScore{
+			nodeID: nodeID,
+			score:  s,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	it.Release()
+
+	slices.SortFunc(loaded, func(a, b nodeScore) int {
+		return a.score.updated.Compare(b.score.updated)
+	})
+	if numExcess := len(loaded) - t.config.MaxTracked; numExcess > 0 {
+		for _, s := range loaded[:numExcess] {
+			pruned = append(pruned, s.nodeID.Bytes())
+		}
+		loaded = loaded[numExcess:]
+	}
+	for _, s := range loaded {
+		t.scores.Put(s.nodeID, s.score)
+	}
+
+	batch := t.db.NewBatch()
+	for _, key := range pruned {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+
+	t.numTracked.Set(float64(t.scores.Len()))
+	t.log.Info("loaded peer reputations",
+		zap.Int("numPeers", t.scores.Len()),
+		zap.Int("numPruned", len(pruned)),
+	)
+	return nil
+}
+
+func (t *tracker) Record(nodeID ids.NodeID, event Event) {
+	t.numEvents.WithLabelValues(event.String()).Inc()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.clock.Time()
+	current, _ := t.scores.Get(nodeID)
+	value := current.decayed(now, t.config.Halflife) + event.weight()
+	t.scores.Put(nodeID, score{
+		value:   min(max(value, MinScore), MaxScore),
+		updated: now,
+	})
+	t.dirty.Add(nodeID)
+
+	// Forget the least recently updated score, so that peers can't grow the
+	// tracked scores without bound by using new nodeIDs.
+	if t.scores.Len() > t.config.MaxTracked {
+		oldestNodeID, _, _ := t.scores.Oldest()
+		t.scores.Delete(oldestNodeID)
+		t.dirty.Add(oldestNodeID)
+	}
+	t.numTracked.Set(float64(t.scores.Len()))
+}
+
+func (t *tracker) Score(nodeID ids.NodeID) float64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	s, _ := t.scores.Get(nodeID)
+	return s.decayed(t.clock.Time(), t.config.Halflife)
+}
+
+func (t *tracker) ShouldAvoid(nodeID ids.NodeID) bool {
+	return t.Score(nodeID) < t.config.Threshold
+}
+
+func (t *tracker) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.closed)
+	})
+	t.done.Wait()
+	return t.flush()
+}
+
+func (t *tracker) flushPeriodically() {
+	defer t.done.Done()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// Failing to persist scores only means that peers may have a more
+			// favorable reputation after a restart, so the error is only
+			// logged.
+			if err := t.flush(); err != nil {
+				t.log.Warn("failed to persist peer reputations",
+					zap.Error(err),
+				)
+			}
+		case <-t.closed:
+			return
+		}
+	}
+}
+
+// flush persists the scores that were updated or forgotten since the last
+// flush.
+func (t *tracker) flush() error {
+	t.flushLock.Lock()
+	defer t.flushLock.Unlock()
+
+	t.lock.Lock()
+	ops := make([]database.BatchOp, 0, t.dirty.Len())
+	for nodeID := range t.dirty {
+		op := database.BatchOp{
+			Key: nodeID.Bytes(),
+		}
+		if s, ok := t.scores.Get(nodeID); ok {
+			op.Value = s.bytes()
+		} else {
+			op.Delete = true
+		}
+		ops = append(ops, op)
+	}
+	dirty := t.dirty
+	t.dirty = nil
+	t.lock.Unlock()
+
+	if len(ops) == 0 {
+		return nil
+	}
+
+	batch := t.db.NewBatch()
+	for _, op := range ops {
+		var err error
+		if op.Delete {
+			err = batch.Delete(op.Key)
+		} else {
+			err = batch.Put(op.Key, op.Value)
+		}
+		if err != nil {
+			return t.flushFailed(dirty, err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return t.flushFailed(dirty, err)
+	}
+	return nil
+}
+
+// flushFailed marks the scores that failed to be persisted as dirty, so that
+// they are persisted by the next flush.
+func (t *tracker) flushFailed(dirty set.Set[ids.NodeID], err error) error {
+	t.numWriteErrors.Inc()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.dirty.Union(dirty)
+	return err
+}
+
+func (s score) bytes() []byte {
+	b := make([]byte, scoreLen)
+	binary.BigEndian.PutUint64(b, math.Float64bits(s.value))
+	binary.BigEndian.PutUint64(b[database.Uint64Size:], uint64(s.updated.UnixNano()))
+	return b
+}
+
+func parseScore(b []byte) (score, error) {
+	if len(b) != scoreLen {
+		return score{}, fmt.Errorf("%w: %d", errInvalidScoreLen, len(b))
+	}
+	return score{
+		value:   math.Float64frombits(binary.BigEndian.Uint64(b)),
+		updated: time.Unix(0, int64(binary.BigEndian.Uint64(b[database.Uint64Size:]))),
+	}, nil
+}
+
+// NoTracker ignores all events and never avoids any peer.
+type NoTracker struct{}
+
+func (NoTracker) Record(ids.NodeID, Event) {}
+
+func (NoTracker) Score(ids.NodeID) float64 {
+	return 0
+}
+
+func (NoTracker) ShouldAvoid(ids.NodeID) bool {
+	return false
+}
+
+func (NoTracker) Close() error {
+	return nil
+}
@@ -0,0 +1,245 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+var testConfig = Config{
+	Halflife:   time.Hour,
+	Threshold:  -50,
+	MaxTracked: 1_000,
+}
+
+func newTestTracker(t *testing.T, db *memdb.Database, config Config) *tracker {
+	t.Helper()
+
+	tr, err := NewTracker(db, config, logging.NoLog{}, prometheus.NewRegistry())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, tr.Close())
+	})
+	return tr.(*tracker)
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			config: testConfig,
+		},
+		{
+			name: "zero halflife",
+			config: Config{
+				Threshold:  -50,
+				MaxTracked: 1_000,
+			},
+			expectedErr: errNonPositiveHalflife,
+		},
+		{
+			name: "positive threshold",
+			config: Config{
+				Halflife:   time.Hour,
+				Threshold:  1,
+				MaxTracked: 1_000,
+			},
+			expectedErr: errInvalidThreshold,
+		},
+		{
+			name: "threshold below min score",
+			config: Config{
+				Halflife:   time.Hour,
+				Threshold:  MinScore - 1,
+				MaxTracked: 1_000,
+			},
+			expectedErr: errInvalidThreshold,
+		},
+		{
+			name: "zero max tracked",
+			config: Config{
+				Halflife:  time.Hour,
+				Threshold: -50,
+			},
+			expectedErr: errNonPositiveMaxTracked,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Verify()
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestTrackerRecord(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newTestTracker(t, memdb.New(), testConfig)
+	now := time.Now()
+	tracker.clock.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.Zero(tracker.Score(nodeID))
+	require.False(tracker.ShouldAvoid(nodeID))
+
+	tracker.Record(nodeID, UsefulResponse)
+	require.Equal(UsefulResponse.weight(), tracker.Score(nodeID))
+
+	for i := 0; i < 6; i++ {
+		tracker.Record(nodeID, InvalidMessage)
+	}
+	require.Equal(UsefulResponse.weight()+6*InvalidMessage.weight(), tracker.Score(nodeID))
+	require.True(tracker.ShouldAvoid(nodeID))
+
+	// Other peers are unaffected.
+	require.False(tracker.ShouldAvoid(ids.GenerateTestNodeID()))
+}
+
+func TestTrackerDecay(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newTestTracker(t, memdb.New(), testConfig)
+	now := time.Now()
+	tracker.clock.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 4; i++ {
+		tracker.Record(nodeID, HandshakeFailure)
+	}
+	require.Equal(-80.0, tracker.Score(nodeID))
+	require.True(tracker.ShouldAvoid(nodeID))
+
+	tracker.clock.Set(now.Add(testConfig.Halflife))
+	require.InDelta(-40.0, tracker.Score(nodeID), 0.001)
+	require.False(tracker.ShouldAvoid(nodeID))
+}
+
+func TestTrackerBounds(t *testing.T) {
+	require := require.New(t)
+
+	tracker := newTestTracker(t, memdb.New(), testConfig)
+	tracker.clock.Set(time.Now())
+
+	goodNodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 2*MaxScore; i++ {
+		tracker.Record(goodNodeID, UsefulResponse)
+	}
+	require.Equal(float64(MaxScore), tracker.Score(goodNodeID))
+
+	badNodeID := ids.GenerateTestNodeID()
+	for i := 0; i < -MinScore; i++ {
+		tracker.Record(badNodeID, InvalidMessage)
+	}
+	require.Equal(float64(MinScore), tracker.Score(badNodeID))
+}
+
+func TestTrackerPersistence(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	tracker := newTestTracker(t, db, testConfig)
+
+	var (
+		badNodeID     = ids.GenerateTestNodeID()
+		neutralNodeID = ids.GenerateTestNodeID()
+	)
+	for i := 0; i < 10; i++ {
+		tracker.Record(badNodeID, InvalidMessage)
+	}
+	// Recording opposite events results in a score of 0, which is pruned on
+	// restart.
+	tracker.Record(neutralNodeID, Timeout)
+	tracker.Record(neutralNodeID, UsefulResponse)
+	tracker.Record(neutralNodeID, UsefulResponse)
+
+	expectedScore := tracker.Score(badNodeID)
+
+	// Scores are only persisted when they are flushed.
+	has, err := db.Has(badNodeID.Bytes())
+	require.NoError(err)
+	require.False(has)
+	require.NoError(tracker.Close())
+
+	// Restarting the tracker doesn't reset the reputation of peers.
+	tracker = newTestTracker(t, db, testConfig)
+	require.InDelta(expectedScore, tracker.Score(badNodeID), 0.01)
+	require.True(tracker.ShouldAvoid(badNodeID))
+	require.Equal(1, tracker.scores.Len())
+
+	has, err = db.Has(neutralNodeID.Bytes())
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestTrackerMaxTracked(t *testing.T) {
+	require := require.New(t)
+
+	config := testConfig
+	config.MaxTracked = 2
+
+	db := memdb.New()
+	tracker := newTestTracker(t, db, config)
+	now := time.Now()
+	tracker.clock.Set(now)
+
+	nodeIDs := []ids.NodeID{
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestNodeID(),
+	}
+	tracker.Record(nodeIDs[0], InvalidMessage)
+	tracker.Record(nodeIDs[1], InvalidMessage)
+	require.NoError(tracker.flush())
+
+	// Updating a score makes it the most recently updated score, so the
+	// score of nodeIDs[1] is forgotten.
+	tracker.clock.Set(now.Add(time.Second))
+	tracker.Record(nodeIDs[0], InvalidMessage)
+	tracker.clock.Set(now.Add(2 * time.Second))
+	tracker.Record(nodeIDs[2], InvalidMessage)
+	require.Equal(2, tracker.scores.Len())
+	require.Zero(tracker.Score(nodeIDs[1]))
+	require.NotZero(tracker.Score(nodeIDs[0]))
+	require.NotZero(tracker.Score(nodeIDs[2]))
+
+	// Forgotten scores are removed from disk.
+	require.NoError(tracker.flush())
+	has, err := db.Has(nodeIDs[1].Bytes())
+	require.NoError(err)
+	require.False(has)
+	require.NoError(tracker.Close())
+
+	// Only the most recently updated scores are loaded.
+	config.MaxTracked = 1
+	tracker = newTestTracker(t, db, config)
+	require.Equal(1, tracker.scores.Len())
+	require.NotZero(tracker.Score(nodeIDs[2]))
+
+	has, err = db.Has(nodeIDs[0].Bytes())
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestTrackerInvalidScore(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	require.NoError(db.Put(ids.GenerateTestNodeID().Bytes(), []byte{0x00}))
+
+	_, err := NewTracker(db, testConfig, logging.NoLog{}, prometheus.NewRegistry())
+	require.ErrorIs(err, errInvalidScoreLen)
+}
@@ -16,8 +16,10 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/network/capture"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
 	"github.com/MetalBlockchain/metalgo/network/peer"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
@@ -114,6 +116,18 @@ func NewTestNetwork(
 		return nil, err
 	}
 
+	networkDialer, err := dialer.NewDialer(
+		constants.NetworkType,
+		dialer.Config{
+			ThrottleRps:       constants.DefaultOutboundConnectionThrottlingRps,
+			ConnectionTimeout: constants.DefaultOutboundConnectionTimeout,
+		},
+		log,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return NewNetwork(
 		&Config{
 			HealthConfig: HealthConfig{
@@ -196,6 +210,9 @@ func NewTestNetwork(
 			PeerReadBufferSize:           constants.DefaultNetworkPeerReadBufferSize,
 			PeerWriteBufferSize:          constants.DefaultNetworkPeerWriteBufferSize,
 			ResourceTracker:              resourceTracker,
+			Reputation:                   reputation.NoTracker{},
+			BanList:                      peer.NoBanList{},
+			Capture:                      capture.NoRecorder{},
 			CPUTargeter: tracker.NewTargeter(
 				logging.NoLog{},
 				&tracker.TargeterConfig{
@@ -222,14 +239,7 @@ func NewTestNetwork(
 		metrics,
 		log,
 		newNoopListener(),
-		dialer.NewDialer(
-			constants.NetworkType,
-			dialer.Config{
-				ThrottleRps:       constants.DefaultOutboundConnectionThrottlingRps,
-				ConnectionTimeout: constants.DefaultOutboundConnectionTimeout,
-			},
-			log,
-		),
+		networkDialer,
 		router,
 	)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/snow/networking/tracker"
 	"github.com/MetalBlockchain/metalgo/snow/validators"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
@@ -28,6 +29,20 @@ type InboundMsgThrottler interface {
 	//            given nodeID. Callers must enforce this invariant.
 	Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID) ReleaseFunc
 
+	// Charges a message of type [op] and size [msgSize] from [nodeID] for
+	// [chainID], which has already been acquired with Acquire, to the
+	// allocation of the chain's subnet. Returns false if the subnet's
+	// allocation is exhausted, in which case the message should be dropped.
+	// Responses are never dropped. Otherwise, the returned release function
+	// must be called when done processing the message.
+	// It's safe for multiple goroutines to concurrently call AcquireChain.
+	AcquireChain(msgSize uint64, nodeID ids.NodeID, chainID ids.ID, op message.Op) (ReleaseFunc, bool)
+
+	// Register that [chainID] is validated by [subnetID].
+	// Must be called before messages for [chainID] are charged to the
+	// allocation of [subnetID].
+	AddChain(chainID ids.ID, subnetID ids.ID)
+
 	// Add a new node to this throttler.
 	// Must be called before Acquire(..., [nodeID]) is called.
 	// RemoveNode([nodeID]) must have been called since the last time
@@ -47,6 +62,7 @@ type InboundMsgThrottlerConfig struct {
 	BandwidthThrottlerConfig `json:"bandwidthThrottlerConfig"`
 	CPUThrottlerConfig       SystemThrottlerConfig `json:"cpuThrottlerConfig"`
 	DiskThrottlerConfig      SystemThrottlerConfig `json:"diskThrottlerConfig"`
+	SubnetThrottlerConfig    SubnetThrottlerConfig `json:"subnetThrottlerConfig"`
 	MaxProcessingMsgsPerNode uint64                `json:"maxProcessingMsgsPerNode"`
 }
 
@@ -104,12 +120,22 @@ func NewInboundMsgThrottler(
 	if err != nil {
 		return nil, err
 	}
+	subnetThrottler, err := newInboundSubnetThrottler(
+		registerer,
+		throttlerConfig.SubnetThrottlerConfig,
+		throttlerConfig.MsgByteThrottlerConfig,
+		throttlerConfig.BandwidthThrottlerConfig,
+	)
+	if err != nil {
+		return nil, err
+	}
 	return &inboundMsgThrottler{
 		byteThrottler:      byteThrottler,
 		bufferThrottler:    bufferThrottler,
 		bandwidthThrottler: bandwidthThrottler,
 		cpuThrottler:       cpuThrottler,
 		diskThrottler:      diskThrottler,
+		subnetThrottler:    subnetThrottler,
 	}, nil
 }
 
@@ -125,6 +151,10 @@ func NewInboundMsgThrottler(
 //  3. Bandwidth. The bandwidth rate-limiting is implemented using a token
 //     bucket, where each token is 1 byte. See BandwidthThrottler.
 //
+// Once a message has been parsed, the message bytes and bandwidth are also
+// charged to the allocation of the message's subnet, so that one subnet can't
+// consume the resources reserved for the primary network or other subnets.
+//
 // A call to Acquire([msgSize], [nodeID]) blocks until we've secured
 // enough of both these resources to read a message of size [msgSize] from
 // [nodeID].
@@ -141,6 +171,9 @@ type inboundMsgThrottler struct {
 	cpuThrottler SystemThrottler
 	// Rate-limits based on disk usage caused by a given node.
 	diskThrottler SystemThrottler
+	// Rate-limits based on the bytes and bandwidth used by messages for a
+	// given subnet.
+	subnetThrottler *inboundSubnetThrottler
 }
 
 // Returns when we can read a message of size [msgSize] from node [nodeID].
@@ -165,6 +198,14 @@ func (t *inboundMsgThrottler) Acquire(ctx context.Context, msgSize uint64, nodeI
 	}
 }
 
+func (t *inboundMsgThrottler) AcquireChain(msgSize uint64, nodeID ids.NodeID, chainID ids.ID, op message.Op) (ReleaseFunc, bool) {
+	return t.subnetThrottler.Acquire(msgSize, nodeID, chainID, op)
+}
+
+func (t *inboundMsgThrottler) AddChain(chainID ids.ID, subnetID ids.ID) {
+	t.subnetThrottler.AddChain(chainID, subnetID)
+}
+
 // See BandwidthThrottler.
 func (t *inboundMsgThrottler) AddNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.AddNode(nodeID)
@@ -173,4 +214,5 @@ func (t *inboundMsgThrottler) AddNode(nodeID ids.NodeID) {
 // See BandwidthThrottler.
 func (t *inboundMsgThrottler) RemoveNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.RemoveNode(nodeID)
+	t.subnetThrottler.RemoveNode(nodeID)
 }
@@ -0,0 +1,265 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const (
+	subnetIDLabel = "subnetID"
+	reasonLabel   = "reason"
+
+	bytesReason     = "bytes"
+	bandwidthReason = "bandwidth"
+)
+
+var errInvalidPrimaryNetworkReserve = errors.New("primary network reserve must be in [0, 1]")
+
+type SubnetThrottlerConfig struct {
+	// PrimaryNetworkReserve is the portion, in [0, 1], of the inbound byte and
+	// bandwidth allocations that can only be used by primary network messages.
+	PrimaryNetworkReserve float64 `json:"primaryNetworkReserve"`
+	// Weights of the tracked subnets. The portion of the allocations that
+	// isn't reserved for the primary network is divided between the subnets
+	// proportionally to their weights.
+	//
+	// Messages for subnets that don't have a weight are not limited by the
+	// subnet throttler.
+	Weights map[ids.ID]uint64 `json:"weights"`
+}
+
+func (c *SubnetThrottlerConfig) Verify() error {
+	if c.PrimaryNetworkReserve < 0 || c.PrimaryNetworkReserve > 1 {
+		return fmt.Errorf("%w: %f", errInvalidPrimaryNetworkReserve, c.PrimaryNetworkReserve)
+	}
+	return nil
+}
+
+// See inbound_msg_throttler.go
+
+func newInboundSubnetThrottler(
+	registerer prometheus.Registerer,
+	config SubnetThrottlerConfig,
+	byteConfig MsgByteThrottlerConfig,
+	bandwidthConfig BandwidthThrottlerConfig,
+) (*inboundSubnetThrottler, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	var totalWeight uint64
+	for subnetID, weight := range config.Weights {
+		if subnetID != constants.PrimaryNetworkID {
+			totalWeight += weight
+		}
+	}
+
+	t := &inboundSubnetThrottler{
+		chainToSubnet: make(map[ids.ID]ids.ID),
+		allocations:   make(map[ids.ID]*subnetAllocation, len(config.Weights)),
+	}
+	var (
+		subnetPortion = 1 - config.PrimaryNetworkReserve
+		totalBytes    = byteConfig.VdrAllocSize + byteConfig.AtLargeAllocSize
+	)
+	for subnetID, weight := range config.Weights {
+		if subnetID == constants.PrimaryNetworkID {
+			continue
+		}
+
+		var portion float64
+		if totalWeight > 0 {
+			portion = subnetPortion * float64(weight) / float64(totalWeight)
+		}
+		t.allocations[subnetID] = &subnetAllocation{
+			// A subnet must always be able to process at least one message,
+			// otherwise messages of the maximum size could never be read.
+			maxBytes:   max(uint64(portion*float64(totalBytes)), constants.DefaultMaxMessageSize),
+			refillRate: rate.Limit(portion * float64(bandwidthConfig.RefillRate)),
+			burstSize:  int(bandwidthConfig.MaxBurstSize),
+			limiters:   make(map[ids.NodeID]*rate.Limiter),
+		}
+	}
+	return t, t.metrics.initialize(registerer)
+}
+
+// subnetAllocation is the portion of the inbound allocations given to a subnet.
+type subnetAllocation struct {
+	// Max number of bytes of messages for the subnet that can be processed at
+	// once.
+	maxBytes uint64
+	// Number of bytes of messages for the subnet currently being processed.
+	bytesUsed uint64
+	// Rate at which each node's bandwidth allocation for the subnet refills.
+	refillRate rate.Limit
+	// Max amount of bandwidth that can accumulate for each node.
+	burstSize int
+	// Node ID --> token bucket based rate limiter where each token is a byte
+	// of bandwidth for the subnet.
+	limiters map[ids.NodeID]*rate.Limiter
+}
+
+// remainingBytes returns the number of bytes remaining in the allocation,
+// which is negative if responses were charged to an exhausted allocation.
+func (a *subnetAllocation) remainingBytes() float64 {
+	return float64(a.maxBytes) - float64(a.bytesUsed)
+}
+
+// Rate-limits inbound messages based on the subnet of the chain they are for.
+//
+// Unlike the other inbound throttlers, which block until a message can be
+// read, this throttler is applied once the message has been read and parsed.
+// Messages that exceed their subnet's allocation are dropped rather than
+// delayed, so that a busy subnet never blocks the reading of primary network
+// messages from the same peer. Responses to requests sent by this node are
+// never dropped, as they would otherwise cause the requests to time out, which
+// would be blamed on the peer. They are charged to the allocation even if it
+// is exhausted, which causes the subnet's unrequested messages to be dropped
+// instead.
+type inboundSubnetThrottler struct {
+	clock   mockable.Clock
+	metrics inboundSubnetThrottlerMetrics
+
+	lock sync.Mutex
+	// Chain ID --> Subnet ID of the chain
+	chainToSubnet map[ids.ID]ids.ID
+	// Subnet ID --> Allocation of the subnet
+	allocations map[ids.ID]*subnetAllocation
+}
+
+// AddChain registers that messages for [chainID] should be charged to the
+// allocation of [subnetID].
+func (t *inboundSubnetThrottler) AddChain(chainID ids.ID, subnetID ids.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.chainToSubnet[chainID] = subnetID
+}
+
+// Acquire charges a message of type [op] and size [msgSize] from [nodeID] for
+// [chainID] to the allocation of the chain's subnet. If the allocation is
+// exhausted and the message isn't a response, false is returned and the
+// message should be dropped. Otherwise, the returned release function must be
+// called (!) when done processing the message.
+func (t *inboundSubnetThrottler) Acquire(msgSize uint64, nodeID ids.NodeID, chainID ids.ID, op message.Op) (ReleaseFunc, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	subnetID, ok := t.chainToSubnet[chainID]
+	if !ok {
+		// Messages for unknown chains are dropped by the router.
+		return noopRelease, true
+	}
+	allocation, ok := t.allocations[subnetID]
+	if !ok {
+		// Primary network messages, and messages for subnets without a
+		// weight, are never throttled.
+		return noopRelease, true
+	}
+
+	var (
+		subnetIDStr = subnetID.String()
+		isResponse  = !message.UnrequestedOps.Contains(op)
+	)
+	if !isResponse && allocation.bytesUsed+msgSize > allocation.maxBytes {
+		t.metrics.dropped.With(prometheus.Labels{
+			subnetIDLabel: subnetIDStr,
+			reasonLabel:   bytesReason,
+		}).Inc()
+		return noopRelease, false
+	}
+
+	limiter, ok := allocation.limiters[nodeID]
+	if !ok {
+		limiter = rate.NewLimiter(allocation.refillRate, allocation.burstSize)
+		allocation.limiters[nodeID] = limiter
+	}
+	now := t.clock.Time()
+	if isResponse {
+		// Responses consume bandwidth even if the node's burst is exhausted.
+		limiter.ReserveN(now, int(msgSize))
+	} else if !limiter.AllowN(now, int(msgSize)) {
+		t.metrics.dropped.With(prometheus.Labels{
+			subnetIDLabel: subnetIDStr,
+			reasonLabel:   bandwidthReason,
+		}).Inc()
+		return noopRelease, false
+	}
+
+	allocation.bytesUsed += msgSize
+	t.metrics.remainingBytes.WithLabelValues(subnetIDStr).Set(allocation.remainingBytes())
+	t.metrics.acquiredBytes.WithLabelValues(subnetIDStr).Add(float64(msgSize))
+	return func() {
+		t.release(allocation, subnetIDStr, msgSize)
+	}, true
+}
+
+// Must correspond to a previous successful call of Acquire([msgSize], ...)
+// for the subnet with [allocation].
+func (t *inboundSubnetThrottler) release(allocation *subnetAllocation, subnetIDStr string, msgSize uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	allocation.bytesUsed -= msgSize
+	t.metrics.remainingBytes.WithLabelValues(subnetIDStr).Set(allocation.remainingBytes())
+}
+
+// RemoveNode removes the bandwidth allocations of [nodeID] for all subnets.
+func (t *inboundSubnetThrottler) RemoveNode(nodeID ids.NodeID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, allocation := range t.allocations {
+		delete(allocation.limiters, nodeID)
+	}
+}
+
+type inboundSubnetThrottlerMetrics struct {
+	remainingBytes *prometheus.GaugeVec
+	acquiredBytes  *prometheus.CounterVec
+	dropped        *prometheus.CounterVec
+}
+
+func (m *inboundSubnetThrottlerMetrics) initialize(reg prometheus.Registerer) error {
+	m.remainingBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "subnet_throttler_inbound_remaining_bytes",
+			Help: "Bytes remaining in each subnet's inbound message byte allocation",
+		},
+		[]string{subnetIDLabel},
+	)
+	m.acquiredBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subnet_throttler_inbound_acquired_bytes",
+			Help: "Bytes of inbound messages charged to each subnet's allocation",
+		},
+		[]string{subnetIDLabel},
+	)
+	m.dropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "subnet_throttler_inbound_dropped",
+			Help: "Number of inbound messages dropped because their subnet's allocation was exhausted",
+		},
+		[]string{subnetIDLabel, reasonLabel},
+	)
+	errs := wrappers.Errs{}
+	errs.Add(
+		reg.Register(m.remainingBytes),
+		reg.Register(m.acquiredBytes),
+		reg.Register(m.dropped),
+	)
+	return errs.Err
+}
@@ -0,0 +1,189 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+func TestNewInboundSubnetThrottler(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		subnetID1 = ids.GenerateTestID()
+		subnetID2 = ids.GenerateTestID()
+		subnetID3 = ids.GenerateTestID()
+	)
+	throttler, err := newInboundSubnetThrottler(
+		prometheus.NewRegistry(),
+		SubnetThrottlerConfig{
+			PrimaryNetworkReserve: .5,
+			Weights: map[ids.ID]uint64{
+				constants.PrimaryNetworkID: 100,
+				subnetID1:                  6,
+				subnetID2:                  1,
+				subnetID3:                  1,
+			},
+		},
+		MsgByteThrottlerConfig{
+			VdrAllocSize:     12 * units.MiB,
+			AtLargeAllocSize: 4 * units.MiB,
+		},
+		BandwidthThrottlerConfig{
+			RefillRate:   1600,
+			MaxBurstSize: 10,
+		},
+	)
+	require.NoError(err)
+
+	// The primary network isn't given an allocation, so its messages are never
+	// throttled.
+	require.Len(throttler.allocations, 3)
+	require.NotContains(throttler.allocations, constants.PrimaryNetworkID)
+
+	allocation1 := throttler.allocations[subnetID1]
+	require.Equal(uint64(6*units.MiB), allocation1.maxBytes)
+	require.Equal(rate.Limit(600), allocation1.refillRate)
+	require.Equal(10, allocation1.burstSize)
+
+	// Allocations are never smaller than the max message size.
+	allocation2 := throttler.allocations[subnetID2]
+	require.Equal(uint64(constants.DefaultMaxMessageSize), allocation2.maxBytes)
+	require.Equal(rate.Limit(100), allocation2.refillRate)
+}
+
+func TestNewInboundSubnetThrottlerInvalidReserve(t *testing.T) {
+	tests := []struct {
+		name    string
+		reserve float64
+	}{
+		{
+			name:    "negative",
+			reserve: -.1,
+		},
+		{
+			name:    "greater than one",
+			reserve: 1.1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newInboundSubnetThrottler(
+				prometheus.NewRegistry(),
+				SubnetThrottlerConfig{
+					PrimaryNetworkReserve: test.reserve,
+				},
+				MsgByteThrottlerConfig{},
+				BandwidthThrottlerConfig{},
+			)
+			require.ErrorIs(t, err, errInvalidPrimaryNetworkReserve)
+		})
+	}
+}
+
+func TestInboundSubnetThrottlerAcquire(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		subnetID  = ids.GenerateTestID()
+		chainID   = ids.GenerateTestID()
+		pChainID  = ids.GenerateTestID()
+		nodeID1   = ids.GenerateTestNodeID()
+		nodeID2   = ids.GenerateTestNodeID()
+		burstSize = uint64(3 * units.MiB)
+	)
+	throttler, err := newInboundSubnetThrottler(
+		prometheus.NewRegistry(),
+		SubnetThrottlerConfig{
+			Weights: map[ids.ID]uint64{
+				subnetID: 1,
+			},
+		},
+		MsgByteThrottlerConfig{
+			VdrAllocSize: 4 * units.MiB,
+		},
+		BandwidthThrottlerConfig{
+			RefillRate:   units.KiB,
+			MaxBurstSize: burstSize,
+		},
+	)
+	require.NoError(err)
+	now := time.Now()
+	throttler.clock.Set(now)
+
+	// Messages for chains that haven't been registered aren't throttled.
+	release, ok := throttler.Acquire(8*units.MiB, nodeID1, chainID, message.PushQueryOp)
+	require.True(ok)
+	release()
+
+	throttler.AddChain(chainID, subnetID)
+	throttler.AddChain(pChainID, constants.PrimaryNetworkID)
+
+	// Primary network messages aren't throttled.
+	release, ok = throttler.Acquire(8*units.MiB, nodeID1, pChainID, message.PushQueryOp)
+	require.True(ok)
+	release()
+
+	// Acquire most of the subnet's byte allocation.
+	release1, ok := throttler.Acquire(3*units.MiB, nodeID1, chainID, message.PushQueryOp)
+	require.True(ok)
+	require.Equal(uint64(3*units.MiB), throttler.allocations[subnetID].bytesUsed)
+
+	// The byte allocation is shared by all nodes.
+	_, ok = throttler.Acquire(2*units.MiB, nodeID2, chainID, message.PushQueryOp)
+	require.False(ok)
+
+	// Releasing returns the bytes to the allocation.
+	release1()
+	require.Zero(throttler.allocations[subnetID].bytesUsed)
+
+	// nodeID1 has used its bandwidth burst.
+	_, ok = throttler.Acquire(units.MiB, nodeID1, chainID, message.PushQueryOp)
+	require.False(ok)
+	require.Zero(throttler.allocations[subnetID].bytesUsed)
+
+	// Bandwidth is tracked per node.
+	release2, ok := throttler.Acquire(units.MiB, nodeID2, chainID, message.PushQueryOp)
+	require.True(ok)
+	release2()
+
+	// nodeID1's bandwidth refills over time.
+	throttler.clock.Set(now.Add(time.Second))
+	release1, ok = throttler.Acquire(units.KiB, nodeID1, chainID, message.PushQueryOp)
+	require.True(ok)
+	release1()
+
+	// Removing a node resets its bandwidth allocation.
+	throttler.RemoveNode(nodeID1)
+	require.NotContains(throttler.allocations[subnetID].limiters, nodeID1)
+	release1, ok = throttler.Acquire(burstSize, nodeID1, chainID, message.PushQueryOp)
+	require.True(ok)
+	release1()
+
+	// Responses aren't dropped when nodeID1's bandwidth is exhausted.
+	release1, ok = throttler.Acquire(units.MiB, nodeID1, chainID, message.ChitsOp)
+	require.True(ok)
+
+	// Responses aren't dropped when the byte allocation is exhausted.
+	release2, ok = throttler.Acquire(4*units.MiB, nodeID2, chainID, message.AncestorsOp)
+	require.True(ok)
+	require.Equal(uint64(5*units.MiB), throttler.allocations[subnetID].bytesUsed)
+
+	// Unrequested messages are dropped until the responses are released.
+	_, ok = throttler.Acquire(units.KiB, nodeID2, chainID, message.PushQueryOp)
+	require.False(ok)
+	release1()
+	release2()
+	require.Zero(throttler.allocations[subnetID].bytesUsed)
+}
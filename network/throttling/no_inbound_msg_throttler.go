@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/message"
 )
 
 var _ InboundMsgThrottler = (*noInboundMsgThrottler)(nil)
@@ -23,6 +24,12 @@ func (*noInboundMsgThrottler) Acquire(context.Context, uint64, ids.NodeID) Relea
 	return noopRelease
 }
 
+func (*noInboundMsgThrottler) AcquireChain(uint64, ids.NodeID, ids.ID, message.Op) (ReleaseFunc, bool) {
+	return noopRelease, true
+}
+
+func (*noInboundMsgThrottler) AddChain(ids.ID, ids.ID) {}
+
 func (*noInboundMsgThrottler) AddNode(ids.NodeID) {}
 
 func (*noInboundMsgThrottler) RemoveNode(ids.NodeID) {}
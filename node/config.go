@@ -8,10 +8,12 @@ import (
 	"net/netip"
 	"time"
 
+	"github.com/MetalBlockchain/metalgo/api/health"
 	"github.com/MetalBlockchain/metalgo/api/server"
 	"github.com/MetalBlockchain/metalgo/chains"
 	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/indexer"
 	"github.com/MetalBlockchain/metalgo/network"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
 	"github.com/MetalBlockchain/metalgo/snow/networking/router"
@@ -24,11 +26,13 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/profiler"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/utils/timer"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime/subprocess"
 )
 
 type APIIndexerConfig struct {
-	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
-	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	IndexAPIEnabled      bool                  `json:"indexAPIEnabled"`
+	IndexAllowIncomplete bool                  `json:"indexAllowIncomplete"`
+	IndexWebhooks        indexer.WebhookConfig `json:"indexWebhooks"`
 }
 
 type HTTPConfig struct {
@@ -41,8 +45,10 @@ type HTTPConfig struct {
 	HTTPSKey     []byte `json:"-"`
 	HTTPSCert    []byte `json:"-"`
 
-	HTTPAllowedOrigins []string `json:"httpAllowedOrigins"`
-	HTTPAllowedHosts   []string `json:"httpAllowedHosts"`
+	HTTPAllowedOrigins []string               `json:"httpAllowedOrigins"`
+	HTTPAllowedHosts   []string               `json:"httpAllowedHosts"`
+	HTTPAuthConfigFile string                 `json:"httpAuthConfigFile"`
+	HTTPRateLimits     server.RateLimitConfig `json:"httpRateLimits"`
 
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
@@ -61,6 +67,7 @@ type APIConfig struct {
 
 type IPConfig struct {
 	PublicIP                  string        `json:"publicIP"`
+	PublicSecondaryIP         string        `json:"publicSecondaryIP"`
 	PublicIPResolutionService string        `json:"publicIPResolutionService"`
 	PublicIPResolutionFreq    time.Duration `json:"publicIPResolutionFreq"`
 	// The host portion of the address to listen on. The port to
@@ -105,6 +112,9 @@ type BootstrapConfig struct {
 	BootstrapMaxTimeGetAncestors time.Duration `json:"bootstrapMaxTimeGetAncestors"`
 
 	Bootstrappers []genesis.Bootstrapper `json:"bootstrappers"`
+
+	// Trusted checkpoints of each chain, sorted by height
+	BootstrapCheckpoints map[ids.ID][]genesis.Checkpoint `json:"bootstrapCheckpoints"`
 }
 
 type DatabaseConfig struct {
@@ -141,7 +151,8 @@ type Config struct {
 	NetworkID uint32 `json:"networkID"`
 
 	// Health
-	HealthCheckFreq time.Duration `json:"healthCheckFreq"`
+	HealthCheckFreq   time.Duration `json:"healthCheckFreq"`
+	HealthCheckConfig health.Config `json:"healthCheckConfig"`
 
 	// Network configuration
 	NetworkConfig network.Config `json:"networkConfig"`
@@ -156,6 +167,8 @@ type Config struct {
 
 	PluginDir string `json:"pluginDir"`
 
+	PluginSupervisionConfig subprocess.SupervisorConfig `json:"pluginSupervisionConfig"`
+
 	// File Descriptor Limit
 	FdLimit uint64 `json:"fdLimit"`
 
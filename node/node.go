@@ -46,8 +46,10 @@ import (
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/nat"
 	"github.com/MetalBlockchain/metalgo/network"
+	"github.com/MetalBlockchain/metalgo/network/capture"
 	"github.com/MetalBlockchain/metalgo/network/dialer"
 	"github.com/MetalBlockchain/metalgo/network/peer"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/network/throttling"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
@@ -109,11 +111,14 @@ var (
 	genesisHashKey     = []byte("genesisID")
 	ungracefulShutdown = []byte("ungracefulShutdown")
 
-	indexerDBPrefix  = []byte{0x00}
-	keystoreDBPrefix = []byte("keystore")
+	indexerDBPrefix    = []byte{0x00}
+	keystoreDBPrefix   = []byte("keystore")
+	reputationDBPrefix = []byte("reputation")
+	banListDBPrefix    = []byte("bans")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
+	errSameIPFamily  = errors.New("secondary public IP must be in a different IP family than the public IP")
 )
 
 // New returns an instance of Node
@@ -178,6 +183,10 @@ func New(
 		return nil, fmt.Errorf("couldn't initialize metrics: %w", err)
 	}
 
+	if err := n.initMetricsExporter(); err != nil {
+		return nil, fmt.Errorf("couldn't initialize metrics exporter: %w", err)
+	}
+
 	n.initNAT()
 	if err := n.initAPIServer(); err != nil { // Start the API Server
 		return nil, fmt.Errorf("couldn't initialize API server: %w", err)
@@ -326,6 +335,9 @@ type Node struct {
 	// Manages validator benching
 	benchlistManager benchlist.Manager
 
+	// Tracks the reputation of peers across restarts
+	reputation reputation.Tracker
+
 	uptimeCalculator uptime.LockedCalculator
 
 	// dispatcher for events as they happen in consensus
@@ -356,11 +368,18 @@ type Node struct {
 	// Handles HTTP API calls
 	APIServer server.Server
 
+	// Authorizes HTTP API calls. Nil if authorization is disabled.
+	apiAuthorizer server.Authorizer
+
 	// This node's configuration
 	Config *Config
 
 	tracer trace.Tracer
 
+	// Exports metrics to the tracing endpoint. Nil if metrics aren't exported.
+	metricsExporter *metrics.OTLPExporter
+	otlpClient      trace.OTLPClient
+
 	// ensures that we only close the node once.
 	shutdownOnce sync.Once
 
@@ -499,6 +518,21 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 		)
 	}
 
+	var secondaryIP netip.AddrPort
+	if n.Config.PublicSecondaryIP != "" {
+		secondaryAddr, err := ips.ParseAddr(n.Config.PublicSecondaryIP)
+		if err != nil {
+			return fmt.Errorf("invalid secondary public IP address %q: %w", n.Config.PublicSecondaryIP, err)
+		}
+		if ips.IsSameFamily(publicAddr, secondaryAddr) {
+			return fmt.Errorf("%w: %s and %s", errSameIPFamily, publicAddr, secondaryAddr)
+		}
+		secondaryIP = netip.AddrPortFrom(
+			secondaryAddr,
+			stakingPort,
+		)
+	}
+
 	// Regularly update our public IP and port mappings.
 	n.portMapper.Map(
 		stakingPort,
@@ -511,6 +545,7 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 
 	n.Log.Info("initializing networking",
 		zap.Stringer("ip", atomicIP.Get()),
+		zap.Stringer("secondaryIP", secondaryIP),
 	)
 
 	tlsKey, ok := n.Config.StakingTLSCert.PrivateKey.(crypto.Signer)
@@ -568,7 +603,20 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 		return err
 	}
 
-	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
+	n.reputation, err = reputation.NewTracker(
+		prefixdb.New(reputationDBPrefix, n.DB),
+		n.Config.NetworkConfig.ReputationConfig,
+		n.Log,
+		reg,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize peer reputations: %w", err)
+	}
+
+	n.benchlistManager = &reputationBenchlist{
+		Manager:    benchlist.NewManager(&n.Config.BenchlistConfig),
+		reputation: n.reputation,
+	}
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
 
@@ -617,6 +665,7 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	// add node configs to network config
 	n.Config.NetworkConfig.MyNodeID = n.ID
 	n.Config.NetworkConfig.MyIPPort = atomicIP
+	n.Config.NetworkConfig.MySecondaryIPPort = secondaryIP
 	n.Config.NetworkConfig.NetworkID = n.Config.NetworkID
 	n.Config.NetworkConfig.Validators = n.vdrs
 	n.Config.NetworkConfig.Beacons = n.bootstrappers
@@ -629,6 +678,32 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
+	n.Config.NetworkConfig.Reputation = n.reputation
+
+	n.Config.NetworkConfig.BanList, err = peer.NewBanList(prefixdb.New(banListDBPrefix, n.DB))
+	if err != nil {
+		return fmt.Errorf("failed to initialize ban list: %w", err)
+	}
+
+	n.Config.NetworkConfig.Capture = capture.NoRecorder{}
+	if n.Config.NetworkConfig.CaptureConfig.Enabled {
+		n.Log.Warn("recording p2p messages",
+			zap.String("directory", n.Config.NetworkConfig.CaptureConfig.Directory),
+		)
+		n.Config.NetworkConfig.Capture, err = capture.NewRecorder(
+			n.Config.NetworkConfig.CaptureConfig,
+			n.Log,
+			reg,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to initialize message capture: %w", err)
+		}
+	}
+
+	networkDialer, err := dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log)
+	if err != nil {
+		return fmt.Errorf("failed to create dialer: %w", err)
+	}
 
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
@@ -637,7 +712,7 @@ func (n *Node) initNetworking(reg prometheus.Registerer) error {
 		reg,
 		n.Log,
 		listener,
-		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		networkDialer,
 		consensusRouter,
 	)
 
@@ -881,6 +956,28 @@ func (n *Node) initEventDispatchers() {
 	n.VertexAcceptorGroup = snow.NewAcceptorGroup(n.Log)
 }
 
+// initMetricsExporter exports the metrics of [n.MetricsGatherer] to the tracing
+// endpoint, if enabled.
+func (n *Node) initMetricsExporter() error {
+	if !n.Config.TraceConfig.Enabled || !n.Config.TraceConfig.ExportMetrics {
+		return nil
+	}
+
+	var err error
+	n.otlpClient, err = trace.NewOTLPClient(n.Config.TraceConfig)
+	if err != nil {
+		return err
+	}
+	n.metricsExporter = metrics.NewOTLPExporter(
+		n.Log,
+		n.MetricsGatherer,
+		n.otlpClient,
+		n.Config.TraceConfig.MetricsExportFrequency,
+	)
+	go n.metricsExporter.Dispatch()
+	return nil
+}
+
 // Initialize [n.indexer].
 // Should only be called after [n.DB], [n.DecisionAcceptorGroup],
 // [n.ConsensusAcceptorGroup], [n.Log], [n.APIServer], [n.chainManager] are
@@ -891,6 +988,8 @@ func (n *Node) initIndexer() error {
 	n.indexer, err = indexer.NewIndexer(indexer.Config{
 		IndexingEnabled:      n.Config.IndexAPIEnabled,
 		AllowIncompleteIndex: n.Config.IndexAllowIncomplete,
+		Webhooks:             n.Config.IndexWebhooks,
+		AllowedOrigins:       n.Config.HTTPAllowedOrigins,
 		DB:                   txIndexerDB,
 		Log:                  n.Log,
 		BlockAcceptorGroup:   n.BlockAcceptorGroup,
@@ -1031,6 +1130,17 @@ func (n *Node) initAPIServer() error {
 		return err
 	}
 
+	if n.Config.HTTPAuthConfigFile != "" {
+		n.apiAuthorizer, err = server.NewAuthorizer(
+			n.Log,
+			n.Config.HTTPAuthConfigFile,
+			apiRegisterer,
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't initialize API authorization: %w", err)
+		}
+	}
+
 	n.APIServer, err = server.New(
 		n.Log,
 		n.LogFactory,
@@ -1043,6 +1153,8 @@ func (n *Node) initAPIServer() error {
 		apiRegisterer,
 		n.Config.HTTPConfig.HTTPConfig,
 		n.Config.HTTPAllowedHosts,
+		n.apiAuthorizer,
+		n.Config.HTTPRateLimits,
 	)
 	return err
 }
@@ -1173,8 +1285,10 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 			BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
 			BootstrapAncestorsMaxContainersSent:     n.Config.BootstrapAncestorsMaxContainersSent,
 			BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
+			BootstrapCheckpoints:                    n.Config.BootstrapCheckpoints,
 			Upgrades:                                n.Config.UpgradeConfig,
 			ResourceTracker:                         n.resourceTracker,
+			Reputation:                              n.reputation,
 			StateSyncBeacons:                        n.Config.StateSyncIDs,
 			TracingEnabled:                          n.Config.TraceConfig.Enabled,
 			Tracer:                                  n.tracer,
@@ -1188,6 +1302,9 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 
 	// Notify the API server when new chains are created
 	n.chainManager.AddRegistrant(n.APIServer)
+	// Notify the network when new chains are created so that their messages
+	// are charged to their subnet's throttler allocation
+	n.chainManager.AddRegistrant(n.Net)
 	return nil
 }
 
@@ -1254,12 +1371,13 @@ func (n *Node) initVMs() error {
 	// initialize the vm registry
 	n.VMRegistry = registry.NewVMRegistry(registry.VMRegistryConfig{
 		VMGetter: registry.NewVMGetter(registry.VMGetterConfig{
-			FileReader:      filesystem.NewReader(),
-			Manager:         n.VMManager,
-			PluginDirectory: n.Config.PluginDir,
-			CPUTracker:      n.resourceManager,
-			RuntimeTracker:  n.runtimeManager,
-			MetricsGatherer: rpcchainvmMetricsGatherer,
+			FileReader:        filesystem.NewReader(),
+			Manager:           n.VMManager,
+			PluginDirectory:   n.Config.PluginDir,
+			CPUTracker:        n.resourceManager,
+			RuntimeTracker:    n.runtimeManager,
+			MetricsGatherer:   rpcchainvmMetricsGatherer,
+			PluginSupervision: n.Config.PluginSupervisionConfig,
 		}),
 		VMManager: n.VMManager,
 	})
@@ -1353,11 +1471,13 @@ func (n *Node) initAdminAPI() error {
 			DB:           n.DB,
 			ChainManager: n.chainManager,
 			HTTPServer:   n.APIServer,
+			Authorizer:   n.apiAuthorizer,
 			ProfileDir:   n.Config.ProfilerConfig.Dir,
 			LogFactory:   n.LogFactory,
 			NodeConfig:   n.Config,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
+			Network:      n.Net,
 		},
 	)
 	if err != nil {
@@ -1441,7 +1561,7 @@ func (n *Node) initHealthAPI() error {
 		return err
 	}
 
-	n.health, err = health.New(n.Log, healthReg)
+	n.health, err = health.New(n.Log, healthReg, n.Config.HealthCheckConfig)
 	if err != nil {
 		return err
 	}
@@ -1705,6 +1825,22 @@ func (n *Node) shutdown() {
 	n.Log.Info("cleaning up plugin runtimes")
 	n.runtimeManager.Stop(context.TODO())
 
+	if n.Config.NetworkConfig.Capture != nil {
+		if err := n.Config.NetworkConfig.Capture.Close(); err != nil {
+			n.Log.Debug("error closing message capture",
+				zap.Error(err),
+			)
+		}
+	}
+
+	if n.reputation != nil {
+		if err := n.reputation.Close(); err != nil {
+			n.Log.Warn("failed to persist peer reputations",
+				zap.Error(err),
+			)
+		}
+	}
+
 	if n.DB != nil {
 		if err := n.DB.Delete(ungracefulShutdown); err != nil {
 			n.Log.Error(
@@ -1730,6 +1866,15 @@ func (n *Node) shutdown() {
 		)
 	}
 
+	if n.metricsExporter != nil {
+		n.metricsExporter.Close()
+		if err := n.otlpClient.Close(); err != nil {
+			n.Log.Warn("error during metrics exporter shutdown",
+				zap.Error(err),
+			)
+		}
+	}
+
 	n.DoneShuttingDown.Done()
 	n.Log.Info("finished node shutdown")
 }
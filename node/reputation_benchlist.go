@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package node
+
+import (
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
+	"github.com/MetalBlockchain/metalgo/snow/networking/benchlist"
+)
+
+var _ benchlist.Manager = (*reputationBenchlist)(nil)
+
+// reputationBenchlist records request timeouts in the reputation of peers
+// before passing them to the benchlist.
+type reputationBenchlist struct {
+	benchlist.Manager
+	reputation reputation.Tracker
+}
+
+func (r *reputationBenchlist) RegisterFailure(chainID ids.ID, nodeID ids.NodeID) {
+	r.reputation.Record(nodeID, reputation.Timeout)
+	r.Manager.RegisterFailure(chainID, nodeID)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/bootstrap/interval"
 	"github.com/MetalBlockchain/metalgo/utils/bimap"
+	"github.com/MetalBlockchain/metalgo/utils/heap"
 	"github.com/MetalBlockchain/metalgo/utils/set"
 	"github.com/MetalBlockchain/metalgo/utils/timer"
 	"github.com/MetalBlockchain/metalgo/version"
@@ -41,6 +42,17 @@ const (
 	// outstanding when broadcasting.
 	maxOutstandingBroadcastRequests = 50
 
+	// maxOutstandingRequestsPerPeer is the maximum number of GetAncestors
+	// requests to have outstanding to a single peer. Limiting the number of
+	// requests per peer spreads the missing blocks across many peers at once.
+	maxOutstandingRequestsPerPeer = 4
+
+	// peerSelectionAttempts is the number of peers that are selected from the
+	// peer tracker when choosing the peer to send a GetAncestors request to.
+	// The selected peer with the fewest outstanding requests is sent the
+	// request.
+	peerSelectionAttempts = 8
+
 	epsilon = 1e-6 // small amount to add to time to avoid division by 0
 )
 
@@ -95,10 +107,23 @@ type Bootstrapper struct {
 	initiallyFetched uint64
 	// Time that startSyncing was last called
 	startTime time.Time
+	// True once the accepted frontier is known. Blocks may be fetched before
+	// the accepted frontier is known, but they are not executed.
+	syncing bool
+
+	checkpoints checkpoints
 
 	// tracks which validators were asked for which containers in which requests
 	outstandingRequests     *bimap.BiMap[common.Request, ids.ID]
 	outstandingRequestTimes map[common.Request]time.Time
+	// number of outstanding requests to each peer
+	outstandingPeerRequests map[ids.NodeID]int
+
+	// missing blocks that haven't been requested yet, prioritized by the number
+	// of missing heights that can be fetched by requesting them
+	fetchQueue heap.Map[ids.ID, uint64]
+	// heights of the missing blocks whose heights are known
+	missingBlockHeights map[ids.ID]uint64
 
 	// number of state transitions executed
 	executedStateTransitions uint64
@@ -136,6 +161,12 @@ func New(config Config, onFinished func(ctx context.Context, lastReqID uint32) e
 
 		outstandingRequests:     bimap.New[common.Request, ids.ID](),
 		outstandingRequestTimes: make(map[common.Request]time.Time),
+		outstandingPeerRequests: make(map[ids.NodeID]int),
+
+		fetchQueue:          newFetchQueue(),
+		missingBlockHeights: make(map[ids.ID]uint64),
+
+		checkpoints: newCheckpoints(config.Checkpoints),
 
 		executedStateTransitions: math.MaxInt,
 		onFinished:               onFinished,
@@ -173,6 +204,10 @@ func (b *Bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 		zap.Uint64("lastAcceptedHeight", lastAcceptedHeight),
 	)
 
+	if err := b.verifyAcceptedCheckpoints(ctx, lastAcceptedHeight); err != nil {
+		return err
+	}
+
 	// Set the starting height
 	b.startingHeight = lastAcceptedHeight
 	b.requestID = startReqID
@@ -190,6 +225,34 @@ func (b *Bootstrapper) Start(ctx context.Context, startReqID uint32) error {
 	return b.tryStartBootstrapping(ctx)
 }
 
+// verifyAcceptedCheckpoints returns an error if a block that was already
+// accepted conflicts with a trusted checkpoint.
+func (b *Bootstrapper) verifyAcceptedCheckpoints(ctx context.Context, lastAcceptedHeight uint64) error {
+	for _, checkpoint := range b.Config.Checkpoints {
+		if checkpoint.Height > lastAcceptedHeight {
+			break
+		}
+
+		blkID, err := b.VM.GetBlockIDAtHeight(ctx, checkpoint.Height)
+		if errors.Is(err, database.ErrNotFound) {
+			// The VM may not index the heights of all its accepted blocks.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get block at checkpoint height %d: %w", checkpoint.Height, err)
+		}
+		if blkID != checkpoint.BlockID {
+			return fmt.Errorf("%w: accepted block %s at height %d, expected %s",
+				errCheckpointMismatch,
+				blkID,
+				checkpoint.Height,
+				checkpoint.BlockID,
+			)
+		}
+	}
+	return nil
+}
+
 func (b *Bootstrapper) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
 	if err := b.VM.Connected(ctx, nodeID, nodeVersion); err != nil {
 		return err
@@ -221,6 +284,8 @@ func (b *Bootstrapper) tryStartBootstrapping(ctx context.Context) error {
 }
 
 func (b *Bootstrapper) startBootstrapping(ctx context.Context) error {
+	b.syncing = false
+
 	currentBeacons := b.Beacons.GetMap(b.Ctx.SubnetID)
 	nodeWeights := make(map[ids.NodeID]uint64, len(currentBeacons))
 	for nodeID, beacon := range currentBeacons {
@@ -255,10 +320,44 @@ func (b *Bootstrapper) startBootstrapping(ctx context.Context) error {
 		return b.startSyncing(ctx, accepted)
 	}
 
+	// While the accepted frontier is being determined, start fetching the
+	// ancestry of the latest trusted checkpoint.
+	if err := b.fetchCheckpoints(ctx, 1); err != nil {
+		return err
+	}
+
 	b.requestID++
 	return b.sendBootstrappingMessagesOrFinish(ctx)
 }
 
+// fetchCheckpoints fetches up to [limit] of the latest trusted checkpoints
+// that haven't been accepted or fetched.
+func (b *Bootstrapper) fetchCheckpoints(ctx context.Context, limit int) error {
+	lastAccepted, err := b.getLastAccepted(ctx)
+	if err != nil {
+		return err
+	}
+
+	lastAcceptedHeight := lastAccepted.Height()
+	for i := len(b.Config.Checkpoints) - 1; i >= 0 && limit > 0; i-- {
+		checkpoint := b.Config.Checkpoints[i]
+		if checkpoint.Height <= lastAcceptedHeight {
+			break
+		}
+		if b.tree.Contains(checkpoint.Height) {
+			continue
+		}
+
+		b.missingBlockIDs.Add(checkpoint.BlockID)
+		b.missingBlockHeights[checkpoint.BlockID] = checkpoint.Height
+		if err := b.fetch(ctx, checkpoint.BlockID); err != nil {
+			return err
+		}
+		limit--
+	}
+	return nil
+}
+
 func (b *Bootstrapper) sendBootstrappingMessagesOrFinish(ctx context.Context) error {
 	if peers := b.minority.GetPeers(ctx); peers.Len() > 0 {
 		b.Sender.SendGetAcceptedFrontier(ctx, peers, b.requestID)
@@ -364,6 +463,11 @@ func (b *Bootstrapper) GetAcceptedFailed(ctx context.Context, nodeID ids.NodeID,
 }
 
 func (b *Bootstrapper) startSyncing(ctx context.Context, acceptedBlockIDs []ids.ID) error {
+	b.syncing = true
+	if err := b.fetchCheckpoints(ctx, len(b.Config.Checkpoints)); err != nil {
+		return err
+	}
+
 	knownBlockIDs := genesis.GetCheckpoints(b.Ctx.NetworkID, b.Ctx.ChainID)
 	b.missingBlockIDs.Union(knownBlockIDs)
 	b.missingBlockIDs.Add(acceptedBlockIDs...)
@@ -406,22 +510,111 @@ func (b *Bootstrapper) startSyncing(ctx context.Context, acceptedBlockIDs []ids.
 	return b.tryStartExecuting(ctx)
 }
 
-// Get block [blkID] and its ancestors from a validator
+func newFetchQueue() heap.Map[ids.ID, uint64] {
+	return heap.NewMap[ids.ID, uint64](func(a, b uint64) bool {
+		return a > b
+	})
+}
+
+// Get block [blkID] and its ancestors from a validator. The request is queued
+// until a peer is able to be sent another request.
 func (b *Bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 	// Make sure we haven't already requested this block
-	if b.outstandingRequests.HasValue(blkID) {
+	if b.outstandingRequests.HasValue(blkID) || b.fetchQueue.Contains(blkID) {
 		return nil
 	}
 
-	nodeID, ok := b.PeerTracker.SelectPeer()
+	b.fetchQueue.Push(blkID, b.fetchPriority(blkID))
+	b.dispatch(ctx)
+	return nil
+}
+
+// fetchPriority returns the number of missing heights that can be fetched by
+// requesting [blkID] and its ancestors.
+//
+// GetAncestors requests are keyed by block ID, so a missing range can only be
+// split at blocks whose IDs are known before their descendants are fetched.
+// These are the accepted frontier, the compiled-in known blocks, the trusted
+// checkpoints, and the parents of the intervals fetched by previous runs. The
+// blocks without a known height are the tips of the missing ranges, so they are
+// requested first. This allows each range to be fetched from a different peer
+// in parallel.
+func (b *Bootstrapper) fetchPriority(blkID ids.ID) uint64 {
+	height, ok := b.missingBlockHeights[blkID]
 	if !ok {
-		// If we aren't connected to any peers, we send a request to ourself
-		// which is guaranteed to fail. We send this message to use the message
-		// timeout as a retry mechanism. Once we are connected to another node
-		// again we will select them to sample from.
-		nodeID = b.Ctx.NodeID
+		return math.MaxUint64
+	}
+
+	missing, ok := b.tree.Missing(height)
+	if !ok {
+		return 0
+	}
+	lowerBound := max(missing.LowerBound, b.startingHeight+1)
+	if height < lowerBound {
+		return 0
+	}
+	return height - lowerBound + 1
+}
+
+// dispatch requests the queued missing blocks, starting with the blocks with
+// the highest priority, until there are no peers left that can be sent another
+// request.
+func (b *Bootstrapper) dispatch(ctx context.Context) {
+	for {
+		blkID, _, ok := b.fetchQueue.Peek()
+		if !ok {
+			return
+		}
+
+		// The block may have been fetched as an ancestor of another block since
+		// it was queued.
+		if !b.missingBlockIDs.Contains(blkID) {
+			b.fetchQueue.Pop()
+			delete(b.missingBlockHeights, blkID)
+			continue
+		}
+
+		nodeID, ok := b.selectPeer()
+		if !ok {
+			// All the selected peers are busy. The queued blocks will be
+			// requested once the outstanding requests complete.
+			return
+		}
+
+		b.fetchQueue.Pop()
+		b.sendGetAncestors(ctx, nodeID, blkID)
+	}
+}
+
+// selectPeer returns the peer to send the next GetAncestors request to. Peers
+// are selected by the peer tracker, which prefers the peers with the highest
+// measured throughput. Of the selected peers, the peer with the fewest
+// outstanding requests is chosen so that the missing ranges are fetched from
+// different peers. Returns false if all the selected peers have
+// maxOutstandingRequestsPerPeer outstanding requests.
+func (b *Bootstrapper) selectPeer() (ids.NodeID, bool) {
+	var (
+		bestNodeID      ids.NodeID
+		bestNumRequests = maxOutstandingRequestsPerPeer
+	)
+	for i := 0; i < peerSelectionAttempts && bestNumRequests > 0; i++ {
+		nodeID, ok := b.PeerTracker.SelectPeer()
+		if !ok {
+			// If we aren't connected to any peers, we send a request to
+			// ourself which is guaranteed to fail. We send this message to use
+			// the message timeout as a retry mechanism. Once we are connected
+			// to another node again we will select them to sample from.
+			return b.Ctx.NodeID, true
+		}
+		if numRequests := b.outstandingPeerRequests[nodeID]; numRequests < bestNumRequests {
+			bestNodeID = nodeID
+			bestNumRequests = numRequests
+		}
 	}
+	return bestNodeID, bestNumRequests < maxOutstandingRequestsPerPeer
+}
 
+func (b *Bootstrapper) sendGetAncestors(ctx context.Context, nodeID ids.NodeID, blkID ids.ID) {
 	b.PeerTracker.RegisterRequest(nodeID)
 
 	b.requestID++
@@ -431,8 +624,27 @@ func (b *Bootstrapper) fetch(ctx context.Context, blkID ids.ID) error {
 	}
 	b.outstandingRequests.Put(request, blkID)
 	b.outstandingRequestTimes[request] = time.Now()
+	b.outstandingPeerRequests[nodeID]++
 	b.Config.Sender.SendGetAncestors(ctx, nodeID, b.requestID, blkID) // request block and ancestors
-	return nil
+}
+
+// completeRequest removes [request] from the outstanding requests and returns
+// the ID of the requested block and the time the request was sent.
+func (b *Bootstrapper) completeRequest(request common.Request) (ids.ID, time.Time, bool) {
+	blkID, ok := b.outstandingRequests.DeleteKey(request)
+	if !ok {
+		return ids.Empty, time.Time{}, false
+	}
+
+	requestTime := b.outstandingRequestTimes[request]
+	delete(b.outstandingRequestTimes, request)
+
+	if numRequests := b.outstandingPeerRequests[request.NodeID]; numRequests > 1 {
+		b.outstandingPeerRequests[request.NodeID] = numRequests - 1
+	} else {
+		delete(b.outstandingPeerRequests, request.NodeID)
+	}
+	return blkID, requestTime, true
 }
 
 // Ancestors handles the receipt of multiple containers. Should be received in
@@ -443,7 +655,7 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		NodeID:    nodeID,
 		RequestID: requestID,
 	}
-	wantedBlkID, ok := b.outstandingRequests.DeleteKey(request)
+	wantedBlkID, requestTime, ok := b.completeRequest(request)
 	if !ok { // this message isn't in response to a request we made
 		b.Ctx.Log.Debug("received unexpected Ancestors",
 			zap.Stringer("nodeID", nodeID),
@@ -451,8 +663,6 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 		)
 		return nil
 	}
-	requestTime := b.outstandingRequestTimes[request]
-	delete(b.outstandingRequestTimes, request)
 
 	lenBlks := len(blks)
 	if lenBlks == 0 {
@@ -523,10 +733,13 @@ func (b *Bootstrapper) Ancestors(ctx context.Context, nodeID ids.NodeID, request
 	)
 	b.PeerTracker.RegisterResponse(nodeID, bandwidth)
 
+	delete(b.missingBlockHeights, wantedBlkID)
 	if err := b.process(ctx, requestedBlock, ancestors); err != nil {
 		return err
 	}
 
+	// The peer is able to be sent another request.
+	b.dispatch(ctx)
 	return b.tryStartExecuting(ctx)
 }
 
@@ -535,7 +748,7 @@ func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 		NodeID:    nodeID,
 		RequestID: requestID,
 	}
-	blkID, ok := b.outstandingRequests.DeleteKey(request)
+	blkID, _, ok := b.completeRequest(request)
 	if !ok {
 		b.Ctx.Log.Debug("unexpectedly called GetAncestorsFailed",
 			zap.Stringer("nodeID", nodeID),
@@ -543,7 +756,6 @@ func (b *Bootstrapper) GetAncestorsFailed(ctx context.Context, nodeID ids.NodeID
 		)
 		return nil
 	}
-	delete(b.outstandingRequestTimes, request)
 
 	// This node timed out their request.
 	b.PeerTracker.RegisterFailure(nodeID)
@@ -577,6 +789,7 @@ func (b *Bootstrapper) process(
 		b.tree,
 		b.missingBlockIDs,
 		lastAccepted.Height(),
+		b.checkpoints,
 		blk,
 		ancestors,
 	)
@@ -621,6 +834,11 @@ func (b *Bootstrapper) process(
 	}
 
 	b.missingBlockIDs.Add(missingBlockID)
+	// The missing block is the parent of the lowest block of the interval that
+	// was just fetched.
+	if fetched, ok := b.tree.Interval(blk.Height()); ok {
+		b.missingBlockHeights[missingBlockID] = fetched.LowerBound - 1
+	}
 	// Attempt to fetch the newly discovered block
 	return b.fetch(ctx, missingBlockID)
 }
@@ -629,7 +847,7 @@ func (b *Bootstrapper) process(
 // being fetched. After executing all pending blocks it will either restart
 // bootstrapping, or transition into normal operations.
 func (b *Bootstrapper) tryStartExecuting(ctx context.Context) error {
-	if numMissingBlockIDs := b.missingBlockIDs.Len(); numMissingBlockIDs != 0 {
+	if numMissingBlockIDs := b.missingBlockIDs.Len(); !b.syncing || numMissingBlockIDs != 0 {
 		return nil
 	}
 
@@ -739,6 +957,9 @@ func (b *Bootstrapper) restartBootstrapping(ctx context.Context) error {
 	b.restarted = true
 	b.outstandingRequests = bimap.New[common.Request, ids.ID]()
 	b.outstandingRequestTimes = make(map[common.Request]time.Time)
+	b.outstandingPeerRequests = make(map[ids.NodeID]int)
+	b.fetchQueue = newFetchQueue()
+	b.missingBlockHeights = make(map[ids.ID]uint64)
 	return b.startBootstrapping(ctx)
 }
 
@@ -15,8 +15,10 @@ import (
 
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/snowmantest"
@@ -85,6 +87,7 @@ func newConfig(t *testing.T) (Config, ids.NodeID, *enginetest.Sender, *blocktest
 		prometheus.NewRegistry(),
 		nil,
 		nil,
+		reputation.NoTracker{},
 	)
 	require.NoError(err)
 
@@ -140,6 +143,7 @@ func TestBootstrapperStartsOnlyIfEnoughStakeIsConnected(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		nil,
+		reputation.NoTracker{},
 	)
 	require.NoError(err)
 
@@ -338,6 +342,211 @@ func TestBootstrapperPartialFetch(t *testing.T) {
 	require.Equal(snow.NormalOp, config.Ctx.State.Get().State)
 }
 
+// Missing blocks are spread across peers, with a limited number of outstanding
+// requests to each peer.
+func TestBootstrapperLimitsOutstandingRequestsPerPeer(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm, _ := newConfig(t)
+
+	blks := snowmantest.BuildChain(8)
+	initializeVMWithBlockchain(vm, blks)
+
+	bs, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	require.NoError(bs.Start(context.Background(), 0))
+
+	requests := make(map[uint32]ids.ID)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, reqID uint32, blkID ids.ID) {
+		require.Equal(peerID, nodeID)
+		requests[reqID] = blkID
+	}
+
+	// Only maxOutstandingRequestsPerPeer of the missing blocks are requested
+	// from the only connected peer.
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[2:8])))
+	require.Len(requests, maxOutstandingRequestsPerPeer)
+	require.Equal(6-maxOutstandingRequestsPerPeer, bs.fetchQueue.Len())
+
+	// Receiving a response allows another missing block to be requested.
+	var (
+		requestID uint32
+		blkID     ids.ID
+	)
+	for requestID, blkID = range requests {
+		break
+	}
+	var requestedBlk *snowmantest.Block
+	for _, blk := range blks {
+		if blk.ID() == blkID {
+			requestedBlk = blk
+		}
+	}
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestID, blocksToBytes([]*snowmantest.Block{requestedBlk})))
+	require.Len(requests, maxOutstandingRequestsPerPeer+1)
+	require.Equal(maxOutstandingRequestsPerPeer, bs.outstandingPeerRequests[peerID])
+}
+
+// The missing ranges below the accepted frontier and below a trusted
+// checkpoint are fetched from different peers at the same time.
+func TestBootstrapperFetchesRangesFromDifferentPeers(t *testing.T) {
+	require := require.New(t)
+
+	config, _, sender, vm, _ := newConfig(t)
+
+	otherPeerID := ids.GenerateTestNodeID()
+	config.PeerTracker.Connected(otherPeerID, version.CurrentApp)
+
+	blks := snowmantest.BuildChain(6)
+	initializeVMWithBlockchain(vm, blks)
+
+	config.Checkpoints = []genesis.Checkpoint{
+		{
+			Height:  3,
+			BlockID: blks[3].ID(),
+		},
+	}
+	bs, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	requests := make(map[ids.ID]common.Request)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, reqID uint32, blkID ids.ID) {
+		requests[blkID] = common.Request{
+			NodeID:    nodeID,
+			RequestID: reqID,
+		}
+	}
+
+	require.NoError(bs.Start(context.Background(), 0))
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[5:6])))
+
+	// Both ranges are requested before either request is answered.
+	require.Len(requests, 2)
+	checkpointRequest := requests[blks[3].ID()]
+	frontierRequest := requests[blks[5].ID()]
+	require.NotEqual(checkpointRequest.NodeID, frontierRequest.NodeID)
+	require.Equal(1, bs.outstandingPeerRequests[checkpointRequest.NodeID])
+	require.Equal(1, bs.outstandingPeerRequests[frontierRequest.NodeID])
+
+	require.NoError(bs.Ancestors(context.Background(), frontierRequest.NodeID, frontierRequest.RequestID, blocksToBytes(blks[4:6])))
+	require.NoError(bs.Ancestors(context.Background(), checkpointRequest.NodeID, checkpointRequest.RequestID, blocksToBytes(blks[1:4])))
+	snowmantest.RequireStatusIs(require, snowtest.Accepted, blks...)
+
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[5:6])))
+	require.Equal(snow.NormalOp, config.Ctx.State.Get().State)
+}
+
+// The ancestry of the latest trusted checkpoint is fetched while the accepted
+// frontier is being determined, but isn't executed until the frontier is known.
+func TestBootstrapperFetchesCheckpointBeforeFrontier(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm, _ := newConfig(t)
+
+	blks := snowmantest.BuildChain(5)
+	initializeVMWithBlockchain(vm, blks)
+
+	config.Checkpoints = []genesis.Checkpoint{
+		{
+			Height:  2,
+			BlockID: blks[2].ID(),
+		},
+		{
+			Height:  3,
+			BlockID: blks[3].ID(),
+		},
+	}
+	bs, err := New(
+		config,
+		func(context.Context, uint32) error {
+			config.Ctx.State.Set(snow.EngineState{
+				Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+				State: snow.NormalOp,
+			})
+			return nil
+		},
+	)
+	require.NoError(err)
+
+	var (
+		requestID uint32
+		requested ids.ID
+	)
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, reqID uint32, blkID ids.ID) {
+		require.Equal(peerID, nodeID)
+		requestID = reqID
+		requested = blkID
+	}
+
+	require.NoError(bs.Start(context.Background(), 0))
+	require.Equal(blks[3].ID(), requested)
+
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestID, blocksToBytes(blks[1:4])))
+	require.Equal(snow.Bootstrapping, config.Ctx.State.Get().State)
+	snowmantest.RequireStatusIs(require, snowtest.Undecided, blks[1:]...)
+
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[4:5])))
+	require.Equal(blks[4].ID(), requested)
+
+	require.NoError(bs.Ancestors(context.Background(), peerID, requestID, blocksToBytes(blks[4:5])))
+	require.Equal(snow.Bootstrapping, config.Ctx.State.Get().State)
+	snowmantest.RequireStatusIs(require, snowtest.Accepted, blks...)
+
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[4:5])))
+	require.Equal(snow.NormalOp, config.Ctx.State.Get().State)
+}
+
+// Bootstrapping fails if the fetched chain doesn't pass through a trusted
+// checkpoint.
+func TestBootstrapperCheckpointMismatch(t *testing.T) {
+	require := require.New(t)
+
+	config, peerID, sender, vm, _ := newConfig(t)
+
+	blks := snowmantest.BuildChain(4)
+	initializeVMWithBlockchain(vm, blks)
+
+	config.Checkpoints = []genesis.Checkpoint{
+		{
+			Height:  2,
+			BlockID: ids.GenerateTestID(),
+		},
+	}
+	bs, err := New(config, nil)
+	require.NoError(err)
+
+	var requestID uint32
+	sender.SendGetAncestorsF = func(_ context.Context, nodeID ids.NodeID, reqID uint32, _ ids.ID) {
+		require.Equal(peerID, nodeID)
+		requestID = reqID
+	}
+
+	require.NoError(bs.Start(context.Background(), 0))
+	require.NoError(bs.startSyncing(context.Background(), blocksToIDs(blks[3:4])))
+
+	err = bs.Ancestors(context.Background(), peerID, requestID, blocksToBytes(blks[3:4]))
+	require.ErrorIs(err, errCheckpointMismatch)
+}
+
 // There are multiple needed blocks and some validators do not have all the
 // blocks.
 func TestBootstrapperEmptyResponse(t *testing.T) {
@@ -673,6 +882,7 @@ func TestBootstrapNoParseOnNew(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		nil,
+		reputation.NoTracker{},
 	)
 	require.NoError(err)
 
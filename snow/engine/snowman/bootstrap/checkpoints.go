@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MetalBlockchain/metalgo/genesis"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+)
+
+var errCheckpointMismatch = errors.New("block conflicts with trusted checkpoint")
+
+// checkpoints tracks the blocks that are trusted to have been accepted.
+type checkpoints struct {
+	blockIDs map[uint64]ids.ID
+	heights  map[ids.ID]uint64
+}
+
+func newCheckpoints(trusted []genesis.Checkpoint) checkpoints {
+	c := checkpoints{
+		blockIDs: make(map[uint64]ids.ID, len(trusted)),
+		heights:  make(map[ids.ID]uint64, len(trusted)),
+	}
+	for _, checkpoint := range trusted {
+		c.blockIDs[checkpoint.Height] = checkpoint.BlockID
+		c.heights[checkpoint.BlockID] = checkpoint.Height
+	}
+	return c
+}
+
+// verify returns an error if [blk] can't be on a chain that passes through all
+// the checkpoints.
+func (c checkpoints) verify(blk snowman.Block) error {
+	var (
+		blkID  = blk.ID()
+		height = blk.Height()
+	)
+	if expectedID, ok := c.blockIDs[height]; ok && expectedID != blkID {
+		return fmt.Errorf("%w: block %s at height %d, expected %s",
+			errCheckpointMismatch,
+			blkID,
+			height,
+			expectedID,
+		)
+	}
+	if expectedHeight, ok := c.heights[blkID]; ok && expectedHeight != height {
+		return fmt.Errorf("%w: block %s at height %d, expected height %d",
+			errCheckpointMismatch,
+			blkID,
+			height,
+			expectedHeight,
+		)
+	}
+	if height == 0 {
+		return nil
+	}
+	if expectedParentID, ok := c.blockIDs[height-1]; ok && expectedParentID != blk.Parent() {
+		return fmt.Errorf("%w: block %s at height %d has parent %s, expected %s",
+			errCheckpointMismatch,
+			blkID,
+			height,
+			blk.Parent(),
+			expectedParentID,
+		)
+	}
+	return nil
+}
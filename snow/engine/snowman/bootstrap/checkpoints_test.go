@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/genesis"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/snowmantest"
+	"github.com/MetalBlockchain/metalgo/snow/snowtest"
+)
+
+func TestCheckpointsVerify(t *testing.T) {
+	blocks := snowmantest.BuildChain(4)
+	checkpoints := newCheckpoints([]genesis.Checkpoint{
+		{
+			Height:  2,
+			BlockID: blocks[2].ID(),
+		},
+	})
+
+	tests := []struct {
+		name        string
+		blk         snowman.Block
+		expectedErr error
+	}{
+		{
+			name:        "block before checkpoint",
+			blk:         blocks[1],
+			expectedErr: nil,
+		},
+		{
+			name:        "checkpoint",
+			blk:         blocks[2],
+			expectedErr: nil,
+		},
+		{
+			name:        "child of checkpoint",
+			blk:         blocks[3],
+			expectedErr: nil,
+		},
+		{
+			name: "conflicting block at checkpoint height",
+			blk: &snowmantest.Block{
+				Decidable: snowtest.Decidable{
+					IDV: ids.GenerateTestID(),
+				},
+				ParentV: blocks[1].ID(),
+				HeightV: 2,
+			},
+			expectedErr: errCheckpointMismatch,
+		},
+		{
+			name: "checkpoint at wrong height",
+			blk: &snowmantest.Block{
+				Decidable: snowtest.Decidable{
+					IDV: blocks[2].ID(),
+				},
+				ParentV: blocks[0].ID(),
+				HeightV: 1,
+			},
+			expectedErr: errCheckpointMismatch,
+		},
+		{
+			name: "conflicting child of checkpoint",
+			blk: &snowmantest.Block{
+				Decidable: snowtest.Decidable{
+					IDV: ids.GenerateTestID(),
+				},
+				ParentV: ids.GenerateTestID(),
+				HeightV: 3,
+			},
+			expectedErr: errCheckpointMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkpoints.verify(test.blk)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -5,6 +5,7 @@ package bootstrap
 
 import (
 	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
@@ -32,6 +33,10 @@ type Config struct {
 	// containers in an ancestors message it receives.
 	AncestorsMaxContainersReceived int
 
+	// Checkpoints are blocks that are trusted to have been accepted, sorted by
+	// height. Bootstrapping fails if a fetched block conflicts with them.
+	Checkpoints []genesis.Checkpoint
+
 	// Database used to track the fetched, but not yet executed, blocks during
 	// bootstrapping.
 	DB database.Database
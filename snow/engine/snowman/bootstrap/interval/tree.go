@@ -4,6 +4,8 @@
 package interval
 
 import (
+	"math"
+
 	"github.com/google/btree"
 
 	"github.com/MetalBlockchain/metalgo/database"
@@ -169,6 +171,66 @@ func (t *Tree) Contains(height uint64) bool {
 	return higher.Contains(height)
 }
 
+// Interval returns the interval in the tree that contains [height], if one
+// exists.
+func (t *Tree) Interval(height uint64) (*Interval, bool) {
+	var (
+		i = &Interval{
+			LowerBound: height,
+			UpperBound: height,
+		}
+		higher *Interval
+	)
+	t.knownHeights.AscendGreaterOrEqual(i, func(item *Interval) bool {
+		higher = item
+		return false
+	})
+	if !higher.Contains(height) {
+		return nil, false
+	}
+	return &Interval{
+		LowerBound: higher.LowerBound,
+		UpperBound: higher.UpperBound,
+	}, true
+}
+
+// Missing returns the largest interval that contains [height] and doesn't
+// contain any height in the tree. Returns false if [height] is in the tree.
+func (t *Tree) Missing(height uint64) (*Interval, bool) {
+	var (
+		i = &Interval{
+			LowerBound: height,
+			UpperBound: height,
+		}
+		higher *Interval
+		lower  *Interval
+	)
+	t.knownHeights.AscendGreaterOrEqual(i, func(item *Interval) bool {
+		higher = item
+		return false
+	})
+	if higher.Contains(height) {
+		return nil, false
+	}
+
+	t.knownHeights.DescendLessOrEqual(i, func(item *Interval) bool {
+		lower = item
+		return false
+	})
+
+	missing := &Interval{
+		LowerBound: 0,
+		UpperBound: math.MaxUint64,
+	}
+	if lower != nil {
+		missing.LowerBound = lower.UpperBound + 1
+	}
+	if higher != nil {
+		missing.UpperBound = higher.LowerBound - 1
+	}
+	return missing, true
+}
+
 func (t *Tree) Flatten() []*Interval {
 	intervals := make([]*Interval, 0, t.knownHeights.Len())
 	t.knownHeights.Ascend(func(item *Interval) bool {
@@ -368,6 +368,152 @@ func TestTreeContains(t *testing.T) {
 	}
 }
 
+func TestTreeInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		tree     []*Interval
+		height   uint64
+		expected *Interval
+	}{
+		{
+			name: "below",
+			tree: []*Interval{
+				{
+					LowerBound: 10,
+					UpperBound: 11,
+				},
+			},
+			height:   9,
+			expected: nil,
+		},
+		{
+			name: "above",
+			tree: []*Interval{
+				{
+					LowerBound: 10,
+					UpperBound: 11,
+				},
+			},
+			height:   12,
+			expected: nil,
+		},
+		{
+			name: "inside",
+			tree: []*Interval{
+				{
+					LowerBound: 5,
+					UpperBound: 6,
+				},
+				{
+					LowerBound: 9,
+					UpperBound: 11,
+				},
+			},
+			height: 10,
+			expected: &Interval{
+				LowerBound: 9,
+				UpperBound: 11,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tree := newTree(require, memdb.New(), test.tree)
+			interval, ok := tree.Interval(test.height)
+			require.Equal(test.expected != nil, ok)
+			require.Equal(test.expected, interval)
+		})
+	}
+}
+
+func TestTreeMissing(t *testing.T) {
+	tests := []struct {
+		name     string
+		tree     []*Interval
+		height   uint64
+		expected *Interval
+	}{
+		{
+			name:   "empty",
+			tree:   nil,
+			height: 10,
+			expected: &Interval{
+				LowerBound: 0,
+				UpperBound: math.MaxUint64,
+			},
+		},
+		{
+			name: "below",
+			tree: []*Interval{
+				{
+					LowerBound: 10,
+					UpperBound: 11,
+				},
+			},
+			height: 5,
+			expected: &Interval{
+				LowerBound: 0,
+				UpperBound: 9,
+			},
+		},
+		{
+			name: "above",
+			tree: []*Interval{
+				{
+					LowerBound: 10,
+					UpperBound: 11,
+				},
+			},
+			height: 15,
+			expected: &Interval{
+				LowerBound: 12,
+				UpperBound: math.MaxUint64,
+			},
+		},
+		{
+			name: "between",
+			tree: []*Interval{
+				{
+					LowerBound: 2,
+					UpperBound: 3,
+				},
+				{
+					LowerBound: 10,
+					UpperBound: 11,
+				},
+			},
+			height: 9,
+			expected: &Interval{
+				LowerBound: 4,
+				UpperBound: 9,
+			},
+		},
+		{
+			name: "contained",
+			tree: []*Interval{
+				{
+					LowerBound: 10,
+					UpperBound: 11,
+				},
+			},
+			height:   10,
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tree := newTree(require, memdb.New(), test.tree)
+			missing, ok := tree.Missing(test.height)
+			require.Equal(test.expected != nil, ok)
+			require.Equal(test.expected, missing)
+		})
+	}
+}
+
 func TestTreeLenOverflow(t *testing.T) {
 	require := require.New(t)
 
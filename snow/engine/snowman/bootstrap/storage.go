@@ -76,16 +76,23 @@ func getMissingBlockIDs(
 // If [blk]'s height is <= the last accepted height, then it will be removed
 // from the missingIDs set.
 //
+// Returns an error if a processed block conflicts with [checkpoints].
+//
 // Returns a newly discovered blockID that should be fetched.
 func process(
 	db database.KeyValueWriterDeleter,
 	tree *interval.Tree,
 	missingBlockIDs set.Set[ids.ID],
 	lastAcceptedHeight uint64,
+	checkpoints checkpoints,
 	blk snowman.Block,
 	ancestors map[ids.ID]snowman.Block,
 ) (ids.ID, bool, error) {
 	for {
+		if err := checkpoints.verify(blk); err != nil {
+			return ids.Empty, false, err
+		}
+
 		// It's possible that missingBlockIDs contain values contained inside of
 		// ancestors. So, it's important to remove IDs from the set for each
 		// iteration, not just the first block's ID.
@@ -200,6 +200,7 @@ func TestProcess(t *testing.T) {
 				tree,
 				test.missingBlockIDs,
 				test.lastAcceptedHeight,
+				checkpoints{},
 				test.blk,
 				test.ancestors,
 			)
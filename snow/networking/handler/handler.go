@@ -440,10 +440,11 @@ func (h *handler) dispatchChans(ctx context.Context) {
 // Any returned error is treated as fatal
 func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 	var (
-		nodeID    = msg.NodeID()
-		op        = msg.Op().String()
-		body      = msg.Message()
-		startTime = h.clock.Time()
+		nodeID      = msg.NodeID()
+		op          = msg.Op().String()
+		body        = msg.Message()
+		startTime   = h.clock.Time()
+		spanContext = logging.SpanContext(ctx)
 		// Check if the chain is in normal operation at the start of message
 		// execution (may change during execution)
 		isNormalOp = h.ctx.State.Get().State == snow.NormalOp
@@ -453,11 +454,13 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 			zap.Stringer("nodeID", nodeID),
 			zap.String("messageOp", op),
 			zap.Stringer("message", body),
+			spanContext,
 		)
 	} else {
 		h.ctx.Log.Debug("forwarding sync message to consensus",
 			zap.Stringer("nodeID", nodeID),
 			zap.String("messageOp", op),
+			spanContext,
 		)
 	}
 	h.resourceTracker.StartProcessing(nodeID, startTime)
@@ -482,6 +485,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 		msg.OnFinishedHandling()
 		h.ctx.Log.Debug("finished handling sync message",
 			zap.String("messageOp", op),
+			spanContext,
 		)
 		if lockingTime+handlingTime > syncProcessingTimeWarnLimit && isNormalOp {
 			h.ctx.Log.Warn("handling sync message took longer than expected",
@@ -490,6 +494,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Stringer("nodeID", nodeID),
 				zap.String("messageOp", op),
 				zap.Stringer("message", body),
+				spanContext,
 			)
 		}
 	}()
@@ -507,6 +512,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 			zap.String("messageOp", op),
 			zap.Stringer("currentEngineType", currentState.Type),
 			zap.Stringer("requestedEngineType", msg.EngineType),
+			spanContext,
 		)
 		return nil
 	}
@@ -538,6 +544,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 			zap.Stringer("currentEngineType", currentState.Type),
 			zap.Stringer("requestedEngineType", msg.EngineType),
 			zap.Stringer("engineState", currentState.State),
+			spanContext,
 		)
 		return nil
 	}
@@ -574,6 +581,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Uint32("requestID", msg.RequestId),
 				zap.String("field", "SummaryIDs"),
 				zap.Error(err),
+				spanContext,
 			)
 			return engine.GetAcceptedStateSummaryFailed(ctx, nodeID, msg.RequestId)
 		}
@@ -597,6 +605,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Uint32("requestID", msg.RequestId),
 				zap.String("field", "ContainerID"),
 				zap.Error(err),
+				spanContext,
 			)
 			return engine.GetAcceptedFrontierFailed(ctx, nodeID, msg.RequestId)
 		}
@@ -615,6 +624,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Uint32("requestID", msg.RequestId),
 				zap.String("field", "ContainerIDs"),
 				zap.Error(err),
+				spanContext,
 			)
 			return nil
 		}
@@ -630,6 +640,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Uint32("requestID", msg.RequestId),
 				zap.String("field", "ContainerIDs"),
 				zap.Error(err),
+				spanContext,
 			)
 			return engine.GetAcceptedFailed(ctx, nodeID, msg.RequestId)
 		}
@@ -648,6 +659,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Uint32("requestID", msg.RequestId),
 				zap.String("field", "ContainerID"),
 				zap.Error(err),
+				spanContext,
 			)
 			return nil
 		}
@@ -669,6 +681,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Uint32("requestID", msg.RequestId),
 				zap.String("field", "ContainerID"),
 				zap.Error(err),
+				spanContext,
 			)
 			return nil
 		}
@@ -693,6 +706,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Uint32("requestID", msg.RequestId),
 				zap.String("field", "ContainerID"),
 				zap.Error(err),
+				spanContext,
 			)
 			return nil
 		}
@@ -708,6 +722,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Uint32("requestID", msg.RequestId),
 				zap.String("field", "PreferredID"),
 				zap.Error(err),
+				spanContext,
 			)
 			return engine.QueryFailed(ctx, nodeID, msg.RequestId)
 		}
@@ -720,6 +735,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Uint32("requestID", msg.RequestId),
 				zap.String("field", "PreferredIDAtHeight"),
 				zap.Error(err),
+				spanContext,
 			)
 			return engine.QueryFailed(ctx, nodeID, msg.RequestId)
 		}
@@ -732,6 +748,7 @@ func (h *handler) handleSyncMsg(ctx context.Context, msg Message) error {
 				zap.Uint32("requestID", msg.RequestId),
 				zap.String("field", "AcceptedID"),
 				zap.Error(err),
+				spanContext,
 			)
 			return engine.QueryFailed(ctx, nodeID, msg.RequestId)
 		}
@@ -783,21 +800,24 @@ func (h *handler) handleAsyncMsg(ctx context.Context, msg Message) {
 // Any returned error is treated as fatal
 func (h *handler) executeAsyncMsg(ctx context.Context, msg Message) error {
 	var (
-		nodeID    = msg.NodeID()
-		op        = msg.Op().String()
-		body      = msg.Message()
-		startTime = h.clock.Time()
+		nodeID      = msg.NodeID()
+		op          = msg.Op().String()
+		body        = msg.Message()
+		startTime   = h.clock.Time()
+		spanContext = logging.SpanContext(ctx)
 	)
 	if h.ctx.Log.Enabled(logging.Verbo) {
 		h.ctx.Log.Verbo("forwarding async message to consensus",
 			zap.Stringer("nodeID", nodeID),
 			zap.String("messageOp", op),
 			zap.Stringer("message", body),
+			spanContext,
 		)
 	} else {
 		h.ctx.Log.Debug("forwarding async message to consensus",
 			zap.Stringer("nodeID", nodeID),
 			zap.String("messageOp", op),
+			spanContext,
 		)
 	}
 	h.resourceTracker.StartProcessing(nodeID, startTime)
@@ -816,6 +836,7 @@ func (h *handler) executeAsyncMsg(ctx context.Context, msg Message) error {
 		msg.OnFinishedHandling()
 		h.ctx.Log.Debug("finished handling async message",
 			zap.String("messageOp", op),
+			spanContext,
 		)
 	}()
 
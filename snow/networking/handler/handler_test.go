@@ -4,6 +4,7 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"sync"
@@ -12,10 +13,12 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
 	"github.com/MetalBlockchain/metalgo/snow/engine/enginetest"
@@ -63,6 +66,7 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		reputation.NoTracker{},
 	)
 	require.NoError(err)
 
@@ -170,6 +174,7 @@ func TestHandlerClosesOnError(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		reputation.NoTracker{},
 	)
 	require.NoError(err)
 
@@ -273,6 +278,7 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		reputation.NoTracker{},
 	)
 	require.NoError(err)
 
@@ -364,6 +370,7 @@ func TestHandlerDispatchInternal(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		reputation.NoTracker{},
 	)
 	require.NoError(err)
 
@@ -540,6 +547,7 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 				prometheus.NewRegistry(),
 				nil,
 				version.CurrentApp,
+				reputation.NoTracker{},
 			)
 			require.NoError(err)
 
@@ -604,6 +612,126 @@ func TestDynamicEngineTypeDispatch(t *testing.T) {
 	}
 }
 
+type logBuffer struct {
+	bytes.Buffer
+}
+
+func (*logBuffer) Close() error {
+	return nil
+}
+
+// Tests that the logs of a message include the span it was routed in
+func TestHandlerLogsSpanContext(t *testing.T) {
+	require := require.New(t)
+
+	logs := &logBuffer{}
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	snowCtx.Log = logging.NewLogger("", logging.NewWrappedCore(logging.Debug, logs, logging.JSON.ConsoleEncoder()))
+	ctx := snowtest.ConsensusContext(snowCtx)
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, ids.GenerateTestNodeID(), nil, ids.Empty, 1))
+
+	resourceTracker, err := tracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		time.Second,
+	)
+	require.NoError(err)
+
+	peerTracker, err := p2p.NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		version.CurrentApp,
+		reputation.NoTracker{},
+	)
+	require.NoError(err)
+
+	handler, err := New(
+		ctx,
+		vdrs,
+		nil,
+		time.Second,
+		testThreadPoolSize,
+		resourceTracker,
+		subnets.New(ctx.NodeID, subnets.Config{}),
+		commontracker.NewPeers(),
+		peerTracker,
+		prometheus.NewRegistry(),
+		func() {},
+	)
+	require.NoError(err)
+
+	bootstrapper := &enginetest.Bootstrapper{
+		Engine: enginetest.Engine{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+
+	messageReceived := make(chan struct{})
+	engine := &enginetest.Engine{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext {
+		return ctx
+	}
+	engine.ChitsF = func(context.Context, ids.NodeID, uint32, ids.ID, ids.ID, ids.ID, uint64) error {
+		close(messageReceived)
+		return nil
+	}
+
+	handler.SetEngineManager(&EngineManager{
+		Snowman: &Engine{
+			Bootstrapper: bootstrapper,
+			Consensus:    engine,
+		},
+	})
+	ctx.State.Set(snow.EngineState{
+		Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.NormalOp, // assumed bootstrap is done
+	})
+
+	bootstrapper.StartF = func(context.Context, uint32) error {
+		return nil
+	}
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+	handler.Start(context.Background(), false)
+	handler.Push(trace.ContextWithSpanContext(context.Background(), spanContext), Message{
+		InboundMessage: message.InboundChits(
+			ids.Empty,
+			uint32(0),
+			ids.Empty,
+			ids.Empty,
+			ids.Empty,
+			ids.EmptyNodeID,
+		),
+		EngineType: p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+	})
+	<-messageReceived
+
+	// Stopping the handler guarantees that the message has finished being
+	// logged.
+	handler.Stop(context.Background())
+	_, err = handler.AwaitStopped(context.Background())
+	require.NoError(err)
+
+	var numSpanLogs int
+	for _, line := range bytes.Split(bytes.TrimSpace(logs.Bytes()), []byte("\n")) {
+		if bytes.Contains(line, []byte(`"messageOp":"chits"`)) {
+			require.Contains(string(line), `"traceID":"`+spanContext.TraceID().String()+`"`)
+			require.Contains(string(line), `"spanID":"`+spanContext.SpanID().String()+`"`)
+			numSpanLogs++
+		}
+	}
+	require.Equal(2, numSpanLogs) // forwarding and finished handling
+}
+
 func TestHandlerStartError(t *testing.T) {
 	require := require.New(t)
 
@@ -623,6 +751,7 @@ func TestHandlerStartError(t *testing.T) {
 		prometheus.NewRegistry(),
 		nil,
 		version.CurrentApp,
+		reputation.NoTracker{},
 	)
 	require.NoError(err)
 
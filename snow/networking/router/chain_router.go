@@ -404,7 +404,7 @@ func (cr *ChainRouter) AddChain(ctx context.Context, chain handler.Handler) {
 		zap.Stringer("chainID", chainID),
 	)
 	chain.SetOnStopped(func() {
-		cr.removeChain(ctx, chainID)
+		cr.removeChain(ctx, chain)
 	})
 	cr.chainHandlers[chainID] = chain
 
@@ -647,10 +647,15 @@ func (cr *ChainRouter) HealthCheck(context.Context) (interface{}, error) {
 
 // RemoveChain removes the specified chain so that incoming
 // messages can't be routed to it
-func (cr *ChainRouter) removeChain(ctx context.Context, chainID ids.ID) {
+//
+// If the chain has since been replaced by a new handler, for example because it
+// was restarted, the new handler is left registered.
+func (cr *ChainRouter) removeChain(ctx context.Context, chain handler.Handler) {
+	chainID := chain.Context().ChainID
+
 	cr.lock.Lock()
-	chain, exists := cr.chainHandlers[chainID]
-	if !exists {
+	registeredChain, exists := cr.chainHandlers[chainID]
+	if !exists || registeredChain != chain {
 		cr.log.Debug("can't remove unknown chain",
 			zap.Stringer("chainID", chainID),
 		)
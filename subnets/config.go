@@ -13,6 +13,10 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/set"
 )
 
+// DefaultThrottlerWeight is the weight given to a subnet's portion of the
+// inbound message throttler allocations if one isn't specified.
+const DefaultThrottlerWeight = 100
+
 var errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
 
 type Config struct {
@@ -49,6 +53,15 @@ type Config struct {
 	// TODO: Move this flag once the proposervm is configurable on a per-chain
 	// basis.
 	ProposerNumHistoricalBlocks uint64 `json:"proposerNumHistoricalBlocks" yaml:"proposerNumHistoricalBlocks"`
+
+	// ThrottlerWeight is the weight of this Subnet's portion of the inbound
+	// message throttler allocations. The allocations that aren't reserved for
+	// the primary network are divided between the tracked Subnets
+	// proportionally to their weights. If 0, messages for this Subnet's Chains
+	// are dropped once each peer's initial bandwidth burst is used.
+	//
+	// Ignored for the primary network.
+	ThrottlerWeight uint64 `json:"throttlerWeight" yaml:"throttlerWeight"`
 }
 
 func (c *Config) Valid() error {
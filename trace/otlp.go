@@ -0,0 +1,206 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+const (
+	otlpLogsPath    = "/v1/logs"
+	otlpMetricsPath = "/v1/metrics"
+)
+
+var errUnexpectedStatusCode = errors.New("unexpected status code")
+
+// OTLPClient exports logs and metrics to the same endpoint that traces are
+// exported to.
+type OTLPClient interface {
+	ExportLogs(ctx context.Context, records []*logspb.LogRecord) error
+	ExportMetrics(ctx context.Context, metrics []*metricspb.Metric) error
+	io.Closer
+}
+
+// NewOTLPClient returns a client that exports logs and metrics to the endpoint
+// described by [config.ExporterConfig].
+func NewOTLPClient(config Config) (OTLPClient, error) {
+	c := otlpClient{
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{
+				stringKeyValue("service.name", config.AppName),
+				stringKeyValue("version", config.Version),
+			},
+		},
+		scope: &commonpb.InstrumentationScope{
+			Name:    config.AppName,
+			Version: config.Version,
+		},
+	}
+
+	switch config.Type {
+	case GRPC:
+		creds := credentials.NewTLS(nil)
+		if config.Insecure {
+			creds = insecure.NewCredentials()
+		}
+		conn, err := grpc.Dial(config.Endpoint, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, err
+		}
+		return &otlpGRPCClient{
+			otlpClient:    c,
+			conn:          conn,
+			headers:       metadata.New(config.Headers),
+			logsClient:    collogspb.NewLogsServiceClient(conn),
+			metricsClient: colmetricspb.NewMetricsServiceClient(conn),
+		}, nil
+	case HTTP:
+		scheme := "https"
+		if config.Insecure {
+			scheme = "http"
+		}
+		return &otlpHTTPClient{
+			otlpClient: c,
+			client:     &http.Client{},
+			baseURL:    scheme + "://" + config.Endpoint,
+			headers:    config.Headers,
+		}, nil
+	default:
+		return nil, errUnknownExporterType
+	}
+}
+
+type otlpClient struct {
+	resource *resourcepb.Resource
+	scope    *commonpb.InstrumentationScope
+}
+
+func (c *otlpClient) logsRequest(records []*logspb.LogRecord) *collogspb.ExportLogsServiceRequest {
+	return &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: c.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      c.scope,
+				LogRecords: records,
+			}},
+		}},
+	}
+}
+
+func (c *otlpClient) metricsRequest(metrics []*metricspb.Metric) *colmetricspb.ExportMetricsServiceRequest {
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: c.resource,
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Scope:   c.scope,
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
+
+type otlpGRPCClient struct {
+	otlpClient
+
+	conn          *grpc.ClientConn
+	headers       metadata.MD
+	logsClient    collogspb.LogsServiceClient
+	metricsClient colmetricspb.MetricsServiceClient
+}
+
+func (c *otlpGRPCClient) ExportLogs(ctx context.Context, records []*logspb.LogRecord) error {
+	ctx = metadata.NewOutgoingContext(ctx, c.headers)
+	_, err := c.logsClient.Export(ctx, c.logsRequest(records))
+	return err
+}
+
+func (c *otlpGRPCClient) ExportMetrics(ctx context.Context, metrics []*metricspb.Metric) error {
+	ctx = metadata.NewOutgoingContext(ctx, c.headers)
+	_, err := c.metricsClient.Export(ctx, c.metricsRequest(metrics))
+	return err
+}
+
+func (c *otlpGRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+type otlpHTTPClient struct {
+	otlpClient
+
+	client  *http.Client
+	baseURL string
+	headers map[string]string
+}
+
+func (c *otlpHTTPClient) ExportLogs(ctx context.Context, records []*logspb.LogRecord) error {
+	return c.post(ctx, otlpLogsPath, c.logsRequest(records))
+}
+
+func (c *otlpHTTPClient) ExportMetrics(ctx context.Context, metrics []*metricspb.Metric) error {
+	return c.post(ctx, otlpMetricsPath, c.metricsRequest(metrics))
+}
+
+func (c *otlpHTTPClient) post(ctx context.Context, path string, msg proto.Message) error {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-protobuf")
+	for key, value := range c.headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// Drain the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %d", errUnexpectedStatusCode, response.StatusCode)
+	}
+	return nil
+}
+
+func (c *otlpHTTPClient) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+func stringKeyValue(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key: key,
+		Value: &commonpb.AnyValue{
+			Value: &commonpb.AnyValue_StringValue{
+				StringValue: value,
+			},
+		},
+	}
+}
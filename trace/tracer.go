@@ -36,6 +36,13 @@ type Config struct {
 
 	AppName string `json:"appName"`
 	Version string `json:"version"`
+
+	// If true, logs are exported to the same endpoint as traces
+	ExportLogs bool `json:"exportLogs"`
+	// If true, metrics are exported to the same endpoint as traces every
+	// [MetricsExportFrequency]
+	ExportMetrics          bool          `json:"exportMetrics"`
+	MetricsExportFrequency time.Duration `json:"metricsExportFrequency"`
 }
 
 type Tracer interface {
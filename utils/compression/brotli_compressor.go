@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/andybalholm/brotli"
+)
+
+var _ Compressor = (*brotliCompressor)(nil)
+
+func NewBrotliCompressor(maxSize int64) (Compressor, error) {
+	if maxSize == math.MaxInt64 {
+		// "Decompress" creates "io.LimitReader" with max size + 1:
+		// if the max size + 1 overflows, "io.LimitReader" reads nothing
+		// returning 0 byte for the decompress call
+		// require max size < math.MaxInt64 to prevent int64 overflows
+		return nil, ErrInvalidMaxSizeCompressor
+	}
+
+	return &brotliCompressor{
+		maxSize: maxSize,
+	}, nil
+}
+
+type brotliCompressor struct {
+	maxSize int64
+}
+
+func (b *brotliCompressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > b.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), b.maxSize)
+	}
+
+	var buf bytes.Buffer
+	writer := brotli.NewWriterLevel(&buf, brotli.DefaultCompression)
+	if _, err := writer.Write(msg); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *brotliCompressor) Decompress(msg []byte) ([]byte, error) {
+	reader := brotli.NewReader(bytes.NewReader(msg))
+
+	// We allow [io.LimitReader] to read up to [b.maxSize + 1] bytes, so that if
+	// the decompressed payload is greater than the maximum size, this function
+	// will return the appropriate error instead of an incomplete byte slice.
+	limitReader := io.LimitReader(reader, b.maxSize+1)
+	decompressed, err := io.ReadAll(limitReader)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > b.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, len(decompressed), b.maxSize)
+	}
+	return decompressed, nil
+}
@@ -24,14 +24,24 @@ var (
 		TypeNone: func(int64) (Compressor, error) { //nolint:unparam // an error is needed to be returned to compile
 			return NewNoCompressor(), nil
 		},
-		TypeZstd: NewZstdCompressor,
+		TypeZstd:   NewZstdCompressor,
+		TypeBrotli: NewBrotliCompressor,
+		TypeLZ4:    NewLZ4Compressor,
 	}
 
 	//go:embed zstd_zip_bomb.bin
 	zstdZipBomb []byte
 
+	//go:embed brotli_zip_bomb.bin
+	brotliZipBomb []byte
+
+	//go:embed lz4_zip_bomb.bin
+	lz4ZipBomb []byte
+
 	zipBombs = map[Type][]byte{
-		TypeZstd: zstdZipBomb,
+		TypeZstd:   zstdZipBomb,
+		TypeBrotli: brotliZipBomb,
+		TypeLZ4:    lz4ZipBomb,
 	}
 )
 
@@ -153,6 +163,14 @@ func FuzzZstdCompressor(f *testing.F) {
 	fuzzHelper(f, TypeZstd)
 }
 
+func FuzzBrotliCompressor(f *testing.F) {
+	fuzzHelper(f, TypeBrotli)
+}
+
+func FuzzLZ4Compressor(f *testing.F) {
+	fuzzHelper(f, TypeLZ4)
+}
+
 func fuzzHelper(f *testing.F, compressionType Type) {
 	var (
 		compressor Compressor
@@ -162,6 +180,12 @@ func fuzzHelper(f *testing.F, compressionType Type) {
 	case TypeZstd:
 		compressor, err = NewZstdCompressor(maxMessageSize)
 		require.NoError(f, err)
+	case TypeBrotli:
+		compressor, err = NewBrotliCompressor(maxMessageSize)
+		require.NoError(f, err)
+	case TypeLZ4:
+		compressor, err = NewLZ4Compressor(maxMessageSize)
+		require.NoError(f, err)
 	default:
 		require.FailNow(f, "Unknown compression type")
 	}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/bkaradzic/go-lz4"
+)
+
+// lz4HeaderLen is the length of the little-endian uncompressed size that
+// prefixes every lz4 block.
+const lz4HeaderLen = 4
+
+var (
+	_ Compressor = (*lz4Compressor)(nil)
+
+	errLZ4MsgTooShort = errors.New("lz4 msg too short")
+)
+
+func NewLZ4Compressor(maxSize int64) (Compressor, error) {
+	if maxSize == math.MaxInt64 || maxSize >= lz4.MaxInputSize {
+		// lz4 blocks can't describe payloads larger than [lz4.MaxInputSize].
+		// Rejecting math.MaxInt64 keeps the behavior consistent with the other
+		// compressors.
+		return nil, ErrInvalidMaxSizeCompressor
+	}
+
+	return &lz4Compressor{
+		maxSize: maxSize,
+	}, nil
+}
+
+type lz4Compressor struct {
+	maxSize int64
+}
+
+func (l *lz4Compressor) Compress(msg []byte) ([]byte, error) {
+	if int64(len(msg)) > l.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrMsgTooLarge, len(msg), l.maxSize)
+	}
+	return lz4.Encode(nil, msg)
+}
+
+func (l *lz4Compressor) Decompress(msg []byte) ([]byte, error) {
+	if len(msg) < lz4HeaderLen {
+		return nil, fmt.Errorf("%w: (%d) < (%d)", errLZ4MsgTooShort, len(msg), lz4HeaderLen)
+	}
+
+	// The decoder allocates the size claimed by the header up front, so the
+	// claimed size must be checked before decoding to avoid zip bombs.
+	decompressedLen := int64(binary.LittleEndian.Uint32(msg))
+	if decompressedLen > l.maxSize {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", ErrDecompressedMsgTooLarge, decompressedLen, l.maxSize)
+	}
+	// A valid block is never larger than the bound of its decompressed size.
+	// Rejecting larger blocks also prevents length overflows in the decoder.
+	if maxLen := lz4.CompressBound(int(decompressedLen)); len(msg) > maxLen {
+		return nil, fmt.Errorf("%w: (%d) > (%d)", lz4.ErrCorrupt, len(msg), maxLen)
+	}
+
+	decompressed, err := lz4.Decode(nil, msg)
+	if err != nil {
+		return nil, err
+	}
+	if decompressed == nil {
+		// An empty message is decoded as nil.
+		decompressed = []byte{}
+	}
+	return decompressed, nil
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/bkaradzic/go-lz4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLZ4DecompressInvalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		msg         []byte
+		expectedErr error
+	}{
+		{
+			name:        "missing header",
+			msg:         []byte{0, 1},
+			expectedErr: errLZ4MsgTooShort,
+		},
+		{
+			name:        "claimed size too large",
+			msg:         binary.LittleEndian.AppendUint32(nil, maxMessageSize+1),
+			expectedErr: ErrDecompressedMsgTooLarge,
+		},
+		{
+			name:        "block larger than bound",
+			msg:         append(binary.LittleEndian.AppendUint32(nil, 1), make([]byte, 32)...),
+			expectedErr: lz4.ErrCorrupt,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			compressor, err := NewLZ4Compressor(maxMessageSize)
+			require.NoError(t, err)
+
+			_, err = compressor.Decompress(test.msg)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
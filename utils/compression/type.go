@@ -15,6 +15,8 @@ type Type byte
 const (
 	TypeNone Type = iota + 1
 	TypeZstd
+	TypeBrotli
+	TypeLZ4
 )
 
 func (t Type) String() string {
@@ -23,6 +25,10 @@ func (t Type) String() string {
 		return "none"
 	case TypeZstd:
 		return "zstd"
+	case TypeBrotli:
+		return "brotli"
+	case TypeLZ4:
+		return "lz4"
 	default:
 		return "unknown"
 	}
@@ -34,6 +40,10 @@ func TypeFromString(s string) (Type, error) {
 		return TypeNone, nil
 	case TypeZstd.String():
 		return TypeZstd, nil
+	case TypeBrotli.String():
+		return TypeBrotli, nil
+	case TypeLZ4.String():
+		return TypeLZ4, nil
 	default:
 		return TypeNone, errUnknownCompressionType
 	}
@@ -66,6 +66,15 @@ const (
 	DefaultBenchlistDuration           = 15 * time.Minute
 	DefaultBenchlistMinFailingDuration = 2*time.Minute + 30*time.Second
 
+	// Reputation
+	DefaultNetworkReputationHalflife   = 24 * time.Hour
+	DefaultNetworkReputationThreshold  = -100
+	DefaultNetworkReputationMaxTracked = 16_384
+
+	// Capture
+	DefaultNetworkCaptureMaxSize  = 100 // MB
+	DefaultNetworkCaptureMaxFiles = 10
+
 	// Router
 	DefaultConsensusAppConcurrency  = 2
 	DefaultConsensusShutdownTimeout = time.Minute
@@ -80,6 +89,7 @@ const (
 	DefaultInboundThrottlerBandwidthMaxBurstSize    = DefaultMaxMessageSize
 	DefaultInboundThrottlerCPUMaxRecheckDelay       = 5 * time.Second
 	DefaultInboundThrottlerDiskMaxRecheckDelay      = 5 * time.Second
+	DefaultInboundThrottlerPrimaryNetworkReserve    = 0.5
 	MinInboundThrottlerMaxRecheckDelay              = time.Millisecond
 
 	// Outbound Throttling
@@ -33,6 +33,12 @@ type ClaimedIPPort struct {
 	// actually claimed by the peer in question, and not by a malicious peer
 	// trying to get us to dial bogus IPPorts.
 	Signature []byte
+	// The peer's claimed IP and port in the other IP family of [AddrPort].
+	// Only populated if the peer is dual-stack.
+	SecondaryAddrPort netip.AddrPort
+	// [Cert]'s signature over both IPPorts and the timestamp. Only populated
+	// if [SecondaryAddrPort] is.
+	SecondarySignature []byte
 	// NodeID derived from the peer certificate.
 	NodeID ids.NodeID
 	// GossipID derived from the nodeID and timestamp.
@@ -64,5 +70,9 @@ func NewClaimedIPPort(
 
 // Returns the approximate size of the binary representation of this ClaimedIPPort.
 func (i *ClaimedIPPort) Size() int {
-	return baseIPCertDescLen + len(i.Cert.Raw) + len(i.Signature)
+	size := baseIPCertDescLen + len(i.Cert.Raw) + len(i.Signature)
+	if i.SecondaryAddrPort.IsValid() {
+		size += net.IPv6len + wrappers.ShortLen + len(i.SecondarySignature)
+	}
+	return size
 }
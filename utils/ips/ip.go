@@ -11,6 +11,13 @@ func IsPublic(addr netip.Addr) bool {
 	return addr.IsGlobalUnicast() && !addr.IsPrivate()
 }
 
+// IsSameFamily returns true if both addresses are IPv4 addresses or both
+// addresses are IPv6 addresses. IPv4 addresses in IPv6 addresses are treated as
+// IPv4 addresses.
+func IsSameFamily(a, b netip.Addr) bool {
+	return a.Unmap().Is4() == b.Unmap().Is4()
+}
+
 // ParseAddr returns the IP address from the provided string. If the string
 // represents an IPv4 address in an IPv6 address, the IPv4 address is returned.
 func ParseAddr(s string) (netip.Addr, error) {
@@ -20,4 +20,7 @@ type Config struct {
 	LogFormat               Format `json:"logFormat"`
 	MsgPrefix               string `json:"-"`
 	LoggerName              string `json:"-"`
+	// If non-nil, logs at or above [LogLevel] are also exported to
+	// [Exporter].
+	Exporter LogExporter `json:"-"`
 }
@@ -57,15 +57,22 @@ type factory struct {
 	// For each logger created by this factory:
 	// Logger name --> the logger.
 	loggers map[string]logWrapper
+
+	// Exports the logs of all loggers. Nil if [config.Exporter] is nil.
+	otlpBatcher *otlpBatcher
 }
 
 // NewFactory returns a new instance of a Factory producing loggers configured with
 // the values set in the [config] parameter
 func NewFactory(config Config) Factory {
-	return &factory{
+	f := &factory{
 		config:  config,
 		loggers: make(map[string]logWrapper),
 	}
+	if config.Exporter != nil {
+		f.otlpBatcher = newOTLPBatcher(config.Exporter)
+	}
+	return f
 }
 
 // Assumes [f.lock] is held
@@ -89,7 +96,13 @@ func (f *factory) makeLogger(config Config) (Logger, error) {
 	fileCore := NewWrappedCore(config.LogLevel, rw, fileEnc)
 	prefix := config.LogFormat.WrapPrefix(config.MsgPrefix)
 
-	l := NewLogger(prefix, consoleCore, fileCore)
+	cores := []WrappedCore{consoleCore, fileCore}
+	if f.otlpBatcher != nil {
+		// The exported logs share the level of the log file
+		cores = append(cores, newOTLPWrappedCore(fileCore.AtomicLevel, f.otlpBatcher))
+	}
+
+	l := NewLogger(prefix, cores...)
 	f.loggers[config.LoggerName] = logWrapper{
 		logger:       l,
 		displayLevel: consoleCore.AtomicLevel,
@@ -178,4 +191,8 @@ func (f *factory) Close() {
 		lw.logger.Stop()
 	}
 	f.loggers = nil
+
+	if f.otlpBatcher != nil {
+		f.otlpBatcher.Close()
+	}
 }
@@ -0,0 +1,339 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/exp/maps"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+const (
+	traceIDKey = "traceID"
+	spanIDKey  = "spanID"
+
+	otlpMaxBatchSize   = 512
+	otlpMaxPending     = 8192
+	otlpFlushFrequency = time.Second
+	otlpExportTimeout  = 10 * time.Second
+)
+
+var (
+	_ zapcore.Core            = (*otlpCore)(nil)
+	_ zapcore.ObjectMarshaler = spanContext{}
+)
+
+// LogExporter exports log records to an OpenTelemetry collector.
+type LogExporter interface {
+	ExportLogs(ctx context.Context, records []*logspb.LogRecord) error
+}
+
+// SpanContext returns a field that correlates a log with the span in [ctx].
+// If [ctx] doesn't contain a span, the field is skipped.
+func SpanContext(ctx context.Context) zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return zap.Skip()
+	}
+	return zap.Inline(spanContext{sc: sc})
+}
+
+type spanContext struct {
+	sc trace.SpanContext
+}
+
+func (s spanContext) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString(traceIDKey, s.sc.TraceID().String())
+	enc.AddString(spanIDKey, s.sc.SpanID().String())
+	return nil
+}
+
+// otlpBatcher batches log records and periodically exports them.
+//
+// If records are logged faster than they can be exported, records are
+// dropped.
+type otlpBatcher struct {
+	exporter  LogExporter
+	records   chan *logspb.LogRecord
+	closing   chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newOTLPBatcher(exporter LogExporter) *otlpBatcher {
+	b := &otlpBatcher{
+		exporter: exporter,
+		records:  make(chan *logspb.LogRecord, otlpMaxPending),
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+func (b *otlpBatcher) add(record *logspb.LogRecord) {
+	select {
+	case b.records <- record:
+	case <-b.closing:
+	default:
+	}
+}
+
+func (b *otlpBatcher) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(otlpFlushFrequency)
+	defer ticker.Stop()
+
+	batch := make([]*logspb.LogRecord, 0, otlpMaxBatchSize)
+	for {
+		select {
+		case record := <-b.records:
+			batch = append(batch, record)
+			if len(batch) >= otlpMaxBatchSize {
+				b.export(batch)
+				batch = make([]*logspb.LogRecord, 0, otlpMaxBatchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				b.export(batch)
+				batch = make([]*logspb.LogRecord, 0, otlpMaxBatchSize)
+			}
+		case <-b.closing:
+			// Flush the remaining records
+			for {
+				select {
+				case record := <-b.records:
+					batch = append(batch, record)
+				default:
+					if len(batch) > 0 {
+						b.export(batch)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+func (b *otlpBatcher) export(batch []*logspb.LogRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+	defer cancel()
+
+	// Failures can't be logged, as doing so would generate more records to
+	// export.
+	_ = b.exporter.ExportLogs(ctx, batch)
+}
+
+// Close flushes the pending records and stops the batcher.
+func (b *otlpBatcher) Close() {
+	b.closeOnce.Do(func() {
+		close(b.closing)
+	})
+	<-b.done
+}
+
+// otlpCore is a zapcore.Core that exports log entries as OTLP log records.
+type otlpCore struct {
+	zapcore.LevelEnabler
+
+	batcher *otlpBatcher
+	fields  []zapcore.Field
+}
+
+func (c *otlpCore) With(fields []zapcore.Field) zapcore.Core {
+	return &otlpCore{
+		LevelEnabler: c.LevelEnabler,
+		batcher:      c.batcher,
+		fields:       append(slices.Clip(c.fields), fields...),
+	}
+}
+
+func (c *otlpCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *otlpCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	record := newOTLPRecord(entry.Time, zapcore.Level(entry.Level), entry.Message)
+	if traceID, ok := enc.Fields[traceIDKey].(string); ok {
+		record.TraceId, _ = hex.DecodeString(traceID)
+		delete(enc.Fields, traceIDKey)
+	}
+	if spanID, ok := enc.Fields[spanIDKey].(string); ok {
+		record.SpanId, _ = hex.DecodeString(spanID)
+		delete(enc.Fields, spanIDKey)
+	}
+	if entry.LoggerName != "" {
+		record.Attributes = append(record.Attributes, otlpKeyValue("logger", entry.LoggerName))
+	}
+	if entry.Caller.Defined {
+		record.Attributes = append(record.Attributes, otlpKeyValue("caller", entry.Caller.TrimmedPath()))
+	}
+	keys := maps.Keys(enc.Fields)
+	slices.Sort(keys)
+	for _, key := range keys {
+		record.Attributes = append(record.Attributes, otlpKeyValue(key, enc.Fields[key]))
+	}
+
+	c.batcher.add(record)
+	return nil
+}
+
+func (*otlpCore) Sync() error {
+	return nil
+}
+
+// otlpWriter exports raw writes, such as the ones from the standard library
+// logger, as OTLP log records.
+type otlpWriter struct {
+	batcher *otlpBatcher
+}
+
+func (w otlpWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	w.batcher.add(newOTLPRecord(time.Now(), zapcore.Level(Info), msg))
+	return len(p), nil
+}
+
+// Close is a noop, as the batcher is shared by all the loggers of a factory
+// and is closed by the factory.
+func (otlpWriter) Close() error {
+	return nil
+}
+
+// newOTLPWrappedCore returns a core that exports log entries through
+// [batcher] at [level].
+func newOTLPWrappedCore(level zap.AtomicLevel, batcher *otlpBatcher) WrappedCore {
+	return WrappedCore{
+		Core: &otlpCore{
+			LevelEnabler: level,
+			batcher:      batcher,
+		},
+		Writer:      otlpWriter{batcher: batcher},
+		AtomicLevel: level,
+	}
+}
+
+func newOTLPRecord(t time.Time, level zapcore.Level, msg string) *logspb.LogRecord {
+	timestamp := uint64(t.UnixNano())
+	return &logspb.LogRecord{
+		TimeUnixNano:         timestamp,
+		ObservedTimeUnixNano: timestamp,
+		SeverityNumber:       otlpSeverity(Level(level)),
+		SeverityText:         Level(level).String(),
+		Body: &commonpb.AnyValue{
+			Value: &commonpb.AnyValue_StringValue{
+				StringValue: msg,
+			},
+		},
+	}
+}
+
+func otlpSeverity(level Level) logspb.SeverityNumber {
+	switch level {
+	case Verbo:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_TRACE
+	case Debug:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG
+	case Trace:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG2
+	case Info:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_INFO
+	case Warn:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_WARN
+	case Error:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_ERROR
+	case Fatal:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_FATAL
+	default:
+		return logspb.SeverityNumber_SEVERITY_NUMBER_UNSPECIFIED
+	}
+}
+
+func otlpKeyValue(key string, value interface{}) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: otlpAnyValue(value),
+	}
+}
+
+// otlpAnyValue converts a value produced by a zapcore.MapObjectEncoder into an
+// OTLP value.
+func otlpAnyValue(value interface{}) *commonpb.AnyValue {
+	switch v := value.(type) {
+	case string:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+	case bool:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+	case int:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case int8:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case int16:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case int32:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case int64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v}}
+	case uint8:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case uint16:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case uint32:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v)}}
+	case float32:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: float64(v)}}
+	case float64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v}}
+	case []byte:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BytesValue{BytesValue: v}}
+	case time.Time:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.Format(time.RFC3339Nano)}}
+	case []interface{}:
+		values := make([]*commonpb.AnyValue, len(v))
+		for i, elem := range v {
+			values[i] = otlpAnyValue(elem)
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{
+			ArrayValue: &commonpb.ArrayValue{Values: values},
+		}}
+	case map[string]interface{}:
+		keys := maps.Keys(v)
+		slices.Sort(keys)
+		values := make([]*commonpb.KeyValue, len(keys))
+		for i, key := range keys {
+			values[i] = otlpKeyValue(key, v[key])
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{
+			KvlistValue: &commonpb.KeyValueList{Values: values},
+		}}
+	default:
+		// Unsigned integers that may not fit in an int64, durations and other
+		// values are exported as strings.
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: fmt.Sprint(v)}}
+	}
+}
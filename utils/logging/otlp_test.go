@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+type testLogExporter struct {
+	lock    sync.Mutex
+	records []*logspb.LogRecord
+}
+
+func (e *testLogExporter) ExportLogs(_ context.Context, records []*logspb.LogRecord) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.records = append(e.records, records...)
+	return nil
+}
+
+func TestOTLPExport(t *testing.T) {
+	require := require.New(t)
+
+	exporter := &testLogExporter{}
+	factory := NewFactory(Config{
+		RotatingWriterConfig: RotatingWriterConfig{
+			Directory: t.TempDir(),
+		},
+		DisableWriterDisplaying: true,
+		LogLevel:                Info,
+		DisplayLevel:            Off,
+		Exporter:                exporter,
+	})
+	log, err := factory.Make("test")
+	require.NoError(err)
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{2},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	log.Debug("not exported")
+	log.Warn("exported",
+		zap.Int("int", 1),
+		zap.Error(errors.New("oops")),
+		SpanContext(ctx),
+	)
+	log.Info("no span",
+		SpanContext(context.Background()),
+	)
+
+	// Closing the factory flushes the pending records
+	factory.Close()
+
+	require.Len(exporter.records, 2)
+
+	record := exporter.records[0]
+	require.Equal(logspb.SeverityNumber_SEVERITY_NUMBER_WARN, record.SeverityNumber)
+	require.Equal(Warn.String(), record.SeverityText)
+	require.Equal("exported", record.Body.GetStringValue())
+	require.Equal(spanContext.TraceID().String(), trace.TraceID(record.TraceId).String())
+	require.Equal(spanContext.SpanID().String(), trace.SpanID(record.SpanId).String())
+
+	attributes := make(map[string]*commonpb.AnyValue)
+	for _, attribute := range record.Attributes {
+		attributes[attribute.Key] = attribute.Value
+	}
+	require.Equal(int64(1), attributes["int"].GetIntValue())
+	require.Equal("oops", attributes["error"].GetStringValue())
+	require.Contains(attributes, "caller")
+	require.NotContains(attributes, traceIDKey)
+	require.NotContains(attributes, spanIDKey)
+
+	record = exporter.records[1]
+	require.Equal(logspb.SeverityNumber_SEVERITY_NUMBER_INFO, record.SeverityNumber)
+	require.Empty(record.TraceId)
+	require.Empty(record.SpanId)
+}
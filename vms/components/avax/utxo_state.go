@@ -143,6 +143,13 @@ func NewMeteredUTXOState(
 	return s, s.initChecksum()
 }
 
+// NewUTXOIterator returns an iterator over the serialized UTXOs stored by a
+// UTXOState that was created with [db]. The keys of the iterator are the UTXO
+// IDs.
+func NewUTXOIterator(db database.Database) database.Iterator {
+	return prefixdb.New(utxoPrefix, db).NewIterator()
+}
+
 func (s *utxoState) GetUTXO(utxoID ids.ID) (*UTXO, error) {
 	if utxo, found := s.utxoCache.Get(utxoID); found {
 		if utxo == nil {
@@ -91,4 +91,12 @@ func TestUTXOState(t *testing.T) {
 	utxoIDs, err = s.UTXOIDs(addr[:], ids.Empty, 5)
 	require.NoError(err)
 	require.Equal([]ids.ID{utxoID}, utxoIDs)
+
+	it := NewUTXOIterator(db)
+	defer it.Release()
+
+	require.True(it.Next())
+	require.Equal(utxoID[:], it.Key())
+	require.False(it.Next())
+	require.NoError(it.Error())
 }
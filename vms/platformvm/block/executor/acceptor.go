@@ -30,6 +30,9 @@ type acceptor struct {
 	metrics      metrics.Metrics
 	validators   validators.Manager
 	bootstrapped *utils.Atomic[bool]
+	// onCommit, if non-nil, is called after the state of an accepted decision
+	// block has been written to the database.
+	onCommit func(block.Block)
 }
 
 func (a *acceptor) BanffAbortBlock(b *block.BanffAbortBlock) error {
@@ -104,6 +107,8 @@ func (a *acceptor) ApricotAtomicBlock(b *block.ApricotAtomicBlock) error {
 		)
 	}
 
+	a.commit(b)
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", "apricot atomic"),
@@ -173,6 +178,8 @@ func (a *acceptor) optionBlock(b block.Block, blockType string) error {
 		onAcceptFunc()
 	}
 
+	a.commit(b)
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", blockType),
@@ -252,6 +259,8 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 		onAcceptFunc()
 	}
 
+	a.commit(b)
+
 	a.ctx.Log.Trace(
 		"accepted block",
 		zap.String("blockType", blockType),
@@ -264,6 +273,14 @@ func (a *acceptor) standardBlock(b block.Block, blockType string) error {
 	return nil
 }
 
+// commit notifies [onCommit] that the state of [b] has been written to the
+// database.
+func (a *acceptor) commit(b block.Block) {
+	if a.onCommit != nil {
+		a.onCommit(b)
+	}
+}
+
 func (a *acceptor) commonAccept(b block.Block) error {
 	blkID := b.ID()
 
@@ -29,18 +29,21 @@ func (*Block) ShouldVerifyWithContext(context.Context) (bool, error) {
 	return true, nil
 }
 
-func (b *Block) VerifyWithContext(_ context.Context, ctx *smblock.Context) error {
+func (b *Block) VerifyWithContext(ctx context.Context, blockContext *smblock.Context) error {
 	pChainHeight := uint64(0)
-	if ctx != nil {
-		pChainHeight = ctx.PChainHeight
+	if blockContext != nil {
+		pChainHeight = blockContext.PChainHeight
 	}
 
 	blkID := b.ID()
 	if blkState, ok := b.manager.blkIDToState[blkID]; ok {
 		if !blkState.verifiedHeights.Contains(pChainHeight) {
-			// PlatformVM blocks are currently valid regardless of the ProposerVM's
-			// PChainHeight. If this changes, those validity checks should be done prior
-			// to adding [pChainHeight] to [verifiedHeights].
+			// Only the warp messages included in PlatformVM blocks depend on
+			// the ProposerVM's PChainHeight. All other checks have already
+			// been performed.
+			if err := b.verifyWarpMessages(ctx, pChainHeight); err != nil {
+				return err
+			}
 			blkState.verifiedHeights.Add(pChainHeight)
 		}
 
@@ -48,6 +51,10 @@ func (b *Block) VerifyWithContext(_ context.Context, ctx *smblock.Context) error
 		return nil
 	}
 
+	if err := b.verifyWarpMessages(ctx, pChainHeight); err != nil {
+		return err
+	}
+
 	return b.Visit(&verifier{
 		backend:           b.manager.backend,
 		txExecutorBackend: b.manager.txExecutorBackend,
@@ -55,6 +62,16 @@ func (b *Block) VerifyWithContext(_ context.Context, ctx *smblock.Context) error
 	})
 }
 
+func (b *Block) verifyWarpMessages(ctx context.Context, pChainHeight uint64) error {
+	return VerifyWarpMessages(
+		ctx,
+		b.manager.ctx.NetworkID,
+		b.manager.validators,
+		pChainHeight,
+		b,
+	)
+}
+
 func (b *Block) Verify(ctx context.Context) error {
 	return b.VerifyWithContext(ctx, nil)
 }
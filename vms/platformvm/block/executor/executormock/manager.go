@@ -131,6 +131,18 @@ func (mr *ManagerMockRecorder) Preferred() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preferred", reflect.TypeOf((*Manager)(nil).Preferred))
 }
 
+// SetLastAccepted mocks base method.
+func (m *Manager) SetLastAccepted(blkID ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLastAccepted", blkID)
+}
+
+// SetLastAccepted indicates an expected call of SetLastAccepted.
+func (mr *ManagerMockRecorder) SetLastAccepted(blkID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAccepted", reflect.TypeOf((*Manager)(nil).SetLastAccepted), blkID)
+}
+
 // SetPreference mocks base method.
 func (m *Manager) SetPreference(blkID ids.ID) bool {
 	m.ctrl.T.Helper()
@@ -166,6 +166,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller, f upgradetest.Fork) *
 			res.state,
 			res.backend,
 			validatorstest.Manager,
+			nil,
 		)
 		addSubnet(t, res)
 	} else {
@@ -175,6 +176,7 @@ func newEnvironment(t *testing.T, ctrl *gomock.Controller, f upgradetest.Fork) *
 			res.mockedState,
 			res.backend,
 			validatorstest.Manager,
+			nil,
 		)
 		// we do not add any subnet to state, since we can mock
 		// whatever we need
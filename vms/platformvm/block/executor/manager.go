@@ -4,6 +4,7 @@
 package executor
 
 import (
+	"context"
 	"errors"
 
 	"github.com/MetalBlockchain/metalgo/ids"
@@ -30,6 +31,10 @@ type Manager interface {
 	// Returns the ID of the most recently accepted block.
 	LastAccepted() ids.ID
 
+	// SetLastAccepted marks [blkID] as the last accepted and preferred block.
+	// It is used after the state has been replaced by state sync.
+	SetLastAccepted(blkID ids.ID)
+
 	SetPreference(blkID ids.ID) (updated bool)
 	Preferred() ids.ID
 
@@ -52,6 +57,7 @@ func NewManager(
 	s state.State,
 	txExecutorBackend *executor.Backend,
 	validatorManager validators.Manager,
+	onCommit func(block.Block),
 ) Manager {
 	lastAccepted := s.GetLastAccepted()
 	backend := &backend{
@@ -69,6 +75,7 @@ func NewManager(
 			metrics:      metrics,
 			validators:   validatorManager,
 			bootstrapped: txExecutorBackend.Bootstrapped,
+			onCommit:     onCommit,
 		},
 		rejector: &rejector{
 			backend:         backend,
@@ -76,6 +83,7 @@ func NewManager(
 		},
 		preferred:         lastAccepted,
 		txExecutorBackend: txExecutorBackend,
+		validators:        validatorManager,
 	}
 }
 
@@ -86,6 +94,7 @@ type manager struct {
 
 	preferred         ids.ID
 	txExecutorBackend *executor.Backend
+	validators        validators.Manager
 }
 
 func (m *manager) GetBlock(blkID ids.ID) (snowman.Block, error) {
@@ -107,6 +116,11 @@ func (m *manager) NewBlock(blk block.Block) snowman.Block {
 	}
 }
 
+func (m *manager) SetLastAccepted(blkID ids.ID) {
+	m.backend.lastAccepted = blkID
+	m.preferred = blkID
+}
+
 func (m *manager) SetPreference(blkID ids.ID) bool {
 	updated := m.preferred != blkID
 	m.preferred = blkID
@@ -127,7 +141,26 @@ func (m *manager) VerifyTx(tx *txs.Tx) error {
 		return err
 	}
 
-	nextBlkTime, _, err := state.NextBlockTime(stateDiff, m.txExecutorBackend.Clk)
+	recommendedPChainHeight, err := m.validators.GetMinimumHeight(context.TODO())
+	if err != nil {
+		return err
+	}
+	err = executor.VerifyWarpMessages(
+		context.TODO(),
+		m.ctx.NetworkID,
+		m.validators,
+		recommendedPChainHeight,
+		tx.Unsigned,
+	)
+	if err != nil {
+		return err
+	}
+
+	nextBlkTime, _, err := state.NextBlockTime(
+		m.txExecutorBackend.Config.ValidatorFeeConfig,
+		stateDiff,
+		m.txExecutorBackend.Clk,
+	)
 	if err != nil {
 		return err
 	}
@@ -300,6 +300,7 @@ func (v *verifier) banffNonOptionBlock(b block.BanffBlock) error {
 	newChainTime := b.Timestamp()
 	now := v.txExecutorBackend.Clk.Time()
 	return executor.VerifyNewChainTime(
+		v.txExecutorBackend.Config.ValidatorFeeConfig,
 		newChainTime,
 		now,
 		parentState,
@@ -104,6 +104,7 @@ func TestVerifierVisitProposalBlock(t *testing.T) {
 	parentOnAcceptState.EXPECT().GetTimestamp().Return(timestamp).Times(2)
 	parentOnAcceptState.EXPECT().GetFeeState().Return(gas.State{}).Times(2)
 	parentOnAcceptState.EXPECT().GetAccruedFees().Return(uint64(0)).Times(2)
+	parentOnAcceptState.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(2)
 
 	backend := &backend{
 		lastAccepted: parentID,
@@ -130,6 +131,8 @@ func TestVerifierVisitProposalBlock(t *testing.T) {
 	}
 
 	blkTx := txsmock.NewUnsignedTx(ctrl)
+	// Visited by the warp verifier.
+	blkTx.EXPECT().Visit(gomock.Any()).Return(nil).Times(1)
 	blkTx.EXPECT().Visit(gomock.AssignableToTypeOf(&executor.ProposalTxExecutor{})).Return(nil).Times(1)
 
 	// We can't serialize [blkTx] because it isn't
@@ -212,6 +215,8 @@ func TestVerifierVisitAtomicBlock(t *testing.T) {
 	onAccept := state.NewMockDiff(ctrl)
 	blkTx := txsmock.NewUnsignedTx(ctrl)
 	inputs := set.Of(ids.GenerateTestID())
+	// Visited by the warp verifier.
+	blkTx.EXPECT().Visit(gomock.Any()).Return(nil).Times(1)
 	blkTx.EXPECT().Visit(gomock.AssignableToTypeOf(&executor.AtomicTxExecutor{})).DoAndReturn(
 		func(e *executor.AtomicTxExecutor) error {
 			e.OnAccept = onAccept
@@ -304,6 +309,8 @@ func TestVerifierVisitStandardBlock(t *testing.T) {
 			},
 		},
 	}
+	// Visited by the warp verifier.
+	blkTx.EXPECT().Visit(gomock.Any()).Return(nil).Times(1)
 	blkTx.EXPECT().Visit(gomock.AssignableToTypeOf(&executor.StandardTxExecutor{})).DoAndReturn(
 		func(e *executor.StandardTxExecutor) error {
 			e.OnAccept = func() {}
@@ -336,6 +343,7 @@ func TestVerifierVisitStandardBlock(t *testing.T) {
 	parentState.EXPECT().GetTimestamp().Return(timestamp).Times(1)
 	parentState.EXPECT().GetFeeState().Return(gas.State{}).Times(1)
 	parentState.EXPECT().GetAccruedFees().Return(uint64(0)).Times(1)
+	parentState.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(1)
 	parentStatelessBlk.EXPECT().Height().Return(uint64(1)).Times(1)
 	mempool.EXPECT().Remove(apricotBlk.Txs()).Times(1)
 
@@ -598,6 +606,7 @@ func TestBanffAbortBlockTimestampChecks(t *testing.T) {
 			s.EXPECT().GetTimestamp().Return(parentTime).Times(3)
 			s.EXPECT().GetFeeState().Return(gas.State{}).Times(3)
 			s.EXPECT().GetAccruedFees().Return(uint64(0)).Times(3)
+			s.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(3)
 
 			onDecisionState, err := state.NewDiff(parentID, backend)
 			require.NoError(err)
@@ -696,6 +705,7 @@ func TestBanffCommitBlockTimestampChecks(t *testing.T) {
 			s.EXPECT().GetTimestamp().Return(parentTime).Times(3)
 			s.EXPECT().GetFeeState().Return(gas.State{}).Times(3)
 			s.EXPECT().GetAccruedFees().Return(uint64(0)).Times(3)
+			s.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(3)
 
 			onDecisionState, err := state.NewDiff(parentID, backend)
 			require.NoError(err)
@@ -812,6 +822,7 @@ func TestVerifierVisitStandardBlockWithDuplicateInputs(t *testing.T) {
 	parentState.EXPECT().GetTimestamp().Return(timestamp).Times(1)
 	parentState.EXPECT().GetFeeState().Return(gas.State{}).Times(1)
 	parentState.EXPECT().GetAccruedFees().Return(uint64(0)).Times(1)
+	parentState.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(1)
 	parentStatelessBlk.EXPECT().Parent().Return(grandParentID).Times(1)
 
 	err = verifier.ApricotStandardBlock(blk)
@@ -1157,7 +1168,11 @@ func TestBlockExecutionWithComplexity(t *testing.T) {
 			clear(verifier.blkIDToState)
 
 			verifier.txExecutorBackend.Clk.Set(test.timestamp)
-			timestamp, _, err := state.NextBlockTime(s, verifier.txExecutorBackend.Clk)
+			timestamp, _, err := state.NextBlockTime(
+				verifier.txExecutorBackend.Config.ValidatorFeeConfig,
+				s,
+				verifier.txExecutorBackend.Clk,
+			)
 			require.NoError(err)
 
 			lastAcceptedID := s.GetLastAccepted()
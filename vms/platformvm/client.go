@@ -20,6 +20,8 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/fx"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/status"
 	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
+
+	validatorfee "github.com/MetalBlockchain/metalgo/vms/platformvm/validators/fee"
 )
 
 var _ Client = (*client)(nil)
@@ -71,6 +73,19 @@ type Client interface {
 	GetStakingAssetID(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (ids.ID, error)
 	// GetCurrentValidators returns the list of current validators for subnet with ID [subnetID]
 	GetCurrentValidators(ctx context.Context, subnetID ids.ID, nodeIDs []ids.NodeID, options ...rpc.Option) ([]ClientPermissionlessValidator, error)
+	// GetL1Validator returns the L1 validator with [validationID] along with
+	// the P-chain height
+	GetL1Validator(ctx context.Context, validationID ids.ID, options ...rpc.Option) (ClientL1Validator, uint64, error)
+	// GetL1Validators returns up to [limit] L1 validators of [subnetID] with a
+	// validationID greater than [startValidationID]. The validationID to
+	// continue paginating from is returned along with the P-chain height
+	GetL1Validators(
+		ctx context.Context,
+		subnetID ids.ID,
+		startValidationID ids.ID,
+		limit uint32,
+		options ...rpc.Option,
+	) ([]ClientL1Validator, ids.ID, uint64, error)
 	// GetCurrentSupply returns an upper bound on the supply of AVAX in the system along with the P-chain height
 	GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
@@ -128,6 +143,10 @@ type Client interface {
 	GetFeeConfig(ctx context.Context, options ...rpc.Option) (*gas.Config, error)
 	// GetFeeState returns the current fee state of the chain.
 	GetFeeState(ctx context.Context, options ...rpc.Option) (gas.State, gas.Price, time.Time, error)
+	// GetValidatorFeeState returns the current state of the continuous
+	// subnet-only validator fee along with the current price and the total
+	// fees accrued per validator.
+	GetValidatorFeeState(ctx context.Context, options ...rpc.Option) (validatorfee.State, gas.Price, uint64, error)
 }
 
 // Client implementation for interacting with the P Chain endpoint
@@ -324,6 +343,45 @@ func (c *client) GetCurrentValidators(
 	return getClientPermissionlessValidators(res.Validators)
 }
 
+func (c *client) GetL1Validator(ctx context.Context, validationID ids.ID, options ...rpc.Option) (ClientL1Validator, uint64, error) {
+	res := &GetL1ValidatorReply{}
+	err := c.requester.SendRequest(ctx, "platform.getL1Validator", &GetL1ValidatorArgs{
+		ValidationID: validationID,
+	}, res, options...)
+	if err != nil {
+		return ClientL1Validator{}, 0, err
+	}
+	validator, err := apiL1ValidatorToClientL1Validator(res.APIL1Validator)
+	return validator, uint64(res.Height), err
+}
+
+func (c *client) GetL1Validators(
+	ctx context.Context,
+	subnetID ids.ID,
+	startValidationID ids.ID,
+	limit uint32,
+	options ...rpc.Option,
+) ([]ClientL1Validator, ids.ID, uint64, error) {
+	res := &GetL1ValidatorsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getL1Validators", &GetL1ValidatorsArgs{
+		SubnetID:          subnetID,
+		StartValidationID: startValidationID,
+		Limit:             json.Uint32(limit),
+	}, res, options...)
+	if err != nil {
+		return nil, ids.Empty, 0, err
+	}
+
+	validators := make([]ClientL1Validator, len(res.Validators))
+	for i, apiValidator := range res.Validators {
+		validators[i], err = apiL1ValidatorToClientL1Validator(apiValidator)
+		if err != nil {
+			return nil, ids.Empty, 0, err
+		}
+	}
+	return validators, res.EndValidationID, uint64(res.Height), nil
+}
+
 func (c *client) GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error) {
 	res := &GetCurrentSupplyReply{}
 	err := c.requester.SendRequest(ctx, "platform.getCurrentSupply", &GetCurrentSupplyArgs{
@@ -539,6 +597,12 @@ func (c *client) GetFeeState(ctx context.Context, options ...rpc.Option) (gas.St
 	return res.State, res.Price, res.Time, err
 }
 
+func (c *client) GetValidatorFeeState(ctx context.Context, options ...rpc.Option) (validatorfee.State, gas.Price, uint64, error) {
+	res := &GetFeeStateReply{}
+	err := c.requester.SendRequest(ctx, "platform.getFeeState", struct{}{}, res, options...)
+	return res.Validator.State, res.Validator.Price, uint64(res.Validator.AccruedFees), err
+}
+
 func AwaitTxAccepted(
 	c Client,
 	ctx context.Context,
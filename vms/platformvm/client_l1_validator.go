@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
+)
+
+// ClientL1Validator is the repr. of an L1 validator sent over client
+type ClientL1Validator struct {
+	// the validationID of this L1 validator
+	ValidationID ids.ID
+	// the subnet this L1 validator is validating
+	SubnetID ids.ID
+	// the node ID of the L1 validator
+	NodeID ids.NodeID
+	// the BLS public key of the L1 validator
+	PublicKey *bls.PublicKey
+	// the owner of the balance that is refunded when this validator is removed
+	RemainingBalanceOwner *ClientOwner
+	// the owner that can manually deactivate this validator
+	DeactivationOwner *ClientOwner
+	// the Unix time when this validator was added
+	StartTime uint64
+	// the validator weight when sampling validators
+	Weight uint64
+	// the smallest nonce that can be used to modify this validator's weight
+	MinNonce uint64
+	// the remaining balance available to pay the continuous fee
+	Balance uint64
+	// true if this validator is currently active
+	Active bool
+}
+
+func apiL1ValidatorToClientL1Validator(validator APIL1Validator) (ClientL1Validator, error) {
+	publicKey, err := bls.PublicKeyFromCompressedBytes(validator.PublicKey)
+	if err != nil {
+		return ClientL1Validator{}, err
+	}
+
+	remainingBalanceOwner, err := apiOwnerToClientOwner(validator.RemainingBalanceOwner)
+	if err != nil {
+		return ClientL1Validator{}, err
+	}
+
+	deactivationOwner, err := apiOwnerToClientOwner(validator.DeactivationOwner)
+	if err != nil {
+		return ClientL1Validator{}, err
+	}
+
+	return ClientL1Validator{
+		ValidationID:          validator.ValidationID,
+		SubnetID:              validator.SubnetID,
+		NodeID:                validator.NodeID,
+		PublicKey:             publicKey,
+		RemainingBalanceOwner: remainingBalanceOwner,
+		DeactivationOwner:     deactivationOwner,
+		StartTime:             uint64(validator.StartTime),
+		Weight:                uint64(validator.Weight),
+		MinNonce:              uint64(validator.MinNonce),
+		Balance:               uint64(validator.Balance),
+		Active:                validator.Active,
+	}, nil
+}
@@ -12,34 +12,51 @@ import (
 )
 
 var DefaultExecutionConfig = ExecutionConfig{
-	Network:                      network.DefaultConfig,
-	BlockCacheSize:               64 * units.MiB,
-	TxCacheSize:                  128 * units.MiB,
-	TransformedSubnetTxCacheSize: 4 * units.MiB,
-	RewardUTXOsCacheSize:         2048,
-	ChainCacheSize:               2048,
-	ChainDBCacheSize:             2048,
-	BlockIDCacheSize:             8192,
-	FxOwnerCacheSize:             4 * units.MiB,
-	SubnetManagerCacheSize:       4 * units.MiB,
-	ChecksumsEnabled:             false,
-	MempoolPruneFrequency:        30 * time.Minute,
+	Network:                       network.DefaultConfig,
+	BlockCacheSize:                64 * units.MiB,
+	TxCacheSize:                   128 * units.MiB,
+	TransformedSubnetTxCacheSize:  4 * units.MiB,
+	RewardUTXOsCacheSize:          2048,
+	ChainCacheSize:                2048,
+	ChainDBCacheSize:              2048,
+	BlockIDCacheSize:              8192,
+	FxOwnerCacheSize:              4 * units.MiB,
+	SubnetManagerCacheSize:        4 * units.MiB,
+	L1WeightsCacheSize:            16 * units.KiB,
+	L1InactiveValidatorsCacheSize: 256 * units.KiB,
+	L1SubnetIDNodeIDCacheSize:     16 * units.KiB,
+	ChecksumsEnabled:              false,
+	MempoolPruneFrequency:         30 * time.Minute,
+	StateSyncEnabled:              false,
+	StateSyncSummaryFrequency:     0,
 }
 
 // ExecutionConfig provides execution parameters of PlatformVM
 type ExecutionConfig struct {
-	Network                      network.Config `json:"network"`
-	BlockCacheSize               int            `json:"block-cache-size"`
-	TxCacheSize                  int            `json:"tx-cache-size"`
-	TransformedSubnetTxCacheSize int            `json:"transformed-subnet-tx-cache-size"`
-	RewardUTXOsCacheSize         int            `json:"reward-utxos-cache-size"`
-	ChainCacheSize               int            `json:"chain-cache-size"`
-	ChainDBCacheSize             int            `json:"chain-db-cache-size"`
-	BlockIDCacheSize             int            `json:"block-id-cache-size"`
-	FxOwnerCacheSize             int            `json:"fx-owner-cache-size"`
-	SubnetManagerCacheSize       int            `json:"subnet-manager-cache-size"`
-	ChecksumsEnabled             bool           `json:"checksums-enabled"`
-	MempoolPruneFrequency        time.Duration  `json:"mempool-prune-frequency"`
+	Network                       network.Config `json:"network"`
+	BlockCacheSize                int            `json:"block-cache-size"`
+	TxCacheSize                   int            `json:"tx-cache-size"`
+	TransformedSubnetTxCacheSize  int            `json:"transformed-subnet-tx-cache-size"`
+	RewardUTXOsCacheSize          int            `json:"reward-utxos-cache-size"`
+	ChainCacheSize                int            `json:"chain-cache-size"`
+	ChainDBCacheSize              int            `json:"chain-db-cache-size"`
+	BlockIDCacheSize              int            `json:"block-id-cache-size"`
+	FxOwnerCacheSize              int            `json:"fx-owner-cache-size"`
+	SubnetManagerCacheSize        int            `json:"subnet-manager-cache-size"`
+	L1WeightsCacheSize            int            `json:"l1-weights-cache-size"`
+	L1InactiveValidatorsCacheSize int            `json:"l1-inactive-validators-cache-size"`
+	L1SubnetIDNodeIDCacheSize     int            `json:"l1-subnet-id-node-id-cache-size"`
+	ChecksumsEnabled              bool           `json:"checksums-enabled"`
+	MempoolPruneFrequency         time.Duration  `json:"mempool-prune-frequency"`
+	// StateSyncEnabled allows a node without any accepted blocks to sync the
+	// state of a recent block from its peers, rather than executing every
+	// block since genesis.
+	StateSyncEnabled bool `json:"state-sync-enabled"`
+	// StateSyncSummaryFrequency is the number of blocks between the state
+	// summaries that are served to syncing nodes. Creating a summary copies
+	// the syncable state, so summaries are only created by nodes that opt in
+	// to serving them. If 0, no summaries are created.
+	StateSyncSummaryFrequency uint64 `json:"state-sync-summary-frequency"`
 }
 
 // GetExecutionConfig returns an ExecutionConfig
@@ -81,17 +81,22 @@ func TestExecutionConfigUnmarshal(t *testing.T) {
 				ExpectedBloomFilterFalsePositiveProbability: 16,
 				MaxBloomFilterFalsePositiveProbability:      17,
 			},
-			BlockCacheSize:               1,
-			TxCacheSize:                  2,
-			TransformedSubnetTxCacheSize: 3,
-			RewardUTXOsCacheSize:         5,
-			ChainCacheSize:               6,
-			ChainDBCacheSize:             7,
-			BlockIDCacheSize:             8,
-			FxOwnerCacheSize:             9,
-			SubnetManagerCacheSize:       10,
-			ChecksumsEnabled:             true,
-			MempoolPruneFrequency:        time.Minute,
+			BlockCacheSize:                1,
+			TxCacheSize:                   2,
+			TransformedSubnetTxCacheSize:  3,
+			RewardUTXOsCacheSize:          5,
+			ChainCacheSize:                6,
+			ChainDBCacheSize:              7,
+			BlockIDCacheSize:              8,
+			FxOwnerCacheSize:              9,
+			SubnetManagerCacheSize:        10,
+			L1WeightsCacheSize:            11,
+			L1InactiveValidatorsCacheSize: 12,
+			L1SubnetIDNodeIDCacheSize:     13,
+			ChecksumsEnabled:              true,
+			MempoolPruneFrequency:         time.Minute,
+			StateSyncEnabled:              true,
+			StateSyncSummaryFrequency:     14,
 		}
 		verifyInitializedStruct(t, *expected)
 		verifyInitializedStruct(t, expected.Network)
@@ -37,12 +37,14 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/state"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/status"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp/message"
 	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
 	"github.com/MetalBlockchain/metalgo/vms/types"
 
 	avajson "github.com/MetalBlockchain/metalgo/utils/json"
 	safemath "github.com/MetalBlockchain/metalgo/utils/math"
 	platformapi "github.com/MetalBlockchain/metalgo/vms/platformvm/api"
+	validatorfee "github.com/MetalBlockchain/metalgo/vms/platformvm/validators/fee"
 )
 
 const (
@@ -966,6 +968,187 @@ func (s *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentValidato
 	return nil
 }
 
+// APIL1Validator is the representation of an L1 validator sent over APIs.
+type APIL1Validator struct {
+	ValidationID          ids.ID              `json:"validationID"`
+	SubnetID              ids.ID              `json:"subnetID"`
+	NodeID                ids.NodeID          `json:"nodeID"`
+	PublicKey             types.JSONByteSlice `json:"publicKey"`
+	RemainingBalanceOwner *platformapi.Owner  `json:"remainingBalanceOwner"`
+	DeactivationOwner     *platformapi.Owner  `json:"deactivationOwner"`
+	StartTime             avajson.Uint64      `json:"startTime"`
+	Weight                avajson.Uint64      `json:"weight"`
+	MinNonce              avajson.Uint64      `json:"minNonce"`
+	// Balance is the remaining amount of nAVAX this L1 validator has for
+	// paying the continuous fee, according to the last accepted state. If the
+	// validator is inactive, the balance will be 0.
+	Balance avajson.Uint64 `json:"balance"`
+	// Active is true if the validator is currently paying the continuous fee
+	// and participating in the L1's validator set.
+	Active bool `json:"active"`
+}
+
+// GetL1ValidatorArgs are the arguments for calling GetL1Validator
+type GetL1ValidatorArgs struct {
+	ValidationID ids.ID `json:"validationID"`
+}
+
+// GetL1ValidatorReply is the response from calling GetL1Validator
+type GetL1ValidatorReply struct {
+	APIL1Validator
+	// Height is the height of the last accepted block
+	Height avajson.Uint64 `json:"height"`
+}
+
+// GetL1Validator returns the L1 validator with the provided validationID.
+func (s *Service) GetL1Validator(r *http.Request, args *GetL1ValidatorArgs, reply *GetL1ValidatorReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getL1Validator"),
+		zap.Stringer("validationID", args.ValidationID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	sov, err := s.vm.state.GetSubnetOnlyValidator(args.ValidationID)
+	if err != nil {
+		return fmt.Errorf("fetching L1 validator %q failed: %w", args.ValidationID, err)
+	}
+
+	reply.APIL1Validator, err = s.getAPIL1Validator(sov)
+	if err != nil {
+		return err
+	}
+
+	height, err := s.vm.GetCurrentHeight(r.Context())
+	if err != nil {
+		return fmt.Errorf("fetching current height failed: %w", err)
+	}
+	reply.Height = avajson.Uint64(height)
+	return nil
+}
+
+// GetL1ValidatorsArgs are the arguments for calling GetL1Validators
+type GetL1ValidatorsArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+	// StartValidationID is the validationID to start after. If empty, the
+	// validators are returned starting from the beginning.
+	StartValidationID ids.ID `json:"startValidationID"`
+	// Limit is the maximum number of validators to return. If 0 or larger
+	// than the maximum page size, the maximum page size is used.
+	Limit avajson.Uint32 `json:"limit"`
+}
+
+// GetL1ValidatorsReply is the response from calling GetL1Validators
+type GetL1ValidatorsReply struct {
+	Validators []APIL1Validator `json:"validators"`
+	// EndValidationID is the last validationID that was returned. It should be
+	// provided as the StartValidationID of the next request to fetch the next
+	// page.
+	EndValidationID ids.ID `json:"endValidationID"`
+	// Height is the height of the last accepted block
+	Height avajson.Uint64 `json:"height"`
+}
+
+// GetL1Validators returns a page of the L1 validators of the provided subnet,
+// both active and inactive, in increasing order of validationID.
+func (s *Service) GetL1Validators(r *http.Request, args *GetL1ValidatorsArgs, reply *GetL1ValidatorsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getL1Validators"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	if args.SubnetID == constants.PrimaryNetworkID {
+		return errPrimaryNetworkIsNotASubnet
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || maxPageSize < limit {
+		limit = maxPageSize
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	validationIDs, err := s.vm.state.SubnetOnlyValidatorIDs(args.SubnetID, args.StartValidationID, limit)
+	if err != nil {
+		return fmt.Errorf("fetching L1 validators of %q failed: %w", args.SubnetID, err)
+	}
+
+	reply.Validators = make([]APIL1Validator, len(validationIDs))
+	for i, validationID := range validationIDs {
+		sov, err := s.vm.state.GetSubnetOnlyValidator(validationID)
+		if err != nil {
+			return fmt.Errorf("fetching L1 validator %q failed: %w", validationID, err)
+		}
+
+		reply.Validators[i], err = s.getAPIL1Validator(sov)
+		if err != nil {
+			return err
+		}
+	}
+
+	reply.EndValidationID = args.StartValidationID
+	if len(validationIDs) > 0 {
+		reply.EndValidationID = validationIDs[len(validationIDs)-1]
+	}
+
+	height, err := s.vm.GetCurrentHeight(r.Context())
+	if err != nil {
+		return fmt.Errorf("fetching current height failed: %w", err)
+	}
+	reply.Height = avajson.Uint64(height)
+	return nil
+}
+
+func (s *Service) getAPIL1Validator(sov state.SubnetOnlyValidator) (APIL1Validator, error) {
+	remainingBalanceOwner, err := s.getAPIL1ValidatorOwner(sov.RemainingBalanceOwner)
+	if err != nil {
+		return APIL1Validator{}, fmt.Errorf("problem parsing remaining balance owner: %w", err)
+	}
+	deactivationOwner, err := s.getAPIL1ValidatorOwner(sov.DeactivationOwner)
+	if err != nil {
+		return APIL1Validator{}, fmt.Errorf("problem parsing deactivation owner: %w", err)
+	}
+
+	var (
+		publicKey   = bls.PublicKeyFromValidUncompressedBytes(sov.PublicKey)
+		accruedFees = s.vm.state.GetAccruedFees()
+		balance     uint64
+	)
+	// Inactive validators have an EndAccumulatedFee of 0, so they are reported
+	// as having no remaining balance.
+	if sov.EndAccumulatedFee > accruedFees {
+		balance = sov.EndAccumulatedFee - accruedFees
+	}
+	return APIL1Validator{
+		ValidationID:          sov.ValidationID,
+		SubnetID:              sov.SubnetID,
+		NodeID:                sov.NodeID,
+		PublicKey:             bls.PublicKeyToCompressedBytes(publicKey),
+		RemainingBalanceOwner: remainingBalanceOwner,
+		DeactivationOwner:     deactivationOwner,
+		StartTime:             avajson.Uint64(sov.StartTime),
+		Weight:                avajson.Uint64(sov.Weight),
+		MinNonce:              avajson.Uint64(sov.MinNonce),
+		Balance:               avajson.Uint64(balance),
+		Active:                sov.EndAccumulatedFee != 0,
+	}, nil
+}
+
+func (s *Service) getAPIL1ValidatorOwner(ownerBytes []byte) (*platformapi.Owner, error) {
+	var owner message.PChainOwner
+	if _, err := txs.Codec.Unmarshal(ownerBytes, &owner); err != nil {
+		return nil, err
+	}
+	return s.getAPIOwner(&secp256k1fx.OutputOwners{
+		Threshold: owner.Threshold,
+		Addrs:     owner.Addresses,
+	})
+}
+
 // GetCurrentSupplyArgs are the arguments for calling GetCurrentSupply
 type GetCurrentSupplyArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -1870,8 +2053,17 @@ func (s *Service) GetFeeConfig(_ *http.Request, _ *struct{}, reply *gas.Config)
 
 type GetFeeStateReply struct {
 	gas.State
-	Price gas.Price `json:"price"`
-	Time  time.Time `json:"timestamp"`
+	Price     gas.Price         `json:"price"`
+	Time      time.Time         `json:"timestamp"`
+	Validator ValidatorFeeState `json:"validator"`
+}
+
+// ValidatorFeeState is the state of the continuous fee charged to active
+// subnet-only validators.
+type ValidatorFeeState struct {
+	validatorfee.State
+	Price       gas.Price      `json:"price"`
+	AccruedFees avajson.Uint64 `json:"accruedFees"`
 }
 
 // GetFeeState returns the current fee state of the chain.
@@ -1891,6 +2083,17 @@ func (s *Service) GetFeeState(_ *http.Request, _ *struct{}, reply *GetFeeStateRe
 		s.vm.DynamicFeeConfig.ExcessConversionConstant,
 	)
 	reply.Time = s.vm.state.GetTimestamp()
+
+	reply.Validator.State = validatorfee.State{
+		Current: gas.Gas(s.vm.state.NumActiveSubnetOnlyValidators()),
+		Excess:  s.vm.state.GetSoVExcess(),
+	}
+	reply.Validator.Price = gas.CalculatePrice(
+		s.vm.ValidatorFeeConfig.MinPrice,
+		reply.Validator.Excess,
+		s.vm.ValidatorFeeConfig.ExcessConversionConstant,
+	)
+	reply.Validator.AccruedFees = avajson.Uint64(s.vm.state.GetAccruedFees())
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net/http"
 	"testing"
 	"time"
 
@@ -45,6 +46,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/state"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/status"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp/message"
 	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
 	"github.com/MetalBlockchain/metalgo/wallet/subnet/primary/common"
 
@@ -1184,6 +1186,9 @@ func FuzzGetFeeState(f *testing.F) {
 					defaultDynamicFeeConfig.ExcessConversionConstant,
 				),
 				Time: expectedTime,
+				Validator: ValidatorFeeState{
+					Price: defaultValidatorFeeConfig.MinPrice,
+				},
 			}
 		)
 
@@ -1197,3 +1202,172 @@ func FuzzGetFeeState(f *testing.F) {
 		require.Equal(expectedReply, reply)
 	})
 }
+
+func TestGetL1Validators(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	ownerAddr, err := service.addrManager.ParseLocalAddress(testAddress)
+	require.NoError(err)
+
+	owner := message.PChainOwner{
+		Threshold: 1,
+		Addresses: []ids.ShortID{ownerAddr},
+	}
+	ownerBytes, err := txs.Codec.Marshal(txs.CodecVersion, &owner)
+	require.NoError(err)
+
+	expectedOwner := &pchainapi.Owner{
+		Threshold: 1,
+		Addresses: []string{testAddress},
+	}
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+
+	const accruedFees = 10
+	var (
+		subnetID = ids.GenerateTestID()
+		active   = state.SubnetOnlyValidator{
+			ValidationID:          ids.ID{1},
+			SubnetID:              subnetID,
+			NodeID:                ids.GenerateTestNodeID(),
+			PublicKey:             bls.PublicKeyToUncompressedBytes(pk),
+			RemainingBalanceOwner: ownerBytes,
+			DeactivationOwner:     ownerBytes,
+			StartTime:             1,
+			Weight:                2,
+			MinNonce:              3,
+			EndAccumulatedFee:     accruedFees + 4,
+		}
+		inactive = state.SubnetOnlyValidator{
+			ValidationID:          ids.ID{2},
+			SubnetID:              subnetID,
+			NodeID:                ids.GenerateTestNodeID(),
+			PublicKey:             bls.PublicKeyToUncompressedBytes(pk),
+			RemainingBalanceOwner: ownerBytes,
+			DeactivationOwner:     ownerBytes,
+			StartTime:             5,
+			Weight:                6,
+			MinNonce:              7,
+		}
+		otherSubnet = state.SubnetOnlyValidator{
+			ValidationID:          ids.ID{3},
+			SubnetID:              ids.GenerateTestID(),
+			NodeID:                ids.GenerateTestNodeID(),
+			PublicKey:             bls.PublicKeyToUncompressedBytes(pk),
+			RemainingBalanceOwner: ownerBytes,
+			DeactivationOwner:     ownerBytes,
+			Weight:                1,
+		}
+
+		compressedPK        = bls.PublicKeyToCompressedBytes(pk)
+		expectedActiveReply = APIL1Validator{
+			ValidationID:          active.ValidationID,
+			SubnetID:              subnetID,
+			NodeID:                active.NodeID,
+			PublicKey:             compressedPK,
+			RemainingBalanceOwner: expectedOwner,
+			DeactivationOwner:     expectedOwner,
+			StartTime:             1,
+			Weight:                2,
+			MinNonce:              3,
+			Balance:               4,
+			Active:                true,
+		}
+		expectedInactiveReply = APIL1Validator{
+			ValidationID:          inactive.ValidationID,
+			SubnetID:              subnetID,
+			NodeID:                inactive.NodeID,
+			PublicKey:             compressedPK,
+			RemainingBalanceOwner: expectedOwner,
+			DeactivationOwner:     expectedOwner,
+			StartTime:             5,
+			Weight:                6,
+			MinNonce:              7,
+		}
+	)
+
+	service.vm.ctx.Lock.Lock()
+	service.vm.state.SetAccruedFees(accruedFees)
+	require.NoError(service.vm.state.PutSubnetOnlyValidator(active))
+	require.NoError(service.vm.state.PutSubnetOnlyValidator(inactive))
+	require.NoError(service.vm.state.PutSubnetOnlyValidator(otherSubnet))
+	require.NoError(service.vm.state.Commit())
+	service.vm.ctx.Lock.Unlock()
+
+	var getReply GetL1ValidatorReply
+	require.NoError(service.GetL1Validator(
+		&http.Request{},
+		&GetL1ValidatorArgs{ValidationID: active.ValidationID},
+		&getReply,
+	))
+	require.Equal(expectedActiveReply, getReply.APIL1Validator)
+
+	require.NoError(service.GetL1Validator(
+		&http.Request{},
+		&GetL1ValidatorArgs{ValidationID: inactive.ValidationID},
+		&getReply,
+	))
+	require.Equal(expectedInactiveReply, getReply.APIL1Validator)
+
+	err = service.GetL1Validator(
+		&http.Request{},
+		&GetL1ValidatorArgs{ValidationID: ids.GenerateTestID()},
+		&getReply,
+	)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	var listReply GetL1ValidatorsReply
+	require.NoError(service.GetL1Validators(
+		&http.Request{},
+		&GetL1ValidatorsArgs{SubnetID: subnetID},
+		&listReply,
+	))
+	require.Equal([]APIL1Validator{expectedActiveReply, expectedInactiveReply}, listReply.Validators)
+	require.Equal(inactive.ValidationID, listReply.EndValidationID)
+
+	// Paginate one validator at a time
+	require.NoError(service.GetL1Validators(
+		&http.Request{},
+		&GetL1ValidatorsArgs{
+			SubnetID: subnetID,
+			Limit:    1,
+		},
+		&listReply,
+	))
+	require.Equal([]APIL1Validator{expectedActiveReply}, listReply.Validators)
+	require.Equal(active.ValidationID, listReply.EndValidationID)
+
+	require.NoError(service.GetL1Validators(
+		&http.Request{},
+		&GetL1ValidatorsArgs{
+			SubnetID:          subnetID,
+			StartValidationID: listReply.EndValidationID,
+			Limit:             1,
+		},
+		&listReply,
+	))
+	require.Equal([]APIL1Validator{expectedInactiveReply}, listReply.Validators)
+	require.Equal(inactive.ValidationID, listReply.EndValidationID)
+
+	require.NoError(service.GetL1Validators(
+		&http.Request{},
+		&GetL1ValidatorsArgs{
+			SubnetID:          subnetID,
+			StartValidationID: listReply.EndValidationID,
+			Limit:             1,
+		},
+		&listReply,
+	))
+	require.Empty(listReply.Validators)
+	require.Equal(inactive.ValidationID, listReply.EndValidationID)
+
+	err = service.GetL1Validators(
+		&http.Request{},
+		&GetL1ValidatorsArgs{SubnetID: constants.PrimaryNetworkID},
+		&listReply,
+	)
+	require.ErrorIs(err, errPrimaryNetworkIsNotASubnet)
+}
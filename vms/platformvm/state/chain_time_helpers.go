@@ -8,13 +8,20 @@ import (
 	"time"
 
 	"github.com/MetalBlockchain/metalgo/utils/iterator"
+	"github.com/MetalBlockchain/metalgo/utils/math"
 	"github.com/MetalBlockchain/metalgo/utils/timer/mockable"
 	"github.com/MetalBlockchain/metalgo/vms/components/gas"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/config"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs/fee"
+
+	validatorfee "github.com/MetalBlockchain/metalgo/vms/platformvm/validators/fee"
 )
 
-func NextBlockTime(state Chain, clk *mockable.Clock) (time.Time, bool, error) {
+func NextBlockTime(
+	config validatorfee.Config,
+	state Chain,
+	clk *mockable.Clock,
+) (time.Time, bool, error) {
 	var (
 		timestamp  = clk.Time()
 		parentTime = state.GetTimestamp()
@@ -27,7 +34,7 @@ func NextBlockTime(state Chain, clk *mockable.Clock) (time.Time, bool, error) {
 	// If the NextStakerChangeTime is after timestamp, then we shouldn't return
 	// that the time was capped.
 	nextStakerChangeTimeCap := timestamp.Add(time.Second)
-	nextStakerChangeTime, err := GetNextStakerChangeTime(state, nextStakerChangeTimeCap)
+	nextStakerChangeTime, err := GetNextStakerChangeTime(config, state, nextStakerChangeTimeCap)
 	if err != nil {
 		return time.Time{}, false, fmt.Errorf("failed getting next staker change time: %w", err)
 	}
@@ -42,9 +49,13 @@ func NextBlockTime(state Chain, clk *mockable.Clock) (time.Time, bool, error) {
 }
 
 // GetNextStakerChangeTime returns the next time a staker will be either added
-// or removed to/from the current validator set. If the next staker change time
-// is further in the future than [defaultTime], then [defaultTime] is returned.
-func GetNextStakerChangeTime(state Chain, defaultTime time.Time) (time.Time, error) {
+// or removed to/from the validator set. If the next staker change time is
+// further in the future than [nextTime], then [nextTime] is returned.
+func GetNextStakerChangeTime(
+	config validatorfee.Config,
+	state Chain,
+	nextTime time.Time,
+) (time.Time, error) {
 	currentIterator, err := state.GetCurrentStakerIterator()
 	if err != nil {
 		return time.Time{}, err
@@ -64,11 +75,67 @@ func GetNextStakerChangeTime(state Chain, defaultTime time.Time) (time.Time, err
 		}
 
 		time := it.Value().NextTime
-		if time.Before(defaultTime) {
-			defaultTime = time
+		if time.Before(nextTime) {
+			nextTime = time
+		}
+	}
+
+	return getNextSoVEvictionTime(config, state, nextTime)
+}
+
+// getNextSoVEvictionTime returns the next time a subnet-only validator will be
+// deactivated due to running out of funds. If the next deactivation time is
+// further in the future than [nextTime], then [nextTime] is returned.
+func getNextSoVEvictionTime(
+	config validatorfee.Config,
+	state Chain,
+	nextTime time.Time,
+) (time.Time, error) {
+	sovIterator, err := state.GetActiveSubnetOnlyValidatorsIterator()
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer sovIterator.Release()
+
+	// If there are no active SoVs, there is no deactivation time.
+	if !sovIterator.Next() {
+		return nextTime, nil
+	}
+
+	currentTime := state.GetTimestamp()
+	if !nextTime.After(currentTime) {
+		return nextTime, nil
+	}
+
+	// GetActiveSubnetOnlyValidatorsIterator iterates in order of increasing
+	// EndAccumulatedFee, so the first SoV is the next SoV to be deactivated.
+	var (
+		sov         = sovIterator.Value()
+		accruedFees = state.GetAccruedFees()
+	)
+	remainingFunds, err := math.Sub(sov.EndAccumulatedFee, accruedFees)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Calculate how many seconds the remaining funds can last for.
+	var (
+		maxSeconds = uint64(nextTime.Sub(currentTime) / time.Second)
+		feeState   = validatorfee.State{
+			Current: gas.Gas(state.NumActiveSubnetOnlyValidators()),
+			Excess:  state.GetSoVExcess(),
 		}
+		remainingSeconds = feeState.SecondsRemaining(
+			config,
+			maxSeconds,
+			remainingFunds,
+		)
+		deactivationTime = currentTime.Add(time.Duration(remainingSeconds) * time.Second)
+	)
+	if deactivationTime.Before(nextTime) {
+		nextTime = deactivationTime
 	}
-	return defaultTime, nil
+	return nextTime, nil
 }
 
 // PickFeeCalculator creates either a static or a dynamic fee calculator,
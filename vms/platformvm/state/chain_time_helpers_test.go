@@ -69,7 +69,7 @@ func TestNextBlockTime(t *testing.T) {
 			s.SetTimestamp(test.chainTime)
 			clk.Set(test.now)
 
-			actualTime, actualCapped, err := NextBlockTime(s, &clk)
+			actualTime, actualCapped, err := NextBlockTime(genesis.LocalParams.ValidatorFeeConfig, s, &clk)
 			require.NoError(err)
 			require.Equal(test.expectedTime.Local(), actualTime.Local())
 			require.Equal(test.expectedCapped, actualCapped)
@@ -81,6 +81,7 @@ func TestGetNextStakerChangeTime(t *testing.T) {
 	tests := []struct {
 		name     string
 		pending  []*Staker
+		sovs     []SubnetOnlyValidator
 		maxTime  time.Time
 		expected time.Time
 	}{
@@ -112,6 +113,34 @@ func TestGetNextStakerChangeTime(t *testing.T) {
 			maxTime:  genesistest.DefaultValidatorStartTime,
 			expected: genesistest.DefaultValidatorStartTime,
 		},
+		{
+			name: "subnet-only validator eviction",
+			sovs: []SubnetOnlyValidator{
+				{
+					ValidationID:      ids.GenerateTestID(),
+					SubnetID:          ids.GenerateTestID(),
+					NodeID:            ids.GenerateTestNodeID(),
+					Weight:            1,
+					EndAccumulatedFee: 5 * uint64(genesis.LocalParams.ValidatorFeeConfig.MinPrice),
+				},
+			},
+			maxTime:  mockable.MaxTime,
+			expected: genesistest.DefaultValidatorStartTime.Add(5 * time.Second),
+		},
+		{
+			name: "subnet-only validator eviction after max time",
+			sovs: []SubnetOnlyValidator{
+				{
+					ValidationID:      ids.GenerateTestID(),
+					SubnetID:          ids.GenerateTestID(),
+					NodeID:            ids.GenerateTestNodeID(),
+					Weight:            1,
+					EndAccumulatedFee: 5 * uint64(genesis.LocalParams.ValidatorFeeConfig.MinPrice),
+				},
+			},
+			maxTime:  genesistest.DefaultValidatorStartTime.Add(time.Second),
+			expected: genesistest.DefaultValidatorStartTime.Add(time.Second),
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -122,8 +151,11 @@ func TestGetNextStakerChangeTime(t *testing.T) {
 			for _, staker := range test.pending {
 				require.NoError(s.PutPendingValidator(staker))
 			}
+			for _, sov := range test.sovs {
+				require.NoError(s.PutSubnetOnlyValidator(sov))
+			}
 
-			actual, err := GetNextStakerChangeTime(s, test.maxTime)
+			actual, err := GetNextStakerChangeTime(genesis.LocalParams.ValidatorFeeConfig, s, test.maxTime)
 			require.NoError(err)
 			require.Equal(test.expected.Local(), actual.Local())
 		})
@@ -37,12 +37,14 @@ type diff struct {
 
 	timestamp   time.Time
 	feeState    gas.State
+	sovExcess   gas.Gas
 	accruedFees uint64
 
 	// Subnet ID --> supply of native asset of the subnet
 	currentSupply map[ids.ID]uint64
 
 	expiryDiff *expiryDiff
+	sovDiff    *subnetOnlyValidatorsDiff
 
 	currentStakerDiffs diffStakers
 	// map of subnetID -> nodeID -> total accrued delegatee rewards
@@ -80,8 +82,10 @@ func NewDiff(
 		stateVersions:  stateVersions,
 		timestamp:      parentState.GetTimestamp(),
 		feeState:       parentState.GetFeeState(),
+		sovExcess:      parentState.GetSoVExcess(),
 		accruedFees:    parentState.GetAccruedFees(),
 		expiryDiff:     newExpiryDiff(),
+		sovDiff:        newSubnetOnlyValidatorsDiff(),
 		subnetOwners:   make(map[ids.ID]fx.Owner),
 		subnetManagers: make(map[ids.ID]chainIDAndAddr),
 	}, nil
@@ -117,6 +121,14 @@ func (d *diff) SetFeeState(feeState gas.State) {
 	d.feeState = feeState
 }
 
+func (d *diff) GetSoVExcess() gas.Gas {
+	return d.sovExcess
+}
+
+func (d *diff) SetSoVExcess(excess gas.Gas) {
+	d.sovExcess = excess
+}
+
 func (d *diff) GetAccruedFees() uint64 {
 	return d.accruedFees
 }
@@ -184,6 +196,75 @@ func (d *diff) DeleteExpiry(entry ExpiryEntry) {
 	d.expiryDiff.DeleteExpiry(entry)
 }
 
+func (d *diff) GetActiveSubnetOnlyValidatorsIterator() (iterator.Iterator[SubnetOnlyValidator], error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentIterator, err := parentState.GetActiveSubnetOnlyValidatorsIterator()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.sovDiff.getActiveSubnetOnlyValidatorsIterator(parentIterator), nil
+}
+
+func (d *diff) NumActiveSubnetOnlyValidators() int {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return 0
+	}
+
+	return parentState.NumActiveSubnetOnlyValidators() + d.sovDiff.numAddedActive
+}
+
+func (d *diff) WeightOfSubnetOnlyValidators(subnetID ids.ID) (uint64, error) {
+	if weight, modified := d.sovDiff.modifiedTotalWeight[subnetID]; modified {
+		return weight, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	return parentState.WeightOfSubnetOnlyValidators(subnetID)
+}
+
+func (d *diff) GetSubnetOnlyValidator(validationID ids.ID) (SubnetOnlyValidator, error) {
+	if sov, modified := d.sovDiff.modified[validationID]; modified {
+		if sov.isDeleted() {
+			return SubnetOnlyValidator{}, database.ErrNotFound
+		}
+		return sov, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return SubnetOnlyValidator{}, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	return parentState.GetSubnetOnlyValidator(validationID)
+}
+
+func (d *diff) HasSubnetOnlyValidator(subnetID ids.ID, nodeID ids.NodeID) (bool, error) {
+	if has, modified := d.sovDiff.hasSubnetOnlyValidator(subnetID, nodeID); modified {
+		return has, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return false, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	return parentState.HasSubnetOnlyValidator(subnetID, nodeID)
+}
+
+func (d *diff) PutSubnetOnlyValidator(sov SubnetOnlyValidator) error {
+	return d.sovDiff.putSubnetOnlyValidator(d, sov)
+}
+
 func (d *diff) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
 	// If the validator was modified in this diff, return the modified
 	// validator.
@@ -485,6 +566,7 @@ func (d *diff) DeleteUTXO(utxoID ids.ID) {
 func (d *diff) Apply(baseState Chain) error {
 	baseState.SetTimestamp(d.timestamp)
 	baseState.SetFeeState(d.feeState)
+	baseState.SetSoVExcess(d.sovExcess)
 	baseState.SetAccruedFees(d.accruedFees)
 	for subnetID, supply := range d.currentSupply {
 		baseState.SetCurrentSupply(subnetID, supply)
@@ -496,6 +578,24 @@ func (d *diff) Apply(baseState Chain) error {
 			baseState.DeleteExpiry(entry)
 		}
 	}
+	// Validators must be deleted before any additions are made, as a new
+	// validator may share the subnetID+nodeID pair of a removed validator.
+	for _, sov := range d.sovDiff.modified {
+		if !sov.isDeleted() {
+			continue
+		}
+		if err := baseState.PutSubnetOnlyValidator(sov); err != nil {
+			return err
+		}
+	}
+	for _, sov := range d.sovDiff.modified {
+		if sov.isDeleted() {
+			continue
+		}
+		if err := baseState.PutSubnetOnlyValidator(sov); err != nil {
+			return err
+		}
+	}
 	for _, subnetValidatorDiffs := range d.currentStakerDiffs.validatorDiffs {
 		for _, validatorDiff := range subnetValidatorDiffs {
 			switch validatorDiff.validatorStatus {
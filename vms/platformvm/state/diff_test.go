@@ -86,6 +86,24 @@ func TestDiffAccruedFees(t *testing.T) {
 	assertChainsEqual(t, state, d)
 }
 
+func TestDiffSoVExcess(t *testing.T) {
+	require := require.New(t)
+
+	state := newTestState(t, memdb.New())
+
+	d, err := NewDiffOn(state)
+	require.NoError(err)
+
+	initialExcess := state.GetSoVExcess()
+	newExcess := initialExcess + 1
+	d.SetSoVExcess(newExcess)
+	require.Equal(newExcess, d.GetSoVExcess())
+	require.Equal(initialExcess, state.GetSoVExcess())
+
+	require.NoError(d.Apply(state))
+	assertChainsEqual(t, state, d)
+}
+
 func TestDiffCurrentSupply(t *testing.T) {
 	require := require.New(t)
 
@@ -271,6 +289,7 @@ func TestDiffCurrentValidator(t *testing.T) {
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetFeeState().Return(gas.State{}).Times(1)
 	state.EXPECT().GetAccruedFees().Return(uint64(0)).Times(1)
+	state.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(1)
 
 	d, err := NewDiffOn(state)
 	require.NoError(err)
@@ -306,6 +325,7 @@ func TestDiffPendingValidator(t *testing.T) {
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetFeeState().Return(gas.State{}).Times(1)
 	state.EXPECT().GetAccruedFees().Return(uint64(0)).Times(1)
+	state.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(1)
 
 	d, err := NewDiffOn(state)
 	require.NoError(err)
@@ -347,6 +367,7 @@ func TestDiffCurrentDelegator(t *testing.T) {
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetFeeState().Return(gas.State{}).Times(1)
 	state.EXPECT().GetAccruedFees().Return(uint64(0)).Times(1)
+	state.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(1)
 
 	d, err := NewDiffOn(state)
 	require.NoError(err)
@@ -391,6 +412,7 @@ func TestDiffPendingDelegator(t *testing.T) {
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetFeeState().Return(gas.State{}).Times(1)
 	state.EXPECT().GetAccruedFees().Return(uint64(0)).Times(1)
+	state.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(1)
 
 	d, err := NewDiffOn(state)
 	require.NoError(err)
@@ -529,6 +551,7 @@ func TestDiffTx(t *testing.T) {
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetFeeState().Return(gas.State{}).Times(1)
 	state.EXPECT().GetAccruedFees().Return(uint64(0)).Times(1)
+	state.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(1)
 
 	d, err := NewDiffOn(state)
 	require.NoError(err)
@@ -627,6 +650,7 @@ func TestDiffUTXO(t *testing.T) {
 	state.EXPECT().GetTimestamp().Return(time.Now()).Times(1)
 	state.EXPECT().GetFeeState().Return(gas.State{}).Times(1)
 	state.EXPECT().GetAccruedFees().Return(uint64(0)).Times(1)
+	state.EXPECT().GetSoVExcess().Return(gas.Gas(0)).Times(1)
 
 	d, err := NewDiffOn(state)
 	require.NoError(err)
@@ -704,6 +728,7 @@ func assertChainsEqual(t *testing.T, expected, actual Chain) {
 	require.Equal(expected.GetTimestamp(), actual.GetTimestamp())
 	require.Equal(expected.GetFeeState(), actual.GetFeeState())
 	require.Equal(expected.GetAccruedFees(), actual.GetAccruedFees())
+	require.Equal(expected.GetSoVExcess(), actual.GetSoVExcess())
 
 	expectedCurrentSupply, err := expected.GetCurrentSupply(constants.PrimaryNetworkID)
 	require.NoError(err)
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -32,6 +33,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/utils/hashing"
 	"github.com/MetalBlockchain/metalgo/utils/iterator"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
 	"github.com/MetalBlockchain/metalgo/utils/timer"
 	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 	"github.com/MetalBlockchain/metalgo/vms/components/avax"
@@ -83,22 +85,31 @@ var (
 	SupplyPrefix                  = []byte("supply")
 	ChainPrefix                   = []byte("chain")
 	ExpiryReplayProtectionPrefix  = []byte("expiryReplayProtection")
+	SubnetOnlyValidatorsPrefix    = []byte("subnetOnlyValidators")
+	WeightsPrefix                 = []byte("weights")
+	SubnetIDNodeIDPrefix          = []byte("subnetIDNodeID")
+	SubnetIDValidationIDPrefix    = []byte("subnetIDValidationID")
+	ActivePrefix                  = []byte("active")
+	InactivePrefix                = []byte("inactive")
 	SingletonPrefix               = []byte("singleton")
 
 	TimestampKey       = []byte("timestamp")
 	FeeStateKey        = []byte("fee state")
+	SoVExcessKey       = []byte("sov excess")
 	AccruedFeesKey     = []byte("accrued fees")
 	CurrentSupplyKey   = []byte("current supply")
 	LastAcceptedKey    = []byte("last accepted")
 	HeightsIndexedKey  = []byte("heights indexed")
 	InitializedKey     = []byte("initialized")
 	BlocksReindexedKey = []byte("blocks reindexed")
+	SyncedHeightKey    = []byte("synced height")
 )
 
 // Chain collects all methods to manage the state of the chain for block
 // execution.
 type Chain interface {
 	Expiry
+	SubnetOnlyValidators
 	Stakers
 	avax.UTXOAdder
 	avax.UTXOGetter
@@ -110,6 +121,9 @@ type Chain interface {
 	GetFeeState() gas.State
 	SetFeeState(f gas.State)
 
+	GetSoVExcess() gas.Gas
+	SetSoVExcess(e gas.Gas)
+
 	GetAccruedFees() uint64
 	SetAccruedFees(f uint64)
 
@@ -154,6 +168,13 @@ type State interface {
 	GetSubnetIDs() ([]ids.ID, error)
 	GetChains(subnetID ids.ID) ([]*txs.Tx, error)
 
+	// SubnetOnlyValidatorIDs returns up to [limit] validationIDs of the
+	// subnet-only validators of [subnetID] in increasing order. Only
+	// validationIDs greater than [start] are returned.
+	//
+	// Only validators that have been committed are returned.
+	SubnetOnlyValidatorIDs(subnetID ids.ID, start ids.ID, limit int) ([]ids.ID, error)
+
 	// ApplyValidatorWeightDiffs iterates from [startHeight] towards the genesis
 	// block until it has applied all of the diffs up to and including
 	// [endHeight]. Applying the diffs modifies [validators].
@@ -189,10 +210,18 @@ type State interface {
 		validators map[ids.NodeID]*validators.GetValidatorOutput,
 		startHeight uint64,
 		endHeight uint64,
+		subnetID ids.ID,
 	) error
 
 	SetHeight(height uint64)
 
+	// NewSyncableSnapshot returns a snapshot of the portion of the last
+	// committed state that is transferred during state sync.
+	NewSyncableSnapshot() (SyncableSnapshot, error)
+
+	// Reload replaces the in-memory state with the state stored on disk.
+	Reload() error
+
 	// Discard uncommitted changes to the database.
 	Abort()
 
@@ -282,15 +311,28 @@ type stateBlk struct {
  * |     '-- txID -> nil
  * |-. expiryReplayProtection
  * | '-- timestamp + validationID -> nil
+ * |-. subnetOnlyValidators
+ * | |-. weights
+ * | | '-- subnetID -> weight
+ * | |-. subnetIDNodeID
+ * | | '-- subnetID+nodeID -> nil
+ * | |-. subnetIDValidationID
+ * | | '-- subnetID+validationID -> nil
+ * | |-. active
+ * | | '-- validationID -> subnetOnlyValidator
+ * | '-. inactive
+ * |   '-- validationID -> subnetOnlyValidator
  * '-. singletons
  *   |-- initializedKey -> nil
  *   |-- blocksReindexedKey -> nil
  *   |-- timestampKey -> timestamp
  *   |-- feeStateKey -> feeState
+ *   |-- sovExcessKey -> sovExcess
  *   |-- accruedFeesKey -> accruedFees
  *   |-- currentSupplyKey -> currentSupply
  *   |-- lastAcceptedKey -> lastAccepted
- *   '-- heightsIndexKey -> startIndexHeight + endIndexHeight
+ *   |-- heightsIndexKey -> startIndexHeight + endIndexHeight
+ *   '-- syncedHeightKey -> height at or below which validator diffs weren't synced
  */
 type state struct {
 	validatorState
@@ -307,6 +349,18 @@ type state struct {
 	expiryDiff *expiryDiff
 	expiryDB   database.Database
 
+	activeSOVs             *activeSubnetOnlyValidators
+	sovDiff                *subnetOnlyValidatorsDiff
+	subnetOnlyValidatorsDB database.Database
+	weightsCache           cache.Cacher[ids.ID, uint64] // subnetID -> total SoV weight
+	weightsDB              database.Database
+	subnetIDNodeIDCache    cache.Cacher[subnetIDNodeID, bool] // subnetID+nodeID -> is validator
+	subnetIDNodeIDDB       database.Database
+	subnetIDValidationIDDB database.Database
+	activeDB               database.Database
+	inactiveCache          cache.Cacher[ids.ID, maybe.Maybe[SubnetOnlyValidator]] // validationID -> SubnetOnlyValidator
+	inactiveDB             database.Database
+
 	currentStakers *baseStakers
 	pendingStakers *baseStakers
 
@@ -384,13 +438,20 @@ type state struct {
 	// The persisted fields represent the current database value
 	timestamp, persistedTimestamp         time.Time
 	feeState, persistedFeeState           gas.State
+	sovExcess, persistedSoVExcess         gas.Gas
 	accruedFees, persistedAccruedFees     uint64
 	currentSupply, persistedCurrentSupply uint64
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	// TODO: Remove indexedHeights once v1.11.3 has been released.
 	indexedHeights *heightRange
-	singletonDB    database.Database
+	// [syncedHeight] is the height at or below which the validator diffs were
+	// not synced. If the state wasn't synced, or all of the diffs were synced,
+	// it is 0.
+	syncedHeight uint64
+	singletonDB  database.Database
+
+	checksumsEnabled bool
 }
 
 // heightRange is used to track which heights are safe to use the native DB
@@ -513,6 +574,52 @@ func New(
 	validatorWeightDiffsDB := prefixdb.New(ValidatorWeightDiffsPrefix, validatorsDB)
 	validatorPublicKeyDiffsDB := prefixdb.New(ValidatorPublicKeyDiffsPrefix, validatorsDB)
 
+	subnetOnlyValidatorsDB := prefixdb.New(SubnetOnlyValidatorsPrefix, baseDB)
+
+	weightsCache, err := metercacher.New(
+		"sov_weights_cache",
+		metricsReg,
+		cache.NewSizedLRU[ids.ID, uint64](execCfg.L1WeightsCacheSize, func(ids.ID, uint64) int {
+			return ids.IDLen + database.Uint64Size
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	inactiveSOVsCache, err := metercacher.New(
+		"sov_inactive_cache",
+		metricsReg,
+		cache.NewSizedLRU[ids.ID, maybe.Maybe[SubnetOnlyValidator]](
+			execCfg.L1InactiveValidatorsCacheSize,
+			func(_ ids.ID, maybeSOV maybe.Maybe[SubnetOnlyValidator]) int {
+				const sovOverhead = ids.IDLen + ids.NodeIDLen + 4*database.Uint64Size + 3*constants.PointerOverhead
+				const maybeSOVOverhead = wrappers.BoolLen + sovOverhead
+				const entryOverhead = ids.IDLen + maybeSOVOverhead
+				if maybeSOV.IsNothing() {
+					return entryOverhead
+				}
+
+				sov := maybeSOV.Value()
+				return entryOverhead + len(sov.PublicKey) + len(sov.RemainingBalanceOwner) + len(sov.DeactivationOwner)
+			},
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	subnetIDNodeIDCache, err := metercacher.New(
+		"sov_subnet_id_node_id_cache",
+		metricsReg,
+		cache.NewSizedLRU[subnetIDNodeID, bool](execCfg.L1SubnetIDNodeIDCacheSize, func(subnetIDNodeID, bool) int {
+			return ids.IDLen + ids.NodeIDLen + wrappers.BoolLen
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	txCache, err := metercacher.New(
 		"tx_cache",
 		metricsReg,
@@ -622,6 +729,18 @@ func New(
 		expiryDiff: newExpiryDiff(),
 		expiryDB:   prefixdb.New(ExpiryReplayProtectionPrefix, baseDB),
 
+		activeSOVs:             newActiveSubnetOnlyValidators(),
+		sovDiff:                newSubnetOnlyValidatorsDiff(),
+		subnetOnlyValidatorsDB: subnetOnlyValidatorsDB,
+		weightsCache:           weightsCache,
+		weightsDB:              prefixdb.New(WeightsPrefix, subnetOnlyValidatorsDB),
+		subnetIDNodeIDCache:    subnetIDNodeIDCache,
+		subnetIDNodeIDDB:       prefixdb.New(SubnetIDNodeIDPrefix, subnetOnlyValidatorsDB),
+		subnetIDValidationIDDB: prefixdb.New(SubnetIDValidationIDPrefix, subnetOnlyValidatorsDB),
+		activeDB:               prefixdb.New(ActivePrefix, subnetOnlyValidatorsDB),
+		inactiveCache:          inactiveSOVsCache,
+		inactiveDB:             prefixdb.New(InactivePrefix, subnetOnlyValidatorsDB),
+
 		currentStakers: newBaseStakers(),
 		pendingStakers: newBaseStakers(),
 
@@ -684,6 +803,8 @@ func New(
 		chainDBCache: chainDBCache,
 
 		singletonDB: prefixdb.New(SingletonPrefix, baseDB),
+
+		checksumsEnabled: execCfg.ChecksumsEnabled,
 	}
 
 	if err := s.sync(genesisBytes); err != nil {
@@ -717,6 +838,122 @@ func (s *state) DeleteExpiry(entry ExpiryEntry) {
 	s.expiryDiff.DeleteExpiry(entry)
 }
 
+func (s *state) GetActiveSubnetOnlyValidatorsIterator() (iterator.Iterator[SubnetOnlyValidator], error) {
+	return s.sovDiff.getActiveSubnetOnlyValidatorsIterator(
+		s.activeSOVs.newIterator(),
+	), nil
+}
+
+func (s *state) NumActiveSubnetOnlyValidators() int {
+	return s.activeSOVs.len() + s.sovDiff.numAddedActive
+}
+
+func (s *state) WeightOfSubnetOnlyValidators(subnetID ids.ID) (uint64, error) {
+	if weight, modified := s.sovDiff.modifiedTotalWeight[subnetID]; modified {
+		return weight, nil
+	}
+
+	if weight, ok := s.weightsCache.Get(subnetID); ok {
+		return weight, nil
+	}
+
+	weight, err := database.GetUInt64(s.weightsDB, subnetID[:])
+	if err == database.ErrNotFound {
+		weight, err = 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	s.weightsCache.Put(subnetID, weight)
+	return weight, nil
+}
+
+func (s *state) GetSubnetOnlyValidator(validationID ids.ID) (SubnetOnlyValidator, error) {
+	if sov, modified := s.sovDiff.modified[validationID]; modified {
+		if sov.isDeleted() {
+			return SubnetOnlyValidator{}, database.ErrNotFound
+		}
+		return sov, nil
+	}
+
+	return s.getPersistedSubnetOnlyValidator(validationID)
+}
+
+// getPersistedSubnetOnlyValidator returns the currently persisted
+// SubnetOnlyValidator with the given validationID. It is guaranteed that any
+// returned validator is either active or inactive (not deleted).
+func (s *state) getPersistedSubnetOnlyValidator(validationID ids.ID) (SubnetOnlyValidator, error) {
+	if sov, ok := s.activeSOVs.get(validationID); ok {
+		return sov, nil
+	}
+
+	if maybeSOV, ok := s.inactiveCache.Get(validationID); ok {
+		if maybeSOV.IsNothing() {
+			return SubnetOnlyValidator{}, database.ErrNotFound
+		}
+		return maybeSOV.Value(), nil
+	}
+
+	sov, err := getSubnetOnlyValidator(s.inactiveDB, validationID)
+	switch err {
+	case nil:
+		s.inactiveCache.Put(validationID, maybe.Some(sov))
+	case database.ErrNotFound:
+		s.inactiveCache.Put(validationID, maybe.Nothing[SubnetOnlyValidator]())
+	}
+	return sov, err
+}
+
+func (s *state) HasSubnetOnlyValidator(subnetID ids.ID, nodeID ids.NodeID) (bool, error) {
+	if has, modified := s.sovDiff.hasSubnetOnlyValidator(subnetID, nodeID); modified {
+		return has, nil
+	}
+
+	subnetIDNodeID := subnetIDNodeID{
+		subnetID: subnetID,
+		nodeID:   nodeID,
+	}
+	if has, ok := s.subnetIDNodeIDCache.Get(subnetIDNodeID); ok {
+		return has, nil
+	}
+
+	has, err := s.subnetIDNodeIDDB.Has(subnetIDNodeID.Marshal())
+	if err != nil {
+		return false, err
+	}
+
+	s.subnetIDNodeIDCache.Put(subnetIDNodeID, has)
+	return has, nil
+}
+
+func (s *state) PutSubnetOnlyValidator(sov SubnetOnlyValidator) error {
+	return s.sovDiff.putSubnetOnlyValidator(s, sov)
+}
+
+func (s *state) SubnetOnlyValidatorIDs(subnetID ids.ID, start ids.ID, limit int) ([]ids.ID, error) {
+	iter := s.subnetIDValidationIDDB.NewIteratorWithStartAndPrefix(
+		subnetIDValidationIDKey(subnetID, start),
+		subnetID[:],
+	)
+	defer iter.Release()
+
+	validationIDs := []ids.ID(nil)
+	for len(validationIDs) < limit && iter.Next() {
+		validationID, err := ids.ToID(iter.Key()[ids.IDLen:])
+		if err != nil {
+			return nil, err
+		}
+		if validationID == start {
+			continue
+		}
+
+		start = ids.Empty
+		validationIDs = append(validationIDs, validationID)
+	}
+	return validationIDs, iter.Error()
+}
+
 func (s *state) GetCurrentValidator(subnetID ids.ID, nodeID ids.NodeID) (*Staker, error) {
 	return s.currentStakers.GetValidator(subnetID, nodeID)
 }
@@ -1091,6 +1328,14 @@ func (s *state) SetFeeState(feeState gas.State) {
 	s.feeState = feeState
 }
 
+func (s *state) GetSoVExcess() gas.Gas {
+	return s.sovExcess
+}
+
+func (s *state) SetSoVExcess(e gas.Gas) {
+	s.sovExcess = e
+}
+
 func (s *state) GetAccruedFees() uint64 {
 	return s.accruedFees
 }
@@ -1146,6 +1391,21 @@ func (s *state) SetCurrentSupply(subnetID ids.ID, cs uint64) {
 	}
 }
 
+// verifyDiffsAvailable returns an error if applying the validator diffs in
+// [endHeight, startHeight] requires diffs that were not synced.
+func (s *state) verifyDiffsAvailable(startHeight uint64, endHeight uint64) error {
+	// A synced height of 0 means that the state wasn't synced or that all of
+	// the diffs were synced.
+	if s.syncedHeight == 0 || startHeight < endHeight || endHeight > s.syncedHeight {
+		return nil
+	}
+	return fmt.Errorf("%w: diffs at or below height %d were not synced but diffs were requested down to height %d",
+		ErrMissingValidatorDiffs,
+		s.syncedHeight,
+		endHeight,
+	)
+}
+
 func (s *state) ApplyValidatorWeightDiffs(
 	ctx context.Context,
 	validators map[ids.NodeID]*validators.GetValidatorOutput,
@@ -1153,6 +1413,10 @@ func (s *state) ApplyValidatorWeightDiffs(
 	endHeight uint64,
 	subnetID ids.ID,
 ) error {
+	if err := s.verifyDiffsAvailable(startHeight, endHeight); err != nil {
+		return err
+	}
+
 	diffIter := s.validatorWeightDiffsDB.NewIteratorWithStartAndPrefix(
 		marshalStartDiffKey(subnetID, startHeight),
 		subnetID[:],
@@ -1243,10 +1507,15 @@ func (s *state) ApplyValidatorPublicKeyDiffs(
 	validators map[ids.NodeID]*validators.GetValidatorOutput,
 	startHeight uint64,
 	endHeight uint64,
+	subnetID ids.ID,
 ) error {
+	if err := s.verifyDiffsAvailable(startHeight, endHeight); err != nil {
+		return err
+	}
+
 	diffIter := s.validatorPublicKeyDiffsDB.NewIteratorWithStartAndPrefix(
-		marshalStartDiffKey(constants.PrimaryNetworkID, startHeight),
-		constants.PrimaryNetworkID[:],
+		marshalStartDiffKey(subnetID, startHeight),
+		subnetID[:],
 	)
 	defer diffIter.Release()
 
@@ -1370,6 +1639,7 @@ func (s *state) load() error {
 	return errors.Join(
 		s.loadMetadata(),
 		s.loadExpiry(),
+		s.loadActiveSubnetOnlyValidators(),
 		s.loadCurrentValidators(),
 		s.loadPendingValidators(),
 		s.initValidatorSets(),
@@ -1391,6 +1661,13 @@ func (s *state) loadMetadata() error {
 	s.persistedFeeState = feeState
 	s.SetFeeState(feeState)
 
+	sovExcess, err := getSoVExcess(s.singletonDB)
+	if err != nil {
+		return err
+	}
+	s.persistedSoVExcess = sovExcess
+	s.SetSoVExcess(sovExcess)
+
 	accruedFees, err := getAccruedFees(s.singletonDB)
 	if err != nil {
 		return err
@@ -1412,6 +1689,16 @@ func (s *state) loadMetadata() error {
 	s.persistedLastAccepted = lastAccepted
 	s.lastAccepted = lastAccepted
 
+	syncedHeight, err := database.GetUInt64(s.singletonDB, SyncedHeightKey)
+	switch err {
+	case nil:
+		s.syncedHeight = syncedHeight
+	case database.ErrNotFound:
+		s.syncedHeight = 0
+	default:
+		return err
+	}
+
 	// Lookup the most recently indexed range on disk. If we haven't started
 	// indexing the weights, then we keep the indexed heights as nil.
 	indexedHeightsBytes, err := s.singletonDB.Get(HeightsIndexedKey)
@@ -1712,8 +1999,35 @@ func (s *state) loadPendingValidators() error {
 	)
 }
 
-// Invariant: initValidatorSets requires loadCurrentValidators to have already
-// been called.
+func (s *state) loadActiveSubnetOnlyValidators() error {
+	it := s.activeDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		validationID, err := ids.ToID(key)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal ValidationID during load: %w", err)
+		}
+
+		var (
+			value = it.Value()
+			sov   = SubnetOnlyValidator{
+				ValidationID: validationID,
+			}
+		)
+		if _, err := block.GenesisCodec.Unmarshal(value, &sov); err != nil {
+			return fmt.Errorf("failed to unmarshal SubnetOnlyValidator: %w", err)
+		}
+
+		s.activeSOVs.put(sov)
+	}
+
+	return it.Error()
+}
+
+// Invariant: initValidatorSets requires loadActiveSubnetOnlyValidators and
+// loadCurrentValidators to have already been called.
 func (s *state) initValidatorSets() error {
 	for subnetID, validators := range s.currentStakers.validators {
 		if s.validators.Count(subnetID) != 0 {
@@ -1739,6 +2053,54 @@ func (s *state) initValidatorSets() error {
 		}
 	}
 
+	// Active subnet-only validators are reported with their own node IDs and
+	// public keys.
+	activeWeights := make(map[ids.ID]uint64)
+	for validationID, sov := range s.activeSOVs.lookup {
+		pk := bls.PublicKeyFromValidUncompressedBytes(sov.PublicKey)
+		if err := s.validators.AddStaker(sov.SubnetID, sov.NodeID, pk, validationID, sov.Weight); err != nil {
+			return err
+		}
+
+		weight, err := safemath.Add(activeWeights[sov.SubnetID], sov.Weight)
+		if err != nil {
+			return err
+		}
+		activeWeights[sov.SubnetID] = weight
+	}
+
+	// The weight of inactive subnet-only validators is reported under the
+	// empty nodeID.
+	weightsIt := s.weightsDB.NewIterator()
+	defer weightsIt.Release()
+
+	for weightsIt.Next() {
+		subnetID, err := ids.ToID(weightsIt.Key())
+		if err != nil {
+			return err
+		}
+
+		totalWeight, err := database.ParseUInt64(weightsIt.Value())
+		if err != nil {
+			return err
+		}
+
+		inactiveWeight, err := safemath.Sub(totalWeight, activeWeights[subnetID])
+		if err != nil {
+			return err
+		}
+		if inactiveWeight == 0 {
+			continue
+		}
+
+		if err := s.validators.AddStaker(subnetID, ids.EmptyNodeID, nil, ids.Empty, inactiveWeight); err != nil {
+			return err
+		}
+	}
+	if err := weightsIt.Error(); err != nil {
+		return err
+	}
+
 	s.metrics.SetLocalStake(s.validators.GetWeight(constants.PrimaryNetworkID, s.ctx.NodeID))
 	totalWeight, err := s.validators.TotalWeight(constants.PrimaryNetworkID)
 	if err != nil {
@@ -1758,6 +2120,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		s.writeBlocks(),
 		s.writeExpiry(),
 		s.writeCurrentStakers(updateValidators, height, codecVersion),
+		s.writeSubnetOnlyValidators(updateValidators, height), // Must be called after writeCurrentStakers
 		s.writePendingStakers(),
 		s.WriteValidatorMetadata(s.currentValidatorList, s.currentSubnetValidatorList, codecVersion), // Must be called after writeCurrentStakers
 		s.writeTXs(),
@@ -1776,6 +2139,12 @@ func (s *state) write(updateValidators bool, height uint64) error {
 func (s *state) Close() error {
 	return errors.Join(
 		s.expiryDB.Close(),
+		s.weightsDB.Close(),
+		s.subnetIDNodeIDDB.Close(),
+		s.subnetIDValidationIDDB.Close(),
+		s.activeDB.Close(),
+		s.inactiveDB.Close(),
+		s.subnetOnlyValidatorsDB.Close(),
 		s.pendingSubnetValidatorBaseDB.Close(),
 		s.pendingSubnetDelegatorBaseDB.Close(),
 		s.pendingDelegatorBaseDB.Close(),
@@ -2004,6 +2373,242 @@ func (s *state) writeExpiry() error {
 	return nil
 }
 
+// sovValidatorChange tracks the changes of a single entry in the validator set
+// of a subnet caused by subnet-only validators during a single block.
+type sovValidatorChange struct {
+	weightDiff      ValidatorWeightDiff
+	prevPublicKey   []byte
+	newPublicKey    []byte
+	newValidationID ids.ID
+}
+
+func (s *state) writeSubnetOnlyValidators(updateValidators bool, height uint64) error {
+	for subnetID, weight := range s.sovDiff.modifiedTotalWeight {
+		var err error
+		if weight == 0 {
+			err = s.weightsDB.Delete(subnetID[:])
+		} else {
+			err = database.PutUInt64(s.weightsDB, subnetID[:], weight)
+		}
+		if err != nil {
+			return err
+		}
+
+		s.weightsCache.Put(subnetID, weight)
+	}
+
+	// The subnetID+nodeID index is written directly from the final state of
+	// the diff so that a validator can be replaced by another validator with
+	// the same subnetID+nodeID pair in a single block.
+	for subnetIDNodeID, isAdded := range s.sovDiff.modifiedHasNodeIDs {
+		var (
+			key = subnetIDNodeID.Marshal()
+			err error
+		)
+		if isAdded {
+			err = s.subnetIDNodeIDDB.Put(key, nil)
+		} else {
+			err = s.subnetIDNodeIDDB.Delete(key)
+		}
+		if err != nil {
+			return err
+		}
+
+		s.subnetIDNodeIDCache.Put(subnetIDNodeID, isAdded)
+	}
+
+	changes := make(map[subnetIDNodeID]*sovValidatorChange)
+	getChange := func(subnetID ids.ID, nodeID ids.NodeID) *sovValidatorChange {
+		key := subnetIDNodeID{
+			subnetID: subnetID,
+			nodeID:   nodeID,
+		}
+		change, ok := changes[key]
+		if !ok {
+			change = &sovValidatorChange{}
+			changes[key] = change
+		}
+		return change
+	}
+
+	// Remove all the prior versions of the modified validators before adding
+	// any of the new versions.
+	for validationID := range s.sovDiff.modified {
+		priorSOV, err := s.getPersistedSubnetOnlyValidator(validationID)
+		if err == database.ErrNotFound {
+			// This validator is being newly added.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if priorSOV.isActive() {
+			s.activeSOVs.delete(validationID)
+			err = deleteSubnetOnlyValidator(s.activeDB, validationID)
+		} else {
+			s.inactiveCache.Put(validationID, maybe.Nothing[SubnetOnlyValidator]())
+			err = deleteSubnetOnlyValidator(s.inactiveDB, validationID)
+		}
+		if err != nil {
+			return err
+		}
+
+		change := getChange(priorSOV.SubnetID, priorSOV.effectiveNodeID())
+		if err := change.weightDiff.Add(true, priorSOV.Weight); err != nil {
+			return err
+		}
+		change.prevPublicKey = priorSOV.effectivePublicKeyBytes()
+	}
+
+	for validationID, sov := range s.sovDiff.modified {
+		subnetIDValidationID := subnetIDValidationIDKey(sov.SubnetID, validationID)
+		if sov.isDeleted() {
+			if err := s.subnetIDValidationIDDB.Delete(subnetIDValidationID); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.subnetIDValidationIDDB.Put(subnetIDValidationID, nil); err != nil {
+			return err
+		}
+
+		var err error
+		if sov.isActive() {
+			s.activeSOVs.put(sov)
+			err = putSubnetOnlyValidator(s.activeDB, sov)
+		} else {
+			s.inactiveCache.Put(validationID, maybe.Some(sov))
+			err = putSubnetOnlyValidator(s.inactiveDB, sov)
+		}
+		if err != nil {
+			return err
+		}
+
+		change := getChange(sov.SubnetID, sov.effectiveNodeID())
+		if err := change.weightDiff.Add(false, sov.Weight); err != nil {
+			return err
+		}
+		change.newPublicKey = sov.effectivePublicKeyBytes()
+		change.newValidationID = validationID
+	}
+
+	for key, change := range changes {
+		if err := s.writeSubnetOnlyValidatorChange(key, change, height); err != nil {
+			return err
+		}
+
+		// TODO: Move the validator set management out of the state package
+		if !updateValidators {
+			continue
+		}
+
+		if err := s.updateSubnetOnlyValidatorSet(key, change); err != nil {
+			return fmt.Errorf("failed to update validator weight: %w", err)
+		}
+	}
+
+	s.sovDiff = newSubnetOnlyValidatorsDiff()
+	return nil
+}
+
+// writeSubnetOnlyValidatorChange records the weight and public key diffs of
+// [change] at [height].
+//
+// Invariant: writeSubnetOnlyValidatorChange must be called after
+// writeCurrentStakers, as a legacy subnet validator may have modified the same
+// entry in the validator set during this block.
+func (s *state) writeSubnetOnlyValidatorChange(
+	key subnetIDNodeID,
+	change *sovValidatorChange,
+	height uint64,
+) error {
+	diffKey := marshalDiffKey(key.subnetID, height, key.nodeID)
+	if change.weightDiff.Amount != 0 {
+		weightDiff := change.weightDiff
+		weightDiffBytes, err := s.validatorWeightDiffsDB.Get(diffKey)
+		switch err {
+		case nil:
+			priorWeightDiff, err := unmarshalWeightDiff(weightDiffBytes)
+			if err != nil {
+				return err
+			}
+			if err := weightDiff.Add(priorWeightDiff.Decrease, priorWeightDiff.Amount); err != nil {
+				return err
+			}
+		case database.ErrNotFound:
+		default:
+			return err
+		}
+
+		if weightDiff.Amount == 0 {
+			err = s.validatorWeightDiffsDB.Delete(diffKey)
+		} else {
+			err = s.validatorWeightDiffsDB.Put(diffKey, marshalWeightDiff(&weightDiff))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if bytes.Equal(change.prevPublicKey, change.newPublicKey) {
+		return nil
+	}
+	// Record the prior value of the public key.
+	//
+	// Note: We store the uncompressed public key here as it is significantly
+	// more efficient to parse when applying diffs.
+	return s.validatorPublicKeyDiffsDB.Put(diffKey, change.prevPublicKey)
+}
+
+// updateSubnetOnlyValidatorSet applies [change] to the in-memory validator
+// set.
+func (s *state) updateSubnetOnlyValidatorSet(key subnetIDNodeID, change *sovValidatorChange) error {
+	if !bytes.Equal(change.prevPublicKey, change.newPublicKey) {
+		// The public key can't be modified in place, so the validator must be
+		// removed and re-added.
+		weight := s.validators.GetWeight(key.subnetID, key.nodeID)
+		if weight != 0 {
+			if err := s.validators.RemoveWeight(key.subnetID, key.nodeID, weight); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if change.weightDiff.Decrease {
+			weight, err = safemath.Sub(weight, change.weightDiff.Amount)
+		} else {
+			weight, err = safemath.Add(weight, change.weightDiff.Amount)
+		}
+		if err != nil || weight == 0 {
+			return err
+		}
+
+		var pk *bls.PublicKey
+		if len(change.newPublicKey) != 0 {
+			pk = bls.PublicKeyFromValidUncompressedBytes(change.newPublicKey)
+		}
+		return s.validators.AddStaker(key.subnetID, key.nodeID, pk, change.newValidationID, weight)
+	}
+
+	switch {
+	case change.weightDiff.Amount == 0:
+		return nil
+	case change.weightDiff.Decrease:
+		return s.validators.RemoveWeight(key.subnetID, key.nodeID, change.weightDiff.Amount)
+	}
+
+	if _, ok := s.validators.GetValidator(key.subnetID, key.nodeID); ok {
+		return s.validators.AddWeight(key.subnetID, key.nodeID, change.weightDiff.Amount)
+	}
+
+	var pk *bls.PublicKey
+	if len(change.newPublicKey) != 0 {
+		pk = bls.PublicKeyFromValidUncompressedBytes(change.newPublicKey)
+	}
+	return s.validators.AddStaker(key.subnetID, key.nodeID, pk, change.newValidationID, change.weightDiff.Amount)
+}
+
 func (s *state) writeCurrentStakers(updateValidators bool, height uint64, codecVersion uint16) error {
 	for subnetID, validatorDiffs := range s.currentStakers.validatorDiffs {
 		delete(s.currentStakers.validatorDiffs, subnetID)
@@ -2440,6 +3045,12 @@ func (s *state) writeMetadata() error {
 		}
 		s.persistedFeeState = s.feeState
 	}
+	if s.sovExcess != s.persistedSoVExcess {
+		if err := database.PutUInt64(s.singletonDB, SoVExcessKey, uint64(s.sovExcess)); err != nil {
+			return fmt.Errorf("failed to write sov excess: %w", err)
+		}
+		s.persistedSoVExcess = s.sovExcess
+	}
 	if s.accruedFees != s.persistedAccruedFees {
 		if err := database.PutUInt64(s.singletonDB, AccruedFeesKey, s.accruedFees); err != nil {
 			return fmt.Errorf("failed to write accrued fees: %w", err)
@@ -2666,6 +3277,14 @@ func getFeeState(db database.KeyValueReader) (gas.State, error) {
 	return feeState, nil
 }
 
+func getSoVExcess(db database.KeyValueReader) (gas.Gas, error) {
+	excess, err := database.GetUInt64(db, SoVExcessKey)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return gas.Gas(excess), err
+}
+
 func getAccruedFees(db database.KeyValueReader) (uint64, error) {
 	accruedFees, err := database.GetUInt64(db, AccruedFeesKey)
 	if err == database.ErrNotFound {
@@ -1161,6 +1161,7 @@ func TestStateAddRemoveValidator(t *testing.T) {
 				primaryValidatorSet,
 				currentHeight,
 				prevHeight+1,
+				constants.PrimaryNetworkID,
 			))
 			requireEqualPublicKeysValidatorSet(require, prevDiff.expectedPrimaryValidatorSet, primaryValidatorSet)
 
@@ -1496,6 +1497,23 @@ func TestStateAccruedFeesCommitAndLoad(t *testing.T) {
 	require.Equal(expectedAccruedFees, s.GetAccruedFees())
 }
 
+// Verify that committing the state writes the subnet-only validator excess to
+// the database and that loading the state fetches the excess from the
+// database.
+func TestStateSoVExcessCommitAndLoad(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	s := newTestState(t, db)
+
+	expectedExcess := gas.Gas(1)
+	s.SetSoVExcess(expectedExcess)
+	require.NoError(s.Commit())
+
+	s = newTestState(t, db)
+	require.Equal(expectedExcess, s.GetSoVExcess())
+}
+
 func TestMarkAndIsInitialized(t *testing.T) {
 	require := require.New(t)
 
@@ -1614,3 +1632,189 @@ func TestStateExpiryCommitAndLoad(t *testing.T) {
 	require.NoError(err)
 	require.False(has)
 }
+
+func TestStateSubnetOnlyValidators(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+	pkBytes := bls.PublicKeyToUncompressedBytes(pk)
+
+	var (
+		db       = memdb.New()
+		s        = newTestState(t, db)
+		subnetID = ids.GenerateTestID()
+		active   = SubnetOnlyValidator{
+			ValidationID:          ids.GenerateTestID(),
+			SubnetID:              subnetID,
+			NodeID:                ids.GenerateTestNodeID(),
+			PublicKey:             pkBytes,
+			RemainingBalanceOwner: []byte{},
+			DeactivationOwner:     []byte{},
+			Weight:                1,
+			EndAccumulatedFee:     1,
+		}
+		inactive = SubnetOnlyValidator{
+			ValidationID:          ids.GenerateTestID(),
+			SubnetID:              subnetID,
+			NodeID:                ids.GenerateTestNodeID(),
+			PublicKey:             pkBytes,
+			RemainingBalanceOwner: []byte{},
+			DeactivationOwner:     []byte{},
+			Weight:                2,
+		}
+	)
+
+	// Add an active and an inactive validator at height 1.
+	require.NoError(s.PutSubnetOnlyValidator(active))
+	require.NoError(s.PutSubnetOnlyValidator(inactive))
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// Verify that the validators were written and loaded correctly.
+	s = newTestState(t, db)
+	require.Equal(1, s.NumActiveSubnetOnlyValidators())
+
+	weight, err := s.WeightOfSubnetOnlyValidators(subnetID)
+	require.NoError(err)
+	require.Equal(uint64(3), weight)
+
+	gotActive, err := s.GetSubnetOnlyValidator(active.ValidationID)
+	require.NoError(err)
+	require.Equal(active, gotActive)
+
+	gotInactive, err := s.GetSubnetOnlyValidator(inactive.ValidationID)
+	require.NoError(err)
+	require.Equal(inactive, gotInactive)
+
+	has, err := s.HasSubnetOnlyValidator(subnetID, inactive.NodeID)
+	require.NoError(err)
+	require.True(has)
+
+	activeIt, err := s.GetActiveSubnetOnlyValidatorsIterator()
+	require.NoError(err)
+	require.Equal(
+		[]SubnetOnlyValidator{active},
+		iterator.ToSlice(activeIt),
+	)
+
+	// Inactive validators are reported under the empty nodeID.
+	expectedValidatorSet := map[ids.NodeID]*validators.GetValidatorOutput{
+		active.NodeID: {
+			NodeID:    active.NodeID,
+			PublicKey: pk,
+			Weight:    active.Weight,
+		},
+		ids.EmptyNodeID: {
+			NodeID: ids.EmptyNodeID,
+			Weight: inactive.Weight,
+		},
+	}
+	validatorSet := s.validators.GetMap(subnetID)
+	requireEqualWeightsValidatorSet(require, expectedValidatorSet, validatorSet)
+	requireEqualPublicKeysValidatorSet(require, expectedValidatorSet, validatorSet)
+
+	// Remove the active validator at height 2.
+	active.Weight = 0
+	require.NoError(s.PutSubnetOnlyValidator(active))
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	s = newTestState(t, db)
+	require.Zero(s.NumActiveSubnetOnlyValidators())
+
+	_, err = s.GetSubnetOnlyValidator(active.ValidationID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	has, err = s.HasSubnetOnlyValidator(subnetID, active.NodeID)
+	require.NoError(err)
+	require.False(has)
+
+	// Applying the diffs to the current validator set should result in the
+	// validator set at height 1.
+	validatorSet = s.validators.GetMap(subnetID)
+	require.NoError(s.ApplyValidatorWeightDiffs(
+		context.Background(),
+		validatorSet,
+		2,
+		2,
+		subnetID,
+	))
+	require.NoError(s.ApplyValidatorPublicKeyDiffs(
+		context.Background(),
+		validatorSet,
+		2,
+		2,
+		subnetID,
+	))
+	requireEqualWeightsValidatorSet(require, expectedValidatorSet, validatorSet)
+	requireEqualPublicKeysValidatorSet(require, expectedValidatorSet, validatorSet)
+}
+
+func TestStateSubnetOnlyValidatorIDs(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pkBytes := bls.PublicKeyToUncompressedBytes(bls.PublicFromSecretKey(sk))
+
+	var (
+		db       = memdb.New()
+		s        = newTestState(t, db)
+		subnetID = ids.GenerateTestID()
+		newSoV   = func(validationID ids.ID, subnetID ids.ID) SubnetOnlyValidator {
+			return SubnetOnlyValidator{
+				ValidationID:          validationID,
+				SubnetID:              subnetID,
+				NodeID:                ids.GenerateTestNodeID(),
+				PublicKey:             pkBytes,
+				RemainingBalanceOwner: []byte{},
+				DeactivationOwner:     []byte{},
+				Weight:                1,
+			}
+		}
+		sov0        = newSoV(ids.ID{1}, subnetID)
+		sov1        = newSoV(ids.ID{2}, subnetID)
+		sov2        = newSoV(ids.ID{3}, subnetID)
+		otherSubnet = newSoV(ids.ID{4}, ids.GenerateTestID())
+	)
+
+	// Uncommitted validators are not returned.
+	require.NoError(s.PutSubnetOnlyValidator(sov1))
+	require.NoError(s.PutSubnetOnlyValidator(sov0))
+	require.NoError(s.PutSubnetOnlyValidator(sov2))
+	require.NoError(s.PutSubnetOnlyValidator(otherSubnet))
+
+	validationIDs, err := s.SubnetOnlyValidatorIDs(subnetID, ids.Empty, 10)
+	require.NoError(err)
+	require.Empty(validationIDs)
+
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// Verify that the index is written and loaded correctly.
+	s = newTestState(t, db)
+
+	validationIDs, err = s.SubnetOnlyValidatorIDs(subnetID, ids.Empty, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{sov0.ValidationID, sov1.ValidationID, sov2.ValidationID}, validationIDs)
+
+	validationIDs, err = s.SubnetOnlyValidatorIDs(subnetID, ids.Empty, 2)
+	require.NoError(err)
+	require.Equal([]ids.ID{sov0.ValidationID, sov1.ValidationID}, validationIDs)
+
+	validationIDs, err = s.SubnetOnlyValidatorIDs(subnetID, sov1.ValidationID, 2)
+	require.NoError(err)
+	require.Equal([]ids.ID{sov2.ValidationID}, validationIDs)
+
+	// Removed validators are removed from the index.
+	sov1.Weight = 0
+	require.NoError(s.PutSubnetOnlyValidator(sov1))
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	validationIDs, err = s.SubnetOnlyValidatorIDs(subnetID, ids.Empty, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{sov0.ValidationID, sov2.ValidationID}, validationIDs)
+}
@@ -4,16 +4,67 @@
 package state
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 
 	"github.com/google/btree"
 
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/iterator"
+	"github.com/MetalBlockchain/metalgo/utils/math"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/block"
 )
 
-var _ btree.LessFunc[*SubnetOnlyValidator] = (*SubnetOnlyValidator).Less
+// subnetIDNodeID = [subnetID] + [nodeID]
+const subnetIDNodeIDEntryLength = ids.IDLen + ids.NodeIDLen
+
+var (
+	_ btree.LessFunc[SubnetOnlyValidator] = SubnetOnlyValidator.Less
+
+	ErrMutatedSubnetOnlyValidator   = errors.New("subnet-only validator contains mutated constant fields")
+	ErrDuplicateSubnetOnlyValidator = errors.New("subnet-only validator contains duplicate subnetID + nodeID pair")
+
+	errUnexpectedSubnetIDNodeIDLength = fmt.Errorf("expected subnetID+nodeID entry length %d", subnetIDNodeIDEntryLength)
+)
+
+type SubnetOnlyValidators interface {
+	// GetActiveSubnetOnlyValidatorsIterator returns an iterator of all the
+	// active subnet-only validators in increasing order of EndAccumulatedFee.
+	GetActiveSubnetOnlyValidatorsIterator() (iterator.Iterator[SubnetOnlyValidator], error)
+
+	// NumActiveSubnetOnlyValidators returns the number of currently active
+	// subnet-only validators.
+	NumActiveSubnetOnlyValidators() int
+
+	// WeightOfSubnetOnlyValidators returns the total active and inactive weight
+	// of subnet-only validators on [subnetID].
+	WeightOfSubnetOnlyValidators(subnetID ids.ID) (uint64, error)
+
+	// GetSubnetOnlyValidator returns the validator with [validationID] if it
+	// exists. If the validator does not exist, [err] will equal
+	// [database.ErrNotFound].
+	GetSubnetOnlyValidator(validationID ids.ID) (SubnetOnlyValidator, error)
+
+	// HasSubnetOnlyValidator returns true if a validator with the [subnetID]
+	// and [nodeID] pair currently exists.
+	HasSubnetOnlyValidator(subnetID ids.ID, nodeID ids.NodeID) (bool, error)
+
+	// PutSubnetOnlyValidator inserts [sov] as a validator. If the weight of the
+	// validator is 0, the validator is removed.
+	//
+	// If inserting this validator attempts to modify any of the constant fields
+	// of the subnet-only validator struct, an error will be returned.
+	//
+	// If inserting this validator would cause the total weight of subnet-only
+	// validators on a subnet to overflow MaxUint64, an error will be returned.
+	//
+	// If inserting this validator would cause there to be multiple validators
+	// with the same subnetID and nodeID pair to exist at the same time, an
+	// error will be returned.
+	PutSubnetOnlyValidator(sov SubnetOnlyValidator) error
+}
 
 type SubnetOnlyValidator struct {
 	// ValidationID is not serialized because it is used as the key in the
@@ -27,6 +78,14 @@ type SubnetOnlyValidator struct {
 	// guaranteed to be populated.
 	PublicKey []byte `serialize:"true"`
 
+	// RemainingBalanceOwner is the owner that will be used when returning the
+	// balance of the validator after removing accrued fees.
+	RemainingBalanceOwner []byte `serialize:"true"`
+
+	// DeactivationOwner is the owner that can manually deactivate the
+	// validator.
+	DeactivationOwner []byte `serialize:"true"`
+
 	// StartTime is the unix timestamp, in seconds, when this validator was
 	// added to the set.
 	StartTime uint64 `serialize:"true"`
@@ -46,10 +105,12 @@ type SubnetOnlyValidator struct {
 	// accrue before this validator must be deactivated. It is equal to the
 	// amount of fees this validator is willing to pay plus the amount of
 	// globally accumulated fees when this validator started validating.
+	//
+	// If this value is 0, the validator is inactive.
 	EndAccumulatedFee uint64 `serialize:"true"`
 }
 
-// Less determines a canonical ordering of *SubnetOnlyValidators based on their
+// Less determines a canonical ordering of SubnetOnlyValidators based on their
 // EndAccumulatedFees and ValidationIDs.
 //
 // Returns true if:
@@ -57,7 +118,7 @@ type SubnetOnlyValidator struct {
 //  1. This validator has a lower EndAccumulatedFee than the other.
 //  2. This validator has an equal EndAccumulatedFee to the other and has a
 //     lexicographically lower ValidationID.
-func (v *SubnetOnlyValidator) Less(o *SubnetOnlyValidator) bool {
+func (v SubnetOnlyValidator) Less(o SubnetOnlyValidator) bool {
 	switch {
 	case v.EndAccumulatedFee < o.EndAccumulatedFee:
 		return true
@@ -68,22 +129,62 @@ func (v *SubnetOnlyValidator) Less(o *SubnetOnlyValidator) bool {
 	}
 }
 
-func getSubnetOnlyValidator(db database.KeyValueReader, validationID ids.ID) (*SubnetOnlyValidator, error) {
+// constantsAreUnmodified returns true if the constants of this validator have
+// not been modified compared to the other validator.
+func (v SubnetOnlyValidator) constantsAreUnmodified(o SubnetOnlyValidator) bool {
+	return v.ValidationID == o.ValidationID &&
+		v.SubnetID == o.SubnetID &&
+		v.NodeID == o.NodeID &&
+		bytes.Equal(v.PublicKey, o.PublicKey) &&
+		bytes.Equal(v.RemainingBalanceOwner, o.RemainingBalanceOwner) &&
+		bytes.Equal(v.DeactivationOwner, o.DeactivationOwner) &&
+		v.StartTime == o.StartTime
+}
+
+func (v SubnetOnlyValidator) isDeleted() bool {
+	return v.Weight == 0
+}
+
+func (v SubnetOnlyValidator) isActive() bool {
+	return v.Weight != 0 && v.EndAccumulatedFee != 0
+}
+
+// effectiveNodeID returns the nodeID that this validator is reported under in
+// the validator set. Inactive validators are all reported under
+// [ids.EmptyNodeID] so that their weight is still accounted for.
+func (v SubnetOnlyValidator) effectiveNodeID() ids.NodeID {
+	if v.isActive() {
+		return v.NodeID
+	}
+	return ids.EmptyNodeID
+}
+
+// effectivePublicKeyBytes returns the public key that this validator is
+// reported with in the validator set. Inactive validators do not report a
+// public key.
+func (v SubnetOnlyValidator) effectivePublicKeyBytes() []byte {
+	if v.isActive() {
+		return v.PublicKey
+	}
+	return nil
+}
+
+func getSubnetOnlyValidator(db database.KeyValueReader, validationID ids.ID) (SubnetOnlyValidator, error) {
 	bytes, err := db.Get(validationID[:])
 	if err != nil {
-		return nil, err
+		return SubnetOnlyValidator{}, err
 	}
 
-	vdr := &SubnetOnlyValidator{
+	vdr := SubnetOnlyValidator{
 		ValidationID: validationID,
 	}
-	if _, err = block.GenesisCodec.Unmarshal(bytes, vdr); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal SubnetOnlyValidator: %w", err)
+	if _, err := block.GenesisCodec.Unmarshal(bytes, &vdr); err != nil {
+		return SubnetOnlyValidator{}, fmt.Errorf("failed to unmarshal SubnetOnlyValidator: %w", err)
 	}
-	return vdr, err
+	return vdr, nil
 }
 
-func putSubnetOnlyValidator(db database.KeyValueWriter, vdr *SubnetOnlyValidator) error {
+func putSubnetOnlyValidator(db database.KeyValueWriter, vdr SubnetOnlyValidator) error {
 	bytes, err := block.GenesisCodec.Marshal(block.CodecVersion, vdr)
 	if err != nil {
 		return fmt.Errorf("failed to marshal SubnetOnlyValidator: %w", err)
@@ -94,3 +195,195 @@ func putSubnetOnlyValidator(db database.KeyValueWriter, vdr *SubnetOnlyValidator
 func deleteSubnetOnlyValidator(db database.KeyValueDeleter, validationID ids.ID) error {
 	return db.Delete(validationID[:])
 }
+
+type subnetIDNodeID struct {
+	subnetID ids.ID
+	nodeID   ids.NodeID
+}
+
+func (s *subnetIDNodeID) Marshal() []byte {
+	data := make([]byte, subnetIDNodeIDEntryLength)
+	copy(data, s.subnetID[:])
+	copy(data[ids.IDLen:], s.nodeID[:])
+	return data
+}
+
+func (s *subnetIDNodeID) Unmarshal(data []byte) error {
+	if len(data) != subnetIDNodeIDEntryLength {
+		return errUnexpectedSubnetIDNodeIDLength
+	}
+
+	copy(s.subnetID[:], data)
+	copy(s.nodeID[:], data[ids.IDLen:])
+	return nil
+}
+
+// subnetIDValidationIDKey returns the [subnetID] + [validationID] key used to
+// index the subnet-only validators of a subnet.
+func subnetIDValidationIDKey(subnetID ids.ID, validationID ids.ID) []byte {
+	key := make([]byte, 2*ids.IDLen)
+	copy(key, subnetID[:])
+	copy(key[ids.IDLen:], validationID[:])
+	return key
+}
+
+type subnetOnlyValidatorsDiff struct {
+	numAddedActive      int                            // May be negative
+	modifiedTotalWeight map[ids.ID]uint64              // subnetID -> totalWeight
+	modified            map[ids.ID]SubnetOnlyValidator // validationID -> validator
+	modifiedHasNodeIDs  map[subnetIDNodeID]bool        // subnetID+nodeID -> isAdded
+	active              *btree.BTreeG[SubnetOnlyValidator]
+}
+
+func newSubnetOnlyValidatorsDiff() *subnetOnlyValidatorsDiff {
+	return &subnetOnlyValidatorsDiff{
+		modifiedTotalWeight: make(map[ids.ID]uint64),
+		modified:            make(map[ids.ID]SubnetOnlyValidator),
+		modifiedHasNodeIDs:  make(map[subnetIDNodeID]bool),
+		active:              btree.NewG(defaultTreeDegree, SubnetOnlyValidator.Less),
+	}
+}
+
+// getActiveSubnetOnlyValidatorsIterator takes in the parent iterator, removes
+// all modified validators, and then adds all modified active validators.
+func (d *subnetOnlyValidatorsDiff) getActiveSubnetOnlyValidatorsIterator(parentIterator iterator.Iterator[SubnetOnlyValidator]) iterator.Iterator[SubnetOnlyValidator] {
+	return iterator.Merge(
+		SubnetOnlyValidator.Less,
+		iterator.Filter(parentIterator, func(sov SubnetOnlyValidator) bool {
+			_, ok := d.modified[sov.ValidationID]
+			return ok
+		}),
+		iterator.FromTree(d.active),
+	)
+}
+
+func (d *subnetOnlyValidatorsDiff) hasSubnetOnlyValidator(subnetID ids.ID, nodeID ids.NodeID) (bool, bool) {
+	subnetIDNodeID := subnetIDNodeID{
+		subnetID: subnetID,
+		nodeID:   nodeID,
+	}
+	has, modified := d.modifiedHasNodeIDs[subnetIDNodeID]
+	return has, modified
+}
+
+// putSubnetOnlyValidator records [sov] in the diff. [state] must reflect all
+// prior modifications, including the ones recorded in this diff.
+func (d *subnetOnlyValidatorsDiff) putSubnetOnlyValidator(state SubnetOnlyValidators, sov SubnetOnlyValidator) error {
+	var (
+		prevWeight uint64
+		prevActive bool
+		newActive  = sov.isActive()
+	)
+	switch priorSOV, err := state.GetSubnetOnlyValidator(sov.ValidationID); err {
+	case nil:
+		if !priorSOV.constantsAreUnmodified(sov) {
+			return ErrMutatedSubnetOnlyValidator
+		}
+
+		prevWeight = priorSOV.Weight
+		prevActive = priorSOV.isActive()
+	case database.ErrNotFound:
+		// Deleting a non-existent validator is a noop. This can happen if the
+		// validator was added and then immediately removed.
+		if sov.isDeleted() {
+			return nil
+		}
+
+		has, err := state.HasSubnetOnlyValidator(sov.SubnetID, sov.NodeID)
+		if err != nil {
+			return err
+		}
+		if has {
+			return ErrDuplicateSubnetOnlyValidator
+		}
+	default:
+		return err
+	}
+
+	if prevWeight != sov.Weight {
+		weight, err := state.WeightOfSubnetOnlyValidators(sov.SubnetID)
+		if err != nil {
+			return err
+		}
+
+		weight, err = math.Sub(weight, prevWeight)
+		if err != nil {
+			return err
+		}
+		weight, err = math.Add(weight, sov.Weight)
+		if err != nil {
+			return err
+		}
+
+		d.modifiedTotalWeight[sov.SubnetID] = weight
+	}
+
+	switch {
+	case prevActive && !newActive:
+		d.numAddedActive--
+	case !prevActive && newActive:
+		d.numAddedActive++
+	}
+
+	if prevSOV, ok := d.modified[sov.ValidationID]; ok {
+		d.active.Delete(prevSOV)
+	}
+	d.modified[sov.ValidationID] = sov
+
+	subnetIDNodeID := subnetIDNodeID{
+		subnetID: sov.SubnetID,
+		nodeID:   sov.NodeID,
+	}
+	d.modifiedHasNodeIDs[subnetIDNodeID] = !sov.isDeleted()
+	if newActive {
+		d.active.ReplaceOrInsert(sov)
+	}
+	return nil
+}
+
+// activeSubnetOnlyValidators is the in-memory set of active subnet-only
+// validators. The number of active validators is bounded by the validator fee
+// capacity, so it is always kept in memory.
+type activeSubnetOnlyValidators struct {
+	lookup map[ids.ID]SubnetOnlyValidator
+	tree   *btree.BTreeG[SubnetOnlyValidator]
+}
+
+func newActiveSubnetOnlyValidators() *activeSubnetOnlyValidators {
+	return &activeSubnetOnlyValidators{
+		lookup: make(map[ids.ID]SubnetOnlyValidator),
+		tree:   btree.NewG(defaultTreeDegree, SubnetOnlyValidator.Less),
+	}
+}
+
+func (a *activeSubnetOnlyValidators) get(validationID ids.ID) (SubnetOnlyValidator, bool) {
+	sov, ok := a.lookup[validationID]
+	return sov, ok
+}
+
+func (a *activeSubnetOnlyValidators) put(sov SubnetOnlyValidator) {
+	if prevSOV, ok := a.lookup[sov.ValidationID]; ok {
+		a.tree.Delete(prevSOV)
+	}
+	a.lookup[sov.ValidationID] = sov
+	a.tree.ReplaceOrInsert(sov)
+}
+
+func (a *activeSubnetOnlyValidators) delete(validationID ids.ID) bool {
+	sov, ok := a.lookup[validationID]
+	if !ok {
+		return false
+	}
+
+	delete(a.lookup, validationID)
+	a.tree.Delete(sov)
+	return true
+}
+
+func (a *activeSubnetOnlyValidators) len() int {
+	return len(a.lookup)
+}
+
+func (a *activeSubnetOnlyValidators) newIterator() iterator.Iterator[SubnetOnlyValidator] {
+	return iterator.FromTree(a.tree)
+}
@@ -12,23 +12,24 @@ import (
 	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/database/memdb"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
 )
 
 func TestSubnetOnlyValidator_Less(t *testing.T) {
 	tests := []struct {
 		name  string
-		v     *SubnetOnlyValidator
-		o     *SubnetOnlyValidator
+		v     SubnetOnlyValidator
+		o     SubnetOnlyValidator
 		equal bool
 	}{
 		{
 			name: "v.EndAccumulatedFee < o.EndAccumulatedFee",
-			v: &SubnetOnlyValidator{
+			v: SubnetOnlyValidator{
 				ValidationID:      ids.GenerateTestID(),
 				EndAccumulatedFee: 1,
 			},
-			o: &SubnetOnlyValidator{
+			o: SubnetOnlyValidator{
 				ValidationID:      ids.GenerateTestID(),
 				EndAccumulatedFee: 2,
 			},
@@ -36,11 +37,11 @@ func TestSubnetOnlyValidator_Less(t *testing.T) {
 		},
 		{
 			name: "v.EndAccumulatedFee = o.EndAccumulatedFee, v.ValidationID < o.ValidationID",
-			v: &SubnetOnlyValidator{
+			v: SubnetOnlyValidator{
 				ValidationID:      ids.ID{0},
 				EndAccumulatedFee: 1,
 			},
-			o: &SubnetOnlyValidator{
+			o: SubnetOnlyValidator{
 				ValidationID:      ids.ID{1},
 				EndAccumulatedFee: 1,
 			},
@@ -48,11 +49,11 @@ func TestSubnetOnlyValidator_Less(t *testing.T) {
 		},
 		{
 			name: "v.EndAccumulatedFee = o.EndAccumulatedFee, v.ValidationID = o.ValidationID",
-			v: &SubnetOnlyValidator{
+			v: SubnetOnlyValidator{
 				ValidationID:      ids.ID{0},
 				EndAccumulatedFee: 1,
 			},
-			o: &SubnetOnlyValidator{
+			o: SubnetOnlyValidator{
 				ValidationID:      ids.ID{0},
 				EndAccumulatedFee: 1,
 			},
@@ -79,21 +80,23 @@ func TestSubnetOnlyValidator_DatabaseHelpers(t *testing.T) {
 	sk, err := bls.NewSecretKey()
 	require.NoError(err)
 
-	vdr := &SubnetOnlyValidator{
-		ValidationID:      ids.GenerateTestID(),
-		SubnetID:          ids.GenerateTestID(),
-		NodeID:            ids.GenerateTestNodeID(),
-		PublicKey:         bls.PublicKeyToUncompressedBytes(bls.PublicFromSecretKey(sk)),
-		StartTime:         rand.Uint64(), // #nosec G404
-		Weight:            rand.Uint64(), // #nosec G404
-		MinNonce:          rand.Uint64(), // #nosec G404
-		EndAccumulatedFee: rand.Uint64(), // #nosec G404
+	vdr := SubnetOnlyValidator{
+		ValidationID:          ids.GenerateTestID(),
+		SubnetID:              ids.GenerateTestID(),
+		NodeID:                ids.GenerateTestNodeID(),
+		PublicKey:             bls.PublicKeyToUncompressedBytes(bls.PublicFromSecretKey(sk)),
+		RemainingBalanceOwner: utils.RandomBytes(32),
+		DeactivationOwner:     utils.RandomBytes(32),
+		StartTime:             rand.Uint64(), // #nosec G404
+		Weight:                rand.Uint64(), // #nosec G404
+		MinNonce:              rand.Uint64(), // #nosec G404
+		EndAccumulatedFee:     rand.Uint64(), // #nosec G404
 	}
 
 	// Validator hasn't been put on disk yet
 	gotVdr, err := getSubnetOnlyValidator(db, vdr.ValidationID)
 	require.ErrorIs(err, database.ErrNotFound)
-	require.Nil(gotVdr)
+	require.Zero(gotVdr)
 
 	// Place the validator on disk
 	require.NoError(putSubnetOnlyValidator(db, vdr))
@@ -109,5 +112,58 @@ func TestSubnetOnlyValidator_DatabaseHelpers(t *testing.T) {
 	// Verify that the validator has been removed from disk
 	gotVdr, err = getSubnetOnlyValidator(db, vdr.ValidationID)
 	require.ErrorIs(err, database.ErrNotFound)
-	require.Nil(gotVdr)
+	require.Zero(gotVdr)
+}
+
+func TestSubnetOnlyValidatorsDiff_PutSubnetOnlyValidator(t *testing.T) {
+	require := require.New(t)
+
+	s := newTestState(t, memdb.New())
+	d, err := NewDiffOn(s)
+	require.NoError(err)
+
+	sov := SubnetOnlyValidator{
+		ValidationID:      ids.GenerateTestID(),
+		SubnetID:          ids.GenerateTestID(),
+		NodeID:            ids.GenerateTestNodeID(),
+		Weight:            1,
+		EndAccumulatedFee: 1,
+	}
+	require.NoError(d.PutSubnetOnlyValidator(sov))
+	require.Equal(1, d.NumActiveSubnetOnlyValidators())
+
+	// Constant fields can not be modified.
+	mutatedSOV := sov
+	mutatedSOV.StartTime++
+	err = d.PutSubnetOnlyValidator(mutatedSOV)
+	require.ErrorIs(err, ErrMutatedSubnetOnlyValidator)
+
+	// A second validator with the same subnetID+nodeID can not be added.
+	duplicateSOV := sov
+	duplicateSOV.ValidationID = ids.GenerateTestID()
+	err = d.PutSubnetOnlyValidator(duplicateSOV)
+	require.ErrorIs(err, ErrDuplicateSubnetOnlyValidator)
+
+	// Once the original validator is removed, the subnetID+nodeID pair can be
+	// reused.
+	sov.Weight = 0
+	require.NoError(d.PutSubnetOnlyValidator(sov))
+	require.Zero(d.NumActiveSubnetOnlyValidators())
+	require.NoError(d.PutSubnetOnlyValidator(duplicateSOV))
+	require.Equal(1, d.NumActiveSubnetOnlyValidators())
+
+	weight, err := d.WeightOfSubnetOnlyValidators(sov.SubnetID)
+	require.NoError(err)
+	require.Equal(duplicateSOV.Weight, weight)
+
+	// Applying the diff should result in the same state.
+	require.NoError(d.Apply(s))
+	require.Equal(1, s.NumActiveSubnetOnlyValidators())
+
+	_, err = s.GetSubnetOnlyValidator(sov.ValidationID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	gotSOV, err := s.GetSubnetOnlyValidator(duplicateSOV.ValidationID)
+	require.NoError(err)
+	require.Equal(duplicateSOV, gotSOV)
 }
@@ -0,0 +1,782 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/btree"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/linkeddb"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/database/versiondb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/block"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
+)
+
+// The syncable state is written as records whose keys are a single byte,
+// identifying the database the record belongs to, followed by the key of the
+// record in that database.
+//
+// Records only contain values that are identical on every node that accepted
+// the same blocks. Locally measured values, such as validator uptimes, are
+// reset and historical indices, such as the reward UTXOs, are not synced. Only
+// the transactions that the synced state refers to, such as those that added
+// the current stakers or created the subnets and chains, are synced, and only
+// the validator diffs of the last validatorDiffsSyncWindow heights are synced.
+const (
+	syncBlockPrefix byte = iota
+	syncSingletonPrefix
+	syncCurrentValidatorPrefix
+	syncCurrentDelegatorPrefix
+	syncCurrentSubnetValidatorPrefix
+	syncCurrentSubnetDelegatorPrefix
+	syncPendingValidatorPrefix
+	syncPendingDelegatorPrefix
+	syncPendingSubnetValidatorPrefix
+	syncPendingSubnetDelegatorPrefix
+	syncExpiryPrefix
+	syncWeightsPrefix
+	syncSubnetIDNodeIDPrefix
+	syncSubnetIDValidationIDPrefix
+	syncActivePrefix
+	syncInactivePrefix
+	syncTxPrefix
+	syncUTXOPrefix
+	syncSubnetPrefix
+	syncSubnetOwnerPrefix
+	syncSubnetManagerPrefix
+	syncTransformedSubnetPrefix
+	syncSupplyPrefix
+	syncChainPrefix
+	syncValidatorWeightDiffPrefix
+	syncValidatorPublicKeyDiffPrefix
+
+	// validatorDiffsSyncWindow is the number of heights, up to and including
+	// the synced height, whose validator diffs are synced. This allows synced
+	// nodes to calculate recent validator sets, such as those referenced by
+	// the blocks of other chains.
+	validatorDiffsSyncWindow uint64 = 16384
+
+	// applyCommitSize is the number of bytes that are written or removed while
+	// applying the syncable state before the changes are flushed to disk.
+	applyCommitSize = 16 * units.MiB
+)
+
+var (
+	ErrMissingValidatorDiffs = errors.New("validator diffs are missing")
+
+	errUnknownSyncRecord  = errors.New("unknown sync record")
+	errInvalidChainRecord = errors.New("invalid chain record")
+	errMissingSyncBlock   = errors.New("missing sync block")
+	errUnexpectedBlock    = errors.New("unexpected block")
+)
+
+// syncableDBs returns the databases whose contents are synced as they are
+// stored, keyed by the record prefix used for them.
+//
+// Because nested prefix databases don't share the key space of their parent,
+// only the innermost databases are synced. [db] must be the database that the
+// state is built on.
+func syncableDBs(db database.Database) map[byte]database.Database {
+	subnetOnlyValidatorsDB := prefixdb.New(SubnetOnlyValidatorsPrefix, db)
+	return map[byte]database.Database{
+		syncExpiryPrefix:               prefixdb.New(ExpiryReplayProtectionPrefix, db),
+		syncWeightsPrefix:              prefixdb.New(WeightsPrefix, subnetOnlyValidatorsDB),
+		syncSubnetIDNodeIDPrefix:       prefixdb.New(SubnetIDNodeIDPrefix, subnetOnlyValidatorsDB),
+		syncSubnetIDValidationIDPrefix: prefixdb.New(SubnetIDValidationIDPrefix, subnetOnlyValidatorsDB),
+		syncActivePrefix:               prefixdb.New(ActivePrefix, subnetOnlyValidatorsDB),
+		syncInactivePrefix:             prefixdb.New(InactivePrefix, subnetOnlyValidatorsDB),
+		syncSubnetOwnerPrefix:          prefixdb.New(SubnetOwnerPrefix, db),
+		syncSubnetManagerPrefix:        prefixdb.New(SubnetManagerPrefix, db),
+		syncTransformedSubnetPrefix:    prefixdb.New(TransformedSubnetPrefix, db),
+		syncSupplyPrefix:               prefixdb.New(SupplyPrefix, db),
+	}
+}
+
+// syncableLists returns the databases of the staker and subnet lists, keyed by
+// the record prefix used for them. These lists are synced entry by entry, as
+// the values of the current validator lists include local uptime measurements.
+func syncableLists(db database.Database) map[byte]database.Database {
+	var (
+		validatorsDB        = prefixdb.New(ValidatorsPrefix, db)
+		currentValidatorsDB = prefixdb.New(CurrentPrefix, validatorsDB)
+		pendingValidatorsDB = prefixdb.New(PendingPrefix, validatorsDB)
+	)
+	return map[byte]database.Database{
+		syncCurrentValidatorPrefix:       prefixdb.New(ValidatorPrefix, currentValidatorsDB),
+		syncCurrentDelegatorPrefix:       prefixdb.New(DelegatorPrefix, currentValidatorsDB),
+		syncCurrentSubnetValidatorPrefix: prefixdb.New(SubnetValidatorPrefix, currentValidatorsDB),
+		syncCurrentSubnetDelegatorPrefix: prefixdb.New(SubnetDelegatorPrefix, currentValidatorsDB),
+		syncPendingValidatorPrefix:       prefixdb.New(ValidatorPrefix, pendingValidatorsDB),
+		syncPendingDelegatorPrefix:       prefixdb.New(DelegatorPrefix, pendingValidatorsDB),
+		syncPendingSubnetValidatorPrefix: prefixdb.New(SubnetValidatorPrefix, pendingValidatorsDB),
+		syncPendingSubnetDelegatorPrefix: prefixdb.New(SubnetDelegatorPrefix, pendingValidatorsDB),
+		syncSubnetPrefix:                 prefixdb.New(SubnetPrefix, db),
+	}
+}
+
+// syncableDiffDBs returns the databases of the validator diffs, keyed by the
+// record prefix used for them.
+func syncableDiffDBs(db database.Database) map[byte]database.Database {
+	validatorsDB := prefixdb.New(ValidatorsPrefix, db)
+	return map[byte]database.Database{
+		syncValidatorWeightDiffPrefix:    prefixdb.New(ValidatorWeightDiffsPrefix, validatorsDB),
+		syncValidatorPublicKeyDiffPrefix: prefixdb.New(ValidatorPublicKeyDiffsPrefix, validatorsDB),
+	}
+}
+
+// subnetChainsDB returns the database of the list of the chains of [subnetID].
+func subnetChainsDB(db database.Database, subnetID ids.ID) database.Database {
+	return prefixdb.New(subnetID[:], prefixdb.New(ChainPrefix, db))
+}
+
+func syncRecordKey(prefix byte, key []byte) []byte {
+	recordKey := make([]byte, 1+len(key))
+	recordKey[0] = prefix
+	copy(recordKey[1:], key)
+	return recordKey
+}
+
+// SyncableSnapshot is a view of the syncable state at the time it was taken.
+// It isn't affected by changes that are committed afterwards.
+type SyncableSnapshot interface {
+	// Write writes the records of the syncable state to [w].
+	Write(w database.KeyValueWriter) error
+
+	// Release releases the resources held by the snapshot. It must be called
+	// once the snapshot is no longer needed.
+	Release()
+}
+
+// syncableSnapshot holds the records that are derived from the in-memory state
+// and iterators over the databases that the remaining records are read from.
+// The iterators of the database that the state is built on iterate over
+// snapshots of it, so the records can be written without holding the lock that
+// protects the state.
+type syncableSnapshot struct {
+	records *memdb.Database
+	// iterators are keyed by the record prefix of the values they iterate
+	// over.
+	iterators map[byte]database.Iterator
+	// chains are the iterators over the chain lists, keyed by subnet ID.
+	chains map[ids.ID]database.Iterator
+	// diffs are the iterators over the validator diffs of each subnet, from
+	// the synced height towards the genesis, keyed by record prefix.
+	diffs map[byte][]database.Iterator
+	// diffsHeight is the height at or below which the validator diffs are
+	// not synced.
+	diffsHeight uint64
+	// txIDs are the IDs of the transactions that are referenced by the
+	// records. Only these transactions are synced.
+	txIDs set.Set[ids.ID]
+	txDB  database.KeyValueReader
+}
+
+// NewSyncableSnapshot returns a snapshot of the last committed state.
+//
+// Invariant: There must not be any uncommitted changes.
+func (s *state) NewSyncableSnapshot() (SyncableSnapshot, error) {
+	snapshot := &syncableSnapshot{
+		records:   memdb.New(),
+		iterators: make(map[byte]database.Iterator),
+		chains:    make(map[ids.ID]database.Iterator),
+		diffs:     make(map[byte][]database.Iterator),
+		txIDs:     set.Set[ids.ID]{},
+		txDB:      s.txDB,
+	}
+	if err := s.snapshotSyncableState(snapshot); err != nil {
+		snapshot.Release()
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (s *state) snapshotSyncableState(snapshot *syncableSnapshot) error {
+	blk, err := s.GetStatelessBlock(s.lastAccepted)
+	if err != nil {
+		return err
+	}
+	if err := snapshot.records.Put(syncRecordKey(syncBlockPrefix, s.lastAccepted[:]), blk.Bytes()); err != nil {
+		return err
+	}
+
+	// The validator diffs that this node didn't sync itself can't be synced.
+	height := blk.Height()
+	snapshot.diffsHeight = s.syncedHeight
+	if height > validatorDiffsSyncWindow {
+		snapshot.diffsHeight = max(snapshot.diffsHeight, height-validatorDiffsSyncWindow)
+	}
+	if err := s.snapshotSyncableSingletons(snapshot.records, snapshot.diffsHeight); err != nil {
+		return err
+	}
+	if err := s.snapshotSyncableValidators(snapshot); err != nil {
+		return err
+	}
+
+	// The chains created in the genesis are stored under the primary network.
+	subnetIDs, err := s.GetSubnetIDs()
+	if err != nil {
+		return err
+	}
+	subnetIDs = append([]ids.ID{constants.PrimaryNetworkID}, subnetIDs...)
+	diffDBs := map[byte]database.Database{
+		syncValidatorWeightDiffPrefix:    s.validatorWeightDiffsDB,
+		syncValidatorPublicKeyDiffPrefix: s.validatorPublicKeyDiffsDB,
+	}
+	for _, subnetID := range subnetIDs {
+		snapshot.chains[subnetID] = linkeddb.NewUnorderedIterator(subnetChainsDB(s.baseDB, subnetID))
+		for prefix, db := range diffDBs {
+			snapshot.diffs[prefix] = append(snapshot.diffs[prefix], db.NewIteratorWithStartAndPrefix(
+				marshalStartDiffKey(subnetID, height),
+				subnetID[:],
+			))
+		}
+	}
+
+	// The current validators are snapshotted from memory.
+	for prefix, listDB := range syncableLists(s.baseDB) {
+		if prefix != syncCurrentValidatorPrefix {
+			snapshot.iterators[prefix] = linkeddb.NewUnorderedIterator(listDB)
+		}
+	}
+	for prefix, db := range syncableDBs(s.baseDB) {
+		snapshot.iterators[prefix] = db.NewIterator()
+	}
+	snapshot.iterators[syncUTXOPrefix] = avax.NewUTXOIterator(s.utxoDB)
+	return nil
+}
+
+func (s *state) snapshotSyncableSingletons(records database.KeyValueWriter, diffsHeight uint64) error {
+	singletons := memdb.New()
+	if err := database.PutUInt64(singletons, SyncedHeightKey, diffsHeight); err != nil {
+		return err
+	}
+	if err := database.PutTimestamp(singletons, TimestampKey, s.persistedTimestamp); err != nil {
+		return err
+	}
+	if err := putFeeState(singletons, s.persistedFeeState); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(singletons, SoVExcessKey, uint64(s.persistedSoVExcess)); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(singletons, AccruedFeesKey, s.persistedAccruedFees); err != nil {
+		return err
+	}
+	if err := database.PutUInt64(singletons, CurrentSupplyKey, s.persistedCurrentSupply); err != nil {
+		return err
+	}
+	it := singletons.NewIterator()
+	defer it.Release()
+
+	return writeSyncableRecords(records, syncSingletonPrefix, it, nil)
+}
+
+// snapshotSyncableValidators records the current validators with their
+// uptimes reset, so that the uptime of a validator is measured from its start
+// time by a node that syncs this state, as it would be by a node that executed
+// every block.
+func (s *state) snapshotSyncableValidators(snapshot *syncableSnapshot) error {
+	for subnetID, subnetValidators := range s.currentStakers.validators {
+		prefix := syncCurrentSubnetValidatorPrefix
+		if subnetID == constants.PrimaryNetworkID {
+			prefix = syncCurrentValidatorPrefix
+		}
+
+		for nodeID, validator := range subnetValidators {
+			staker := validator.validator
+			if staker == nil {
+				continue
+			}
+
+			delegateeReward, err := s.GetDelegateeReward(subnetID, nodeID)
+			if err != nil {
+				return err
+			}
+
+			startTime := uint64(staker.StartTime.Unix())
+			metadataBytes, err := MetadataCodec.Marshal(CodecVersion1, &validatorMetadata{
+				LastUpdated:              startTime,
+				StakerStartTime:          startTime,
+				PotentialReward:          staker.PotentialReward,
+				PotentialDelegateeReward: delegateeReward,
+			})
+			if err != nil {
+				return err
+			}
+			if err := snapshot.records.Put(syncRecordKey(prefix, staker.TxID[:]), metadataBytes); err != nil {
+				return err
+			}
+			snapshot.txIDs.Add(staker.TxID)
+		}
+	}
+	return nil
+}
+
+// Write may only be called once.
+func (s *syncableSnapshot) Write(w database.KeyValueWriter) error {
+	if err := s.writeRecords(w); err != nil {
+		return err
+	}
+	for prefix, it := range s.iterators {
+		var txIDs set.Set[ids.ID]
+		switch prefix {
+		case syncCurrentDelegatorPrefix,
+			syncCurrentSubnetDelegatorPrefix,
+			syncPendingValidatorPrefix,
+			syncPendingDelegatorPrefix,
+			syncPendingSubnetValidatorPrefix,
+			syncPendingSubnetDelegatorPrefix,
+			syncSubnetPrefix,
+			syncTransformedSubnetPrefix:
+			txIDs = s.txIDs
+		}
+		if err := writeSyncableRecords(w, prefix, it, txIDs); err != nil {
+			return err
+		}
+	}
+	if err := s.writeChains(w); err != nil {
+		return err
+	}
+	if err := s.writeDiffs(w); err != nil {
+		return err
+	}
+	return s.writeTxs(w)
+}
+
+func (s *syncableSnapshot) writeRecords(w database.KeyValueWriter) error {
+	it := s.records.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		if err := w.Put(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// writeChains writes the chains of every subnet, keyed by the ID of the subnet
+// followed by the ID of the chain.
+func (s *syncableSnapshot) writeChains(w database.KeyValueWriter) error {
+	for subnetID, it := range s.chains {
+		for it.Next() {
+			chainID := it.Key()
+			key := make([]byte, 0, ids.IDLen+len(chainID))
+			key = append(key, subnetID[:]...)
+			key = append(key, chainID...)
+			if err := w.Put(syncRecordKey(syncChainPrefix, key), it.Value()); err != nil {
+				return err
+			}
+			if err := addSyncableTxID(s.txIDs, chainID); err != nil {
+				return err
+			}
+		}
+		if err := it.Error(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDiffs writes the validator diffs above [s.diffsHeight].
+func (s *syncableSnapshot) writeDiffs(w database.KeyValueWriter) error {
+	for prefix, its := range s.diffs {
+		for _, it := range its {
+			for it.Next() {
+				key := it.Key()
+				_, height, _, err := unmarshalDiffKey(key)
+				if err != nil {
+					return err
+				}
+				if height <= s.diffsHeight {
+					break
+				}
+				if err := w.Put(syncRecordKey(prefix, key), it.Value()); err != nil {
+					return err
+				}
+			}
+			if err := it.Error(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeTxs writes the transactions that are referenced by the records. As
+// transactions are never modified or removed once they are accepted, they are
+// read from the database rather than from a snapshot of it.
+func (s *syncableSnapshot) writeTxs(w database.KeyValueWriter) error {
+	for txID := range s.txIDs {
+		txBytes, err := s.txDB.Get(txID[:])
+		if err != nil {
+			return fmt.Errorf("failed to get tx %s: %w", txID, err)
+		}
+		if err := w.Put(syncRecordKey(syncTxPrefix, txID[:]), txBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *syncableSnapshot) Release() {
+	for _, it := range s.iterators {
+		it.Release()
+	}
+	for _, it := range s.chains {
+		it.Release()
+	}
+	for _, its := range s.diffs {
+		for _, it := range its {
+			it.Release()
+		}
+	}
+}
+
+// writeSyncableRecords writes the values of [it] to [w]. If [txIDs] is
+// non-nil, the transaction IDs that the records reference are added to it.
+func writeSyncableRecords(w database.KeyValueWriter, prefix byte, it database.Iterator, txIDs set.Set[ids.ID]) error {
+	for it.Next() {
+		key := it.Key()
+		if err := w.Put(syncRecordKey(prefix, key), it.Value()); err != nil {
+			return err
+		}
+		if txIDs == nil {
+			continue
+		}
+
+		// The transformed subnets are keyed by subnet ID and reference the
+		// transformation transaction by value.
+		txID := key
+		if prefix == syncTransformedSubnetPrefix {
+			txID = it.Value()
+		}
+		if err := addSyncableTxID(txIDs, txID); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func addSyncableTxID(txIDs set.Set[ids.ID], txIDBytes []byte) error {
+	txID, err := ids.ToID(txIDBytes)
+	if err != nil {
+		return err
+	}
+	txIDs.Add(txID)
+	return nil
+}
+
+// ApplySyncableState replaces the syncable state stored in [db] with the
+// records provided by [records] and marks the block with ID [blkID], which
+// must be included in the records, as the last accepted block.
+//
+// Only the validator diffs of recent heights are synced, so older validator
+// sets can't be calculated. The reward UTXOs are not synced.
+//
+// Applying the same records multiple times results in the same state, so an
+// interrupted call can safely be retried.
+func ApplySyncableState(db database.Database, records database.Iterator, blkID ids.ID) error {
+	defer records.Release()
+
+	var (
+		vdb         = versiondb.New(db)
+		applier     = &syncApplier{db: vdb}
+		dbs         = syncableDBs(vdb)
+		listDBs     = syncableLists(vdb)
+		lists       = make(map[byte]linkeddb.LinkedDB, len(listDBs))
+		utxoDB      = prefixdb.New(UTXOPrefix, vdb)
+		txDB        = prefixdb.New(TxPrefix, vdb)
+		diffDBs     = syncableDiffDBs(vdb)
+		singletonDB = prefixdb.New(SingletonPrefix, vdb)
+		blockIDDB   = prefixdb.New(BlockIDPrefix, vdb)
+		blockDB     = prefixdb.New(BlockPrefix, vdb)
+
+		syncedBlock block.Block
+	)
+	utxoState, err := avax.NewUTXOState(utxoDB, txs.GenesisCodec, false)
+	if err != nil {
+		return err
+	}
+
+	// Remove the existing state before writing the records, so that values
+	// that were removed prior to the synced height do not remain. The chains
+	// must be removed before the subnets, as they are found by subnet ID.
+	if err := applier.clearChains(linkeddb.NewDefault(listDBs[syncSubnetPrefix])); err != nil {
+		return err
+	}
+	if err := applier.clearUTXOs(utxoState, utxoDB); err != nil {
+		return err
+	}
+	for _, prefixedDB := range dbs {
+		if err := applier.clear(prefixedDB); err != nil {
+			return err
+		}
+	}
+	for prefix, listDB := range listDBs {
+		if err := applier.clear(listDB); err != nil {
+			return err
+		}
+		lists[prefix] = linkeddb.NewDefault(listDB)
+	}
+
+	for records.Next() {
+		recordKey := records.Key()
+		if len(recordKey) == 0 {
+			return errUnknownSyncRecord
+		}
+
+		var (
+			prefix = recordKey[0]
+			key    = recordKey[1:]
+			value  = records.Value()
+			err    error
+		)
+		switch prefix {
+		case syncBlockPrefix:
+			syncedBlock, err = block.Parse(block.GenesisCodec, value)
+			if err != nil {
+				return err
+			}
+			if syncedBlock.ID() != blkID {
+				return fmt.Errorf("%w: expected %s but got %s", errUnexpectedBlock, blkID, syncedBlock.ID())
+			}
+			err = errors.Join(
+				database.PutID(blockIDDB, database.PackUInt64(syncedBlock.Height()), blkID),
+				blockDB.Put(blkID[:], value),
+			)
+		case syncSingletonPrefix:
+			err = singletonDB.Put(key, value)
+		case syncTxPrefix:
+			// Accepted transactions are never modified, so the transactions
+			// that are already stored aren't removed.
+			err = txDB.Put(key, value)
+		case syncValidatorWeightDiffPrefix, syncValidatorPublicKeyDiffPrefix:
+			// Diffs are never modified once they are written, so the diffs
+			// that are already stored aren't removed. The synced height
+			// record prevents the diffs that weren't synced from being used.
+			err = diffDBs[prefix].Put(key, value)
+		case syncUTXOPrefix:
+			utxo := &avax.UTXO{}
+			if _, err := txs.GenesisCodec.Unmarshal(value, utxo); err != nil {
+				return err
+			}
+			err = utxoState.PutUTXO(utxo)
+		case syncChainPrefix:
+			if len(key) <= ids.IDLen {
+				return fmt.Errorf("%w: %x", errInvalidChainRecord, key)
+			}
+			subnetID := ids.ID(key[:ids.IDLen])
+			err = linkeddb.NewDefault(subnetChainsDB(vdb, subnetID)).Put(key[ids.IDLen:], value)
+		default:
+			if list, ok := lists[prefix]; ok {
+				err = list.Put(key, value)
+			} else if prefixedDB, ok := dbs[prefix]; ok {
+				err = prefixedDB.Put(key, value)
+			} else {
+				err = fmt.Errorf("%w: %d", errUnknownSyncRecord, prefix)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if err := applier.written(len(key) + len(value)); err != nil {
+			return err
+		}
+	}
+	if err := records.Error(); err != nil {
+		return err
+	}
+	if syncedBlock == nil {
+		return fmt.Errorf("%w: %s", errMissingSyncBlock, blkID)
+	}
+
+	if err := database.PutID(singletonDB, LastAcceptedKey, blkID); err != nil {
+		return err
+	}
+	// The validator diffs are only indexed from the synced height onwards.
+	if err := singletonDB.Delete(HeightsIndexedKey); err != nil {
+		return err
+	}
+	if err := markInitialized(singletonDB); err != nil {
+		return err
+	}
+	return vdb.Commit()
+}
+
+// syncApplier flushes the changes made while applying the syncable state to
+// disk once applyCommitSize bytes have been written or removed.
+type syncApplier struct {
+	db           *versiondb.Database
+	pendingBytes int
+}
+
+func (a *syncApplier) written(numBytes int) error {
+	a.pendingBytes += numBytes
+	if a.pendingBytes < applyCommitSize {
+		return nil
+	}
+	a.pendingBytes = 0
+	return a.db.Commit()
+}
+
+// clear removes all the values of [db], which must be built on a.db.
+func (a *syncApplier) clear(db database.Database) error {
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if err := db.Delete(key); err != nil {
+			return err
+		}
+		if err := a.written(len(key)); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// clearUTXOs removes all the UTXOs, along with their address indices, from
+// [utxoState], which must be stored in [utxoDB].
+func (a *syncApplier) clearUTXOs(utxoState avax.UTXOState, utxoDB database.Database) error {
+	it := avax.NewUTXOIterator(utxoDB)
+	defer it.Release()
+
+	for it.Next() {
+		utxoID, err := ids.ToID(it.Key())
+		if err != nil {
+			return err
+		}
+		if err := utxoState.DeleteUTXO(utxoID); err != nil {
+			return err
+		}
+		if err := a.written(len(it.Key()) + len(it.Value())); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// clearChains removes the chains of the primary network and of every subnet
+// in [subnets].
+func (a *syncApplier) clearChains(subnets linkeddb.LinkedDB) error {
+	it := subnets.NewIterator()
+	defer it.Release()
+
+	subnetIDs := []ids.ID{constants.PrimaryNetworkID}
+	for it.Next() {
+		subnetID, err := ids.ToID(it.Key())
+		if err != nil {
+			return err
+		}
+		subnetIDs = append(subnetIDs, subnetID)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, subnetID := range subnetIDs {
+		if err := a.clear(subnetChainsDB(a.db, subnetID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reload replaces the in-memory state with the state stored on disk. It is
+// used after the state on disk has been replaced by ApplySyncableState.
+//
+// Invariant: There must not be any uncommitted changes.
+func (s *state) Reload() error {
+	if err := s.clearValidatorSets(); err != nil {
+		return err
+	}
+
+	utxoState, err := avax.NewUTXOState(s.utxoDB, txs.GenesisCodec, s.checksumsEnabled)
+	if err != nil {
+		return err
+	}
+	// The metered UTXO caches can't be registered again, so the UTXO state is
+	// replaced with one that isn't metered.
+	s.utxoState = utxoState
+
+	s.validatorState = newValidatorState()
+	s.expiry = btree.NewG(defaultTreeDegree, ExpiryEntry.Less)
+	s.activeSOVs = newActiveSubnetOnlyValidators()
+	s.currentStakers = newBaseStakers()
+	s.pendingStakers = newBaseStakers()
+	s.cachedSubnetIDs = nil
+	s.indexedHeights = nil
+
+	s.currentValidatorList = linkeddb.NewDefault(s.currentValidatorBaseDB)
+	s.currentDelegatorList = linkeddb.NewDefault(s.currentDelegatorBaseDB)
+	s.currentSubnetValidatorList = linkeddb.NewDefault(s.currentSubnetValidatorBaseDB)
+	s.currentSubnetDelegatorList = linkeddb.NewDefault(s.currentSubnetDelegatorBaseDB)
+	s.pendingValidatorList = linkeddb.NewDefault(s.pendingValidatorBaseDB)
+	s.pendingDelegatorList = linkeddb.NewDefault(s.pendingDelegatorBaseDB)
+	s.pendingSubnetValidatorList = linkeddb.NewDefault(s.pendingSubnetValidatorBaseDB)
+	s.pendingSubnetDelegatorList = linkeddb.NewDefault(s.pendingSubnetDelegatorBaseDB)
+	s.subnetDB = linkeddb.NewDefault(s.subnetBaseDB)
+
+	s.weightsCache.Flush()
+	s.subnetIDNodeIDCache.Flush()
+	s.inactiveCache.Flush()
+	s.blockIDCache.Flush()
+	s.blockCache.Flush()
+	s.txCache.Flush()
+	s.rewardUTXOsCache.Flush()
+	s.subnetOwnerCache.Flush()
+	s.subnetManagerCache.Flush()
+	s.transformedSubnetCache.Flush()
+	s.supplyCache.Flush()
+	s.chainCache.Flush()
+	s.chainDBCache.Flush()
+
+	return s.load()
+}
+
+// clearValidatorSets removes all the validators that were added to the
+// validator manager by initValidatorSets.
+func (s *state) clearValidatorSets() error {
+	var subnetIDs set.Set[ids.ID]
+	for subnetID := range s.currentStakers.validators {
+		subnetIDs.Add(subnetID)
+	}
+	for _, sov := range s.activeSOVs.lookup {
+		subnetIDs.Add(sov.SubnetID)
+	}
+
+	weightsIt := s.weightsDB.NewIterator()
+	defer weightsIt.Release()
+	for weightsIt.Next() {
+		subnetID, err := ids.ToID(weightsIt.Key())
+		if err != nil {
+			return err
+		}
+		subnetIDs.Add(subnetID)
+	}
+	if err := weightsIt.Error(); err != nil {
+		return err
+	}
+
+	for subnetID := range subnetIDs {
+		for nodeID, vdr := range s.validators.GetMap(subnetID) {
+			if err := s.validators.RemoveWeight(subnetID, nodeID, vdr.Weight); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
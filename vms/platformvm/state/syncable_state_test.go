@@ -0,0 +1,426 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/validators"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/block"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/status"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
+	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
+)
+
+func TestApplySyncableState(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		syncedState = newTestState(t, memdb.New())
+		owner       = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		createSubnetTx = newSyncTestCreateSubnetTx(t, owner)
+		subnetID       = createSubnetTx.ID()
+	)
+	blk, err := block.NewBanffStandardBlock(
+		syncedState.GetTimestamp().Add(time.Second),
+		syncedState.GetLastAccepted(),
+		1,
+		nil,
+	)
+	require.NoError(err)
+
+	syncedState.AddStatelessBlock(blk)
+	syncedState.SetLastAccepted(blk.ID())
+	syncedState.SetHeight(blk.Height())
+	syncedState.SetTimestamp(blk.Timestamp())
+	syncedState.SetAccruedFees(5)
+	syncedState.AddTx(createSubnetTx, status.Committed)
+	syncedState.AddSubnet(subnetID)
+	syncedState.SetSubnetOwner(subnetID, owner)
+	createChainTx := newSyncTestCreateChainTx(t, subnetID)
+	syncedState.AddTx(createChainTx, status.Committed)
+	syncedState.AddChain(createChainTx)
+	utxo := newSyncTestUTXO(owner)
+	syncedState.AddUTXO(utxo)
+	require.NoError(syncedState.Commit())
+
+	records := memdb.New()
+	require.NoError(writeSyncableState(syncedState, records))
+
+	var (
+		db    = memdb.New()
+		state = newTestState(t, db)
+
+		staleSubnetID = ids.GenerateTestID()
+		staleUTXO     = newSyncTestUTXO(owner)
+	)
+	state.AddSubnet(staleSubnetID)
+	state.AddChain(newSyncTestCreateChainTx(t, staleSubnetID))
+	state.AddUTXO(staleUTXO)
+	require.NoError(state.Commit())
+
+	require.NoError(ApplySyncableState(db, records.NewIterator(), blk.ID()))
+	require.NoError(state.Reload())
+
+	require.Equal(blk.ID(), state.GetLastAccepted())
+	require.Equal(blk.Timestamp(), state.GetTimestamp())
+	require.Equal(uint64(5), state.GetAccruedFees())
+	// All of the validator diffs are within the sync window.
+	require.Zero(state.syncedHeight)
+
+	subnetIDs, err := state.GetSubnetIDs()
+	require.NoError(err)
+	require.Contains(subnetIDs, subnetID)
+
+	require.NotContains(subnetIDs, staleSubnetID)
+
+	subnetOwner, err := state.GetSubnetOwner(subnetID)
+	require.NoError(err)
+	require.Equal(owner, subnetOwner)
+
+	chains, err := state.GetChains(subnetID)
+	require.NoError(err)
+	require.Len(chains, 1)
+	require.Equal(createChainTx.ID(), chains[0].ID())
+
+	chains, err = state.GetChains(staleSubnetID)
+	require.NoError(err)
+	require.Empty(chains)
+
+	syncedUTXO, err := state.GetUTXO(utxo.InputID())
+	require.NoError(err)
+	require.Equal(utxo.InputID(), syncedUTXO.InputID())
+	require.Equal(utxo.Out, syncedUTXO.Out)
+
+	utxoIDs, err := state.UTXOIDs(owner.Addrs[0][:], ids.Empty, 10)
+	require.NoError(err)
+	require.Equal([]ids.ID{utxo.InputID()}, utxoIDs)
+
+	_, err = state.GetUTXO(staleUTXO.InputID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.Equal(
+		syncedState.validators.GetMap(constants.PrimaryNetworkID),
+		state.validators.GetMap(constants.PrimaryNetworkID),
+	)
+
+	// The synced state must produce the same records as the state it was
+	// synced from.
+	reproducedRecords := memdb.New()
+	require.NoError(writeSyncableState(state, reproducedRecords))
+	require.Equal(dbContents(t, records), dbContents(t, reproducedRecords))
+
+	// Applying the records again must not modify the state.
+	require.NoError(ApplySyncableState(db, records.NewIterator(), blk.ID()))
+	require.NoError(state.Reload())
+	require.Equal(blk.ID(), state.GetLastAccepted())
+}
+
+func TestApplySyncableStateErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		records     func(*require.Assertions, *state) database.Database
+		blkID       func(*state) ids.ID
+		expectedErr error
+	}{
+		{
+			name: "missing block",
+			records: func(*require.Assertions, *state) database.Database {
+				return memdb.New()
+			},
+			blkID: func(s *state) ids.ID {
+				return s.GetLastAccepted()
+			},
+			expectedErr: errMissingSyncBlock,
+		},
+		{
+			name: "unexpected block",
+			records: func(require *require.Assertions, s *state) database.Database {
+				records := memdb.New()
+				require.NoError(writeSyncableState(s, records))
+				return records
+			},
+			blkID: func(*state) ids.ID {
+				return ids.GenerateTestID()
+			},
+			expectedErr: errUnexpectedBlock,
+		},
+		{
+			name: "unknown record",
+			records: func(require *require.Assertions, s *state) database.Database {
+				records := memdb.New()
+				require.NoError(writeSyncableState(s, records))
+				require.NoError(records.Put([]byte{0xff}, nil))
+				return records
+			},
+			blkID: func(s *state) ids.ID {
+				return s.GetLastAccepted()
+			},
+			expectedErr: errUnknownSyncRecord,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			s := newTestState(t, memdb.New())
+			records := test.records(require, s)
+			err := ApplySyncableState(memdb.New(), records.NewIterator(), test.blkID(s))
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestSyncableStateValidatorDiffsWindow(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		syncedState = newTestState(t, memdb.New())
+		height      = validatorDiffsSyncWindow + 10
+		diffsHeight = height - validatorDiffsSyncWindow
+		nodeID      = ids.GenerateTestNodeID()
+	)
+	blk, err := block.NewBanffStandardBlock(
+		syncedState.GetTimestamp().Add(time.Second),
+		syncedState.GetLastAccepted(),
+		height,
+		nil,
+	)
+	require.NoError(err)
+
+	syncedState.AddStatelessBlock(blk)
+	syncedState.SetLastAccepted(blk.ID())
+	syncedState.SetHeight(blk.Height())
+	syncedState.SetTimestamp(blk.Timestamp())
+	require.NoError(syncedState.Commit())
+
+	// The diff at [diffsHeight] is outside of the sync window.
+	for _, diffHeight := range []uint64{diffsHeight, diffsHeight + 1, height} {
+		require.NoError(syncedState.validatorWeightDiffsDB.Put(
+			marshalDiffKey(constants.PrimaryNetworkID, diffHeight, nodeID),
+			marshalWeightDiff(&ValidatorWeightDiff{
+				Amount: 1,
+			}),
+		))
+	}
+
+	records := memdb.New()
+	require.NoError(writeSyncableState(syncedState, records))
+
+	db := memdb.New()
+	state := newTestState(t, db)
+	require.NoError(ApplySyncableState(db, records.NewIterator(), blk.ID()))
+	require.NoError(state.Reload())
+	require.Equal(diffsHeight, state.syncedHeight)
+
+	// The validator set at [diffsHeight] is calculated with the diffs above it.
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID: {
+			NodeID: nodeID,
+			Weight: 2,
+		},
+	}
+	require.NoError(state.ApplyValidatorWeightDiffs(
+		context.Background(),
+		vdrs,
+		height,
+		diffsHeight+1,
+		constants.PrimaryNetworkID,
+	))
+	require.Empty(vdrs)
+
+	err = state.ApplyValidatorWeightDiffs(
+		context.Background(),
+		map[ids.NodeID]*validators.GetValidatorOutput{},
+		height,
+		diffsHeight,
+		constants.PrimaryNetworkID,
+	)
+	require.ErrorIs(err, ErrMissingValidatorDiffs)
+
+	// Syncing from the synced state doesn't include the missing diffs.
+	reproducedRecords := memdb.New()
+	require.NoError(writeSyncableState(state, reproducedRecords))
+	require.Equal(dbContents(t, records), dbContents(t, reproducedRecords))
+}
+
+func TestSyncedStateMissingValidatorDiffs(t *testing.T) {
+	require := require.New(t)
+
+	state := newTestState(t, memdb.New())
+	state.syncedHeight = 10
+
+	tests := []struct {
+		startHeight uint64
+		endHeight   uint64
+		expectedErr error
+	}{
+		{
+			startHeight: 12,
+			endHeight:   11,
+			expectedErr: nil,
+		},
+		{
+			startHeight: 12,
+			endHeight:   10,
+			expectedErr: ErrMissingValidatorDiffs,
+		},
+		{
+			// No diffs are applied.
+			startHeight: 9,
+			endHeight:   10,
+			expectedErr: nil,
+		},
+	}
+	for _, test := range tests {
+		err := state.ApplyValidatorWeightDiffs(
+			context.Background(),
+			map[ids.NodeID]*validators.GetValidatorOutput{},
+			test.startHeight,
+			test.endHeight,
+			constants.PrimaryNetworkID,
+		)
+		require.ErrorIs(err, test.expectedErr)
+
+		err = state.ApplyValidatorPublicKeyDiffs(
+			context.Background(),
+			map[ids.NodeID]*validators.GetValidatorOutput{},
+			test.startHeight,
+			test.endHeight,
+			constants.PrimaryNetworkID,
+		)
+		require.ErrorIs(err, test.expectedErr)
+	}
+}
+
+func TestSyncableSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		state = newTestState(t, memdb.New())
+		owner = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		createSubnetTx = newSyncTestCreateSubnetTx(t, owner)
+		subnetID       = createSubnetTx.ID()
+		createChainTx  = newSyncTestCreateChainTx(t, subnetID)
+		unreferencedTx = newSyncTestCreateChainTx(t, subnetID)
+	)
+	state.AddTx(createSubnetTx, status.Committed)
+	state.AddSubnet(subnetID)
+	state.AddTx(createChainTx, status.Committed)
+	state.AddChain(createChainTx)
+	state.AddTx(unreferencedTx, status.Committed)
+	require.NoError(state.Commit())
+
+	snapshot, err := state.NewSyncableSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	// Changes committed after the snapshot was taken must not be included.
+	utxo := newSyncTestUTXO(owner)
+	state.AddUTXO(utxo)
+	require.NoError(state.Commit())
+
+	records := memdb.New()
+	require.NoError(snapshot.Write(records))
+
+	hasRecord := func(prefix byte, key []byte) bool {
+		has, err := records.Has(syncRecordKey(prefix, key))
+		require.NoError(err)
+		return has
+	}
+	utxoID := utxo.InputID()
+	require.False(hasRecord(syncUTXOPrefix, utxoID[:]))
+
+	// Only the transactions referenced by the state are included.
+	require.True(hasRecord(syncTxPrefix, subnetID[:]))
+	chainID := createChainTx.ID()
+	require.True(hasRecord(syncTxPrefix, chainID[:]))
+	unreferencedTxID := unreferencedTx.ID()
+	require.False(hasRecord(syncTxPrefix, unreferencedTxID[:]))
+}
+
+func newSyncTestCreateSubnetTx(t *testing.T, owner *secp256k1fx.OutputOwners) *txs.Tx {
+	tx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: constants.PlatformChainID,
+			}},
+			Owner: owner,
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx
+}
+
+func newSyncTestCreateChainTx(t *testing.T, subnetID ids.ID) *txs.Tx {
+	tx := &txs.Tx{
+		Unsigned: &txs.CreateChainTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: constants.PlatformChainID,
+			}},
+			SubnetID:   subnetID,
+			ChainName:  "chain",
+			VMID:       ids.GenerateTestID(),
+			SubnetAuth: &secp256k1fx.Input{},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx
+}
+
+func newSyncTestUTXO(owner *secp256k1fx.OutputOwners) *avax.UTXO {
+	return &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID: ids.GenerateTestID(),
+		},
+		Asset: avax.Asset{
+			ID: ids.GenerateTestID(),
+		},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          1,
+			OutputOwners: *owner,
+		},
+	}
+}
+
+func writeSyncableState(s *state, w database.KeyValueWriter) error {
+	snapshot, err := s.NewSyncableSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	return snapshot.Write(w)
+}
+
+// dbContents returns the key-value pairs of [db]. The values are converted to
+// strings so that nil and empty values are considered equal.
+func dbContents(t *testing.T, db database.Iteratee) map[string]string {
+	it := db.NewIterator()
+	defer it.Release()
+
+	contents := make(map[string]string)
+	for it.Next() {
+		contents[string(it.Key())] = string(it.Value())
+	}
+	require.NoError(t, it.Error())
+	return contents
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/state"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/statesync"
+
+	snowmanblock "github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+)
+
+var (
+	_ snowmanblock.StateSyncableVM = (*VM)(nil)
+
+	stateSyncPrefix = []byte("stateSync")
+)
+
+// StateSyncEnabled returns true if state sync is enabled and either no blocks
+// have been accepted yet or a previous sync hasn't finished.
+func (vm *VM) StateSyncEnabled(context.Context) (bool, error) {
+	if !vm.stateSyncEnabled {
+		return false, nil
+	}
+
+	_, err := vm.stateSyncClient.OngoingSummary()
+	switch err {
+	case nil:
+		return true, nil
+	case database.ErrNotFound:
+	default:
+		return false, err
+	}
+
+	height, err := vm.lastAcceptedHeight()
+	return height == 0, err
+}
+
+func (vm *VM) GetOngoingSyncStateSummary(context.Context) (snowmanblock.StateSummary, error) {
+	return vm.stateSyncClient.OngoingSummary()
+}
+
+func (vm *VM) GetLastStateSummary(context.Context) (snowmanblock.StateSummary, error) {
+	return vm.stateSyncServer.LastSummary()
+}
+
+func (vm *VM) ParseStateSummary(_ context.Context, summaryBytes []byte) (snowmanblock.StateSummary, error) {
+	return vm.stateSyncClient.ParseSummary(summaryBytes)
+}
+
+func (vm *VM) GetStateSummary(_ context.Context, summaryHeight uint64) (snowmanblock.StateSummary, error) {
+	return vm.stateSyncServer.Summary(summaryHeight)
+}
+
+func (vm *VM) lastAcceptedHeight() (uint64, error) {
+	blk, err := vm.manager.GetStatelessBlock(vm.manager.LastAccepted())
+	if err != nil {
+		return 0, err
+	}
+	return blk.Height(), nil
+}
+
+// applySyncedState replaces the state on disk with the synced [records].
+func (vm *VM) applySyncedState(summary *statesync.Summary, records database.Iterator) error {
+	vm.ctx.Log.Info("applying synced state",
+		zap.Uint64("height", summary.Height()),
+		zap.Stringer("blkID", summary.BlockID),
+	)
+	return state.ApplySyncableState(vm.db, records, summary.BlockID)
+}
+
+// acceptSyncedState replaces the state with the synced [records] and marks the
+// block of [summary] as the last accepted block.
+func (vm *VM) acceptSyncedState(summary *statesync.Summary, records database.Iterator) error {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if err := vm.applySyncedState(summary, records); err != nil {
+		return err
+	}
+	if err := vm.state.Reload(); err != nil {
+		return err
+	}
+
+	vm.manager.SetLastAccepted(summary.BlockID)
+	vm.Builder.ResetBlockTimer()
+
+	// Create the chains that were created after genesis. Chains that were
+	// already created are ignored by the chain manager.
+	return vm.initBlockchains()
+}
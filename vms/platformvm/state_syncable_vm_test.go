@@ -0,0 +1,167 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/upgrade/upgradetest"
+	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/genesis/genesistest"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/statesync"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/status"
+	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
+
+	snowmanblock "github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+)
+
+func TestStateSyncEnabled(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM(t, upgradetest.Latest)
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	// State sync is disabled by default.
+	enabled, err := vm.StateSyncEnabled(context.Background())
+	require.NoError(err)
+	require.False(enabled)
+
+	// State sync isn't started after blocks have been accepted.
+	vm.stateSyncEnabled = true
+	enabled, err = vm.StateSyncEnabled(context.Background())
+	require.NoError(err)
+	require.False(enabled)
+
+	_, err = vm.GetLastStateSummary(context.Background())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	_, err = vm.GetOngoingSyncStateSummary(context.Background())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Summaries that aren't ahead of the last accepted block are skipped.
+	lastAccepted, err := vm.manager.GetStatelessBlock(vm.manager.LastAccepted())
+	require.NoError(err)
+	summaryBytes, err := statesync.Codec.Marshal(statesync.CodecVersion, &statesync.Summary{
+		BlockHeight: lastAccepted.Height(),
+		BlockID:     lastAccepted.ID(),
+	})
+	require.NoError(err)
+
+	summary, err := vm.ParseStateSummary(context.Background(), summaryBytes)
+	require.NoError(err)
+	mode, err := summary.Accept(context.Background())
+	require.NoError(err)
+	require.Equal(snowmanblock.StateSyncSkipped, mode)
+
+	// An ongoing sync is continued.
+	summaryBytes, err = statesync.Codec.Marshal(statesync.CodecVersion, &statesync.Summary{
+		BlockHeight: lastAccepted.Height() + 1,
+		BlockID:     ids.GenerateTestID(),
+	})
+	require.NoError(err)
+
+	summary, err = vm.ParseStateSummary(context.Background(), summaryBytes)
+	require.NoError(err)
+	mode, err = summary.Accept(context.Background())
+	require.NoError(err)
+	require.Equal(snowmanblock.StateSyncStatic, mode)
+
+	ongoingSummary, err := vm.GetOngoingSyncStateSummary(context.Background())
+	require.NoError(err)
+	require.Equal(summary.ID(), ongoingSummary.ID())
+
+	enabled, err = vm.StateSyncEnabled(context.Background())
+	require.NoError(err)
+	require.True(enabled)
+}
+
+func TestAcceptSyncedState(t *testing.T) {
+	require := require.New(t)
+
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs: []ids.ShortID{
+			genesistest.DefaultFundedKeys[0].Address(),
+		},
+	}
+
+	syncedVM, _, _ := defaultVM(t, upgradetest.Latest)
+	syncedVM.ctx.Lock.Lock()
+	defer syncedVM.ctx.Lock.Unlock()
+
+	wallet := newWallet(t, syncedVM, walletConfig{})
+	createSubnetTx, err := wallet.IssueCreateSubnetTx(owner)
+	require.NoError(err)
+
+	syncedVM.ctx.Lock.Unlock()
+	require.NoError(syncedVM.issueTxFromRPC(createSubnetTx))
+	syncedVM.ctx.Lock.Lock()
+	require.NoError(buildAndAcceptStandardBlock(syncedVM))
+
+	snapshot, err := syncedVM.state.NewSyncableSnapshot()
+	require.NoError(err)
+	records := memdb.New()
+	require.NoError(snapshot.Write(records))
+	snapshot.Release()
+
+	lastAcceptedID := syncedVM.manager.LastAccepted()
+	lastAccepted, err := syncedVM.manager.GetStatelessBlock(lastAcceptedID)
+	require.NoError(err)
+
+	vm, _, _ := defaultVM(t, upgradetest.Latest)
+	require.NoError(vm.acceptSyncedState(
+		&statesync.Summary{
+			BlockHeight: lastAccepted.Height(),
+			BlockID:     lastAcceptedID,
+		},
+		records.NewIterator(),
+	))
+
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	require.Equal(lastAcceptedID, vm.manager.LastAccepted())
+	require.Equal(lastAcceptedID, vm.manager.Preferred())
+
+	_, txStatus, err := vm.state.GetTx(createSubnetTx.ID())
+	require.NoError(err)
+	require.Equal(status.Committed, txStatus)
+
+	// Validator sets prior to the synced height can be calculated, as the
+	// recent validator diffs are synced.
+	require.Positive(lastAccepted.Height())
+	expectedValidators, err := syncedVM.GetValidatorSet(context.Background(), 0, constants.PrimaryNetworkID)
+	require.NoError(err)
+	validators, err := vm.GetValidatorSet(context.Background(), 0, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(expectedValidators, validators)
+
+	// Blocks built on top of the synced block can be executed.
+	wallet = newWallet(t, syncedVM, walletConfig{})
+	createSubnetTx, err = wallet.IssueCreateSubnetTx(owner)
+	require.NoError(err)
+
+	syncedVM.ctx.Lock.Unlock()
+	require.NoError(syncedVM.issueTxFromRPC(createSubnetTx))
+	syncedVM.ctx.Lock.Lock()
+
+	builtBlk, err := syncedVM.Builder.BuildBlock(context.Background())
+	require.NoError(err)
+
+	blk, err := vm.ParseBlock(context.Background(), builtBlk.Bytes())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+
+	_, txStatus, err = vm.state.GetTx(createSubnetTx.ID())
+	require.NoError(err)
+	require.Equal(status.Committed, txStatus)
+}
@@ -0,0 +1,424 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+)
+
+const (
+	// maxRequestAttempts is the number of times a range is requested before
+	// syncing fails.
+	maxRequestAttempts = 32
+	// retryDelay is the time waited before a failed range request is retried.
+	retryDelay = time.Second
+)
+
+var (
+	stagingPrefix     = []byte("staging")
+	ongoingSummaryKey = []byte("ongoing summary")
+	applyingKey       = []byte("applying")
+
+	errMismatchedRangeLengths = errors.New("number of keys and values differ")
+	errEmptyRange             = errors.New("empty range with more records")
+	errKeyBeforeStart         = errors.New("key before start of range")
+	errRootMismatch           = errors.New("root mismatch")
+)
+
+// ApplyFunc replaces the syncable state with [records], which are the verified
+// records of [summary] sorted by key.
+type ApplyFunc func(summary *Summary, records database.Iterator) error
+
+type ClientConfig struct {
+	Log logging.Logger
+	// DB is the database that the records are downloaded to. It must be the
+	// same database that is provided to FinishApplying.
+	DB database.Database
+	// Client issues range requests to peers serving the syncable state.
+	Client *p2p.Client
+	// LastAcceptedHeight returns the height of the last accepted block.
+	LastAcceptedHeight func() (uint64, error)
+	// Apply is called once all the records of a summary have been downloaded
+	// and verified.
+	Apply ApplyFunc
+	// ToEngine is notified once syncing has finished, regardless of whether it
+	// succeeded.
+	ToEngine chan<- common.Message
+}
+
+// Client downloads the syncable state of an accepted summary from peers.
+type Client struct {
+	config    ClientConfig
+	stagingDB database.Database
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lock sync.Mutex
+	// applyErr is the error that occurred while applying the downloaded
+	// records, if any.
+	applyErr error
+}
+
+func NewClient(config ClientConfig) *Client {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Client{
+		config:    config,
+		stagingDB: prefixdb.New(stagingPrefix, config.DB),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// ParseSummary parses a summary from [summaryBytes] that can be synced to by
+// this client.
+func (c *Client) ParseSummary(summaryBytes []byte) (*Summary, error) {
+	summary, err := ParseSummary(summaryBytes)
+	if err != nil {
+		return nil, err
+	}
+	summary.accept = c.accept
+	return summary, nil
+}
+
+// OngoingSummary returns the summary that is currently being synced to.
+//
+// Returns [database.ErrNotFound] if there is no ongoing sync.
+func (c *Client) OngoingSummary() (*Summary, error) {
+	summaryBytes, err := c.config.DB.Get(ongoingSummaryKey)
+	if err != nil {
+		return nil, err
+	}
+	return c.ParseSummary(summaryBytes)
+}
+
+// Err returns the error that occurred while applying the downloaded records.
+// If an error is returned, the state may have been partially replaced and the
+// chain must not continue running.
+func (c *Client) Err() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.applyErr
+}
+
+// Shutdown stops any ongoing download. Progress that was made is kept, so
+// that the sync can be resumed on restart.
+//
+// Shutdown doesn't wait for the download to exit, as applying the downloaded
+// records may require the lock held by the caller.
+func (c *Client) Shutdown() {
+	c.cancel()
+}
+
+func (c *Client) accept(_ context.Context, summary *Summary) (block.StateSyncMode, error) {
+	lastAcceptedHeight, err := c.config.LastAcceptedHeight()
+	if err != nil {
+		return 0, err
+	}
+	if summary.BlockHeight <= lastAcceptedHeight {
+		c.config.Log.Info("skipping state sync",
+			zap.String("reason", "summary is not ahead of the last accepted block"),
+			zap.Uint64("summaryHeight", summary.BlockHeight),
+			zap.Uint64("lastAcceptedHeight", lastAcceptedHeight),
+		)
+		return block.StateSyncSkipped, nil
+	}
+
+	ongoingSummary, err := c.OngoingSummary()
+	switch {
+	case err == database.ErrNotFound:
+	case err != nil:
+		return 0, err
+	case ongoingSummary.ID() != summary.ID():
+		// The records that were downloaded for a different summary can't be
+		// reused.
+		if err := database.Clear(c.stagingDB, writeBatchSize); err != nil {
+			return 0, err
+		}
+	}
+	if err := c.config.DB.Put(ongoingSummaryKey, summary.Bytes()); err != nil {
+		return 0, err
+	}
+
+	c.config.Log.Info("starting state sync",
+		zap.Uint64("height", summary.BlockHeight),
+		zap.Stringer("blkID", summary.BlockID),
+		zap.Stringer("root", summary.Root),
+	)
+
+	go c.run(summary)
+	return block.StateSyncStatic, nil
+}
+
+func (c *Client) run(summary *Summary) {
+	start := time.Now()
+	err := c.download(summary)
+	if c.ctx.Err() != nil {
+		// The node is shutting down, the sync will be resumed on restart.
+		return
+	}
+
+	switch {
+	case err != nil:
+		// The state hasn't been modified, so the node can fall back to
+		// bootstrapping.
+		c.config.Log.Warn("state sync failed",
+			zap.Uint64("height", summary.BlockHeight),
+			zap.Stringer("blkID", summary.BlockID),
+			zap.Error(err),
+		)
+		if err := c.reset(); err != nil {
+			c.config.Log.Error("failed to reset state sync",
+				zap.Error(err),
+			)
+		}
+	default:
+		if err := finishApplying(c.config.DB, summary, c.config.Apply); err != nil {
+			c.config.Log.Error("failed to apply synced state",
+				zap.Uint64("height", summary.BlockHeight),
+				zap.Stringer("blkID", summary.BlockID),
+				zap.Error(err),
+			)
+
+			c.lock.Lock()
+			c.applyErr = err
+			c.lock.Unlock()
+			break
+		}
+
+		c.config.Log.Info("finished state sync",
+			zap.Uint64("height", summary.BlockHeight),
+			zap.Stringer("blkID", summary.BlockID),
+			zap.Duration("duration", time.Since(start)),
+		)
+	}
+
+	select {
+	case c.config.ToEngine <- common.StateSyncDone:
+	case <-c.ctx.Done():
+	}
+}
+
+// reset removes the ongoing sync so that the node falls back to bootstrapping.
+func (c *Client) reset() error {
+	return errors.Join(
+		database.Clear(c.stagingDB, writeBatchSize),
+		c.config.DB.Delete(ongoingSummaryKey),
+	)
+}
+
+// download fetches the records of [summary] that haven't been downloaded yet
+// and verifies that all the downloaded records match the root of [summary].
+func (c *Client) download(summary *Summary) error {
+	// Resume from the records that were downloaded before a restart.
+	hasher := newRootHasher()
+	it := c.stagingDB.NewIterator()
+	for it.Next() {
+		if err := hasher.Add(it.Key(), it.Value()); err != nil {
+			it.Release()
+			return err
+		}
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+
+	var start []byte
+	if hasher.started {
+		start = nextKey(hasher.lastKey)
+	}
+	for {
+		response, err := c.requestRange(summary.Root, start)
+		if err != nil {
+			return err
+		}
+
+		batch := c.stagingDB.NewBatch()
+		for i, key := range response.Keys {
+			value := response.Values[i]
+			if err := hasher.Add(key, value); err != nil {
+				return err
+			}
+			if err := batch.Put(key, value); err != nil {
+				return err
+			}
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		if !response.More {
+			break
+		}
+		start = nextKey(hasher.lastKey)
+	}
+
+	if root := hasher.Root(); root != summary.Root {
+		return fmt.Errorf("%w: expected %s but got %s", errRootMismatch, summary.Root, root)
+	}
+	return nil
+}
+
+// requestRange requests the records of [root] starting at [start] until a
+// valid response is received.
+func (c *Client) requestRange(root ids.ID, start []byte) (*rangeResponse, error) {
+	requestBytes, err := Codec.Marshal(CodecVersion, &rangeRequest{
+		Root:  root,
+		Start: start,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		response, err := c.request(requestBytes, start)
+		if err == nil {
+			return response, nil
+		}
+		if c.ctx.Err() != nil || attempt >= maxRequestAttempts {
+			return nil, err
+		}
+
+		c.config.Log.Debug("failed to request state sync range",
+			zap.Stringer("root", root),
+			zap.Binary("start", start),
+			zap.Int("attempt", attempt),
+			zap.Error(err),
+		)
+
+		select {
+		case <-time.After(retryDelay):
+		case <-c.ctx.Done():
+			return nil, c.ctx.Err()
+		}
+	}
+}
+
+type rangeResult struct {
+	responseBytes []byte
+	err           error
+}
+
+// request sends [requestBytes] to a random peer and returns its response, if
+// the response is a valid range starting at [start].
+func (c *Client) request(requestBytes []byte, start []byte) (*rangeResponse, error) {
+	resultChan := make(chan rangeResult, 1)
+	onResponse := func(_ context.Context, _ ids.NodeID, responseBytes []byte, err error) {
+		resultChan <- rangeResult{
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}
+	if err := c.config.Client.AppRequestAny(c.ctx, requestBytes, onResponse); err != nil {
+		return nil, err
+	}
+
+	var result rangeResult
+	select {
+	case result = <-resultChan:
+	case <-c.ctx.Done():
+		return nil, c.ctx.Err()
+	}
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	response := &rangeResponse{}
+	if _, err := Codec.Unmarshal(result.responseBytes, response); err != nil {
+		return nil, err
+	}
+	return response, verifyRange(response, start)
+}
+
+// verifyRange verifies that the keys of [response] are sorted and start at or
+// after [start].
+func verifyRange(response *rangeResponse, start []byte) error {
+	if len(response.Keys) != len(response.Values) {
+		return fmt.Errorf("%w: %d keys and %d values",
+			errMismatchedRangeLengths,
+			len(response.Keys),
+			len(response.Values),
+		)
+	}
+	if len(response.Keys) == 0 {
+		if response.More {
+			return errEmptyRange
+		}
+		return nil
+	}
+	if bytes.Compare(response.Keys[0], start) < 0 {
+		return errKeyBeforeStart
+	}
+	for i := 1; i < len(response.Keys); i++ {
+		if bytes.Compare(response.Keys[i-1], response.Keys[i]) >= 0 {
+			return errUnsortedRecords
+		}
+	}
+	return nil
+}
+
+// nextKey returns the smallest key that is greater than [key].
+func nextKey(key []byte) []byte {
+	next := make([]byte, len(key)+1)
+	copy(next, key)
+	return next
+}
+
+// FinishApplying applies the downloaded records again if the node shut down
+// while they were being applied. It must be called before the state is
+// loaded.
+func FinishApplying(db database.Database, apply ApplyFunc) error {
+	summaryBytes, err := db.Get(applyingKey)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	summary, err := ParseSummary(summaryBytes)
+	if err != nil {
+		return err
+	}
+	return finishApplying(db, summary, apply)
+}
+
+// finishApplying applies the records of [summary] that were downloaded into
+// [db] and then removes them.
+func finishApplying(db database.Database, summary *Summary, apply ApplyFunc) error {
+	// If the node shuts down while applying the records, the records are
+	// applied again on restart, as the state may have been partially
+	// replaced.
+	if err := db.Put(applyingKey, summary.Bytes()); err != nil {
+		return err
+	}
+
+	stagingDB := prefixdb.New(stagingPrefix, db)
+	if err := apply(summary, stagingDB.NewIterator()); err != nil {
+		return err
+	}
+	return errors.Join(
+		database.Clear(stagingDB, writeBatchSize),
+		db.Delete(ongoingSummaryKey),
+		db.Delete(applyingKey),
+	)
+}
@@ -0,0 +1,351 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/network/p2p/p2ptest"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+var (
+	_ p2p.Handler = (*modifiedHandler)(nil)
+
+	errTest = errors.New("non-nil error")
+)
+
+// modifiedHandler modifies the values of the responses of [handler].
+type modifiedHandler struct {
+	*Server
+}
+
+func (h modifiedHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, *common.AppError) {
+	responseBytes, appErr := h.Server.AppRequest(ctx, nodeID, deadline, requestBytes)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := &rangeResponse{}
+	if _, err := Codec.Unmarshal(responseBytes, response); err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+	for i := range response.Values {
+		response.Values[i] = []byte{0}
+	}
+	responseBytes, err := Codec.Marshal(CodecVersion, response)
+	if err != nil {
+		return nil, p2p.ErrUnexpected
+	}
+	return responseBytes, nil
+}
+
+// newTestServer returns a server that has taken a snapshot of a state with
+// enough records to require multiple range requests.
+func newTestServer(t *testing.T) (*Server, *testState, *Summary) {
+	require := require.New(t)
+
+	state := &testState{
+		db: memdb.New(),
+	}
+	value := make([]byte, 100*units.KiB)
+	for i := 0; i < 32; i++ {
+		require.NoError(state.db.Put([]byte{byte(i)}, value))
+	}
+
+	server, err := NewServer(logging.NoLog{}, memdb.New(), state, 1, 0)
+	require.NoError(err)
+	commit(server, newTestBlock(t, 1))
+
+	summary, err := server.LastSummary()
+	require.NoError(err)
+	return server, state, summary
+}
+
+type testClient struct {
+	*Client
+	db       database.Database
+	applied  database.Database
+	toEngine chan common.Message
+}
+
+func newTestClient(t *testing.T, handler p2p.Handler, apply ApplyFunc) *testClient {
+	c := &testClient{
+		db:       memdb.New(),
+		applied:  memdb.New(),
+		toEngine: make(chan common.Message, 1),
+	}
+	if apply == nil {
+		apply = func(_ *Summary, records database.Iterator) error {
+			defer records.Release()
+
+			for records.Next() {
+				if err := c.applied.Put(records.Key(), records.Value()); err != nil {
+					return err
+				}
+			}
+			return records.Error()
+		}
+	}
+	c.Client = NewClient(ClientConfig{
+		Log: logging.NoLog{},
+		DB:  c.db,
+		Client: p2ptest.NewClient(
+			t,
+			context.Background(),
+			handler,
+			ids.GenerateTestNodeID(),
+			ids.GenerateTestNodeID(),
+		),
+		LastAcceptedHeight: func() (uint64, error) {
+			return 0, nil
+		},
+		Apply:    apply,
+		ToEngine: c.toEngine,
+	})
+	t.Cleanup(c.Shutdown)
+	return c
+}
+
+func (c *testClient) acceptAndWait(t *testing.T, summaryBytes []byte) {
+	require := require.New(t)
+
+	summary, err := c.ParseSummary(summaryBytes)
+	require.NoError(err)
+
+	mode, err := summary.Accept(context.Background())
+	require.NoError(err)
+	require.Equal(block.StateSyncStatic, mode)
+
+	require.Equal(common.StateSyncDone, <-c.toEngine)
+}
+
+func (c *testClient) requireSyncRemoved(t *testing.T) {
+	require := require.New(t)
+
+	_, err := c.OngoingSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	count, err := database.Count(prefixdb.New(stagingPrefix, c.db))
+	require.NoError(err)
+	require.Zero(count)
+}
+
+func TestClientSync(t *testing.T) {
+	require := require.New(t)
+
+	server, state, summary := newTestServer(t)
+	client := newTestClient(t, server, nil)
+
+	client.acceptAndWait(t, summary.Bytes())
+	require.NoError(client.Err())
+	require.Equal(dbContents(t, state.db), dbContents(t, client.applied))
+	client.requireSyncRemoved(t)
+}
+
+func TestClientSyncResume(t *testing.T) {
+	require := require.New(t)
+
+	server, state, summary := newTestServer(t)
+	client := newTestClient(t, server, nil)
+
+	// Simulate a restart after some of the records were downloaded.
+	stagingDB := prefixdb.New(stagingPrefix, client.db)
+	require.NoError(stagingDB.Put([]byte{0}, make([]byte, 100*units.KiB)))
+	require.NoError(stagingDB.Put([]byte{1}, make([]byte, 100*units.KiB)))
+	require.NoError(client.db.Put(ongoingSummaryKey, summary.Bytes()))
+
+	ongoingSummary, err := client.OngoingSummary()
+	require.NoError(err)
+	require.Equal(summary.ID(), ongoingSummary.ID())
+
+	client.acceptAndWait(t, summary.Bytes())
+	require.NoError(client.Err())
+	require.Equal(dbContents(t, state.db), dbContents(t, client.applied))
+	client.requireSyncRemoved(t)
+}
+
+func TestClientSyncRootMismatch(t *testing.T) {
+	require := require.New(t)
+
+	server, _, summary := newTestServer(t)
+	client := newTestClient(t, modifiedHandler{Server: server}, nil)
+
+	// The node falls back to bootstrapping without modifying the state.
+	client.acceptAndWait(t, summary.Bytes())
+	require.NoError(client.Err())
+	require.Empty(dbContents(t, client.applied))
+	client.requireSyncRemoved(t)
+}
+
+func TestClientSyncApplyFailure(t *testing.T) {
+	require := require.New(t)
+
+	server, _, summary := newTestServer(t)
+	client := newTestClient(t, server, func(*Summary, database.Iterator) error {
+		return errTest
+	})
+
+	client.acceptAndWait(t, summary.Bytes())
+	require.ErrorIs(client.Err(), errTest)
+
+	// The records are applied again on restart.
+	applyingBytes, err := client.db.Get(applyingKey)
+	require.NoError(err)
+	require.Equal(summary.Bytes(), applyingBytes)
+}
+
+func TestClientSkipsOldSummary(t *testing.T) {
+	require := require.New(t)
+
+	_, _, summary := newTestServer(t)
+	client := NewClient(ClientConfig{
+		Log: logging.NoLog{},
+		DB:  memdb.New(),
+		LastAcceptedHeight: func() (uint64, error) {
+			return summary.Height(), nil
+		},
+	})
+
+	parsedSummary, err := client.ParseSummary(summary.Bytes())
+	require.NoError(err)
+
+	mode, err := parsedSummary.Accept(context.Background())
+	require.NoError(err)
+	require.Equal(block.StateSyncSkipped, mode)
+
+	_, err = client.OngoingSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestFinishApplying(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		db        = memdb.New()
+		stagingDB = prefixdb.New(stagingPrefix, db)
+		applied   []*Summary
+		apply     = func(summary *Summary, records database.Iterator) error {
+			records.Release()
+			applied = append(applied, summary)
+			return nil
+		}
+	)
+
+	// Nothing is applied if the node didn't shut down while applying.
+	require.NoError(FinishApplying(db, apply))
+	require.Empty(applied)
+
+	summary, err := newSummary(1, ids.GenerateTestID(), ids.GenerateTestID())
+	require.NoError(err)
+	require.NoError(db.Put(applyingKey, summary.Bytes()))
+	require.NoError(db.Put(ongoingSummaryKey, summary.Bytes()))
+	require.NoError(stagingDB.Put([]byte{1}, []byte{1}))
+
+	require.NoError(FinishApplying(db, apply))
+	require.Len(applied, 1)
+	require.Equal(summary.ID(), applied[0].ID())
+
+	// All the sync data is removed once the records have been applied.
+	count, err := database.Count(db)
+	require.NoError(err)
+	require.Zero(count)
+}
+
+func TestVerifyRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    *rangeResponse
+		start       []byte
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			response: &rangeResponse{
+				Keys:   [][]byte{{1}, {2}},
+				Values: [][]byte{{1}, {2}},
+				More:   true,
+			},
+			start:       []byte{1},
+			expectedErr: nil,
+		},
+		{
+			name:        "empty last range",
+			response:    &rangeResponse{},
+			start:       []byte{1},
+			expectedErr: nil,
+		},
+		{
+			name: "mismatched lengths",
+			response: &rangeResponse{
+				Keys:   [][]byte{{1}, {2}},
+				Values: [][]byte{{1}},
+			},
+			expectedErr: errMismatchedRangeLengths,
+		},
+		{
+			name: "empty range with more",
+			response: &rangeResponse{
+				More: true,
+			},
+			expectedErr: errEmptyRange,
+		},
+		{
+			name: "key before start",
+			response: &rangeResponse{
+				Keys:   [][]byte{{1}},
+				Values: [][]byte{{1}},
+			},
+			start:       []byte{1, 0},
+			expectedErr: errKeyBeforeStart,
+		},
+		{
+			name: "unsorted keys",
+			response: &rangeResponse{
+				Keys:   [][]byte{{2}, {1}},
+				Values: [][]byte{{2}, {1}},
+			},
+			expectedErr: errUnsortedRecords,
+		},
+		{
+			name: "duplicate keys",
+			response: &rangeResponse{
+				Keys:   [][]byte{{1}, {1}},
+				Values: [][]byte{{1}, {1}},
+			},
+			expectedErr: errUnsortedRecords,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := verifyRange(test.response, test.start)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func dbContents(t *testing.T, db database.Iteratee) map[string][]byte {
+	it := db.NewIterator()
+	defer it.Release()
+
+	contents := make(map[string][]byte)
+	for it.Next() {
+		contents[string(it.Key())] = it.Value()
+	}
+	require.NoError(t, it.Error())
+	return contents
+}
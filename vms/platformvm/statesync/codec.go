@@ -0,0 +1,29 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/codec/linearcodec"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+)
+
+const (
+	CodecVersion = 0
+
+	// maxMessageSize is the maximum size of a summary or of a range message.
+	// Range responses are limited to [maxResponseSize], which leaves room for
+	// the codec overhead.
+	maxMessageSize = 2 * units.MiB
+)
+
+var Codec codec.Manager
+
+func init() {
+	Codec = codec.NewManager(maxMessageSize)
+	lc := linearcodec.NewDefault()
+	if err := Codec.RegisterCodec(CodecVersion, lc); err != nil {
+		panic(err)
+	}
+}
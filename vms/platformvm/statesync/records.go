@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/state"
+)
+
+// writeBatchSize is the number of bytes of records that are buffered before
+// they are written to the database.
+const writeBatchSize = 4 * units.MiB
+
+var (
+	_ database.KeyValueWriter = (*batchWriter)(nil)
+
+	errUnsortedRecords = errors.New("records are not sorted")
+	errUnknownRoot     = errors.New("unknown root")
+)
+
+// SyncableState is the state that is transferred during state sync.
+type SyncableState interface {
+	// NewSyncableSnapshot returns a snapshot of the last committed state.
+	NewSyncableSnapshot() (state.SyncableSnapshot, error)
+}
+
+// rangeRequest requests the records of the syncable state with [Root] whose
+// keys are greater than or equal to [Start].
+type rangeRequest struct {
+	Root  ids.ID `serialize:"true"`
+	Start []byte `serialize:"true"`
+}
+
+// rangeResponse contains the next records of the syncable state, sorted by key.
+type rangeResponse struct {
+	Keys   [][]byte `serialize:"true"`
+	Values [][]byte `serialize:"true"`
+	// More is true if there are records after the last key.
+	More bool `serialize:"true"`
+}
+
+// rootHasher calculates the root of a sequence of records, which must be
+// provided in increasing order of their keys.
+type rootHasher struct {
+	hasher  hash.Hash
+	lastKey []byte
+	started bool
+	lenBuf  [4]byte
+}
+
+func newRootHasher() *rootHasher {
+	return &rootHasher{
+		hasher: sha256.New(),
+	}
+}
+
+func (h *rootHasher) Add(key, value []byte) error {
+	if h.started && bytes.Compare(h.lastKey, key) >= 0 {
+		return errUnsortedRecords
+	}
+	h.started = true
+	h.lastKey = append(h.lastKey[:0], key...)
+
+	h.write(key)
+	h.write(value)
+	return nil
+}
+
+func (h *rootHasher) write(b []byte) {
+	binary.BigEndian.PutUint32(h.lenBuf[:], uint32(len(b)))
+	_, _ = h.hasher.Write(h.lenBuf[:])
+	_, _ = h.hasher.Write(b)
+}
+
+func (h *rootHasher) Root() ids.ID {
+	var root ids.ID
+	h.hasher.Sum(root[:0])
+	return root
+}
+
+// calculateRoot returns the root of the records in [db].
+func calculateRoot(db database.Iteratee) (ids.ID, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	h := newRootHasher()
+	for it.Next() {
+		if err := h.Add(it.Key(), it.Value()); err != nil {
+			return ids.Empty, err
+		}
+	}
+	return h.Root(), it.Error()
+}
+
+// batchWriter writes to a database in batches of roughly [writeBatchSize]
+// bytes. Flush must be called to write the last batch.
+type batchWriter struct {
+	batch database.Batch
+}
+
+func newBatchWriter(db database.Batcher) *batchWriter {
+	return &batchWriter{
+		batch: db.NewBatch(),
+	}
+}
+
+func (w *batchWriter) Put(key []byte, value []byte) error {
+	if err := w.batch.Put(key, value); err != nil {
+		return err
+	}
+	return w.maybeFlush()
+}
+
+func (w *batchWriter) Delete(key []byte) error {
+	if err := w.batch.Delete(key); err != nil {
+		return err
+	}
+	return w.maybeFlush()
+}
+
+func (w *batchWriter) maybeFlush() error {
+	if w.batch.Size() < writeBatchSize {
+		return nil
+	}
+	return w.Flush()
+}
+
+func (w *batchWriter) Flush() error {
+	if err := w.batch.Write(); err != nil {
+		return err
+	}
+	w.batch.Reset()
+	return nil
+}
@@ -0,0 +1,382 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/snow/engine/common"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/block"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/state"
+)
+
+const (
+	// numSlots is the number of snapshots of the syncable state that are
+	// kept. Serving the previous snapshot while the next one is written
+	// allows nodes to finish syncing to it.
+	numSlots = 2
+
+	// maxResponseSize is the maximum number of bytes of keys and values
+	// included in a range response.
+	maxResponseSize = units.MiB
+)
+
+var (
+	_ p2p.Handler             = (*Server)(nil)
+	_ database.KeyValueWriter = (*closableWriter)(nil)
+
+	summaryPrefix = []byte("summary")
+	slotPrefix    = []byte("slot")
+
+	errServerClosed = errors.New("server closed")
+)
+
+// Server takes periodic snapshots of the syncable state and serves them to
+// syncing nodes.
+type Server struct {
+	log       logging.Logger
+	state     SyncableState
+	frequency uint64
+	// lastHeight is the height of the last block whose state was committed.
+	lastHeight uint64
+	// snapshotDone is closed once the last snapshot has been written.
+	snapshotDone chan struct{}
+	closed       chan struct{}
+	closeOnce    sync.Once
+
+	lock      sync.RWMutex
+	summaryDB database.Database
+	slotDBs   [numSlots]database.Database
+	summaries [numSlots]*Summary
+}
+
+// NewServer returns a server that snapshots [state] into [db] whenever the
+// height of a committed block reaches a multiple of [frequency]. If
+// [frequency] is 0, no new snapshots are taken.
+//
+// [lastAcceptedHeight] is the height of the last committed block.
+func NewServer(
+	log logging.Logger,
+	db database.Database,
+	state SyncableState,
+	frequency uint64,
+	lastAcceptedHeight uint64,
+) (*Server, error) {
+	s := &Server{
+		log:        log,
+		state:      state,
+		frequency:  frequency,
+		lastHeight: lastAcceptedHeight,
+		closed:     make(chan struct{}),
+		summaryDB:  prefixdb.New(summaryPrefix, db),
+	}
+	slotsDB := prefixdb.New(slotPrefix, db)
+	for slot := range s.slotDBs {
+		s.slotDBs[slot] = prefixdb.New([]byte{byte(slot)}, slotsDB)
+
+		summaryBytes, err := s.summaryDB.Get([]byte{byte(slot)})
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.summaries[slot], err = ParseSummary(summaryBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// OnCommit takes a snapshot of the syncable state if [blk], whose state was
+// just committed, crossed a multiple of the snapshot frequency. The snapshot is
+// written in the background, so that accepting blocks isn't delayed by it. If
+// the previous snapshot is still being written, no snapshot is taken.
+//
+// Invariant: The state must not have any uncommitted changes.
+func (s *Server) OnCommit(blk block.Block) {
+	height := blk.Height()
+	lastHeight := s.lastHeight
+	s.lastHeight = height
+	if s.frequency == 0 || height/s.frequency <= lastHeight/s.frequency {
+		return
+	}
+
+	blkID := blk.ID()
+	if s.snapshotting() {
+		s.log.Warn("skipping state summary",
+			zap.String("reason", "previous summary is still being created"),
+			zap.Uint64("height", height),
+			zap.Stringer("blkID", blkID),
+		)
+		return
+	}
+
+	snapshot, err := s.state.NewSyncableSnapshot()
+	if err != nil {
+		s.log.Error("failed to snapshot syncable state",
+			zap.Uint64("height", height),
+			zap.Stringer("blkID", blkID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	done := make(chan struct{})
+	s.snapshotDone = done
+	go func() {
+		defer close(done)
+		defer snapshot.Release()
+
+		err := s.write(height, blkID, snapshot)
+		if err != nil && !errors.Is(err, errServerClosed) {
+			s.log.Error("failed to create state summary",
+				zap.Uint64("height", height),
+				zap.Stringer("blkID", blkID),
+				zap.Error(err),
+			)
+		}
+	}()
+}
+
+// snapshotting returns true if a snapshot is currently being written.
+func (s *Server) snapshotting() bool {
+	if s.snapshotDone == nil {
+		return false
+	}
+	select {
+	case <-s.snapshotDone:
+		return false
+	default:
+		return true
+	}
+}
+
+// waitForSnapshot blocks until the snapshot that is currently being written,
+// if any, is done.
+func (s *Server) waitForSnapshot() {
+	if s.snapshotDone != nil {
+		<-s.snapshotDone
+	}
+}
+
+// Close stops writing the current snapshot, if any, and waits for it to stop.
+// The slot that was being written doesn't have a summary until it is written
+// again.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+	s.waitForSnapshot()
+}
+
+func (s *Server) write(height uint64, blkID ids.ID, snapshot state.SyncableSnapshot) error {
+	start := time.Now()
+	slot := s.nextSlot()
+
+	// Stop serving the snapshot that is about to be overwritten. Any requests
+	// that are currently reading from the slot finish before the lock is
+	// acquired.
+	s.lock.Lock()
+	s.summaries[slot] = nil
+	err := s.summaryDB.Delete([]byte{byte(slot)})
+	s.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	slotDB := s.slotDBs[slot]
+	if err := database.Clear(slotDB, writeBatchSize); err != nil {
+		return err
+	}
+
+	w := newBatchWriter(slotDB)
+	if err := snapshot.Write(&closableWriter{w: w, closed: s.closed}); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	root, err := calculateRoot(slotDB)
+	if err != nil {
+		return err
+	}
+	summary, err := newSummary(height, blkID, root)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.summaryDB.Put([]byte{byte(slot)}, summary.Bytes()); err != nil {
+		return err
+	}
+	s.summaries[slot] = summary
+
+	s.log.Info("created state summary",
+		zap.Uint64("height", height),
+		zap.Stringer("blkID", blkID),
+		zap.Stringer("root", root),
+		zap.Duration("duration", time.Since(start)),
+	)
+	return nil
+}
+
+// closableWriter writes to [w] until [closed] is closed.
+type closableWriter struct {
+	w      database.KeyValueWriter
+	closed <-chan struct{}
+}
+
+func (c *closableWriter) Put(key []byte, value []byte) error {
+	select {
+	case <-c.closed:
+		return errServerClosed
+	default:
+		return c.w.Put(key, value)
+	}
+}
+
+// nextSlot returns the slot that doesn't contain the latest summary.
+func (s *Server) nextSlot() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	latest := s.latestSlot()
+	if latest == -1 {
+		return 0
+	}
+	return (latest + 1) % numSlots
+}
+
+// latestSlot returns the slot containing the summary with the greatest height,
+// or -1 if there are no summaries.
+//
+// Assumes [s.lock] is held.
+func (s *Server) latestSlot() int {
+	latest := -1
+	for slot, summary := range s.summaries {
+		if summary == nil {
+			continue
+		}
+		if latest == -1 || summary.BlockHeight > s.summaries[latest].BlockHeight {
+			latest = slot
+		}
+	}
+	return latest
+}
+
+// LastSummary returns the latest summary.
+//
+// Returns [database.ErrNotFound] if no summary is available.
+func (s *Server) LastSummary() (*Summary, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	latest := s.latestSlot()
+	if latest == -1 {
+		return nil, database.ErrNotFound
+	}
+	return s.summaries[latest], nil
+}
+
+// Summary returns the summary at [height].
+//
+// Returns [database.ErrNotFound] if no summary is available at [height].
+func (s *Server) Summary(height uint64) (*Summary, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for _, summary := range s.summaries {
+		if summary != nil && summary.BlockHeight == height {
+			return summary, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+func (*Server) AppGossip(context.Context, ids.NodeID, []byte) {}
+
+func (s *Server) AppRequest(_ context.Context, nodeID ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, *common.AppError) {
+	request := &rangeRequest{}
+	if _, err := Codec.Unmarshal(requestBytes, request); err != nil {
+		return nil, &common.AppError{
+			Code:    p2p.ErrUnexpected.Code,
+			Message: fmt.Sprintf("failed to unmarshal request: %s", err),
+		}
+	}
+
+	response, err := s.getRange(request)
+	if err != nil {
+		s.log.Debug("failed to serve state sync request",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("root", request.Root),
+			zap.Error(err),
+		)
+		return nil, &common.AppError{
+			Code:    p2p.ErrUnexpected.Code,
+			Message: fmt.Sprintf("failed to get range: %s", err),
+		}
+	}
+
+	responseBytes, err := Codec.Marshal(CodecVersion, response)
+	if err != nil {
+		return nil, &common.AppError{
+			Code:    p2p.ErrUnexpected.Code,
+			Message: fmt.Sprintf("failed to marshal response: %s", err),
+		}
+	}
+	return responseBytes, nil
+}
+
+func (s *Server) getRange(request *rangeRequest) (*rangeResponse, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	slot := -1
+	for i, summary := range s.summaries {
+		if summary != nil && summary.Root == request.Root {
+			slot = i
+			break
+		}
+	}
+	if slot == -1 {
+		return nil, fmt.Errorf("%w: %s", errUnknownRoot, request.Root)
+	}
+
+	it := s.slotDBs[slot].NewIteratorWithStart(request.Start)
+	defer it.Release()
+
+	var (
+		response = &rangeResponse{}
+		size     int
+	)
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		size += len(key) + len(value)
+		if len(response.Keys) > 0 && size > maxResponseSize {
+			response.More = true
+			break
+		}
+		response.Keys = append(response.Keys, key)
+		response.Values = append(response.Values, value)
+	}
+	return response, it.Error()
+}
@@ -0,0 +1,315 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/block"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/state"
+)
+
+var (
+	_ SyncableState          = (*testState)(nil)
+	_ state.SyncableSnapshot = (*testSnapshot)(nil)
+)
+
+// testState is a SyncableState whose records are the contents of a database.
+type testState struct {
+	db database.Database
+}
+
+func (s *testState) NewSyncableSnapshot() (state.SyncableSnapshot, error) {
+	return &testSnapshot{
+		it: s.db.NewIterator(),
+	}, nil
+}
+
+type testSnapshot struct {
+	it database.Iterator
+}
+
+func (s *testSnapshot) Write(w database.KeyValueWriter) error {
+	for s.it.Next() {
+		if err := w.Put(s.it.Key(), s.it.Value()); err != nil {
+			return err
+		}
+	}
+	return s.it.Error()
+}
+
+func (s *testSnapshot) Release() {
+	s.it.Release()
+}
+
+// commit notifies [server] that [blk] was committed and waits for the
+// resulting snapshot, if any, to be written.
+func commit(server *Server, blk block.Block) {
+	server.OnCommit(blk)
+	server.waitForSnapshot()
+}
+
+func newTestBlock(t *testing.T, height uint64) block.Block {
+	blk, err := block.NewBanffStandardBlock(time.Unix(0, 0), ids.GenerateTestID(), height, nil)
+	require.NoError(t, err)
+	return blk
+}
+
+func TestServerSnapshots(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		state = &testState{
+			db: memdb.New(),
+		}
+		db = memdb.New()
+	)
+	require.NoError(state.db.Put([]byte{1}, []byte{1}))
+
+	server, err := NewServer(logging.NoLog{}, db, state, 10, 0)
+	require.NoError(err)
+
+	// No snapshot is taken before the height reaches the frequency.
+	commit(server, newTestBlock(t, 9))
+	_, err = server.LastSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	blk10 := newTestBlock(t, 10)
+	commit(server, blk10)
+	summary10, err := server.LastSummary()
+	require.NoError(err)
+	require.Equal(uint64(10), summary10.Height())
+	require.Equal(blk10.ID(), summary10.BlockID)
+
+	expectedRoot, err := calculateRoot(state.db)
+	require.NoError(err)
+	require.Equal(expectedRoot, summary10.Root)
+
+	// A snapshot is taken when a multiple of the frequency is skipped, as
+	// happens when a proposal block and its option are committed together.
+	require.NoError(state.db.Put([]byte{2}, []byte{2}))
+	commit(server, newTestBlock(t, 19))
+	commit(server, newTestBlock(t, 21))
+	summary21, err := server.LastSummary()
+	require.NoError(err)
+	require.Equal(uint64(21), summary21.Height())
+	require.NotEqual(summary10.Root, summary21.Root)
+
+	// The previous summary is still served.
+	summary, err := server.Summary(10)
+	require.NoError(err)
+	require.Equal(summary10, summary)
+
+	// The oldest snapshot is replaced by the next snapshot.
+	commit(server, newTestBlock(t, 30))
+	_, err = server.Summary(10)
+	require.ErrorIs(err, database.ErrNotFound)
+	summary30, err := server.LastSummary()
+	require.NoError(err)
+	require.Equal(uint64(30), summary30.Height())
+
+	// Summaries are persisted.
+	server, err = NewServer(logging.NoLog{}, db, state, 10, 30)
+	require.NoError(err)
+	summary, err = server.LastSummary()
+	require.NoError(err)
+	require.Equal(summary30.Bytes(), summary.Bytes())
+	summary, err = server.Summary(21)
+	require.NoError(err)
+	require.Equal(summary21.Bytes(), summary.Bytes())
+}
+
+func TestServerSnapshotsDisabled(t *testing.T) {
+	require := require.New(t)
+
+	state := &testState{
+		db: memdb.New(),
+	}
+	server, err := NewServer(logging.NoLog{}, memdb.New(), state, 0, 0)
+	require.NoError(err)
+
+	commit(server, newTestBlock(t, 1))
+	_, err = server.LastSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+// blockingState is a SyncableState whose snapshots are written once [unblock]
+// is closed.
+type blockingState struct {
+	testState
+	unblock chan struct{}
+}
+
+func (s *blockingState) NewSyncableSnapshot() (state.SyncableSnapshot, error) {
+	snapshot, err := s.testState.NewSyncableSnapshot()
+	return &blockingSnapshot{
+		SyncableSnapshot: snapshot,
+		unblock:          s.unblock,
+	}, err
+}
+
+type blockingSnapshot struct {
+	state.SyncableSnapshot
+	unblock chan struct{}
+}
+
+func (s *blockingSnapshot) Write(w database.KeyValueWriter) error {
+	<-s.unblock
+	return s.SyncableSnapshot.Write(w)
+}
+
+func TestServerSkipsSnapshotWhileWriting(t *testing.T) {
+	require := require.New(t)
+
+	state := &blockingState{
+		testState: testState{
+			db: memdb.New(),
+		},
+		unblock: make(chan struct{}),
+	}
+	require.NoError(state.db.Put([]byte{1}, []byte{1}))
+
+	server, err := NewServer(logging.NoLog{}, memdb.New(), state, 1, 0)
+	require.NoError(err)
+
+	// Committing a block doesn't wait for the snapshot to be written.
+	server.OnCommit(newTestBlock(t, 1))
+	_, err = server.LastSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// No snapshot is taken while the previous one is being written.
+	server.OnCommit(newTestBlock(t, 2))
+
+	close(state.unblock)
+	server.waitForSnapshot()
+	summary, err := server.LastSummary()
+	require.NoError(err)
+	require.Equal(uint64(1), summary.Height())
+	_, err = server.Summary(2)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestServerCloseStopsSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	state := &blockingState{
+		testState: testState{
+			db: memdb.New(),
+		},
+		unblock: make(chan struct{}),
+	}
+	require.NoError(state.db.Put([]byte{1}, []byte{1}))
+
+	server, err := NewServer(logging.NoLog{}, memdb.New(), state, 1, 0)
+	require.NoError(err)
+	server.OnCommit(newTestBlock(t, 1))
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		server.Close()
+	}()
+	// The snapshot is written once the server is closed.
+	<-server.closed
+	close(state.unblock)
+	<-closed
+
+	_, err = server.LastSummary()
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestServerAppRequest(t *testing.T) {
+	state := &testState{
+		db: memdb.New(),
+	}
+	value := make([]byte, 400*units.KiB)
+	for i := byte(0); i < 5; i++ {
+		require.NoError(t, state.db.Put([]byte{i}, value))
+	}
+
+	server, err := NewServer(logging.NoLog{}, memdb.New(), state, 1, 0)
+	require.NoError(t, err)
+	commit(server, newTestBlock(t, 1))
+	summary, err := server.LastSummary()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		start        []byte
+		expectedKeys [][]byte
+		expectedMore bool
+	}{
+		{
+			name:         "first range",
+			start:        nil,
+			expectedKeys: [][]byte{{0}, {1}},
+			expectedMore: true,
+		},
+		{
+			name:         "start between keys",
+			start:        []byte{1, 0},
+			expectedKeys: [][]byte{{2}, {3}},
+			expectedMore: true,
+		},
+		{
+			name:         "last range",
+			start:        []byte{4},
+			expectedKeys: [][]byte{{4}},
+			expectedMore: false,
+		},
+		{
+			name:         "after last key",
+			start:        []byte{5},
+			expectedKeys: [][]byte{},
+			expectedMore: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			requestBytes, err := Codec.Marshal(CodecVersion, &rangeRequest{
+				Root:  summary.Root,
+				Start: test.start,
+			})
+			require.NoError(err)
+
+			responseBytes, appErr := server.AppRequest(context.Background(), ids.GenerateTestNodeID(), time.Time{}, requestBytes)
+			require.Nil(appErr)
+
+			response := &rangeResponse{}
+			_, err = Codec.Unmarshal(responseBytes, response)
+			require.NoError(err)
+			require.Equal(test.expectedKeys, response.Keys)
+			require.Len(response.Values, len(test.expectedKeys))
+			require.Equal(test.expectedMore, response.More)
+		})
+	}
+}
+
+func TestServerAppRequestUnknownRoot(t *testing.T) {
+	require := require.New(t)
+
+	server, err := NewServer(logging.NoLog{}, memdb.New(), &testState{db: memdb.New()}, 1, 0)
+	require.NoError(err)
+
+	requestBytes, err := Codec.Marshal(CodecVersion, &rangeRequest{
+		Root: ids.GenerateTestID(),
+	})
+	require.NoError(err)
+
+	_, appErr := server.AppRequest(context.Background(), ids.GenerateTestNodeID(), time.Time{}, requestBytes)
+	require.NotNil(appErr)
+	require.Equal(p2p.ErrUnexpected.Code, appErr.Code)
+}
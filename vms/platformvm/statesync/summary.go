@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/utils/hashing"
+)
+
+var _ block.StateSummary = (*Summary)(nil)
+
+// Summary describes the syncable state of the P-chain after the block with ID
+// [BlockID] was accepted.
+type Summary struct {
+	// BlockHeight is the height of [BlockID].
+	BlockHeight uint64 `serialize:"true"`
+	BlockID     ids.ID `serialize:"true"`
+	// Root is the hash of all the records of the syncable state.
+	Root ids.ID `serialize:"true"`
+
+	id     ids.ID
+	bytes  []byte
+	accept func(context.Context, *Summary) (block.StateSyncMode, error)
+}
+
+func newSummary(height uint64, blkID ids.ID, root ids.ID) (*Summary, error) {
+	s := &Summary{
+		BlockHeight: height,
+		BlockID:     blkID,
+		Root:        root,
+	}
+	bytes, err := Codec.Marshal(CodecVersion, s)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal summary: %w", err)
+	}
+	s.initialize(bytes)
+	return s, nil
+}
+
+// ParseSummary parses a summary from [bytes].
+func ParseSummary(bytes []byte) (*Summary, error) {
+	s := &Summary{}
+	if _, err := Codec.Unmarshal(bytes, s); err != nil {
+		return nil, fmt.Errorf("couldn't parse summary: %w", err)
+	}
+	s.initialize(bytes)
+	return s, nil
+}
+
+func (s *Summary) initialize(bytes []byte) {
+	s.id = hashing.ComputeHash256Array(bytes)
+	s.bytes = bytes
+}
+
+func (s *Summary) ID() ids.ID {
+	return s.id
+}
+
+func (s *Summary) Height() uint64 {
+	return s.BlockHeight
+}
+
+func (s *Summary) Bytes() []byte {
+	return s.bytes
+}
+
+// Accept starts syncing to this summary. Summaries that weren't provided by a
+// Client are never synced.
+func (s *Summary) Accept(ctx context.Context) (block.StateSyncMode, error) {
+	if s.accept == nil {
+		return block.StateSyncSkipped, nil
+	}
+	return s.accept(ctx, s)
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package statesync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/codec"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/utils/hashing"
+)
+
+func TestSummary(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		blkID = ids.GenerateTestID()
+		root  = ids.GenerateTestID()
+	)
+	summary, err := newSummary(5, blkID, root)
+	require.NoError(err)
+	require.Equal(uint64(5), summary.Height())
+	require.Equal(ids.ID(hashing.ComputeHash256Array(summary.Bytes())), summary.ID())
+
+	parsedSummary, err := ParseSummary(summary.Bytes())
+	require.NoError(err)
+	require.Equal(summary, parsedSummary)
+
+	// Summaries that weren't parsed by a client are never synced.
+	mode, err := parsedSummary.Accept(context.Background())
+	require.NoError(err)
+	require.Equal(block.StateSyncSkipped, mode)
+}
+
+func TestParseSummaryInvalid(t *testing.T) {
+	_, err := ParseSummary([]byte{1, 2, 3})
+	require.ErrorIs(t, err, codec.ErrUnknownVersion)
+}
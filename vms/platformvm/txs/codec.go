@@ -123,5 +123,9 @@ func RegisterDurangoTypes(targetCodec linearcodec.Codec) error {
 func RegisterEtnaTypes(targetCodec linearcodec.Codec) error {
 	return errors.Join(
 		targetCodec.RegisterType(&ConvertSubnetTx{}),
+		targetCodec.RegisterType(&RegisterL1ValidatorTx{}),
+		targetCodec.RegisterType(&SetL1ValidatorWeightTx{}),
+		targetCodec.RegisterType(&IncreaseL1ValidatorBalanceTx{}),
+		targetCodec.RegisterType(&DisableL1ValidatorTx{}),
 	)
 }
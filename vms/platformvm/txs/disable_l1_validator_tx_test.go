@@ -0,0 +1,97 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/snowtest"
+	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
+)
+
+func TestDisableL1ValidatorTxSyntacticVerify(t *testing.T) {
+	var (
+		ctx         = snowtest.Context(t, ids.GenerateTestID())
+		validBaseTx = BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    ctx.NetworkID,
+				BlockchainID: ctx.ChainID,
+			},
+		}
+		validationID       = ids.GenerateTestID()
+		validDisableAuth   = &secp256k1fx.Input{}
+		invalidDisableAuth = &secp256k1fx.Input{
+			SigIndices: []uint32{1, 0},
+		}
+	)
+
+	tests := []struct {
+		name        string
+		tx          *DisableL1ValidatorTx
+		expectedErr error
+	}{
+		{
+			name:        "nil tx",
+			tx:          nil,
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			// The tx includes invalid data to verify that a cached result is
+			// returned.
+			tx: &DisableL1ValidatorTx{
+				BaseTx: BaseTx{
+					SyntacticallyVerified: true,
+				},
+				ValidationID: validationID,
+				DisableAuth:  invalidDisableAuth,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid BaseTx",
+			tx: &DisableL1ValidatorTx{
+				BaseTx:       BaseTx{},
+				ValidationID: validationID,
+				DisableAuth:  validDisableAuth,
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "invalid disableAuth",
+			tx: &DisableL1ValidatorTx{
+				BaseTx:       validBaseTx,
+				ValidationID: validationID,
+				DisableAuth:  invalidDisableAuth,
+			},
+			expectedErr: secp256k1fx.ErrInputIndicesNotSortedUnique,
+		},
+		{
+			name: "passes verification",
+			tx: &DisableL1ValidatorTx{
+				BaseTx:       validBaseTx,
+				ValidationID: validationID,
+				DisableAuth:  validDisableAuth,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			err := test.tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.True(test.tx.SyntacticallyVerified)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/vms/components/verify"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/state"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp/message"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp/payload"
+	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
+)
+
+var (
+	errSubnetNotConverted             = errors.New("subnet has not been converted")
+	errWrongWarpMessageSourceChainID  = errors.New("wrong warp message source chainID")
+	errWrongWarpMessageSourceAddress  = errors.New("wrong warp message source address")
+	errUnauthorizedL1ValidatorDisable = errors.New("unauthorized L1 validator disable")
+)
+
+// parseL1ManagerMessage parses [warpMessageBytes] as a warp message containing
+// an addressed call. The payload of the addressed call is returned.
+//
+// Signatures are not verified here, see [VerifyWarpMessages].
+func parseL1ManagerMessage(warpMessageBytes []byte) (*warp.Message, *payload.AddressedCall, error) {
+	warpMessage, err := warp.ParseMessage(warpMessageBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	addressedCall, err := payload.ParseAddressedCall(warpMessage.Payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return warpMessage, addressedCall, nil
+}
+
+// verifyL1Manager verifies that [sourceChainID] and [sourceAddress] are the
+// manager of [subnetID].
+func verifyL1Manager(
+	chainState state.Chain,
+	subnetID ids.ID,
+	sourceChainID ids.ID,
+	sourceAddress []byte,
+) error {
+	expectedChainID, expectedAddress, err := chainState.GetSubnetManager(subnetID)
+	if err == database.ErrNotFound {
+		return fmt.Errorf("%w: %s", errSubnetNotConverted, subnetID)
+	}
+	if err != nil {
+		return err
+	}
+	if sourceChainID != expectedChainID {
+		return fmt.Errorf("%w: expected %s but got %s", errWrongWarpMessageSourceChainID, expectedChainID, sourceChainID)
+	}
+	if !bytes.Equal(sourceAddress, expectedAddress) {
+		return fmt.Errorf("%w: expected 0x%x but got 0x%x", errWrongWarpMessageSourceAddress, expectedAddress, sourceAddress)
+	}
+	return nil
+}
+
+// verifyL1ValidatorDisableAuthorization carries out the validation for
+// disabling an L1 validator. The last credential in [sTx.Creds] is used as the
+// disable authorization. Returns the remaining tx credentials that should be
+// used to authorize the other operations in the tx.
+func verifyL1ValidatorDisableAuthorization(
+	backend *Backend,
+	sTx *txs.Tx,
+	sov state.SubnetOnlyValidator,
+	disableAuth verify.Verifiable,
+) ([]verify.Verifiable, error) {
+	if len(sTx.Creds) == 0 {
+		// Ensure there is at least one credential for the disable authorization
+		return nil, errWrongNumberOfCredentials
+	}
+
+	baseTxCredsLen := len(sTx.Creds) - 1
+	disableCred := sTx.Creds[baseTxCredsLen]
+
+	disableOwner, err := parseL1ValidatorOwner(sov.DeactivationOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := backend.Fx.VerifyPermission(sTx.Unsigned, disableAuth, disableCred, disableOwner); err != nil {
+		return nil, fmt.Errorf("%w: %w", errUnauthorizedL1ValidatorDisable, err)
+	}
+
+	return sTx.Creds[:baseTxCredsLen], nil
+}
+
+// parseL1ValidatorOwner parses an owner that was stored on an L1 validator.
+func parseL1ValidatorOwner(ownerBytes []byte) (*secp256k1fx.OutputOwners, error) {
+	var owner message.PChainOwner
+	if _, err := txs.Codec.Unmarshal(ownerBytes, &owner); err != nil {
+		return nil, err
+	}
+	return &secp256k1fx.OutputOwners{
+		Threshold: owner.Threshold,
+		Addrs:     owner.Addresses,
+	}, nil
+}
@@ -103,6 +103,22 @@ func (*ProposalTxExecutor) ConvertSubnetTx(*txs.ConvertSubnetTx) error {
 	return ErrWrongTxType
 }
 
+func (*ProposalTxExecutor) RegisterL1ValidatorTx(*txs.RegisterL1ValidatorTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) SetL1ValidatorWeightTx(*txs.SetL1ValidatorWeightTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) IncreaseL1ValidatorBalanceTx(*txs.IncreaseL1ValidatorBalanceTx) error {
+	return ErrWrongTxType
+}
+
+func (*ProposalTxExecutor) DisableL1ValidatorTx(*txs.DisableL1ValidatorTx) error {
+	return ErrWrongTxType
+}
+
 func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	// AddValidatorTx is a proposal transaction until the Banff fork
 	// activation. Following the activation, AddValidatorTxs must be issued into
@@ -271,6 +287,7 @@ func (e *ProposalTxExecutor) AdvanceTimeTx(tx *txs.AdvanceTimeTx) error {
 
 	now := e.Clk.Time()
 	if err := VerifyNewChainTime(
+		e.Config.ValidatorFeeConfig,
 		newChainTime,
 		now,
 		e.OnCommitState,
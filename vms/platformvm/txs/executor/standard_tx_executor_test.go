@@ -41,7 +41,11 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs/txstest"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/utxo"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/utxo/utxomock"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp/message"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp/payload"
 	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
+	"github.com/MetalBlockchain/metalgo/wallet/chain/p/wallet"
 	"github.com/MetalBlockchain/metalgo/wallet/subnet/primary/common"
 )
 
@@ -2573,3 +2577,286 @@ func TestStandardExecutorConvertSubnetTx(t *testing.T) {
 		})
 	}
 }
+
+func TestStandardExecutorL1ValidatorTxs(t *testing.T) {
+	var (
+		fx = &secp256k1fx.Fx{}
+		vm = &secp256k1fx.TestVM{
+			Log: logging.NoLog{},
+		}
+	)
+	require.NoError(t, fx.InitializeVM(vm))
+
+	var (
+		ctx           = snowtest.Context(t, constants.PlatformChainID)
+		defaultConfig = &config.Config{
+			DynamicFeeConfig:   genesis.LocalParams.DynamicFeeConfig,
+			ValidatorFeeConfig: genesis.LocalParams.ValidatorFeeConfig,
+			UpgradeConfig:      upgradetest.GetConfig(upgradetest.Latest),
+		}
+		baseState = statetest.New(t, statetest.Config{
+			Upgrades: defaultConfig.UpgradeConfig,
+		})
+		flowChecker = utxo.NewVerifier(
+			ctx,
+			&vm.Clk,
+			fx,
+		)
+		backend = &Backend{
+			Config:       defaultConfig,
+			Bootstrapped: utils.NewAtomic(true),
+			Fx:           fx,
+			FlowChecker:  flowChecker,
+			Ctx:          ctx,
+		}
+	)
+
+	// newSyncedWallet returns a wallet that is in sync with the current base
+	// state. Transactions that are expected to fail are issued with a fresh
+	// wallet to avoid spending UTXOs that were never accepted.
+	newSyncedWallet := func(subnetIDs ...ids.ID) wallet.Wallet {
+		return txstest.NewWallet(
+			t,
+			ctx,
+			defaultConfig,
+			baseState,
+			secp256k1fx.NewKeychain(genesistest.DefaultFundedKeys...),
+			subnetIDs,
+			nil, // chainIDs
+		)
+	}
+	execute := func(tx *txs.Tx) (state.Diff, error) {
+		diff, err := state.NewDiffOn(baseState)
+		require.NoError(t, err)
+
+		err = tx.Unsigned.Visit(&StandardTxExecutor{
+			Backend:       backend,
+			FeeCalculator: state.PickFeeCalculator(defaultConfig, baseState),
+			Tx:            tx,
+			State:         diff,
+		})
+		return diff, err
+	}
+	accept := func(tx *txs.Tx) {
+		diff, err := execute(tx)
+		require.NoError(t, err)
+		require.NoError(t, diff.Apply(baseState))
+		require.NoError(t, baseState.Commit())
+	}
+
+	// Create the subnet
+	wallet := newSyncedWallet()
+	createSubnetTx, err := wallet.IssueCreateSubnetTx(
+		&secp256k1fx.OutputOwners{},
+	)
+	require.NoError(t, err)
+	accept(createSubnetTx)
+
+	// Convert the subnet
+	var (
+		subnetID       = createSubnetTx.ID()
+		managerChainID = ids.GenerateTestID()
+		managerAddress = utils.RandomBytes(32)
+	)
+	wallet = newSyncedWallet(subnetID)
+	convertSubnetTx, err := wallet.IssueConvertSubnetTx(
+		subnetID,
+		managerChainID,
+		managerAddress,
+	)
+	require.NoError(t, err)
+	accept(convertSubnetTx)
+
+	newWarpMessage := func(sourceAddress []byte, msg []byte) []byte {
+		addressedCall, err := payload.NewAddressedCall(sourceAddress, msg)
+		require.NoError(t, err)
+		unsignedMessage, err := warp.NewUnsignedMessage(
+			ctx.NetworkID,
+			managerChainID,
+			addressedCall.Bytes(),
+		)
+		require.NoError(t, err)
+		warpMessage, err := warp.NewMessage(
+			unsignedMessage,
+			&warp.BitSetSignature{},
+		)
+		require.NoError(t, err)
+		return warpMessage.Bytes()
+	}
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(t, err)
+	var (
+		pop   = signer.NewProofOfPossession(sk)
+		owner = message.PChainOwner{
+			Threshold: 1,
+			Addresses: []ids.ShortID{
+				genesistest.DefaultFundedKeys[0].Address(),
+			},
+		}
+		nodeID = ids.GenerateTestNodeID()
+		expiry = uint64(baseState.GetTimestamp().Unix()) + 1
+	)
+	registerMessage, err := message.NewRegisterSubnetValidator(
+		subnetID,
+		nodeID,
+		pop.PublicKey,
+		expiry,
+		owner,
+		owner,
+		10,
+	)
+	require.NoError(t, err)
+	validationID := registerMessage.ValidationID()
+
+	t.Run("register from wrong address", func(t *testing.T) {
+		registerTx, err := newSyncedWallet(subnetID).IssueRegisterL1ValidatorTx(
+			units.Avax,
+			pop.ProofOfPossession,
+			newWarpMessage(utils.RandomBytes(32), registerMessage.Bytes()),
+		)
+		require.NoError(t, err)
+
+		_, err = execute(registerTx)
+		require.ErrorIs(t, err, errWrongWarpMessageSourceAddress)
+	})
+
+	t.Run("register with invalid proof of possession", func(t *testing.T) {
+		registerTx, err := newSyncedWallet(subnetID).IssueRegisterL1ValidatorTx(
+			units.Avax,
+			[bls.SignatureLen]byte{},
+			newWarpMessage(managerAddress, registerMessage.Bytes()),
+		)
+		require.NoError(t, err)
+
+		_, err = execute(registerTx)
+		require.ErrorIs(t, err, bls.ErrFailedSignatureDecompress)
+	})
+
+	registerTx, err := wallet.IssueRegisterL1ValidatorTx(
+		units.Avax,
+		pop.ProofOfPossession,
+		newWarpMessage(managerAddress, registerMessage.Bytes()),
+	)
+	require.NoError(t, err)
+	accept(registerTx)
+
+	t.Run("register", func(t *testing.T) {
+		require := require.New(t)
+
+		sov, err := baseState.GetSubnetOnlyValidator(validationID)
+		require.NoError(err)
+		require.Equal(subnetID, sov.SubnetID)
+		require.Equal(nodeID, sov.NodeID)
+		require.Equal(bls.PublicKeyToUncompressedBytes(bls.PublicFromSecretKey(sk)), sov.PublicKey)
+		require.Equal(uint64(10), sov.Weight)
+		require.Equal(units.Avax, sov.EndAccumulatedFee)
+
+		hasExpiry, err := baseState.HasExpiry(state.ExpiryEntry{
+			Timestamp:    expiry,
+			ValidationID: validationID,
+		})
+		require.NoError(err)
+		require.True(hasExpiry)
+	})
+
+	t.Run("register replay", func(t *testing.T) {
+		replayTx, err := newSyncedWallet(subnetID).IssueRegisterL1ValidatorTx(
+			units.Avax,
+			pop.ProofOfPossession,
+			newWarpMessage(managerAddress, registerMessage.Bytes()),
+		)
+		require.NoError(t, err)
+
+		_, err = execute(replayTx)
+		require.ErrorIs(t, err, errWarpMessageAlreadyIssued)
+	})
+
+	t.Run("increase balance", func(t *testing.T) {
+		require := require.New(t)
+
+		increaseBalanceTx, err := wallet.IssueIncreaseL1ValidatorBalanceTx(
+			validationID,
+			units.Avax,
+		)
+		require.NoError(err)
+		accept(increaseBalanceTx)
+
+		sov, err := baseState.GetSubnetOnlyValidator(validationID)
+		require.NoError(err)
+		require.Equal(2*units.Avax, sov.EndAccumulatedFee)
+	})
+
+	t.Run("remove last validator", func(t *testing.T) {
+		weightMessage, err := message.NewSubnetValidatorWeight(validationID, 0, 0)
+		require.NoError(t, err)
+
+		setWeightTx, err := newSyncedWallet(subnetID).IssueSetL1ValidatorWeightTx(
+			newWarpMessage(managerAddress, weightMessage.Bytes()),
+		)
+		require.NoError(t, err)
+
+		_, err = execute(setWeightTx)
+		require.ErrorIs(t, err, errRemovingLastValidator)
+	})
+
+	t.Run("set weight", func(t *testing.T) {
+		require := require.New(t)
+
+		weightMessage, err := message.NewSubnetValidatorWeight(validationID, 0, 5)
+		require.NoError(err)
+
+		setWeightTx, err := wallet.IssueSetL1ValidatorWeightTx(
+			newWarpMessage(managerAddress, weightMessage.Bytes()),
+		)
+		require.NoError(err)
+		accept(setWeightTx)
+
+		sov, err := baseState.GetSubnetOnlyValidator(validationID)
+		require.NoError(err)
+		require.Equal(uint64(5), sov.Weight)
+		require.Equal(uint64(1), sov.MinNonce)
+
+		staleWeightMessage, err := message.NewSubnetValidatorWeight(validationID, 0, 7)
+		require.NoError(err)
+
+		staleSetWeightTx, err := newSyncedWallet(subnetID).IssueSetL1ValidatorWeightTx(
+			newWarpMessage(managerAddress, staleWeightMessage.Bytes()),
+		)
+		require.NoError(err)
+
+		_, err = execute(staleSetWeightTx)
+		require.ErrorIs(err, errWarpMessageContainsStaleNonce)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		require := require.New(t)
+
+		disableTx, err := wallet.IssueDisableL1ValidatorTx(validationID)
+		require.NoError(err)
+		accept(disableTx)
+
+		sov, err := baseState.GetSubnetOnlyValidator(validationID)
+		require.NoError(err)
+		require.Zero(sov.EndAccumulatedFee)
+		require.Equal(uint64(5), sov.Weight)
+
+		utx := disableTx.Unsigned.(*txs.DisableL1ValidatorTx)
+		refundUTXOID := avax.UTXOID{
+			TxID:        disableTx.ID(),
+			OutputIndex: uint32(len(utx.Outs)),
+		}
+		refundUTXO, err := baseState.GetUTXO(refundUTXOID.InputID())
+		require.NoError(err)
+		require.Equal(
+			&secp256k1fx.TransferOutput{
+				Amt: 2 * units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: owner.Threshold,
+					Addrs:     owner.Addresses,
+				},
+			},
+			refundUTXO.Out,
+		)
+	})
+}
@@ -6,13 +6,18 @@ package executor
 import (
 	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/utils/constants"
+	"github.com/MetalBlockchain/metalgo/vms/components/gas"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/reward"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/state"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
+
+	safemath "github.com/MetalBlockchain/metalgo/utils/math"
+	validatorfee "github.com/MetalBlockchain/metalgo/vms/platformvm/validators/fee"
 )
 
 var (
@@ -30,6 +35,7 @@ var (
 //   - [newChainTime] <= [nextStakerChangeTime]: so that no staking set changes
 //     are skipped.
 func VerifyNewChainTime(
+	config validatorfee.Config,
 	newChainTime time.Time,
 	now time.Time,
 	currentState state.Chain,
@@ -57,7 +63,7 @@ func VerifyNewChainTime(
 
 	// nextStakerChangeTime is calculated last to ensure that the function is
 	// able to be calculated efficiently.
-	nextStakerChangeTime, err := state.GetNextStakerChangeTime(currentState, newChainTime)
+	nextStakerChangeTime, err := state.GetNextStakerChangeTime(config, currentState, newChainTime)
 	if err != nil {
 		return fmt.Errorf("could not verify block timestamp: %w", err)
 	}
@@ -225,6 +231,17 @@ func advanceTimeTo(
 			duration,
 		)
 		changes.SetFeeState(feeState)
+
+		sovsChanged, err := chargeSubnetOnlyValidatorFees(
+			backend.Config.ValidatorFeeConfig,
+			parentState,
+			changes,
+			duration,
+		)
+		if err != nil {
+			return nil, false, err
+		}
+		changed = changed || sovsChanged
 	}
 
 	// Remove all expiries whose timestamp now implies they can never be
@@ -261,6 +278,67 @@ func advanceTimeTo(
 	return changes, changed, nil
 }
 
+// chargeSubnetOnlyValidatorFees charges the active subnet-only validators the
+// continuous fee for [seconds] and deactivates all of the validators that are
+// unable to pay the fee for the following second. Returns true iff any
+// validator was deactivated.
+//
+// Invariant: [changes] must not have modified any subnet-only validators.
+func chargeSubnetOnlyValidatorFees(
+	config validatorfee.Config,
+	parentState state.Chain,
+	changes state.Diff,
+	seconds uint64,
+) (bool, error) {
+	feeState := validatorfee.State{
+		Current: gas.Gas(changes.NumActiveSubnetOnlyValidators()),
+		Excess:  changes.GetSoVExcess(),
+	}
+	cost := feeState.CostOf(config, seconds)
+
+	accruedFees, err := safemath.Add(changes.GetAccruedFees(), cost)
+	if err != nil {
+		return false, err
+	}
+
+	feeState = feeState.AdvanceTime(config.Target, seconds)
+	changes.SetSoVExcess(feeState.Excess)
+	changes.SetAccruedFees(accruedFees)
+
+	// Validators must be able to pay for the next second to remain active.
+	nextSecondCost := feeState.CostOf(config, 1)
+	minEndAccumulatedFee, err := safemath.Add(accruedFees, nextSecondCost)
+	if err != nil {
+		minEndAccumulatedFee = math.MaxUint64
+	}
+
+	// Invariant: It is not safe to modify the state while iterating over it,
+	// so we use the parentState's iterator rather than the changes iterator.
+	// ParentState must not be modified before this iterator is released.
+	sovIterator, err := parentState.GetActiveSubnetOnlyValidatorsIterator()
+	if err != nil {
+		return false, err
+	}
+	defer sovIterator.Release()
+
+	var changed bool
+	for sovIterator.Next() {
+		sov := sovIterator.Value()
+		// GetActiveSubnetOnlyValidatorsIterator iterates in order of increasing
+		// EndAccumulatedFee, so we can break early.
+		if sov.EndAccumulatedFee >= minEndAccumulatedFee {
+			break
+		}
+
+		sov.EndAccumulatedFee = 0 // Deactivate the validator
+		if err := changes.PutSubnetOnlyValidator(sov); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
 func GetRewardsCalculator(
 	backend *Backend,
 	parentState state.Chain,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/MetalBlockchain/metalgo/genesis"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/upgrade/upgradetest"
 	"github.com/MetalBlockchain/metalgo/utils/iterator"
@@ -79,7 +80,11 @@ func TestAdvanceTimeTo_UpdatesFeeState(t *testing.T) {
 
 			// Ensure the invariant that [nextTime <= nextStakerChangeTime] on
 			// AdvanceTimeTo is maintained.
-			nextStakerChangeTime, err := state.GetNextStakerChangeTime(s, mockable.MaxTime)
+			nextStakerChangeTime, err := state.GetNextStakerChangeTime(
+				genesis.LocalParams.ValidatorFeeConfig,
+				s,
+				mockable.MaxTime,
+			)
 			require.NoError(err)
 			require.False(nextTime.After(nextStakerChangeTime))
 
@@ -185,7 +190,11 @@ func TestAdvanceTimeTo_RemovesStaleExpiries(t *testing.T) {
 
 			// Ensure the invariant that [newTime <= nextStakerChangeTime] on
 			// AdvanceTimeTo is maintained.
-			nextStakerChangeTime, err := state.GetNextStakerChangeTime(s, mockable.MaxTime)
+			nextStakerChangeTime, err := state.GetNextStakerChangeTime(
+				genesis.LocalParams.ValidatorFeeConfig,
+				s,
+				mockable.MaxTime,
+			)
 			require.NoError(err)
 			require.False(newTime.After(nextStakerChangeTime))
 
@@ -214,3 +223,104 @@ func TestAdvanceTimeTo_RemovesStaleExpiries(t *testing.T) {
 		})
 	}
 }
+
+func TestAdvanceTimeTo_DeactivatesSoVs(t *testing.T) {
+	const (
+		secondsToAdvance  = 3
+		durationToAdvance = secondsToAdvance * time.Second
+	)
+
+	var (
+		validatorFeeConfig = genesis.LocalParams.ValidatorFeeConfig
+		price              = uint64(validatorFeeConfig.MinPrice)
+
+		sovToEvict = state.SubnetOnlyValidator{
+			ValidationID:      ids.GenerateTestID(),
+			SubnetID:          ids.GenerateTestID(),
+			NodeID:            ids.GenerateTestNodeID(),
+			Weight:            1,
+			EndAccumulatedFee: secondsToAdvance * price, // Can't pay for the next second
+		}
+		sovToKeep = state.SubnetOnlyValidator{
+			ValidationID:      ids.GenerateTestID(),
+			SubnetID:          ids.GenerateTestID(),
+			NodeID:            ids.GenerateTestNodeID(),
+			Weight:            1,
+			EndAccumulatedFee: (secondsToAdvance + 1) * price,
+		}
+	)
+
+	tests := []struct {
+		name                string
+		fork                upgradetest.Fork
+		expectedModified    bool
+		expectedAccrued     uint64
+		expectedActiveSoVs  []state.SubnetOnlyValidator
+		expectedEvictedSoVs []state.SubnetOnlyValidator
+	}{
+		{
+			name:               "Pre-Etna",
+			fork:               upgradetest.Durango,
+			expectedActiveSoVs: []state.SubnetOnlyValidator{sovToEvict, sovToKeep},
+		},
+		{
+			name:                "Etna",
+			fork:                upgradetest.Etna,
+			expectedModified:    true,
+			expectedAccrued:     secondsToAdvance * price,
+			expectedActiveSoVs:  []state.SubnetOnlyValidator{sovToKeep},
+			expectedEvictedSoVs: []state.SubnetOnlyValidator{sovToEvict},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				require = require.New(t)
+
+				s        = statetest.New(t, statetest.Config{})
+				nextTime = s.GetTimestamp().Add(durationToAdvance)
+			)
+
+			require.NoError(s.PutSubnetOnlyValidator(sovToEvict))
+			require.NoError(s.PutSubnetOnlyValidator(sovToKeep))
+
+			// Ensure the invariant that [nextTime <= nextStakerChangeTime] on
+			// AdvanceTimeTo is maintained.
+			nextStakerChangeTime, err := state.GetNextStakerChangeTime(
+				validatorFeeConfig,
+				s,
+				mockable.MaxTime,
+			)
+			require.NoError(err)
+			require.Equal(nextTime, nextStakerChangeTime)
+
+			validatorsModified, err := AdvanceTimeTo(
+				&Backend{
+					Config: &config.Config{
+						ValidatorFeeConfig: validatorFeeConfig,
+						UpgradeConfig:      upgradetest.GetConfig(test.fork),
+					},
+				},
+				s,
+				nextTime,
+			)
+			require.NoError(err)
+			require.Equal(test.expectedModified, validatorsModified)
+			require.Equal(test.expectedAccrued, s.GetAccruedFees())
+			require.Equal(len(test.expectedActiveSoVs), s.NumActiveSubnetOnlyValidators())
+
+			activeIterator, err := s.GetActiveSubnetOnlyValidatorsIterator()
+			require.NoError(err)
+			require.Equal(
+				test.expectedActiveSoVs,
+				iterator.ToSlice(activeIterator),
+			)
+
+			for _, sov := range test.expectedEvictedSoVs {
+				evicted, err := s.GetSubnetOnlyValidator(sov.ValidationID)
+				require.NoError(err)
+				require.Zero(evicted.EndAccumulatedFee)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/signer"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/stakeable"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/warp"
 	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
 )
 
@@ -67,6 +68,15 @@ const (
 
 	intrinsicInputDBRead = 1
 
+	// A warp message requires reading the chainID -> subnetID mapping, the
+	// current validator set, and the weight and public key diffs.
+	intrinsicWarpDBReads = 3 + 20
+
+	intrinsicBLSAggregateCompute           = 5     // BLS public key aggregation time is around 5us
+	intrinsicBLSVerifyCompute              = 1_000 // BLS verification time is around 1000us
+	intrinsicBLSPublicKeyValidationCompute = 50    // BLS public key validation time is around 50us
+	intrinsicBLSPoPVerifyCompute           = intrinsicBLSPublicKeyValidationCompute + intrinsicBLSVerifyCompute
+
 	intrinsicInputDBWrite  = 1
 	intrinsicOutputDBWrite = 1
 )
@@ -187,6 +197,40 @@ var (
 		gas.Compute: 0,
 	}
 
+	IntrinsicRegisterL1ValidatorTxComplexities = gas.Dimensions{
+		gas.Bandwidth: IntrinsicBaseTxComplexities[gas.Bandwidth] +
+			wrappers.LongLen + // balance
+			bls.SignatureLen + // proof of possession
+			wrappers.IntLen, // message length
+		gas.DBRead:  5, // conversion owner + expiry + subnetID+nodeID + legacy validator + fee state
+		gas.DBWrite: 6, // write current validator + expiry + subnetID+nodeID + weight + active count + weight diff
+		gas.Compute: intrinsicBLSPoPVerifyCompute,
+	}
+	IntrinsicSetL1ValidatorWeightTxComplexities = gas.Dimensions{
+		gas.Bandwidth: IntrinsicBaseTxComplexities[gas.Bandwidth] +
+			wrappers.IntLen, // message length
+		gas.DBRead:  3, // read validator + subnet manager + weight
+		gas.DBWrite: 5, // write validator + weight + weight diff + subnetID+nodeID + refund
+		gas.Compute: 0,
+	}
+	IntrinsicIncreaseL1ValidatorBalanceTxComplexities = gas.Dimensions{
+		gas.Bandwidth: IntrinsicBaseTxComplexities[gas.Bandwidth] +
+			ids.IDLen + // validationID
+			wrappers.LongLen, // balance
+		gas.DBRead:  1, // read validator
+		gas.DBWrite: 5, // weight diff + deactivated weight diff + public key diff + write validator + active count
+		gas.Compute: 0,
+	}
+	IntrinsicDisableL1ValidatorTxComplexities = gas.Dimensions{
+		gas.Bandwidth: IntrinsicBaseTxComplexities[gas.Bandwidth] +
+			ids.IDLen + // validationID
+			wrappers.IntLen + // auth typeID
+			wrappers.IntLen, // authCredential typeID
+		gas.DBRead:  1, // read validator
+		gas.DBWrite: 6, // write remaining balance utxo + weight diff + deactivated weight diff + public key diff + delete validator + active count
+		gas.Compute: 0,
+	}
+
 	errUnsupportedOutput = errors.New("unsupported output type")
 	errUnsupportedInput  = errors.New("unsupported input type")
 	errUnsupportedOwner  = errors.New("unsupported owner type")
@@ -379,6 +423,35 @@ func SignerComplexity(s signer.Signer) (gas.Dimensions, error) {
 	}
 }
 
+// WarpComplexity returns the complexity a warp message adds to a transaction.
+func WarpComplexity(message []byte) (gas.Dimensions, error) {
+	msg, err := warp.ParseMessage(message)
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+
+	numSigners, err := msg.Signature.NumSigners()
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+	aggregationCompute, err := math.Mul(uint64(numSigners), intrinsicBLSAggregateCompute)
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+
+	signatureComputeComplexity, err := math.Add(aggregationCompute, intrinsicBLSVerifyCompute)
+	if err != nil {
+		return gas.Dimensions{}, err
+	}
+
+	return gas.Dimensions{
+		gas.Bandwidth: uint64(len(message)),
+		gas.DBRead:    intrinsicWarpDBReads,
+		gas.DBWrite:   0,
+		gas.Compute:   signatureComputeComplexity,
+	}, nil
+}
+
 type complexityVisitor struct {
 	output gas.Dimensions
 }
@@ -624,6 +697,65 @@ func (c *complexityVisitor) ConvertSubnetTx(tx *txs.ConvertSubnetTx) error {
 	return err
 }
 
+func (c *complexityVisitor) RegisterL1ValidatorTx(tx *txs.RegisterL1ValidatorTx) error {
+	baseTxComplexity, err := baseTxComplexity(&tx.BaseTx)
+	if err != nil {
+		return err
+	}
+	warpComplexity, err := WarpComplexity(tx.Message)
+	if err != nil {
+		return err
+	}
+	c.output, err = IntrinsicRegisterL1ValidatorTxComplexities.Add(
+		&baseTxComplexity,
+		&warpComplexity,
+	)
+	return err
+}
+
+func (c *complexityVisitor) SetL1ValidatorWeightTx(tx *txs.SetL1ValidatorWeightTx) error {
+	baseTxComplexity, err := baseTxComplexity(&tx.BaseTx)
+	if err != nil {
+		return err
+	}
+	warpComplexity, err := WarpComplexity(tx.Message)
+	if err != nil {
+		return err
+	}
+	c.output, err = IntrinsicSetL1ValidatorWeightTxComplexities.Add(
+		&baseTxComplexity,
+		&warpComplexity,
+	)
+	return err
+}
+
+func (c *complexityVisitor) IncreaseL1ValidatorBalanceTx(tx *txs.IncreaseL1ValidatorBalanceTx) error {
+	baseTxComplexity, err := baseTxComplexity(&tx.BaseTx)
+	if err != nil {
+		return err
+	}
+	c.output, err = IntrinsicIncreaseL1ValidatorBalanceTxComplexities.Add(
+		&baseTxComplexity,
+	)
+	return err
+}
+
+func (c *complexityVisitor) DisableL1ValidatorTx(tx *txs.DisableL1ValidatorTx) error {
+	baseTxComplexity, err := baseTxComplexity(&tx.BaseTx)
+	if err != nil {
+		return err
+	}
+	authComplexity, err := AuthComplexity(tx.DisableAuth)
+	if err != nil {
+		return err
+	}
+	c.output, err = IntrinsicDisableL1ValidatorTxComplexities.Add(
+		&baseTxComplexity,
+		&authComplexity,
+	)
+	return err
+}
+
 func baseTxComplexity(tx *txs.BaseTx) (gas.Dimensions, error) {
 	outputsComplexity, err := OutputComplexity(tx.Outs...)
 	if err != nil {
@@ -51,6 +51,22 @@ func (*staticVisitor) ConvertSubnetTx(*txs.ConvertSubnetTx) error {
 	return ErrUnsupportedTx
 }
 
+func (*staticVisitor) RegisterL1ValidatorTx(*txs.RegisterL1ValidatorTx) error {
+	return ErrUnsupportedTx
+}
+
+func (*staticVisitor) SetL1ValidatorWeightTx(*txs.SetL1ValidatorWeightTx) error {
+	return ErrUnsupportedTx
+}
+
+func (*staticVisitor) IncreaseL1ValidatorBalanceTx(*txs.IncreaseL1ValidatorBalanceTx) error {
+	return ErrUnsupportedTx
+}
+
+func (*staticVisitor) DisableL1ValidatorTx(*txs.DisableL1ValidatorTx) error {
+	return ErrUnsupportedTx
+}
+
 func (c *staticVisitor) AddValidatorTx(*txs.AddValidatorTx) error {
 	c.fee = c.config.AddPrimaryNetworkValidatorFee
 	return nil
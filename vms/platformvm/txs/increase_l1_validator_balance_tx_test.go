@@ -0,0 +1,93 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/snowtest"
+	"github.com/MetalBlockchain/metalgo/utils/units"
+	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+)
+
+func TestIncreaseL1ValidatorBalanceTxSyntacticVerify(t *testing.T) {
+	var (
+		ctx         = snowtest.Context(t, ids.GenerateTestID())
+		validBaseTx = BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    ctx.NetworkID,
+				BlockchainID: ctx.ChainID,
+			},
+		}
+		validationID = ids.GenerateTestID()
+	)
+
+	tests := []struct {
+		name        string
+		tx          *IncreaseL1ValidatorBalanceTx
+		expectedErr error
+	}{
+		{
+			name:        "nil tx",
+			tx:          nil,
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			// The tx includes invalid data to verify that a cached result is
+			// returned.
+			tx: &IncreaseL1ValidatorBalanceTx{
+				BaseTx: BaseTx{
+					SyntacticallyVerified: true,
+				},
+				ValidationID: validationID,
+				Balance:      0,
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "zero balance",
+			tx: &IncreaseL1ValidatorBalanceTx{
+				BaseTx:       validBaseTx,
+				ValidationID: validationID,
+				Balance:      0,
+			},
+			expectedErr: ErrZeroBalance,
+		},
+		{
+			name: "invalid BaseTx",
+			tx: &IncreaseL1ValidatorBalanceTx{
+				BaseTx:       BaseTx{},
+				ValidationID: validationID,
+				Balance:      units.Avax,
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "passes verification",
+			tx: &IncreaseL1ValidatorBalanceTx{
+				BaseTx:       validBaseTx,
+				ValidationID: validationID,
+				Balance:      units.Avax,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			err := test.tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.True(test.tx.SyntacticallyVerified)
+		})
+	}
+}
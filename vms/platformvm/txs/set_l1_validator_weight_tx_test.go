@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/snowtest"
+	"github.com/MetalBlockchain/metalgo/vms/components/avax"
+)
+
+func TestSetL1ValidatorWeightTxSyntacticVerify(t *testing.T) {
+	var (
+		ctx         = snowtest.Context(t, ids.GenerateTestID())
+		validBaseTx = BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    ctx.NetworkID,
+				BlockchainID: ctx.ChainID,
+			},
+		}
+	)
+
+	tests := []struct {
+		name        string
+		tx          *SetL1ValidatorWeightTx
+		expectedErr error
+	}{
+		{
+			name:        "nil tx",
+			tx:          nil,
+			expectedErr: ErrNilTx,
+		},
+		{
+			name: "already verified",
+			// The tx includes invalid data to verify that a cached result is
+			// returned.
+			tx: &SetL1ValidatorWeightTx{
+				BaseTx: BaseTx{
+					SyntacticallyVerified: true,
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "invalid BaseTx",
+			tx: &SetL1ValidatorWeightTx{
+				BaseTx: BaseTx{},
+			},
+			expectedErr: avax.ErrWrongNetworkID,
+		},
+		{
+			name: "passes verification",
+			tx: &SetL1ValidatorWeightTx{
+				BaseTx: validBaseTx,
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			err := test.tx.SyntacticVerify(ctx)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.True(test.tx.SyntacticallyVerified)
+		})
+	}
+}
@@ -20,5 +20,9 @@ type Visitor interface {
 	AddPermissionlessDelegatorTx(*AddPermissionlessDelegatorTx) error
 	TransferSubnetOwnershipTx(*TransferSubnetOwnershipTx) error
 	ConvertSubnetTx(*ConvertSubnetTx) error
+	RegisterL1ValidatorTx(*RegisterL1ValidatorTx) error
+	SetL1ValidatorWeightTx(*SetL1ValidatorWeightTx) error
+	IncreaseL1ValidatorBalanceTx(*IncreaseL1ValidatorBalanceTx) error
+	DisableL1ValidatorTx(*DisableL1ValidatorTx) error
 	BaseTx(*BaseTx) error
 }
@@ -85,6 +85,7 @@ type State interface {
 		validators map[ids.NodeID]*validators.GetValidatorOutput,
 		startHeight uint64,
 		endHeight uint64,
+		subnetID ids.ID,
 	) error
 }
 
@@ -282,6 +283,7 @@ func (m *manager) makePrimaryNetworkValidatorSet(
 		validatorSet,
 		currentHeight,
 		lastDiffHeight,
+		constants.PrimaryNetworkID,
 	)
 	return validatorSet, currentHeight, err
 }
@@ -332,22 +334,42 @@ func (m *manager) makeSubnetValidatorSet(
 
 	// Update the subnet validator set to include the public keys at
 	// [currentHeight]. When we apply the public key diffs, we will convert
-	// these keys to represent the public keys at [targetHeight]. If the subnet
+	// these keys to represent the public keys at [targetHeight].
+	//
+	// Subnet-only validators register their own public keys, which are already
+	// included in the subnet validator set. Legacy subnet validators use the
+	// public key registered on the primary network. If a legacy subnet
 	// validator is not currently a primary network validator, it doesn't have a
 	// key at [currentHeight].
+	legacyValidatorSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(subnetValidatorSet))
 	for nodeID, vdr := range subnetValidatorSet {
+		if vdr.PublicKey != nil {
+			continue
+		}
+
+		legacyValidatorSet[nodeID] = vdr
 		if primaryVdr, ok := primaryValidatorSet[nodeID]; ok {
 			vdr.PublicKey = primaryVdr.PublicKey
-		} else {
-			vdr.PublicKey = nil
 		}
 	}
 
+	err = m.state.ApplyValidatorPublicKeyDiffs(
+		ctx,
+		legacyValidatorSet,
+		currentHeight,
+		lastDiffHeight,
+		constants.PrimaryNetworkID,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	err = m.state.ApplyValidatorPublicKeyDiffs(
 		ctx,
 		subnetValidatorSet,
 		currentHeight,
 		lastDiffHeight,
+		subnetID,
 	)
 	return subnetValidatorSet, currentHeight, err
 }
@@ -18,7 +18,9 @@ import (
 	"github.com/MetalBlockchain/metalgo/codec"
 	"github.com/MetalBlockchain/metalgo/codec/linearcodec"
 	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/prefixdb"
 	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
@@ -37,6 +39,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/network"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/reward"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/state"
+	"github.com/MetalBlockchain/metalgo/vms/platformvm/statesync"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/txs"
 	"github.com/MetalBlockchain/metalgo/vms/platformvm/utxo"
 	"github.com/MetalBlockchain/metalgo/vms/secp256k1fx"
@@ -84,6 +87,11 @@ type VM struct {
 
 	manager blockexecutor.Manager
 
+	stateSyncEnabled bool
+	stateSyncDB      database.Database
+	stateSyncServer  *statesync.Server
+	stateSyncClient  *statesync.Client
+
 	// Cancelled on shutdown
 	onShutdownCtx context.Context
 	// Call [onShutdownCtxCancel] to cancel [onShutdownCtx] during Shutdown()
@@ -124,6 +132,8 @@ func (vm *VM) Initialize(
 
 	vm.ctx = chainCtx
 	vm.db = db
+	vm.stateSyncEnabled = execConfig.StateSyncEnabled
+	vm.stateSyncDB = prefixdb.New(stateSyncPrefix, db)
 
 	// Note: this codec is never used to serialize anything
 	vm.codecRegistry = linearcodec.NewDefault()
@@ -134,6 +144,12 @@ func (vm *VM) Initialize(
 
 	rewards := reward.NewCalculator(vm.RewardConfig)
 
+	// If the node shut down while a synced state was being applied, the state
+	// must be fully replaced before it can be loaded.
+	if err := statesync.FinishApplying(vm.stateSyncDB, vm.applySyncedState); err != nil {
+		return fmt.Errorf("failed to apply synced state: %w", err)
+	}
+
 	vm.state, err = state.New(
 		vm.db,
 		genesisBytes,
@@ -171,12 +187,28 @@ func (vm *VM) Initialize(
 		return fmt.Errorf("failed to create mempool: %w", err)
 	}
 
+	lastAcceptedBlk, err := vm.state.GetStatelessBlock(vm.state.GetLastAccepted())
+	if err != nil {
+		return err
+	}
+	vm.stateSyncServer, err = statesync.NewServer(
+		chainCtx.Log,
+		vm.stateSyncDB,
+		vm.state,
+		execConfig.StateSyncSummaryFrequency,
+		lastAcceptedBlk.Height(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize state sync server: %w", err)
+	}
+
 	vm.manager = blockexecutor.NewManager(
 		mempool,
 		vm.metrics,
 		vm.state,
 		txExecutorBackend,
 		validatorManager,
+		vm.stateSyncServer.OnCommit,
 	)
 
 	txVerifier := network.NewLockedTxVerifier(&txExecutorBackend.Ctx.Lock, vm.manager)
@@ -199,6 +231,18 @@ func (vm *VM) Initialize(
 		return fmt.Errorf("failed to initialize network: %w", err)
 	}
 
+	if err := vm.Network.AddHandler(p2p.StateSyncHandlerID, vm.stateSyncServer); err != nil {
+		return fmt.Errorf("failed to initialize state sync server: %w", err)
+	}
+	vm.stateSyncClient = statesync.NewClient(statesync.ClientConfig{
+		Log:                chainCtx.Log,
+		DB:                 vm.stateSyncDB,
+		Client:             vm.Network.NewClient(p2p.StateSyncHandlerID),
+		LastAcceptedHeight: vm.lastAcceptedHeight,
+		Apply:              vm.acceptSyncedState,
+		ToEngine:           toEngine,
+	})
+
 	vm.onShutdownCtx, vm.onShutdownCtxCancel = context.WithCancel(context.Background())
 	// TODO: Wait for this goroutine to exit during Shutdown once the platformvm
 	// has better control of the context lock.
@@ -373,7 +417,13 @@ func (vm *VM) onNormalOperationsStarted() error {
 
 func (vm *VM) SetState(_ context.Context, state snow.State) error {
 	switch state {
+	case snow.StateSyncing:
+		return nil
 	case snow.Bootstrapping:
+		// Bootstrapping starts once state sync has finished.
+		if err := vm.stateSyncClient.Err(); err != nil {
+			return err
+		}
 		return vm.onBootstrapStarted()
 	case snow.NormalOp:
 		return vm.onNormalOperationsStarted()
@@ -389,6 +439,8 @@ func (vm *VM) Shutdown(context.Context) error {
 	}
 
 	vm.onShutdownCtxCancel()
+	vm.stateSyncClient.Shutdown()
+	vm.stateSyncServer.Close()
 	vm.Builder.ShutdownBlockTimer()
 
 	if vm.uptimeManager.StartedTracking() {
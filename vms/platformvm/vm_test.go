@@ -20,6 +20,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/message"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
+	"github.com/MetalBlockchain/metalgo/network/reputation"
 	"github.com/MetalBlockchain/metalgo/snow"
 	"github.com/MetalBlockchain/metalgo/snow/consensus/snowball"
 	"github.com/MetalBlockchain/metalgo/snow/engine/common"
@@ -69,6 +70,7 @@ import (
 	blockbuilder "github.com/MetalBlockchain/metalgo/vms/platformvm/block/builder"
 	blockexecutor "github.com/MetalBlockchain/metalgo/vms/platformvm/block/executor"
 	txexecutor "github.com/MetalBlockchain/metalgo/vms/platformvm/txs/executor"
+	validatorfee "github.com/MetalBlockchain/metalgo/vms/platformvm/validators/fee"
 	walletbuilder "github.com/MetalBlockchain/metalgo/wallet/chain/p/builder"
 	walletcommon "github.com/MetalBlockchain/metalgo/wallet/subnet/primary/common"
 )
@@ -113,6 +115,12 @@ var (
 		MinPrice:                 1,
 		ExcessConversionConstant: 5_000,
 	}
+	defaultValidatorFeeConfig = validatorfee.Config{
+		Capacity:                 100,
+		Target:                   50,
+		MinPrice:                 1,
+		ExcessConversionConstant: 100,
+	}
 
 	// subnet that exists at genesis in defaultVM
 	testSubnet1 *txs.Tx
@@ -135,6 +143,7 @@ func defaultVM(t *testing.T, f upgradetest.Fork) (*VM, database.Database, *mutab
 		Validators:             validators.NewManager(),
 		StaticFeeConfig:        defaultStaticFeeConfig,
 		DynamicFeeConfig:       defaultDynamicFeeConfig,
+		ValidatorFeeConfig:     defaultValidatorFeeConfig,
 		MinValidatorStake:      defaultMinValidatorStake,
 		MaxValidatorStake:      defaultMaxValidatorStake,
 		MinDelegatorStake:      defaultMinDelegatorStake,
@@ -1315,6 +1324,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 		consensusCtx.Registerer,
 		set.Of(ctx.NodeID),
 		nil,
+		reputation.NoTracker{},
 	)
 	require.NoError(err)
 
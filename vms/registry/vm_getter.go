@@ -15,6 +15,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms"
 	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm"
 	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime/subprocess"
 )
 
 var (
@@ -36,12 +37,13 @@ type VMGetter interface {
 
 // VMGetterConfig defines settings for VMGetter
 type VMGetterConfig struct {
-	FileReader      filesystem.Reader
-	Manager         vms.Manager
-	PluginDirectory string
-	CPUTracker      resource.ProcessTracker
-	RuntimeTracker  runtime.Tracker
-	MetricsGatherer metrics.MultiGatherer
+	FileReader        filesystem.Reader
+	Manager           vms.Manager
+	PluginDirectory   string
+	CPUTracker        resource.ProcessTracker
+	RuntimeTracker    runtime.Tracker
+	MetricsGatherer   metrics.MultiGatherer
+	PluginSupervision subprocess.SupervisorConfig
 }
 
 type vmGetter struct {
@@ -106,6 +108,7 @@ func (getter *vmGetter) Get() (map[ids.ID]vms.Factory, map[ids.ID]vms.Factory, e
 			getter.config.CPUTracker,
 			getter.config.RuntimeTracker,
 			getter.config.MetricsGatherer,
+			getter.config.PluginSupervision,
 		)
 	}
 	return registeredVMs, unregisteredVMs, nil
@@ -19,10 +19,11 @@ import (
 var _ vms.Factory = (*factory)(nil)
 
 type factory struct {
-	path            string
-	processTracker  resource.ProcessTracker
-	runtimeTracker  runtime.Tracker
-	metricsGatherer metrics.MultiGatherer
+	path             string
+	processTracker   resource.ProcessTracker
+	runtimeTracker   runtime.Tracker
+	metricsGatherer  metrics.MultiGatherer
+	supervisorConfig subprocess.SupervisorConfig
 }
 
 func NewFactory(
@@ -30,12 +31,14 @@ func NewFactory(
 	processTracker resource.ProcessTracker,
 	runtimeTracker runtime.Tracker,
 	metricsGatherer metrics.MultiGatherer,
+	supervisorConfig subprocess.SupervisorConfig,
 ) vms.Factory {
 	return &factory{
-		path:            path,
-		processTracker:  processTracker,
-		runtimeTracker:  runtimeTracker,
-		metricsGatherer: metricsGatherer,
+		path:             path,
+		processTracker:   processTracker,
+		runtimeTracker:   runtimeTracker,
+		metricsGatherer:  metricsGatherer,
+		supervisorConfig: supervisorConfig,
 	}
 }
 
@@ -47,17 +50,21 @@ func (f *factory) New(log logging.Logger) (interface{}, error) {
 		Log:              log,
 	}
 
-	listener, err := grpcutils.NewListener()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create listener: %w", err)
+	start := func(ctx context.Context) (*subprocess.Status, runtime.Process, error) {
+		listener, err := grpcutils.NewListener()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create listener: %w", err)
+		}
+
+		return subprocess.Bootstrap(
+			ctx,
+			listener,
+			subprocess.NewCmd(f.path),
+			config,
+		)
 	}
 
-	status, stopper, err := subprocess.Bootstrap(
-		context.TODO(),
-		listener,
-		subprocess.NewCmd(f.path),
-		config,
-	)
+	status, process, err := start(context.TODO())
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +75,12 @@ func (f *factory) New(log logging.Logger) (interface{}, error) {
 	}
 
 	f.processTracker.TrackProcess(status.Pid)
-	f.runtimeTracker.TrackRuntime(stopper)
-	return NewClient(clientConn, stopper, status.Pid, f.processTracker, f.metricsGatherer), nil
+	if !f.supervisorConfig.Enabled {
+		f.runtimeTracker.TrackRuntime(process)
+		return NewClient(clientConn, process, status.Pid, f.processTracker, f.metricsGatherer), nil
+	}
+
+	supervisor := subprocess.NewSupervisor(log, f.supervisorConfig, process, start)
+	f.runtimeTracker.TrackRuntime(supervisor)
+	return NewSupervisedClient(log, clientConn, supervisor, status.Pid, f.processTracker, f.metricsGatherer), nil
 }
@@ -39,6 +39,13 @@ type Stopper interface {
 	Stop(ctx context.Context)
 }
 
+type Process interface {
+	Stopper
+	// Exited returns a channel that is closed once the VM process has exited,
+	// regardless of whether it was stopped.
+	Exited() <-chan struct{}
+}
+
 type Tracker interface {
 	// TrackRuntime adds a VM stopper to the manager.
 	TrackRuntime(runtime Stopper)
@@ -11,13 +11,14 @@ package subprocess
 
 import (
 	"context"
+	"errors"
+	"os"
 	"os/exec"
 	"syscall"
 
 	"go.uber.org/zap"
 
 	"github.com/MetalBlockchain/metalgo/utils/logging"
-	"github.com/MetalBlockchain/metalgo/utils/wrappers"
 	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime"
 )
 
@@ -27,31 +28,20 @@ func NewCmd(path string, args ...string) *exec.Cmd {
 	return cmd
 }
 
-func stop(ctx context.Context, log logging.Logger, cmd *exec.Cmd) {
-	waitChan := make(chan error)
-	go func() {
-		// attempt graceful shutdown
-		errs := wrappers.Errs{}
-		err := cmd.Process.Signal(syscall.SIGTERM)
-		errs.Add(err)
-		_, err = cmd.Process.Wait()
-		errs.Add(err)
-		waitChan <- errs.Err
-		close(waitChan)
-	}()
+func stop(ctx context.Context, log logging.Logger, cmd *exec.Cmd, exited <-chan struct{}) {
+	// attempt graceful shutdown
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		log.Error("subprocess graceful shutdown failed",
+			zap.Error(err),
+		)
+	}
 
 	ctx, cancel := context.WithTimeout(ctx, runtime.DefaultGracefulTimeout)
 	defer cancel()
 
 	select {
-	case err := <-waitChan:
-		if err == nil {
-			log.Debug("subprocess gracefully shutdown")
-		} else {
-			log.Error("subprocess graceful shutdown failed",
-				zap.Error(err),
-			)
-		}
+	case <-exited:
+		log.Debug("subprocess gracefully shutdown")
 	case <-ctx.Done():
 		// force kill
 		err := cmd.Process.Kill()
@@ -19,7 +19,7 @@ func NewCmd(path string, args ...string) *exec.Cmd {
 	return exec.Command(path, args...)
 }
 
-func stop(_ context.Context, log logging.Logger, cmd *exec.Cmd) {
+func stop(_ context.Context, log logging.Logger, cmd *exec.Cmd, _ <-chan struct{}) {
 	err := cmd.Process.Kill()
 	if err == nil {
 		log.Debug("subprocess was killed")
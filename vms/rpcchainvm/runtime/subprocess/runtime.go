@@ -52,7 +52,7 @@ func Bootstrap(
 	listener net.Listener,
 	cmd *exec.Cmd,
 	config *Config,
-) (*Status, runtime.Stopper, error) {
+) (*Status, runtime.Process, error) {
 	defer listener.Close()
 
 	switch {
@@ -8,25 +8,51 @@ import (
 	"os/exec"
 	"sync"
 
+	"go.uber.org/zap"
+
 	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime"
 )
 
-func NewStopper(logger logging.Logger, cmd *exec.Cmd) runtime.Stopper {
-	return &stopper{
+// NewStopper returns a stopper of the started process [cmd]. The process is
+// waited on until it exits.
+func NewStopper(logger logging.Logger, cmd *exec.Cmd) runtime.Process {
+	s := &stopper{
 		cmd:    cmd,
 		logger: logger,
+		exited: make(chan struct{}),
 	}
+	go s.wait()
+	return s
 }
 
 type stopper struct {
 	once   sync.Once
 	cmd    *exec.Cmd
 	logger logging.Logger
+	exited chan struct{}
 }
 
 func (s *stopper) Stop(ctx context.Context) {
 	s.once.Do(func() {
-		stop(ctx, s.logger, s.cmd)
+		stop(ctx, s.logger, s.cmd, s.exited)
 	})
 }
+
+func (s *stopper) Exited() <-chan struct{} {
+	return s.exited
+}
+
+func (s *stopper) wait() {
+	state, err := s.cmd.Process.Wait()
+	if err != nil {
+		s.logger.Error("failed waiting for subprocess",
+			zap.Error(err),
+		)
+	} else {
+		s.logger.Debug("subprocess exited",
+			zap.Stringer("state", state),
+		)
+	}
+	close(s.exited)
+}
@@ -0,0 +1,258 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subprocess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime"
+)
+
+var (
+	_ runtime.Stopper = (*Supervisor)(nil)
+
+	ErrTooManyRestarts   = errors.New("too many restarts")
+	ErrSupervisorStopped = errors.New("supervisor stopped")
+)
+
+type SupervisorConfig struct {
+	// Enabled is true if VM processes should be restarted when they exit
+	// unexpectedly.
+	Enabled bool `json:"enabled"`
+	// MaxRestarts is the maximum number of times a process is restarted before
+	// it is considered failed. The count is reset once a replacement process
+	// has been running for [MaxBackoff].
+	MaxRestarts int `json:"maxRestarts"`
+	// InitialBackoff is the time waited before the first restart. The time
+	// waited doubles with each consecutive restart.
+	InitialBackoff time.Duration `json:"initialBackoff"`
+	// MaxBackoff is the maximum time waited before a restart.
+	MaxBackoff time.Duration `json:"maxBackoff"`
+	// RestartTimeout is the maximum time a restart may take, including the
+	// time taken to bring the replacement up to date. A restart that times out
+	// is treated as a failed restart.
+	RestartTimeout time.Duration `json:"restartTimeout"`
+}
+
+// StartFunc starts a new VM process.
+type StartFunc func(ctx context.Context) (*Status, runtime.Process, error)
+
+// Supervised is notified of the lifecycle of a supervised process.
+type Supervised interface {
+	// Exited is called when the process exited without being stopped.
+	Exited()
+	// Restarted is called with the status of a replacement process. If an
+	// error is returned, the replacement is stopped and treated as if it
+	// exited.
+	Restarted(ctx context.Context, status *Status) error
+	// Failed is called once the process will no longer be restarted, either
+	// because it failed too many times or because the supervisor was stopped
+	// while the process wasn't running.
+	Failed(err error)
+}
+
+// Supervisor restarts a VM process, with backoff, whenever it exits without
+// being stopped.
+type Supervisor struct {
+	log    logging.Logger
+	config SupervisorConfig
+	start  StartFunc
+
+	lock    sync.Mutex
+	process runtime.Process
+	// closed once Unsupervise is called
+	stopping        chan struct{}
+	unsuperviseOnce sync.Once
+	stopOnce        sync.Once
+}
+
+// NewSupervisor returns a supervisor of [process]. Processes are only
+// restarted after Supervise is called.
+func NewSupervisor(
+	log logging.Logger,
+	config SupervisorConfig,
+	process runtime.Process,
+	start StartFunc,
+) *Supervisor {
+	return &Supervisor{
+		log:      log,
+		config:   config,
+		start:    start,
+		process:  process,
+		stopping: make(chan struct{}),
+	}
+}
+
+// Supervise restarts the process whenever it exits, notifying [supervised],
+// until Unsupervise is called or the process fails too many times. Must only
+// be called once.
+func (s *Supervisor) Supervise(supervised Supervised) {
+	go s.supervise(supervised)
+}
+
+func (s *Supervisor) supervise(supervised Supervised) {
+	var (
+		restarts  int
+		startTime = time.Now()
+	)
+	for {
+		s.lock.Lock()
+		process := s.process
+		s.lock.Unlock()
+
+		select {
+		case <-s.stopping:
+			return
+		case <-process.Exited():
+		}
+
+		// The process may have exited because it was stopped.
+		select {
+		case <-s.stopping:
+			return
+		default:
+		}
+
+		s.log.Warn("plugin exited unexpectedly",
+			zap.Duration("uptime", time.Since(startTime)),
+		)
+		supervised.Exited()
+
+		if time.Since(startTime) >= s.config.MaxBackoff {
+			restarts = 0
+		}
+
+		var err error
+		for {
+			if restarts >= s.config.MaxRestarts {
+				err = fmt.Errorf("%w: %d", ErrTooManyRestarts, restarts)
+				s.log.Error("plugin will not be restarted",
+					zap.Error(err),
+				)
+				supervised.Failed(err)
+				return
+			}
+
+			backoff := s.backoff(restarts)
+			restarts++
+
+			s.log.Info("restarting plugin",
+				zap.Int("attempt", restarts),
+				zap.Duration("backoff", backoff),
+			)
+			if !s.sleep(backoff) {
+				supervised.Failed(ErrSupervisorStopped)
+				return
+			}
+
+			startTime = time.Now()
+			process, err = s.restart(supervised)
+			if err == nil {
+				break
+			}
+
+			s.log.Warn("failed to restart plugin",
+				zap.Int("attempt", restarts),
+				zap.Error(err),
+			)
+			supervised.Exited()
+		}
+
+		s.lock.Lock()
+		select {
+		case <-s.stopping:
+			s.lock.Unlock()
+			process.Stop(context.TODO())
+			supervised.Failed(ErrSupervisorStopped)
+			return
+		default:
+			s.process = process
+			s.lock.Unlock()
+		}
+
+		s.log.Info("plugin restarted",
+			zap.Int("attempt", restarts),
+		)
+	}
+}
+
+// restart starts a replacement process and hands it to [supervised]. The
+// restart is cancelled if it takes longer than the restart timeout or if the
+// supervisor is stopped.
+func (s *Supervisor) restart(supervised Supervised) (runtime.Process, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.RestartTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopping:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	status, process, err := s.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := supervised.Restarted(ctx, status); err != nil {
+		process.Stop(ctx)
+		return nil, err
+	}
+	return process, nil
+}
+
+// backoff returns the time to wait before the restart following [restarts]
+// consecutive restarts.
+func (s *Supervisor) backoff(restarts int) time.Duration {
+	backoff := s.config.InitialBackoff
+	for i := 0; i < restarts && backoff < s.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, s.config.MaxBackoff)
+}
+
+// sleep waits for [duration]. Returns false if the supervisor was stopped
+// while waiting.
+func (s *Supervisor) sleep(duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-s.stopping:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// Unsupervise stops restarting the process. The current process is left
+// running, so that it can be shutdown gracefully.
+func (s *Supervisor) Unsupervise() {
+	s.unsuperviseOnce.Do(func() {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		close(s.stopping)
+	})
+}
+
+// Stop stops restarting the process and stops the current process.
+func (s *Supervisor) Stop(ctx context.Context) {
+	s.stopOnce.Do(func() {
+		s.Unsupervise()
+
+		s.lock.Lock()
+		process := s.process
+		s.lock.Unlock()
+
+		process.Stop(ctx)
+	})
+}
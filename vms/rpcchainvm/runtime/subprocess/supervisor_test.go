@@ -0,0 +1,344 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package subprocess
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime"
+)
+
+var errTest = errors.New("non-nil error")
+
+type testProcess struct {
+	exitOnce sync.Once
+	exited   chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func newTestProcess() *testProcess {
+	return &testProcess{
+		exited:  make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (p *testProcess) Stop(context.Context) {
+	p.stopOnce.Do(func() {
+		close(p.stopped)
+	})
+	p.exit()
+}
+
+func (p *testProcess) Exited() <-chan struct{} {
+	return p.exited
+}
+
+func (p *testProcess) exit() {
+	p.exitOnce.Do(func() {
+		close(p.exited)
+	})
+}
+
+type testSupervised struct {
+	exited     chan struct{}
+	restarted  chan *Status
+	restartErr error
+	// if true, Restarted blocks until its context is done
+	restartHangs bool
+	failed       chan error
+}
+
+func newTestSupervised() *testSupervised {
+	return &testSupervised{
+		exited:    make(chan struct{}, 16),
+		restarted: make(chan *Status, 16),
+		failed:    make(chan error, 1),
+	}
+}
+
+func (s *testSupervised) Exited() {
+	s.exited <- struct{}{}
+}
+
+func (s *testSupervised) Restarted(ctx context.Context, status *Status) error {
+	s.restarted <- status
+	if s.restartHangs {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return s.restartErr
+}
+
+func (s *testSupervised) Failed(err error) {
+	s.failed <- err
+}
+
+// testStarter starts test processes and records them.
+type testStarter struct {
+	lock      sync.Mutex
+	processes []*testProcess
+	err       error
+}
+
+func (s *testStarter) start(context.Context) (*Status, runtime.Process, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	process := newTestProcess()
+	s.processes = append(s.processes, process)
+	return &Status{Pid: len(s.processes)}, process, nil
+}
+
+func (s *testStarter) process(i int) *testProcess {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.processes[i]
+}
+
+func newTestSupervisor(process runtime.Process, starter *testStarter, maxRestarts int) *Supervisor {
+	return NewSupervisor(
+		logging.NoLog{},
+		SupervisorConfig{
+			Enabled:        true,
+			MaxRestarts:    maxRestarts,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Minute,
+			RestartTimeout: time.Minute,
+		},
+		process,
+		starter.start,
+	)
+}
+
+func TestSupervisorRestartsExitedProcess(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		process    = newTestProcess()
+		starter    = &testStarter{}
+		supervised = newTestSupervised()
+		supervisor = newTestSupervisor(process, starter, 5)
+	)
+	supervisor.Supervise(supervised)
+
+	process.exit()
+	<-supervised.exited
+	require.Equal(&Status{Pid: 1}, <-supervised.restarted)
+
+	// The replacement is supervised as well.
+	starter.process(0).exit()
+	<-supervised.exited
+	require.Equal(&Status{Pid: 2}, <-supervised.restarted)
+
+	supervisor.Stop(context.Background())
+	<-starter.process(1).stopped
+}
+
+func TestSupervisorFailsAfterTooManyRestarts(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		process    = newTestProcess()
+		starter    = &testStarter{err: errTest}
+		supervised = newTestSupervised()
+		supervisor = newTestSupervisor(process, starter, 2)
+	)
+	supervisor.Supervise(supervised)
+
+	process.exit()
+	err := <-supervised.failed
+	require.ErrorIs(err, ErrTooManyRestarts)
+
+	// The exit and both failed restarts are reported.
+	require.Len(supervised.exited, 3)
+	require.Empty(supervised.restarted)
+}
+
+func TestSupervisorStopsRejectedReplacement(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		process    = newTestProcess()
+		starter    = &testStarter{}
+		supervised = newTestSupervised()
+		supervisor = newTestSupervisor(process, starter, 1)
+	)
+	supervised.restartErr = errTest
+	supervisor.Supervise(supervised)
+
+	process.exit()
+	err := <-supervised.failed
+	require.ErrorIs(err, ErrTooManyRestarts)
+	<-starter.process(0).stopped
+}
+
+func TestSupervisorDoesNotRestartStoppedProcess(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		process    = newTestProcess()
+		starter    = &testStarter{}
+		supervised = newTestSupervised()
+		supervisor = newTestSupervisor(process, starter, 5)
+	)
+	supervisor.Supervise(supervised)
+
+	supervisor.Stop(context.Background())
+	<-process.stopped
+
+	// Give the supervisor a chance to incorrectly restart the process.
+	time.Sleep(10 * time.Millisecond)
+	require.Empty(supervised.exited)
+	require.Empty(supervised.restarted)
+}
+
+func TestSupervisorUnsuperviseLeavesProcessRunning(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		process    = newTestProcess()
+		starter    = &testStarter{}
+		supervised = newTestSupervised()
+		supervisor = newTestSupervisor(process, starter, 5)
+	)
+	supervisor.Supervise(supervised)
+
+	supervisor.Unsupervise()
+	process.exit()
+
+	// Give the supervisor a chance to incorrectly restart the process.
+	time.Sleep(10 * time.Millisecond)
+	require.Empty(supervised.exited)
+	require.Empty(supervised.restarted)
+	select {
+	case <-process.stopped:
+		require.FailNow("process was stopped")
+	default:
+	}
+}
+
+func TestSupervisorFailsWhenStoppedDuringBackoff(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		process    = newTestProcess()
+		starter    = &testStarter{}
+		supervised = newTestSupervised()
+		supervisor = NewSupervisor(
+			logging.NoLog{},
+			SupervisorConfig{
+				Enabled:        true,
+				MaxRestarts:    5,
+				InitialBackoff: time.Hour,
+				MaxBackoff:     time.Hour,
+				RestartTimeout: time.Minute,
+			},
+			process,
+			starter.start,
+		)
+	)
+	supervisor.Supervise(supervised)
+
+	process.exit()
+	<-supervised.exited
+	supervisor.Stop(context.Background())
+
+	err := <-supervised.failed
+	require.ErrorIs(err, ErrSupervisorStopped)
+	require.Empty(supervised.restarted)
+}
+
+func TestSupervisorRestartTimeout(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		process    = newTestProcess()
+		starter    = &testStarter{}
+		supervised = newTestSupervised()
+		supervisor = NewSupervisor(
+			logging.NoLog{},
+			SupervisorConfig{
+				Enabled:        true,
+				MaxRestarts:    2,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Minute,
+				RestartTimeout: 10 * time.Millisecond,
+			},
+			process,
+			starter.start,
+		)
+	)
+	supervised.restartHangs = true
+	supervisor.Supervise(supervised)
+
+	process.exit()
+
+	// Restarts that time out are counted as failed restarts.
+	err := <-supervised.failed
+	require.ErrorIs(err, ErrTooManyRestarts)
+	require.Len(supervised.restarted, 2)
+
+	// The replacements that timed out were stopped.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-starter.process(i).stopped:
+		default:
+			require.FailNow("replacement wasn't stopped")
+		}
+	}
+}
+
+func TestSupervisorBackoff(t *testing.T) {
+	supervisor := NewSupervisor(
+		logging.NoLog{},
+		SupervisorConfig{
+			InitialBackoff: time.Second,
+			MaxBackoff:     5 * time.Second,
+		},
+		newTestProcess(),
+		nil,
+	)
+
+	tests := []struct {
+		restarts int
+		expected time.Duration
+	}{
+		{
+			restarts: 0,
+			expected: time.Second,
+		},
+		{
+			restarts: 1,
+			expected: 2 * time.Second,
+		},
+		{
+			restarts: 2,
+			expected: 4 * time.Second,
+		},
+		{
+			restarts: 3,
+			expected: 5 * time.Second,
+		},
+		{
+			restarts: 100,
+			expected: 5 * time.Second,
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, supervisor.backoff(test.restarts))
+	}
+}
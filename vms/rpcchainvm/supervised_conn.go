@@ -0,0 +1,227 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/MetalBlockchain/metalgo/utils/set"
+
+	vmpb "github.com/MetalBlockchain/metalgo/proto/pb/vm"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var (
+	_ grpc.ClientConnInterface = (*supervisedConn)(nil)
+
+	errConnClosed = errors.New("connection closed")
+
+	// idempotentVMMethods are the methods of the VM service that only read
+	// the state of the plugin.
+	idempotentVMMethods = set.Of(
+		vmpb.VM_ParseBlock_FullMethodName,
+		vmpb.VM_GetBlock_FullMethodName,
+		vmpb.VM_Health_FullMethodName,
+		vmpb.VM_Version_FullMethodName,
+		vmpb.VM_Gather_FullMethodName,
+		vmpb.VM_GetAncestors_FullMethodName,
+		vmpb.VM_BatchedParseBlock_FullMethodName,
+		vmpb.VM_GetBlockIDAtHeight_FullMethodName,
+		vmpb.VM_StateSyncEnabled_FullMethodName,
+		vmpb.VM_GetOngoingSyncStateSummary_FullMethodName,
+		vmpb.VM_GetLastStateSummary_FullMethodName,
+		vmpb.VM_ParseStateSummary_FullMethodName,
+		vmpb.VM_GetStateSummary_FullMethodName,
+	)
+)
+
+// supervisedConn is a connection to a supervised plugin. While the plugin is
+// being restarted, calls wait until the replacement is attached. Calls to
+// idempotent methods that fail because the plugin exited are retried against
+// the replacement. Other calls fail, as the exited plugin may have handled
+// them before it exited.
+type supervisedConn struct {
+	// idempotent is the set of methods that can safely be called again.
+	idempotent set.Set[string]
+
+	lock sync.Mutex
+	conn *grpc.ClientConn
+	// closed once a connection is attached, replaced when the connection is
+	// detached
+	attached chan struct{}
+	// non-nil once the plugin will no longer be restarted
+	err error
+}
+
+func newSupervisedConn(conn *grpc.ClientConn, idempotent set.Set[string]) *supervisedConn {
+	attached := make(chan struct{})
+	close(attached)
+	return &supervisedConn{
+		idempotent: idempotent,
+		conn:       conn,
+		attached:   attached,
+	}
+}
+
+func (c *supervisedConn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	var retried *grpc.ClientConn
+	for {
+		conn, err := c.wait(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = conn.Invoke(ctx, method, args, reply, opts...)
+		switch {
+		case err == nil:
+			return nil
+		case !c.idempotent.Contains(method):
+			return err
+		case c.detached(conn):
+		case status.Code(err) == codes.Unavailable && retried != conn:
+			// The plugin may have exited before it was detached. The retry
+			// waits until the plugin is reachable again or the connection is
+			// detached.
+			retried = conn
+		default:
+			return err
+		}
+	}
+}
+
+func (c *supervisedConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	conn, err := c.wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return conn.NewStream(ctx, desc, method, opts...)
+}
+
+// wait returns the attached connection, waiting for one to be attached if the
+// plugin is being restarted.
+func (c *supervisedConn) wait(ctx context.Context) (*grpc.ClientConn, error) {
+	c.lock.Lock()
+	attached := c.attached
+	c.lock.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-attached:
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.conn, c.err
+}
+
+// detached returns true if [conn] is no longer the attached connection.
+func (c *supervisedConn) detached(conn *grpc.ClientConn) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	select {
+	case <-c.attached:
+		return c.conn != conn || c.err != nil
+	default:
+		return true
+	}
+}
+
+// exited returns true if the plugin served by [conn] exited. A plugin that is
+// still running answers the probe, while the probe of an exited plugin waits
+// until [conn] is detached.
+func (c *supervisedConn) exited(ctx context.Context, conn *grpc.ClientConn) bool {
+	if c.detached(conn) {
+		return true
+	}
+	_, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err != nil && c.detached(conn)
+}
+
+// ready returns true if a connection is attached.
+func (c *supervisedConn) ready() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	select {
+	case <-c.attached:
+		return c.err == nil
+	default:
+		return false
+	}
+}
+
+// detach closes the attached connection. Calls wait until a connection is
+// attached or the plugin fails.
+func (c *supervisedConn) detach() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	select {
+	case <-c.attached:
+		c.attached = make(chan struct{})
+	default:
+		// Already detached.
+		return nil
+	}
+	// Closing the connection unblocks calls that are waiting on the exited
+	// plugin.
+	return c.conn.Close()
+}
+
+// attach replaces the detached connection with [conn].
+func (c *supervisedConn) attach(conn *grpc.ClientConn) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.conn = conn
+	select {
+	case <-c.attached:
+	default:
+		close(c.attached)
+	}
+}
+
+// fail causes all calls to return [err].
+func (c *supervisedConn) fail(err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.err = err
+	select {
+	case <-c.attached:
+	default:
+		close(c.attached)
+	}
+}
+
+// Close closes the attached connection. Calls made after Close return an
+// error.
+func (c *supervisedConn) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	select {
+	case <-c.attached:
+		if c.err != nil {
+			// The connection was closed when it was detached.
+			return nil
+		}
+		c.err = errConnClosed
+		return c.conn.Close()
+	default:
+		// The connection was closed when it was detached.
+		c.err = errConnClosed
+		close(c.attached)
+		return nil
+	}
+}
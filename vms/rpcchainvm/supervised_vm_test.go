@@ -0,0 +1,515 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/MetalBlockchain/metalgo/api/metrics"
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman"
+	"github.com/MetalBlockchain/metalgo/snow/consensus/snowman/snowmantest"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
+	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block/blockmock"
+	"github.com/MetalBlockchain/metalgo/snow/snowtest"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/perms"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/grpcutils"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime/subprocess"
+)
+
+const (
+	supervisedVersion = "v1.2.3"
+
+	// supervisedTestDirEnv is the directory that the plugins of a test use to
+	// share state with the plugins that replace them.
+	supervisedTestDirEnv = "SUPERVISED_TEST_DIR"
+)
+
+var (
+	errTestInitialize     = errors.New("test initialize failure")
+	errTestAlreadyDecided = errors.New("block was already decided")
+
+	supervisedBlk = &snowmantest.Block{
+		Decidable: snowtest.Decidable{
+			IDV:    ids.ID{'s', 'u', 'p', 'e', 'r', 'v', 'i', 's', 'e', 'd'},
+			Status: snowtest.Undecided,
+		},
+		ParentV: preSummaryBlk.ID(),
+		HeightV: preSummaryHeight + 1,
+		BytesV:  []byte("supervised"),
+	}
+)
+
+func supervisedTestPlugin(t *testing.T, loadExpectations bool) block.ChainVM {
+	// test key is "supervisedTestKey"
+
+	// create mock
+	ctrl := gomock.NewController(t)
+	vm := blockmock.NewChainVM(ctrl)
+
+	if loadExpectations {
+		gomock.InOrder(
+			// Initialize
+			vm.EXPECT().Initialize(
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+				gomock.Any(),
+			).Return(nil).Times(1),
+			vm.EXPECT().LastAccepted(gomock.Any()).Return(preSummaryBlk.ID(), nil).Times(1),
+			vm.EXPECT().GetBlock(gomock.Any(), gomock.Any()).Return(preSummaryBlk, nil).Times(1),
+		)
+		vm.EXPECT().Version(gomock.Any()).Return(supervisedVersion, nil).AnyTimes()
+		vm.EXPECT().HealthCheck(gomock.Any()).Return(nil, nil).AnyTimes()
+	}
+
+	return vm
+}
+
+// supervisedCrashTestPlugin returns a plugin that serves [blk] as
+// [supervisedBlk].
+func supervisedCrashTestPlugin(t *testing.T, blk snowman.Block) *blockmock.ChainVM {
+	ctrl := gomock.NewController(t)
+	vm := blockmock.NewChainVM(ctrl)
+
+	vm.EXPECT().Initialize(
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(),
+	).Return(nil).Times(1)
+	vm.EXPECT().LastAccepted(gomock.Any()).DoAndReturn(
+		func(context.Context) (ids.ID, error) {
+			if supervisedTestMarked("accepted") {
+				return supervisedBlk.ID(), nil
+			}
+			return preSummaryBlk.ID(), nil
+		},
+	).AnyTimes()
+	vm.EXPECT().GetBlock(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+			switch blkID {
+			case preSummaryBlk.ID():
+				return preSummaryBlk, nil
+			case supervisedBlk.ID():
+				return blk, nil
+			default:
+				return nil, database.ErrNotFound
+			}
+		},
+	).AnyTimes()
+	vm.EXPECT().ParseBlock(gomock.Any(), supervisedBlk.Bytes()).Return(blk, nil).AnyTimes()
+	vm.EXPECT().Version(gomock.Any()).Return(supervisedVersion, nil).AnyTimes()
+	vm.EXPECT().HealthCheck(gomock.Any()).Return(nil, nil).AnyTimes()
+	return vm
+}
+
+func supervisedAcceptCrashTestPlugin(t *testing.T, loadExpectations bool) block.ChainVM {
+	// test key is "supervisedAcceptCrashTestKey"
+
+	if !loadExpectations {
+		return blockmock.NewChainVM(gomock.NewController(t))
+	}
+	replacement := supervisedTestMark("started")
+	return supervisedCrashTestPlugin(t, &crashingBlock{
+		Block:         supervisedBlk,
+		crashOnAccept: !replacement,
+	})
+}
+
+func supervisedVerifyCrashTestPlugin(t *testing.T, loadExpectations bool) block.ChainVM {
+	// test key is "supervisedVerifyCrashTestKey"
+
+	if !loadExpectations {
+		return blockmock.NewChainVM(gomock.NewController(t))
+	}
+	replacement := supervisedTestMark("started")
+	return supervisedCrashTestPlugin(t, &crashingBlock{
+		Block:         supervisedBlk,
+		crashOnVerify: !replacement,
+	})
+}
+
+func supervisedAppGossipCrashTestPlugin(t *testing.T, loadExpectations bool) block.ChainVM {
+	// test key is "supervisedAppGossipCrashTestKey"
+
+	if !loadExpectations {
+		return blockmock.NewChainVM(gomock.NewController(t))
+	}
+	replacement := supervisedTestMark("started")
+	vm := supervisedCrashTestPlugin(t, supervisedBlk)
+	vm.EXPECT().AppGossip(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, ids.NodeID, []byte) error {
+			if !replacement {
+				os.Exit(1)
+			}
+			supervisedTestMark("gossiped")
+			return nil
+		},
+	).AnyTimes()
+	return vm
+}
+
+func supervisedSetPreferenceCrashTestPlugin(t *testing.T, loadExpectations bool) block.ChainVM {
+	// test key is "supervisedSetPreferenceCrashTestKey"
+
+	if !loadExpectations {
+		return blockmock.NewChainVM(gomock.NewController(t))
+	}
+	replacement := supervisedTestMark("started")
+	vm := supervisedCrashTestPlugin(t, supervisedBlk)
+	vm.EXPECT().SetPreference(gomock.Any(), preSummaryBlk.ID()).DoAndReturn(
+		func(context.Context, ids.ID) error {
+			if !replacement {
+				os.Exit(1)
+			}
+			supervisedTestMark("preferred")
+			return nil
+		},
+	).AnyTimes()
+	return vm
+}
+
+func supervisedInitializeFailureTestPlugin(t *testing.T, loadExpectations bool) block.ChainVM {
+	// test key is "supervisedInitializeFailureTestKey"
+
+	if !loadExpectations {
+		return blockmock.NewChainVM(gomock.NewController(t))
+	}
+	if !supervisedTestMark("started") {
+		return supervisedCrashTestPlugin(t, supervisedBlk)
+	}
+
+	ctrl := gomock.NewController(t)
+	vm := blockmock.NewChainVM(ctrl)
+	vm.EXPECT().Initialize(
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(),
+	).Return(errTestInitialize).Times(1)
+	return vm
+}
+
+// supervisedTestMark marks [name] as done in the directory shared by the
+// plugins of a test. Returns true if [name] was already marked.
+func supervisedTestMark(name string) bool {
+	if supervisedTestMarked(name) {
+		return true
+	}
+	path := filepath.Join(os.Getenv(supervisedTestDirEnv), name)
+	if err := os.WriteFile(path, nil, perms.ReadWrite); err != nil {
+		panic(err)
+	}
+	return false
+}
+
+// supervisedTestMarked returns true if [name] was marked as done by a plugin
+// of the test.
+func supervisedTestMarked(name string) bool {
+	_, err := os.Stat(filepath.Join(os.Getenv(supervisedTestDirEnv), name))
+	return err == nil
+}
+
+// crashingBlock exits the plugin while it is being verified or accepted.
+// Accepting the block is recorded so that a replacement reports it as the last
+// accepted block.
+type crashingBlock struct {
+	*snowmantest.Block
+
+	crashOnVerify bool
+	crashOnAccept bool
+}
+
+func (b *crashingBlock) Verify(ctx context.Context) error {
+	if b.crashOnVerify {
+		os.Exit(1)
+	}
+	return b.Block.Verify(ctx)
+}
+
+func (b *crashingBlock) Accept(context.Context) error {
+	if supervisedTestMark("accepted") {
+		return errTestAlreadyDecided
+	}
+	if b.crashOnAccept {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// testProcessTracker records the processes that are currently tracked.
+type testProcessTracker struct {
+	lock sync.Mutex
+	pids set.Set[int]
+}
+
+func (t *testProcessTracker) TrackProcess(pid int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.pids.Add(pid)
+}
+
+func (t *testProcessTracker) UntrackProcess(pid int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.pids.Remove(pid)
+}
+
+func (t *testProcessTracker) tracked() []int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.pids.List()
+}
+
+func buildSupervisedClientHelper(require *require.Assertions, testKey string, processTracker *testProcessTracker) *VMClient {
+	return buildSupervisedClientWithConfigHelper(
+		require,
+		testKey,
+		processTracker,
+		subprocess.SupervisorConfig{
+			Enabled:        true,
+			MaxRestarts:    5,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Second,
+			RestartTimeout: time.Minute,
+		},
+	)
+}
+
+func buildSupervisedClientWithConfigHelper(
+	require *require.Assertions,
+	testKey string,
+	processTracker *testProcessTracker,
+	supervisorConfig subprocess.SupervisorConfig,
+) *VMClient {
+	log := logging.NoLog{}
+	config := &subprocess.Config{
+		Stderr:           log,
+		Stdout:           io.Discard,
+		Log:              log,
+		HandshakeTimeout: runtime.DefaultHandshakeTimeout,
+	}
+	start := func(ctx context.Context) (*subprocess.Status, runtime.Process, error) {
+		listener, err := grpcutils.NewListener()
+		if err != nil {
+			return nil, nil, err
+		}
+		return subprocess.Bootstrap(ctx, listener, helperProcess(testKey), config)
+	}
+
+	status, process, err := start(context.Background())
+	require.NoError(err)
+
+	clientConn, err := grpcutils.Dial(status.Addr)
+	require.NoError(err)
+
+	supervisor := subprocess.NewSupervisor(log, supervisorConfig, process, start)
+	processTracker.TrackProcess(status.Pid)
+	return NewSupervisedClient(log, clientConn, supervisor, status.Pid, processTracker, metrics.NewPrefixGatherer())
+}
+
+func TestSupervisedPluginRestartsAfterCrash(t *testing.T) {
+	require := require.New(t)
+	testKey := supervisedTestKey
+
+	// Create and start the plugin
+	processTracker := &testProcessTracker{}
+	vm := buildSupervisedClientHelper(require, testKey, processTracker)
+	defer vm.runtime.Stop(context.Background())
+
+	ctx := snowtest.Context(t, snowtest.CChainID)
+
+	require.NoError(vm.Initialize(context.Background(), ctx, memdb.New(), nil, nil, nil, nil, nil, nil))
+
+	pid := vm.pid.Get()
+	process, err := os.FindProcess(pid)
+	require.NoError(err)
+	require.NoError(process.Kill())
+
+	// Calls made while the plugin is restarting are served by the replacement.
+	version, err := vm.Version(context.Background())
+	require.NoError(err)
+	require.Equal(supervisedVersion, version)
+
+	newPID := vm.pid.Get()
+	require.NotEqual(pid, newPID)
+	require.Equal([]int{newPID}, processTracker.tracked())
+
+	health, err := vm.HealthCheck(context.Background())
+	require.NoError(err)
+	require.IsType(&supervisionHealth{}, health)
+	require.Equal(uint64(1), health.(*supervisionHealth).Crashes)
+	require.Equal(uint64(1), health.(*supervisionHealth).Restarts)
+}
+
+func TestSupervisedPluginCrashDuringAccept(t *testing.T) {
+	require := require.New(t)
+	t.Setenv(supervisedTestDirEnv, t.TempDir())
+
+	processTracker := &testProcessTracker{}
+	vm := buildSupervisedClientHelper(require, supervisedAcceptCrashTestKey, processTracker)
+	defer vm.runtime.Stop(context.Background())
+
+	ctx := snowtest.Context(t, snowtest.CChainID)
+	require.NoError(vm.Initialize(context.Background(), ctx, memdb.New(), nil, nil, nil, nil, nil, nil))
+
+	blk, err := vm.ParseBlock(context.Background(), supervisedBlk.Bytes())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+
+	// The plugin exits after it accepted the block, so the replacement
+	// reports the block as accepted and the block isn't accepted again.
+	require.NoError(blk.Accept(context.Background()))
+
+	lastAccepted, err := vm.LastAccepted(context.Background())
+	require.NoError(err)
+	require.Equal(supervisedBlk.ID(), lastAccepted)
+
+	health, err := vm.HealthCheck(context.Background())
+	require.NoError(err)
+	require.IsType(&supervisionHealth{}, health)
+	require.Equal(uint64(1), health.(*supervisionHealth).Crashes)
+	require.Equal(uint64(1), health.(*supervisionHealth).Restarts)
+}
+
+func TestSupervisedPluginCrashDuringVerify(t *testing.T) {
+	require := require.New(t)
+	t.Setenv(supervisedTestDirEnv, t.TempDir())
+
+	processTracker := &testProcessTracker{}
+	vm := buildSupervisedClientHelper(require, supervisedVerifyCrashTestKey, processTracker)
+	defer vm.runtime.Stop(context.Background())
+
+	ctx := snowtest.Context(t, snowtest.CChainID)
+	require.NoError(vm.Initialize(context.Background(), ctx, memdb.New(), nil, nil, nil, nil, nil, nil))
+
+	pid := vm.pid.Get()
+	blk, err := vm.ParseBlock(context.Background(), supervisedBlk.Bytes())
+	require.NoError(err)
+
+	// Verify isn't retried, as the plugin may have verified the block before
+	// it exited.
+	err = blk.Verify(context.Background())
+	require.Error(err) //nolint:forbidigo // currently returns grpc errors
+	require.Eventually(
+		func() bool {
+			return vm.pid.Get() != pid
+		},
+		time.Minute,
+		10*time.Millisecond,
+	)
+
+	// The replacement verifies and accepts the block.
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(blk.Accept(context.Background()))
+
+	health, err := vm.HealthCheck(context.Background())
+	require.NoError(err)
+	require.IsType(&supervisionHealth{}, health)
+	require.Equal(uint64(1), health.(*supervisionHealth).Crashes)
+	require.Equal(uint64(1), health.(*supervisionHealth).Restarts)
+}
+
+func TestSupervisedPluginCrashDuringAppGossip(t *testing.T) {
+	require := require.New(t)
+	t.Setenv(supervisedTestDirEnv, t.TempDir())
+
+	processTracker := &testProcessTracker{}
+	vm := buildSupervisedClientHelper(require, supervisedAppGossipCrashTestKey, processTracker)
+	defer vm.runtime.Stop(context.Background())
+
+	ctx := snowtest.Context(t, snowtest.CChainID)
+	require.NoError(vm.Initialize(context.Background(), ctx, memdb.New(), nil, nil, nil, nil, nil, nil))
+
+	// The message is dropped rather than failing the chain.
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(vm.AppGossip(context.Background(), nodeID, []byte("crash")))
+
+	// Later messages are delivered to the replacement.
+	require.NoError(vm.AppGossip(context.Background(), nodeID, []byte("gossip")))
+	require.True(supervisedTestMarked("gossiped"))
+
+	health, err := vm.HealthCheck(context.Background())
+	require.NoError(err)
+	require.IsType(&supervisionHealth{}, health)
+	require.Equal(uint64(1), health.(*supervisionHealth).Crashes)
+	require.Equal(uint64(1), health.(*supervisionHealth).Restarts)
+}
+
+func TestSupervisedPluginCrashDuringSetPreference(t *testing.T) {
+	require := require.New(t)
+	t.Setenv(supervisedTestDirEnv, t.TempDir())
+
+	processTracker := &testProcessTracker{}
+	vm := buildSupervisedClientHelper(require, supervisedSetPreferenceCrashTestKey, processTracker)
+	defer vm.runtime.Stop(context.Background())
+
+	ctx := snowtest.Context(t, snowtest.CChainID)
+	require.NoError(vm.Initialize(context.Background(), ctx, memdb.New(), nil, nil, nil, nil, nil, nil))
+
+	// The preference is set on the replacement when it is re-attached, so
+	// the call succeeds rather than failing the chain.
+	require.NoError(vm.SetPreference(context.Background(), preSummaryBlk.ID()))
+	require.True(supervisedTestMarked("preferred"))
+
+	health, err := vm.HealthCheck(context.Background())
+	require.NoError(err)
+	require.IsType(&supervisionHealth{}, health)
+	require.Equal(uint64(1), health.(*supervisionHealth).Crashes)
+	require.Equal(uint64(1), health.(*supervisionHealth).Restarts)
+}
+
+func TestSupervisedPluginReplacementInitializeFails(t *testing.T) {
+	require := require.New(t)
+	t.Setenv(supervisedTestDirEnv, t.TempDir())
+
+	processTracker := &testProcessTracker{}
+	vm := buildSupervisedClientWithConfigHelper(
+		require,
+		supervisedInitializeFailureTestKey,
+		processTracker,
+		subprocess.SupervisorConfig{
+			Enabled:        true,
+			MaxRestarts:    2,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     time.Millisecond,
+			RestartTimeout: time.Minute,
+		},
+	)
+	defer vm.runtime.Stop(context.Background())
+
+	ctx := snowtest.Context(t, snowtest.CChainID)
+	require.NoError(vm.Initialize(context.Background(), ctx, memdb.New(), nil, nil, nil, nil, nil, nil))
+
+	process, err := os.FindProcess(vm.pid.Get())
+	require.NoError(err)
+	require.NoError(process.Kill())
+
+	// Calls made while the plugin is restarting fail once the supervisor
+	// gives up on the replacements.
+	_, err = vm.Version(context.Background())
+	require.ErrorIs(err, subprocess.ErrTooManyRestarts)
+
+	health, err := vm.HealthCheck(context.Background())
+	require.ErrorIs(err, subprocess.ErrTooManyRestarts)
+	require.IsType(&supervisionHealth{}, health)
+	require.Zero(health.(*supervisionHealth).Restarts)
+	require.Empty(processTracker.tracked())
+}
@@ -0,0 +1,470 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/snow"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/version"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/grpcutils"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime/subprocess"
+
+	vmpb "github.com/MetalBlockchain/metalgo/proto/pb/vm"
+)
+
+var (
+	_ subprocess.Supervised = (*supervision)(nil)
+
+	errPluginRestarting = errors.New("plugin is restarting")
+)
+
+// supervisionHealth is reported by the health check of a supervised plugin.
+type supervisionHealth struct {
+	Crashes  uint64          `json:"crashes"`
+	Restarts uint64          `json:"restarts"`
+	VM       json.RawMessage `json:"vm,omitempty"`
+}
+
+// processingBlock is a block that was verified but not yet decided.
+type processingBlock struct {
+	bytes        []byte
+	height       uint64
+	pChainHeight *uint64
+}
+
+// supervision re-attaches a restarted plugin to its VMClient. The replacement
+// is initialized from the last accepted state in the database and is then
+// brought up to date with the state the engine previously put the plugin in.
+type supervision struct {
+	log  logging.Logger
+	vm   *VMClient
+	conn *supervisedConn
+
+	crashesCounter  prometheus.Counter
+	restartsCounter prometheus.Counter
+
+	// statusLock is never held while calling the plugin, so that health
+	// checks don't wait for a restart to complete.
+	statusLock sync.Mutex
+	crashes    uint64
+	restarts   uint64
+	restarting bool
+	err        error
+
+	// lock guards the state the engine put the plugin in, which is replayed
+	// to a replacement, and the connections to the plugin.
+	lock        sync.Mutex
+	initRequest *vmpb.InitializeRequest
+	state       *snow.State
+	preference  *ids.ID
+	connected   map[ids.NodeID]*version.Application
+	processing  map[ids.ID]*processingBlock
+	// replacedHeight is the last accepted height reported by the latest
+	// replacement when it was initialized. The blocks at or below this height
+	// were decided by the plugins it replaced.
+	replacedHeight uint64
+	// Key: HTTP handler prefix
+	handlers map[string]*supervisedConn
+}
+
+func newSupervision(log logging.Logger, vm *VMClient, conn *supervisedConn) *supervision {
+	return &supervision{
+		log:  log,
+		vm:   vm,
+		conn: conn,
+		crashesCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "plugin_crashes",
+			Help: "number of times the plugin exited unexpectedly or failed to restart",
+		}),
+		restartsCounter: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "plugin_restarts",
+			Help: "number of times the plugin was successfully restarted",
+		}),
+		connected:  make(map[ids.NodeID]*version.Application),
+		processing: make(map[ids.ID]*processingBlock),
+		handlers:   make(map[string]*supervisedConn),
+	}
+}
+
+func (s *supervision) register(reg prometheus.Registerer) error {
+	return errors.Join(
+		reg.Register(s.crashesCounter),
+		reg.Register(s.restartsCounter),
+	)
+}
+
+func (s *supervision) Exited() {
+	s.statusLock.Lock()
+	s.crashes++
+	s.crashesCounter.Inc()
+	wasRestarting := s.restarting
+	s.restarting = true
+	s.statusLock.Unlock()
+
+	if wasRestarting {
+		// The replacement failed to start.
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.vm.processTracker.UntrackProcess(s.vm.pid.Get())
+	for _, conn := range s.conns() {
+		if err := conn.detach(); err != nil {
+			s.log.Debug("failed to close plugin connection",
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (s *supervision) Restarted(ctx context.Context, status *subprocess.Status) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	conn, err := grpcutils.Dial(status.Addr)
+	if err != nil {
+		return err
+	}
+
+	handlerConns, err := s.reattach(ctx, vmpb.NewVMClient(conn))
+	if err != nil {
+		_ = conn.Close()
+		for _, handlerConn := range handlerConns {
+			_ = handlerConn.Close()
+		}
+		return err
+	}
+
+	for prefix, handlerConn := range handlerConns {
+		s.handlers[prefix].attach(handlerConn)
+	}
+	s.conn.attach(conn)
+
+	s.vm.pid.Set(status.Pid)
+	s.vm.processTracker.TrackProcess(status.Pid)
+
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+
+	s.restarting = false
+	s.restarts++
+	s.restartsCounter.Inc()
+	return nil
+}
+
+// reattach replays the state of the previous plugin into the replacement
+// served by [client]. Returns the connections to the replacement's HTTP
+// handlers.
+//
+// Invariant: [s.lock] must be held.
+func (s *supervision) reattach(ctx context.Context, client vmpb.VMClient) (map[string]*grpc.ClientConn, error) {
+	handlerConns := make(map[string]*grpc.ClientConn)
+
+	// The plugin crashed before it was initialized.
+	if s.initRequest == nil {
+		return handlerConns, nil
+	}
+
+	resp, err := client.Initialize(ctx, s.initRequest)
+	if err != nil {
+		return handlerConns, fmt.Errorf("failed to initialize plugin: %w", err)
+	}
+	s.log.Info("initialized restarted plugin",
+		zap.Uint64("lastAcceptedHeight", resp.Height),
+	)
+	s.replacedHeight = resp.Height
+
+	if s.state != nil {
+		_, err := client.SetState(ctx, &vmpb.SetStateRequest{
+			State: vmpb.State(*s.state),
+		})
+		if err != nil {
+			return handlerConns, fmt.Errorf("failed to set plugin state: %w", err)
+		}
+	}
+
+	// Blocks are verified in order of height, so that each block's parent is
+	// verified before it.
+	processing := make([]*processingBlock, 0, len(s.processing))
+	for _, blk := range s.processing {
+		processing = append(processing, blk)
+	}
+	slices.SortFunc(processing, func(a, b *processingBlock) int {
+		return cmp.Compare(a.height, b.height)
+	})
+	for _, blk := range processing {
+		// Blocks at or below the last accepted height were accepted by the
+		// previous plugin before it exited.
+		if blk.height <= resp.Height {
+			continue
+		}
+
+		_, err := client.BlockVerify(ctx, &vmpb.BlockVerifyRequest{
+			Bytes:        blk.bytes,
+			PChainHeight: blk.pChainHeight,
+		})
+		if err != nil {
+			return handlerConns, fmt.Errorf("failed to verify processing block at height %d: %w", blk.height, err)
+		}
+	}
+
+	if s.preference != nil {
+		_, err := client.SetPreference(ctx, &vmpb.SetPreferenceRequest{
+			Id: s.preference[:],
+		})
+		if err != nil {
+			return handlerConns, fmt.Errorf("failed to set plugin preference: %w", err)
+		}
+	}
+
+	for nodeID, nodeVersion := range s.connected {
+		_, err := client.Connected(ctx, &vmpb.ConnectedRequest{
+			NodeId: nodeID.Bytes(),
+			Name:   nodeVersion.Name,
+			Major:  uint32(nodeVersion.Major),
+			Minor:  uint32(nodeVersion.Minor),
+			Patch:  uint32(nodeVersion.Patch),
+		})
+		if err != nil {
+			return handlerConns, fmt.Errorf("failed to connect peer %s: %w", nodeID, err)
+		}
+	}
+
+	if len(s.handlers) == 0 {
+		return handlerConns, nil
+	}
+
+	handlersResp, err := client.CreateHandlers(ctx, &emptypb.Empty{})
+	if err != nil {
+		return handlerConns, fmt.Errorf("failed to create plugin handlers: %w", err)
+	}
+	for _, handler := range handlersResp.Handlers {
+		if _, ok := s.handlers[handler.Prefix]; !ok {
+			s.log.Warn("dropping new plugin handler",
+				zap.String("reason", "handlers can't be added after the chain was created"),
+				zap.String("prefix", handler.Prefix),
+			)
+			continue
+		}
+
+		handlerConn, err := grpcutils.Dial(handler.ServerAddr)
+		if err != nil {
+			return handlerConns, err
+		}
+		handlerConns[handler.Prefix] = handlerConn
+	}
+	for prefix := range s.handlers {
+		if _, ok := handlerConns[prefix]; !ok {
+			return handlerConns, fmt.Errorf("restarted plugin is missing handler %q", prefix)
+		}
+	}
+	return handlerConns, nil
+}
+
+func (s *supervision) Failed(err error) {
+	s.statusLock.Lock()
+	s.err = err
+	s.statusLock.Unlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, conn := range s.conns() {
+		conn.fail(err)
+	}
+}
+
+// healthCheck returns an error if the plugin isn't running.
+func (s *supervision) healthCheck() error {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+
+	switch {
+	case s.err != nil:
+		return s.err
+	case s.restarting:
+		return errPluginRestarting
+	default:
+		return nil
+	}
+}
+
+func (s *supervision) health(vmDetails json.RawMessage) *supervisionHealth {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+
+	return &supervisionHealth{
+		Crashes:  s.crashes,
+		Restarts: s.restarts,
+		VM:       vmDetails,
+	}
+}
+
+func (s *supervision) initialized(request *vmpb.InitializeRequest) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.initRequest = request
+}
+
+func (s *supervision) setState(state snow.State) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.state = &state
+}
+
+func (s *supervision) setPreference(blkID ids.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.preference = &blkID
+}
+
+func (s *supervision) connect(nodeID ids.NodeID, nodeVersion *version.Application) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.connected[nodeID] = nodeVersion
+}
+
+func (s *supervision) disconnect(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.connected, nodeID)
+}
+
+func (s *supervision) verified(blk *blockClient, pChainHeight *uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.processing[blk.id] = &processingBlock{
+		bytes:        blk.bytes,
+		height:       blk.height,
+		pChainHeight: pChainHeight,
+	}
+}
+
+// decide accepts or rejects [blk] by calling [decide]. Deciding a block at or
+// below the height that a replacement was initialized at is a no-op, as the
+// plugin that it replaced already decided the block. If the plugin exits
+// while deciding [blk], the decision only succeeds if the replacement reports
+// that it was made.
+func (s *supervision) decide(ctx context.Context, blk *blockClient, decide func(context.Context) error) error {
+	conn, err := s.conn.wait(ctx)
+	if err != nil {
+		return err
+	}
+	if !s.decidedByReplaced(blk.height) {
+		if err := decide(ctx); err != nil {
+			if !s.conn.exited(ctx, conn) {
+				return err
+			}
+			if _, waitErr := s.conn.wait(ctx); waitErr != nil || !s.decidedByReplaced(blk.height) {
+				return err
+			}
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.processing, blk.id)
+	return nil
+}
+
+// deliver delivers a message from the network to the plugin by calling
+// [call]. If the plugin exits while handling the message, the message is
+// dropped, as if it was lost by the network, rather than failing the chain.
+func (s *supervision) deliver(ctx context.Context, call func(context.Context) error) error {
+	conn, err := s.conn.wait(ctx)
+	if err != nil {
+		return err
+	}
+	if err := call(ctx); err != nil {
+		if !s.conn.exited(ctx, conn) {
+			return err
+		}
+		s.log.Debug("dropped message to exited plugin",
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+// update records a change of the state the engine puts the plugin in by
+// calling [record], and then applies it to the plugin by calling [call]. If
+// the plugin exits while applying the change, the replacement is brought to
+// the recorded state when it is re-attached, so the change succeeds once the
+// replacement is attached.
+func (s *supervision) update(ctx context.Context, record func(), call func(context.Context) error) error {
+	conn, err := s.conn.wait(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The change is recorded before it is applied, so that a replacement
+	// attached after the plugin exits while applying it replays it.
+	record()
+	if err := call(ctx); err != nil {
+		if !s.conn.exited(ctx, conn) {
+			return err
+		}
+		if _, waitErr := s.conn.wait(ctx); waitErr != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decidedByReplaced returns true if a block at [height] was decided by a
+// plugin that has since been replaced.
+func (s *supervision) decidedByReplaced(height uint64) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return height <= s.replacedHeight
+}
+
+// addHandler wraps the connection to an HTTP handler of the plugin so that it
+// can be replaced when the plugin restarts.
+func (s *supervision) addHandler(prefix string, conn *grpc.ClientConn) *supervisedConn {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// HTTP requests may modify the state of the plugin, so they are never
+	// retried.
+	handlerConn := newSupervisedConn(conn, nil)
+	s.handlers[prefix] = handlerConn
+	return handlerConn
+}
+
+// conns returns the connections to the plugin.
+//
+// Invariant: [s.lock] must be held.
+func (s *supervision) conns() []*supervisedConn {
+	conns := make([]*supervisedConn, 0, len(s.handlers)+1)
+	conns = append(conns, s.conn)
+	for _, conn := range s.handlers {
+		conns = append(conns, conn)
+	}
+	return conns
+}
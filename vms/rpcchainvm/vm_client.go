@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -30,7 +31,9 @@ import (
 	"github.com/MetalBlockchain/metalgo/snow/engine/common/appsender"
 	"github.com/MetalBlockchain/metalgo/snow/engine/snowman/block"
 	"github.com/MetalBlockchain/metalgo/snow/validators/gvalidators"
+	"github.com/MetalBlockchain/metalgo/utils"
 	"github.com/MetalBlockchain/metalgo/utils/crypto/bls"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
 	"github.com/MetalBlockchain/metalgo/utils/resource"
 	"github.com/MetalBlockchain/metalgo/utils/units"
 	"github.com/MetalBlockchain/metalgo/utils/wrappers"
@@ -41,6 +44,7 @@ import (
 	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/grpcutils"
 	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/messenger"
 	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime"
+	"github.com/MetalBlockchain/metalgo/vms/rpcchainvm/runtime/subprocess"
 
 	aliasreaderpb "github.com/MetalBlockchain/metalgo/proto/pb/aliasreader"
 	appsenderpb "github.com/MetalBlockchain/metalgo/proto/pb/appsender"
@@ -86,9 +90,11 @@ type VMClient struct {
 	*chain.State
 	client          vmpb.VMClient
 	runtime         runtime.Stopper
-	pid             int
+	pid             utils.Atomic[int]
 	processTracker  resource.ProcessTracker
 	metricsGatherer metrics.MultiGatherer
+	// Name the metrics of this VM are registered with in [metricsGatherer].
+	metricsName string
 
 	messenger            *messenger.Server
 	keystore             *gkeystore.Server
@@ -99,9 +105,13 @@ type VMClient struct {
 	warpSignerServer     *gwarp.Server
 
 	serverCloser grpcutils.ServerCloser
-	conns        []*grpc.ClientConn
+	conns        []io.Closer
 
 	grpcServerMetrics *grpc_prometheus.ServerMetrics
+
+	// Only set if the plugin is supervised.
+	supervisor  *subprocess.Supervisor
+	supervision *supervision
 }
 
 // NewClient returns a VM connected to a remote VM
@@ -112,14 +122,41 @@ func NewClient(
 	processTracker resource.ProcessTracker,
 	metricsGatherer metrics.MultiGatherer,
 ) *VMClient {
-	return &VMClient{
+	vm := &VMClient{
 		client:          vmpb.NewVMClient(clientConn),
 		runtime:         runtime,
-		pid:             pid,
 		processTracker:  processTracker,
 		metricsGatherer: metricsGatherer,
-		conns:           []*grpc.ClientConn{clientConn},
+		conns:           []io.Closer{clientConn},
 	}
+	vm.pid.Set(pid)
+	return vm
+}
+
+// NewSupervisedClient returns a VM connected to a remote VM that is restarted
+// by [supervisor] whenever it exits unexpectedly. The restarted VM is
+// re-initialized and brought back to the state of the VM it replaces.
+func NewSupervisedClient(
+	log logging.Logger,
+	clientConn *grpc.ClientConn,
+	supervisor *subprocess.Supervisor,
+	pid int,
+	processTracker resource.ProcessTracker,
+	metricsGatherer metrics.MultiGatherer,
+) *VMClient {
+	conn := newSupervisedConn(clientConn, idempotentVMMethods)
+	vm := &VMClient{
+		client:          vmpb.NewVMClient(conn),
+		runtime:         supervisor,
+		processTracker:  processTracker,
+		metricsGatherer: metricsGatherer,
+		conns:           []io.Closer{conn},
+		supervisor:      supervisor,
+	}
+	vm.pid.Set(pid)
+	vm.supervision = newSupervision(log, vm, conn)
+	supervisor.Supervise(vm.supervision)
+	return vm
 }
 
 func (vm *VMClient) Initialize(
@@ -151,10 +188,16 @@ func (vm *VMClient) Initialize(
 	if err != nil {
 		return err
 	}
+	vm.metricsName = primaryAlias
 	vm.grpcServerMetrics = grpc_prometheus.NewServerMetrics()
 	if err := serverReg.Register(vm.grpcServerMetrics); err != nil {
 		return err
 	}
+	if vm.supervision != nil {
+		if err := vm.supervision.register(serverReg); err != nil {
+			return err
+		}
+	}
 
 	if err := chainCtx.Metrics.Register("", vm); err != nil {
 		return err
@@ -208,7 +251,7 @@ func (vm *VMClient) Initialize(
 		EtnaTime:                      grpcutils.TimestampFromTime(chainCtx.NetworkUpgrades.EtnaTime),
 	}
 
-	resp, err := vm.client.Initialize(ctx, &vmpb.InitializeRequest{
+	request := &vmpb.InitializeRequest{
 		NetworkId:       chainCtx.NetworkID,
 		SubnetId:        chainCtx.SubnetID[:],
 		ChainId:         chainCtx.ChainID[:],
@@ -224,10 +267,14 @@ func (vm *VMClient) Initialize(
 		ConfigBytes:     configBytes,
 		DbServerAddr:    dbServerAddr,
 		ServerAddr:      serverAddr,
-	})
+	}
+	resp, err := vm.client.Initialize(ctx, request)
 	if err != nil {
 		return err
 	}
+	if vm.supervision != nil {
+		vm.supervision.initialized(request)
+	}
 
 	id, err := ids.ToID(resp.LastAcceptedId)
 	if err != nil {
@@ -329,6 +376,9 @@ func (vm *VMClient) SetState(ctx context.Context, state snow.State) error {
 	if err != nil {
 		return err
 	}
+	if vm.supervision != nil {
+		vm.supervision.setState(state)
+	}
 
 	id, err := ids.ToID(resp.LastAcceptedId)
 	if err != nil {
@@ -358,6 +408,12 @@ func (vm *VMClient) SetState(ctx context.Context, state snow.State) error {
 }
 
 func (vm *VMClient) Shutdown(ctx context.Context) error {
+	// The plugin exits once it is shutdown, which must not be mistaken for a
+	// crash.
+	if vm.supervisor != nil {
+		vm.supervisor.Unsupervise()
+	}
+
 	errs := wrappers.Errs{}
 	_, err := vm.client.Shutdown(ctx, &emptypb.Empty{})
 	errs.Add(err)
@@ -369,7 +425,10 @@ func (vm *VMClient) Shutdown(ctx context.Context) error {
 
 	vm.runtime.Stop(ctx)
 
-	vm.processTracker.UntrackProcess(vm.pid)
+	vm.processTracker.UntrackProcess(vm.pid.Get())
+
+	// Allow the metrics to be registered again if the chain is restarted.
+	vm.metricsGatherer.Deregister(vm.metricsName)
 	return errs.Err
 }
 
@@ -386,13 +445,37 @@ func (vm *VMClient) CreateHandlers(ctx context.Context) (map[string]http.Handler
 			return nil, err
 		}
 
-		vm.conns = append(vm.conns, clientConn)
-		handlers[handler.Prefix] = ghttp.NewClient(httppb.NewHTTPClient(clientConn))
+		if vm.supervision == nil {
+			vm.conns = append(vm.conns, clientConn)
+			handlers[handler.Prefix] = ghttp.NewClient(httppb.NewHTTPClient(clientConn))
+			continue
+		}
+
+		// Requests to the handler are forwarded to the plugin's replacement
+		// if it restarts.
+		conn := vm.supervision.addHandler(handler.Prefix, clientConn)
+		vm.conns = append(vm.conns, conn)
+		handlers[handler.Prefix] = ghttp.NewClient(httppb.NewHTTPClient(conn))
 	}
 	return handlers, nil
 }
 
 func (vm *VMClient) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
+	if vm.supervision != nil {
+		return vm.supervision.update(
+			ctx,
+			func() {
+				vm.supervision.connect(nodeID, nodeVersion)
+			},
+			func(ctx context.Context) error {
+				return vm.connected(ctx, nodeID, nodeVersion)
+			},
+		)
+	}
+	return vm.connected(ctx, nodeID, nodeVersion)
+}
+
+func (vm *VMClient) connected(ctx context.Context, nodeID ids.NodeID, nodeVersion *version.Application) error {
 	_, err := vm.client.Connected(ctx, &vmpb.ConnectedRequest{
 		NodeId: nodeID.Bytes(),
 		Name:   nodeVersion.Name,
@@ -404,6 +487,21 @@ func (vm *VMClient) Connected(ctx context.Context, nodeID ids.NodeID, nodeVersio
 }
 
 func (vm *VMClient) Disconnected(ctx context.Context, nodeID ids.NodeID) error {
+	if vm.supervision != nil {
+		return vm.supervision.update(
+			ctx,
+			func() {
+				vm.supervision.disconnect(nodeID)
+			},
+			func(ctx context.Context) error {
+				return vm.disconnected(ctx, nodeID)
+			},
+		)
+	}
+	return vm.disconnected(ctx, nodeID)
+}
+
+func (vm *VMClient) disconnected(ctx context.Context, nodeID ids.NodeID) error {
 	_, err := vm.client.Disconnected(ctx, &vmpb.DisconnectedRequest{
 		NodeId: nodeID.Bytes(),
 	})
@@ -492,6 +590,21 @@ func (vm *VMClient) getBlock(ctx context.Context, blkID ids.ID) (snowman.Block,
 }
 
 func (vm *VMClient) SetPreference(ctx context.Context, blkID ids.ID) error {
+	if vm.supervision != nil {
+		return vm.supervision.update(
+			ctx,
+			func() {
+				vm.supervision.setPreference(blkID)
+			},
+			func(ctx context.Context) error {
+				return vm.setPreference(ctx, blkID)
+			},
+		)
+	}
+	return vm.setPreference(ctx, blkID)
+}
+
+func (vm *VMClient) setPreference(ctx context.Context, blkID ids.ID) error {
 	_, err := vm.client.SetPreference(ctx, &vmpb.SetPreferenceRequest{
 		Id: blkID[:],
 	})
@@ -499,6 +612,19 @@ func (vm *VMClient) SetPreference(ctx context.Context, blkID ids.ID) error {
 }
 
 func (vm *VMClient) HealthCheck(ctx context.Context) (interface{}, error) {
+	if vm.supervision == nil {
+		return vm.healthCheck(ctx)
+	}
+
+	// Don't wait for a restarting plugin to be re-attached.
+	if err := vm.supervision.healthCheck(); err != nil {
+		return vm.supervision.health(nil), fmt.Errorf("health check failed: %w", err)
+	}
+	details, err := vm.healthCheck(ctx)
+	return vm.supervision.health(details), err
+}
+
+func (vm *VMClient) healthCheck(ctx context.Context) (json.RawMessage, error) {
 	// HealthCheck is a special case, where we want to fail fast instead of block.
 	failFast := grpc.WaitForReady(false)
 	health, err := vm.client.Health(ctx, &emptypb.Empty{}, failFast)
@@ -518,6 +644,15 @@ func (vm *VMClient) Version(ctx context.Context) (string, error) {
 }
 
 func (vm *VMClient) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
+	if vm.supervision != nil {
+		return vm.supervision.deliver(ctx, func(ctx context.Context) error {
+			return vm.appRequest(ctx, nodeID, requestID, deadline, request)
+		})
+	}
+	return vm.appRequest(ctx, nodeID, requestID, deadline, request)
+}
+
+func (vm *VMClient) appRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
 	_, err := vm.client.AppRequest(
 		ctx,
 		&vmpb.AppRequestMsg{
@@ -531,6 +666,15 @@ func (vm *VMClient) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID
 }
 
 func (vm *VMClient) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
+	if vm.supervision != nil {
+		return vm.supervision.deliver(ctx, func(ctx context.Context) error {
+			return vm.appResponse(ctx, nodeID, requestID, response)
+		})
+	}
+	return vm.appResponse(ctx, nodeID, requestID, response)
+}
+
+func (vm *VMClient) appResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
 	_, err := vm.client.AppResponse(
 		ctx,
 		&vmpb.AppResponseMsg{
@@ -543,6 +687,15 @@ func (vm *VMClient) AppResponse(ctx context.Context, nodeID ids.NodeID, requestI
 }
 
 func (vm *VMClient) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32, appErr *common.AppError) error {
+	if vm.supervision != nil {
+		return vm.supervision.deliver(ctx, func(ctx context.Context) error {
+			return vm.appRequestFailed(ctx, nodeID, requestID, appErr)
+		})
+	}
+	return vm.appRequestFailed(ctx, nodeID, requestID, appErr)
+}
+
+func (vm *VMClient) appRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32, appErr *common.AppError) error {
 	msg := &vmpb.AppRequestFailedMsg{
 		NodeId:       nodeID.Bytes(),
 		RequestId:    requestID,
@@ -555,6 +708,15 @@ func (vm *VMClient) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, req
 }
 
 func (vm *VMClient) AppGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
+	if vm.supervision != nil {
+		return vm.supervision.deliver(ctx, func(ctx context.Context) error {
+			return vm.appGossip(ctx, nodeID, msg)
+		})
+	}
+	return vm.appGossip(ctx, nodeID, msg)
+}
+
+func (vm *VMClient) appGossip(ctx context.Context, nodeID ids.NodeID, msg []byte) error {
 	_, err := vm.client.AppGossip(
 		ctx,
 		&vmpb.AppGossipMsg{
@@ -781,6 +943,13 @@ func (b *blockClient) ID() ids.ID {
 }
 
 func (b *blockClient) Accept(ctx context.Context) error {
+	if b.vm.supervision != nil {
+		return b.vm.supervision.decide(ctx, b, b.accept)
+	}
+	return b.accept(ctx)
+}
+
+func (b *blockClient) accept(ctx context.Context) error {
 	_, err := b.vm.client.BlockAccept(ctx, &vmpb.BlockAcceptRequest{
 		Id: b.id[:],
 	})
@@ -788,6 +957,13 @@ func (b *blockClient) Accept(ctx context.Context) error {
 }
 
 func (b *blockClient) Reject(ctx context.Context) error {
+	if b.vm.supervision != nil {
+		return b.vm.supervision.decide(ctx, b, b.reject)
+	}
+	return b.reject(ctx)
+}
+
+func (b *blockClient) reject(ctx context.Context) error {
 	_, err := b.vm.client.BlockReject(ctx, &vmpb.BlockRejectRequest{
 		Id: b.id[:],
 	})
@@ -805,6 +981,9 @@ func (b *blockClient) Verify(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if b.vm.supervision != nil {
+		b.vm.supervision.verified(b, nil)
+	}
 
 	b.time, err = grpcutils.TimestampAsTime(resp.Timestamp)
 	return err
@@ -834,6 +1013,10 @@ func (b *blockClient) VerifyWithContext(ctx context.Context, blockCtx *block.Con
 	if err != nil {
 		return err
 	}
+	if b.vm.supervision != nil {
+		pChainHeight := blockCtx.PChainHeight
+		b.vm.supervision.verified(b, &pChainHeight)
+	}
 
 	b.time, err = grpcutils.TimestampAsTime(resp.Timestamp)
 	return err
@@ -37,6 +37,12 @@ const (
 	lastAcceptedBlockPostStateSummaryAcceptTestKey = "lastAcceptedBlockPostStateSummaryAcceptTest"
 	contextTestKey                                 = "contextTest"
 	batchedParseBlockCachingTestKey                = "batchedParseBlockCachingTest"
+	supervisedTestKey                              = "supervisedTest"
+	supervisedAcceptCrashTestKey                   = "supervisedAcceptCrashTest"
+	supervisedVerifyCrashTestKey                   = "supervisedVerifyCrashTest"
+	supervisedInitializeFailureTestKey             = "supervisedInitializeFailureTest"
+	supervisedAppGossipCrashTestKey                = "supervisedAppGossipCrashTest"
+	supervisedSetPreferenceCrashTestKey            = "supervisedSetPreferenceCrashTest"
 )
 
 var TestServerPluginMap = map[string]func(*testing.T, bool) block.ChainVM{
@@ -49,6 +55,12 @@ var TestServerPluginMap = map[string]func(*testing.T, bool) block.ChainVM{
 	lastAcceptedBlockPostStateSummaryAcceptTestKey: lastAcceptedBlockPostStateSummaryAcceptTestPlugin,
 	contextTestKey:                                 contextEnabledTestPlugin,
 	batchedParseBlockCachingTestKey:                batchedParseBlockCachingTestPlugin,
+	supervisedTestKey:                              supervisedTestPlugin,
+	supervisedAcceptCrashTestKey:                   supervisedAcceptCrashTestPlugin,
+	supervisedVerifyCrashTestKey:                   supervisedVerifyCrashTestPlugin,
+	supervisedInitializeFailureTestKey:             supervisedInitializeFailureTestPlugin,
+	supervisedAppGossipCrashTestKey:                supervisedAppGossipCrashTestPlugin,
+	supervisedSetPreferenceCrashTestKey:            supervisedSetPreferenceCrashTestPlugin,
 }
 
 // helperProcess helps with creating the subnet binary for testing.
@@ -3,7 +3,14 @@
 
 package archivedb
 
-import "github.com/MetalBlockchain/metalgo/database"
+import (
+	"context"
+	"fmt"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/x/merkledb"
+)
 
 var _ database.Batch = (*batch)(nil)
 
@@ -20,6 +27,48 @@ type batch struct {
 }
 
 func (c *batch) Write() error {
+	if c.db.trie == nil {
+		return c.write(nil)
+	}
+
+	c.db.lock.Lock()
+	defer c.db.lock.Unlock()
+
+	// The trie only tracks the latest state, so authenticated databases can
+	// not modify the state at prior heights.
+	height, err := c.db.Height()
+	switch {
+	case err == nil && c.height < height:
+		return fmt.Errorf("%w: %d < %d", ErrDecreasingHeight, c.height, height)
+	case err != nil && err != database.ErrNotFound:
+		return err
+	}
+
+	ctx := context.Background()
+	view, err := c.db.trie.NewView(ctx, merkledb.ViewChanges{
+		BatchOps: c.Ops,
+	})
+	if err != nil {
+		return err
+	}
+	root, err := view.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The archive is written before the trie. If the trie fails to be
+	// updated, it is repaired from the archive when the database is reopened.
+	if err := c.write(&root); err != nil {
+		return err
+	}
+
+	c.db.clearView()
+	return view.CommitToDB(ctx)
+}
+
+// write atomically writes the batch to the archive. If [root] is non-nil, it
+// is recorded as the merkle root at the batch's height.
+func (c *batch) write(root *ids.ID) error {
 	batch := c.db.db.NewBatch()
 	for _, op := range c.Ops {
 		key, _ := newDBKeyFromUser(op.Key, c.height)
@@ -32,6 +81,13 @@ func (c *batch) Write() error {
 		}
 	}
 
+	if root != nil {
+		key, _ := newDBKeyFromRoot(c.height)
+		if err := batch.Put(key, root[:]); err != nil {
+			return err
+		}
+	}
+
 	if err := database.PutUInt64(batch, heightKey, c.height); err != nil {
 		return err
 	}
@@ -7,9 +7,11 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sync"
 
 	"github.com/MetalBlockchain/metalgo/api/health"
 	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/x/merkledb"
 )
 
 var (
@@ -57,8 +59,25 @@ var (
 // foo was deleted at height 1000. When calling `reader.GetHeight(foo)` at
 // height 99 it will return a tuple `("foo's value is bar", 10)` returning the
 // value of `foo` at height 99 (which was set at height 10).
+//
+// A Database created with NewAuthenticated additionally maintains a merkledb
+// of the latest state and records its merkle root at every height. This
+// allows proofs to be generated against the state at any authenticated height,
+// see Reader.GetProof and Reader.GetRangeProof.
 type Database struct {
 	db database.Database
+
+	// trie is nil if the database isn't authenticated.
+	trie merkledb.MerkleDB
+	// lock is held exclusively while [trie] is being modified, which
+	// invalidates any views of [trie].
+	lock sync.RWMutex
+	// viewLock protects [view] and [viewHeight].
+	viewLock sync.Mutex
+	// view is the most recently opened view of the state at [viewHeight].
+	// It is cleared whenever [trie] is modified.
+	view       merkledb.Trie
+	viewHeight uint64
 }
 
 func New(db database.Database) *Database {
@@ -101,5 +120,11 @@ func (db *Database) HealthCheck(ctx context.Context) (interface{}, error) {
 }
 
 func (db *Database) Close() error {
-	return db.db.Close()
+	if db.trie == nil {
+		return db.db.Close()
+	}
+	return errors.Join(
+		db.trie.Close(),
+		db.db.Close(),
+	)
 }
@@ -15,6 +15,10 @@ var (
 	ErrIncorrectKeyLength = errors.New("incorrect key length")
 
 	heightKey = newDBKeyFromMetadata([]byte{})
+	// rootKeyPrefix is a non-minimal encoding of the length 0, which is never
+	// produced by binary.PutUvarint. This guarantees that merkle root keys can
+	// not overlap with user keys or metadata keys.
+	rootKeyPrefix = []byte{0x80, 0x00}
 )
 
 // The requirements of a database key are:
@@ -24,6 +28,8 @@ var (
 // 2. Inside of a database key prefix, the database keys must be sorted by
 // decreasing height.
 // 3. User keys must never overlap with any metadata keys.
+// 4. Neither user keys nor metadata keys may share a prefix with merkle root
+// keys, as merkle roots are looked up by iterating over their prefix.
 
 // newDBKeyFromUser converts a user key and height into a database formatted
 // key.
@@ -61,7 +67,8 @@ func newDBKeyFromUser(key []byte, height uint64) ([]byte, []byte) {
 // been corrupted.
 func parseDBKeyFromUser(dbKey []byte) ([]byte, uint64, error) {
 	keyLen, offset := binary.Uvarint(dbKey)
-	if offset <= 0 {
+	// Non-minimal length encodings are never written for user keys.
+	if offset <= 0 || (offset > 1 && dbKey[offset-1] == 0) {
 		return nil, 0, ErrParsingKeyLength
 	}
 
@@ -93,3 +100,20 @@ func newDBKeyFromMetadata(key []byte) []byte {
 	offset += copy(dbKey[offset:], key)
 	return dbKey[:offset]
 }
+
+// newDBKeyFromRoot returns the database formatted key that the merkle root at
+// the given height is stored under.
+//
+// The key is defined by concatenating the root key prefix and the negation of
+// the big endian encoded height. This guarantees that merkle roots are sorted
+// by decreasing height, similarly to user keys.
+//
+// Returns:
+// - The database key
+// - The database key prefix, which is independent of the height
+func newDBKeyFromRoot(height uint64) ([]byte, []byte) {
+	dbKey := make([]byte, len(rootKeyPrefix)+wrappers.LongLen)
+	copy(dbKey, rootKeyPrefix)
+	binary.BigEndian.PutUint64(dbKey[len(rootKeyPrefix):], ^height)
+	return dbKey, dbKey[:len(rootKeyPrefix)]
+}
@@ -61,3 +61,16 @@ func FuzzMetadataKeyInvariant(f *testing.F) {
 		require.False(t, bytes.HasPrefix(dbKey, dbKeyPrefix))
 	})
 }
+
+func FuzzRootKeyInvariant(f *testing.F) {
+	f.Fuzz(func(t *testing.T, userKey []byte, metadataKey []byte, height uint64) {
+		rootKey, rootKeyPrefix := newDBKeyFromRoot(height)
+
+		dbKey, _ := newDBKeyFromUser(userKey, height)
+		require.False(t, bytes.HasPrefix(dbKey, rootKeyPrefix))
+		require.False(t, bytes.HasPrefix(newDBKeyFromMetadata(metadataKey), rootKeyPrefix))
+
+		_, _, err := parseDBKeyFromUser(rootKey)
+		require.ErrorIs(t, err, ErrParsingKeyLength)
+	})
+}
@@ -0,0 +1,297 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+	"github.com/MetalBlockchain/metalgo/x/merkledb"
+)
+
+var (
+	ErrNotAuthenticated = errors.New("database is not authenticated")
+	ErrDecreasingHeight = errors.New("height is lower than the last written height")
+
+	errRootMismatch = errors.New("merkle root mismatch")
+)
+
+// NewAuthenticated returns an authenticated Database on top of [db].
+//
+// [trie] is used to track the latest state and must not be modified by the
+// caller. If [trie] is out of sync with [db], for example because [db] was
+// previously used without authentication or the node crashed while writing a
+// batch, [trie] is repaired from [db].
+//
+// Merkle roots are only recorded for heights written while the database is
+// authenticated. If [trie] needed to be repaired, the merkle root of the last
+// written height is recorded.
+func NewAuthenticated(ctx context.Context, db database.Database, trie merkledb.MerkleDB) (*Database, error) {
+	archive := &Database{
+		db:   db,
+		trie: trie,
+	}
+
+	height, err := archive.Height()
+	if err == database.ErrNotFound {
+		return archive, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	expectedRoot, err := archive.getMerkleRoot(height)
+	if err != nil && err != database.ErrNotFound {
+		return nil, err
+	}
+	hasExpectedRoot := err == nil
+
+	root, err := trie.GetMerkleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hasExpectedRoot && root == expectedRoot {
+		return archive, nil
+	}
+
+	// Overwrite the trie with the latest state in the archive. Keys that were
+	// deleted in the archive are deleted from the trie.
+	ops, err := archive.stateOps(height, true /*=includeAll*/)
+	if err != nil {
+		return nil, err
+	}
+	view, err := trie.NewView(ctx, merkledb.ViewChanges{
+		BatchOps:     ops,
+		ConsumeBytes: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	root, err = view.GetMerkleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hasExpectedRoot && root != expectedRoot {
+		return nil, fmt.Errorf("%w at height %d: expected %s but got %s", errRootMismatch, height, expectedRoot, root)
+	}
+	if err := view.CommitToDB(ctx); err != nil {
+		return nil, err
+	}
+	if hasExpectedRoot {
+		return archive, nil
+	}
+
+	key, _ := newDBKeyFromRoot(height)
+	return archive, db.Put(key, root[:])
+}
+
+// GetMerkleRoot returns the merkle root of the state at the reader's height.
+//
+// If the database isn't authenticated, ErrNotAuthenticated is returned. If no
+// merkle root was recorded at or below the reader's height, ErrNotFound is
+// returned.
+func (r *Reader) GetMerkleRoot(context.Context) (ids.ID, error) {
+	if r.db.trie == nil {
+		return ids.Empty, ErrNotAuthenticated
+	}
+	return r.db.getMerkleRoot(r.height)
+}
+
+// GetProof returns a proof of the value of [key], or of its absence, in the
+// state at the reader's height. The proof can be verified against the result
+// of GetMerkleRoot.
+//
+// Generating proofs below the last written height requires iterating over the
+// full archive.
+func (r *Reader) GetProof(ctx context.Context, key []byte) (*merkledb.Proof, error) {
+	r.db.lock.RLock()
+	defer r.db.lock.RUnlock()
+
+	trie, err := r.db.openTrie(ctx, r.height)
+	if err != nil {
+		return nil, err
+	}
+	return trie.GetProof(ctx, key)
+}
+
+// GetRangeProof returns a proof of up to [maxLength] key-value pairs with keys
+// in [start, end] in the state at the reader's height. The proof can be
+// verified against the result of GetMerkleRoot.
+//
+// Generating proofs below the last written height requires iterating over the
+// full archive.
+func (r *Reader) GetRangeProof(
+	ctx context.Context,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	maxLength int,
+) (*merkledb.RangeProof, error) {
+	r.db.lock.RLock()
+	defer r.db.lock.RUnlock()
+
+	trie, err := r.db.openTrie(ctx, r.height)
+	if err != nil {
+		return nil, err
+	}
+	return trie.GetRangeProof(ctx, start, end, maxLength)
+}
+
+// getMerkleRoot returns the merkle root recorded at the highest height that is
+// less than or equal to [height].
+func (db *Database) getMerkleRoot(height uint64) (ids.ID, error) {
+	key, prefix := newDBKeyFromRoot(height)
+	root, err := db.db.Get(key)
+	if err == nil {
+		return ids.ToID(root)
+	}
+	if err != database.ErrNotFound {
+		return ids.Empty, err
+	}
+
+	// No batch was written at [height], so the state at [height] is the state
+	// at the highest height below it.
+	it := db.db.NewIteratorWithStartAndPrefix(key, prefix)
+	defer it.Release()
+
+	next := it.Next()
+	if err := it.Error(); err != nil {
+		return ids.Empty, err
+	}
+
+	// There is no merkle root recorded at or below the requested height
+	if !next {
+		return ids.Empty, database.ErrNotFound
+	}
+	return ids.ToID(it.Value())
+}
+
+// openTrie returns a trie of the state at [height]. The returned trie is only
+// valid until the next batch is written.
+//
+// Assumes [db.lock] is held.
+func (db *Database) openTrie(ctx context.Context, height uint64) (merkledb.Trie, error) {
+	if db.trie == nil {
+		return nil, ErrNotAuthenticated
+	}
+
+	db.viewLock.Lock()
+	defer db.viewLock.Unlock()
+
+	if db.view != nil && db.viewHeight == height {
+		return db.view, nil
+	}
+
+	expectedRoot, err := db.getMerkleRoot(height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Revert all the keys that were modified after [height].
+	ops, err := db.stateOps(height, false /*=includeAll*/)
+	if err != nil {
+		return nil, err
+	}
+
+	var trie merkledb.Trie = db.trie
+	if len(ops) > 0 {
+		trie, err = db.trie.NewView(ctx, merkledb.ViewChanges{
+			BatchOps:     ops,
+			ConsumeBytes: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	root, err := trie.GetMerkleRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if root != expectedRoot {
+		return nil, fmt.Errorf("%w at height %d: expected %s but got %s", errRootMismatch, height, expectedRoot, root)
+	}
+
+	db.view = trie
+	db.viewHeight = height
+	return trie, nil
+}
+
+// clearView drops the cached view, which is invalidated when the trie is
+// modified.
+func (db *Database) clearView() {
+	db.viewLock.Lock()
+	defer db.viewLock.Unlock()
+
+	db.view = nil
+}
+
+// stateOps returns the operations that set every key to its state at
+// [height].
+//
+// If [includeAll] is false, only keys that were modified after [height] are
+// included. Applying these operations to the latest state results in the
+// state at [height].
+func (db *Database) stateOps(height uint64, includeAll bool) ([]database.BatchOp, error) {
+	it := db.db.NewIterator()
+	defer it.Release()
+
+	var (
+		ops []database.BatchOp
+
+		hasKey       bool
+		key          []byte
+		latestHeight uint64
+		value        maybe.Maybe[[]byte]
+		foundValue   bool
+	)
+	addOp := func() {
+		if !hasKey || (!includeAll && latestHeight <= height) {
+			return
+		}
+		ops = append(ops, database.BatchOp{
+			Key:    key,
+			Value:  value.Value(),
+			Delete: value.IsNothing(),
+		})
+	}
+	for it.Next() {
+		userKey, keyHeight, err := parseDBKeyFromUser(it.Key())
+		if err != nil {
+			// Metadata keys can not be parsed as user keys.
+			continue
+		}
+
+		// Entries of the same key are sorted by decreasing height, so the
+		// first entry of each key is its latest modification.
+		if !hasKey || !bytes.Equal(key, userKey) {
+			addOp()
+
+			hasKey = true
+			key = slices.Clone(userKey)
+			latestHeight = keyHeight
+			value = maybe.Nothing[[]byte]()
+			foundValue = false
+		}
+		if foundValue || keyHeight > height {
+			continue
+		}
+
+		foundValue = true
+		if dbValue, exists := parseDBValue(it.Value()); exists {
+			value = maybe.Some(slices.Clone(dbValue))
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	addOp()
+	return ops, nil
+}
@@ -0,0 +1,280 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/trace"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+	"github.com/MetalBlockchain/metalgo/x/merkledb"
+)
+
+func newTestTrie(t *testing.T, db database.Database) merkledb.MerkleDB {
+	trie, err := merkledb.New(
+		context.Background(),
+		db,
+		merkledb.Config{
+			BranchFactor:                merkledb.BranchFactor16,
+			Hasher:                      merkledb.DefaultHasher,
+			HistoryLength:               1,
+			ValueNodeCacheSize:          1_000,
+			IntermediateNodeCacheSize:   1_000,
+			IntermediateWriteBufferSize: 1_000,
+			IntermediateWriteBatchSize:  1_000,
+			Reg:                         prometheus.NewRegistry(),
+			Tracer:                      trace.Noop,
+		},
+	)
+	require.NoError(t, err)
+	return trie
+}
+
+// merkleRoot returns the merkle root of a trie containing [state].
+func merkleRoot(t *testing.T, state map[string][]byte) ids.ID {
+	require := require.New(t)
+	ctx := context.Background()
+
+	ops := make([]database.BatchOp, 0, len(state))
+	for key, value := range state {
+		ops = append(ops, database.BatchOp{
+			Key:   []byte(key),
+			Value: value,
+		})
+	}
+	trie := newTestTrie(t, memdb.New())
+	view, err := trie.NewView(ctx, merkledb.ViewChanges{
+		BatchOps: ops,
+	})
+	require.NoError(err)
+	root, err := view.GetMerkleRoot(ctx)
+	require.NoError(err)
+	return root
+}
+
+func TestAuthenticatedProofs(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db, err := NewAuthenticated(ctx, memdb.New(), newTestTrie(t, memdb.New()))
+	require.NoError(err)
+
+	// state at each height
+	states := []map[string][]byte{
+		{},
+		{
+			"key1": []byte("value1@1"),
+			"key2": []byte("value2@1"),
+		},
+		{
+			"key1": []byte("value1@2"),
+			"key2": []byte("value2@1"),
+			"key3": []byte("value3@2"),
+		},
+		{
+			"key1": []byte("value1@2"),
+			"key3": []byte("value3@2"),
+		},
+		{
+			"key1": []byte("value1@2"),
+			"key2": []byte("value2@4"),
+			"key3": []byte{},
+		},
+	}
+
+	batch := db.NewBatch(0)
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@1")))
+	require.NoError(batch.Put([]byte("key2"), []byte("value2@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@2")))
+	require.NoError(batch.Put([]byte("key3"), []byte("value3@2")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Delete([]byte("key2")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(4)
+	require.NoError(batch.Put([]byte("key2"), []byte("value2@4")))
+	require.NoError(batch.Put([]byte("key3"), []byte{}))
+	require.NoError(batch.Write())
+
+	keys := [][]byte{
+		[]byte("key0"),
+		[]byte("key1"),
+		[]byte("key2"),
+		[]byte("key3"),
+		[]byte("key4"),
+	}
+	tokenSize := merkledb.BranchFactorToTokenSize[merkledb.BranchFactor16]
+	for height, state := range states {
+		reader := db.Open(uint64(height))
+
+		root, err := reader.GetMerkleRoot(ctx)
+		require.NoError(err)
+		require.Equal(merkleRoot(t, state), root)
+
+		if len(state) == 0 {
+			_, err := reader.GetProof(ctx, keys[0])
+			require.ErrorIs(err, merkledb.ErrEmptyProof)
+			continue
+		}
+
+		for _, key := range keys {
+			proof, err := reader.GetProof(ctx, key)
+			require.NoError(err)
+			require.NoError(proof.Verify(ctx, root, tokenSize, merkledb.DefaultHasher))
+
+			expectedValue, ok := state[string(key)]
+			require.Equal(ok, proof.Value.HasValue())
+			if ok {
+				require.Equal(expectedValue, proof.Value.Value())
+			}
+		}
+
+		start := maybe.Some([]byte("key1"))
+		end := maybe.Some([]byte("key3"))
+		rangeProof, err := reader.GetRangeProof(ctx, start, end, len(keys))
+		require.NoError(err)
+		require.NoError(rangeProof.Verify(ctx, start, end, root, tokenSize, merkledb.DefaultHasher))
+		require.Len(rangeProof.KeyValues, len(state))
+		for _, kv := range rangeProof.KeyValues {
+			require.Equal(state[string(kv.Key)], kv.Value)
+		}
+	}
+
+	// Heights after the last written height have the same state as the last
+	// written height.
+	root, err := db.Open(10).GetMerkleRoot(ctx)
+	require.NoError(err)
+	expectedRoot, err := db.Open(4).GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(expectedRoot, root)
+
+	batch = db.NewBatch(3)
+	err = batch.Write()
+	require.ErrorIs(err, ErrDecreasingHeight)
+}
+
+func TestAuthenticatedRootKeyCollision(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	db, err := NewAuthenticated(ctx, memdb.New(), newTestTrie(t, memdb.New()))
+	require.NoError(err)
+
+	// The user key has the same length as the metadata key of a merkle root
+	// and starts with "root".
+	key := append([]byte("root"), bytes.Repeat([]byte{0xff}, 9)...)
+	value := ids.GenerateTestID()
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put(key, value[:]))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Put([]byte("key"), []byte("value")))
+	require.NoError(batch.Write())
+
+	expectedRoot := merkleRoot(t, map[string][]byte{
+		string(key): value[:],
+	})
+	for _, height := range []uint64{1, 2} {
+		root, err := db.Open(height).GetMerkleRoot(ctx)
+		require.NoError(err)
+		require.Equal(expectedRoot, root)
+	}
+
+	_, err = db.Open(0).GetMerkleRoot(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Merkle roots are not returned as user keys.
+	ops, err := db.stateOps(0, true /*=includeAll*/)
+	require.NoError(err)
+	require.Len(ops, 2)
+}
+
+func TestAuthenticatedRepair(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	baseDB := memdb.New()
+	unauthenticatedDB := New(baseDB)
+
+	batch := unauthenticatedDB.NewBatch(1)
+	require.NoError(batch.Put([]byte("key1"), []byte("value1@1")))
+	require.NoError(batch.Put([]byte("key2"), []byte("value2@1")))
+	require.NoError(batch.Write())
+
+	_, err := unauthenticatedDB.Open(1).GetMerkleRoot(ctx)
+	require.ErrorIs(err, ErrNotAuthenticated)
+	_, err = unauthenticatedDB.Open(1).GetProof(ctx, []byte("key1"))
+	require.ErrorIs(err, ErrNotAuthenticated)
+
+	// The trie is populated from the archive and the root of the last height
+	// is recorded.
+	trieDB := memdb.New()
+	db, err := NewAuthenticated(ctx, baseDB, newTestTrie(t, trieDB))
+	require.NoError(err)
+
+	root, err := db.Open(1).GetMerkleRoot(ctx)
+	require.NoError(err)
+	require.Equal(
+		merkleRoot(t, map[string][]byte{
+			"key1": []byte("value1@1"),
+			"key2": []byte("value2@1"),
+		}),
+		root,
+	)
+
+	_, err = db.Open(0).GetMerkleRoot(ctx)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Simulate a crash after the archive was written but before the trie was
+	// updated.
+	require.NoError(db.trie.Close())
+
+	batch = unauthenticatedDB.NewBatch(2)
+	require.NoError(batch.Delete([]byte("key1")))
+	require.NoError(batch.Write())
+
+	wrongRoot := ids.GenerateTestID()
+	key, _ := newDBKeyFromRoot(2)
+	require.NoError(baseDB.Put(key, wrongRoot[:]))
+
+	// The wrong root was recorded, so the trie can't be repaired.
+	_, err = NewAuthenticated(ctx, baseDB, newTestTrie(t, trieDB))
+	require.ErrorIs(err, errRootMismatch)
+
+	expectedRoot := merkleRoot(t, map[string][]byte{
+		"key2": []byte("value2@1"),
+	})
+	require.NoError(baseDB.Put(key, expectedRoot[:]))
+
+	db, err = NewAuthenticated(ctx, baseDB, newTestTrie(t, trieDB))
+	require.NoError(err)
+
+	proof, err := db.Open(2).GetProof(ctx, []byte("key1"))
+	require.NoError(err)
+	require.NoError(proof.Verify(
+		ctx,
+		expectedRoot,
+		merkledb.BranchFactorToTokenSize[merkledb.BranchFactor16],
+		merkledb.DefaultHasher,
+	))
+	require.True(proof.Value.IsNothing())
+}
@@ -43,6 +43,7 @@ var (
 	metadataPrefix         = []byte{0}
 	valueNodePrefix        = []byte{1}
 	intermediateNodePrefix = []byte{2}
+	historyPrefix          = []byte{3}
 
 	// cleanShutdownKey is used to flag that the database did (or did not)
 	// previously shutdown correctly.
@@ -59,10 +60,12 @@ var (
 	// always be persisted correctly.
 	cleanShutdownKey        = []byte(string(metadataPrefix) + "cleanShutdown")
 	rootDBKey               = []byte(string(metadataPrefix) + "root")
+	historyMetadataKey      = []byte(string(metadataPrefix) + "history")
 	hadCleanShutdown        = []byte{1}
 	didNotHaveCleanShutdown = []byte{0}
 
-	errSameRoot = errors.New("start and end root are the same")
+	errSameRoot            = errors.New("start and end root are the same")
+	errHistoryRootMismatch = errors.New("history root mismatch")
 )
 
 type ChangeProofer interface {
@@ -177,6 +180,15 @@ type Config struct {
 	// The number of changes to the database that we store in memory in order to
 	// serve change proofs.
 	HistoryLength uint
+	// The number of changes to the database that we store on disk in order to
+	// serve change proofs for roots that are no longer in memory. The history
+	// on disk survives restarts.
+	// If 0, the history is only stored in memory.
+	DiskHistoryLength uint
+	// The maximum number of bytes of changes to store on disk. Once exceeded,
+	// the oldest changes are pruned.
+	// If 0, the history on disk is only limited by [DiskHistoryLength].
+	DiskHistorySize uint
 	// The number of bytes used to cache nodes with values.
 	ValueNodeCacheSize uint
 	// The number of bytes used to cache nodes without values.
@@ -216,6 +228,10 @@ type merkleDB struct {
 	// historical views of the trie.
 	history *trieHistory
 
+	// Stores value change lists on disk. Used when [history] is insufficient.
+	// Nil if the history is only stored in memory.
+	diskHistory *historyDB
+
 	// True iff the db has been closed.
 	closed bool
 
@@ -335,6 +351,20 @@ func newDatabase(
 		nodes:  map[Key]*change[*node]{},
 	})
 
+	// The disk history is loaded after the trie is rebuilt so that rebuilding
+	// isn't recorded as a change.
+	if config.DiskHistoryLength > 0 {
+		trieDB.diskHistory, err = newHistoryDB(
+			db,
+			uint64(config.DiskHistoryLength),
+			uint64(config.DiskHistorySize),
+			trieDB.rootID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// mark that the db has not yet been cleanly closed
 	err = trieDB.baseDB.Put(cleanShutdownKey, didNotHaveCleanShutdown)
 	return trieDB, err
@@ -697,7 +727,7 @@ func (db *merkleDB) GetRangeProofAtRoot(
 		return nil, ErrEmptyProof
 	}
 
-	historicalTrie, err := db.getTrieAtRootForRange(rootID, start, end)
+	historicalTrie, err := db.getTrieAtRootForRange(ctx, rootID, start, end)
 	if err != nil {
 		return nil, err
 	}
@@ -732,6 +762,9 @@ func (db *merkleDB) GetChangeProof(
 	}
 
 	changes, err := db.history.getValueChanges(startRootID, endRootID, start, end, maxLength)
+	if errors.Is(err, ErrInsufficientHistory) && db.diskHistory != nil {
+		changes, err = db.diskHistory.getValueChanges(startRootID, endRootID, start, end, maxLength)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -763,7 +796,7 @@ func (db *merkleDB) GetChangeProof(
 
 	// Since we hold [db.commitlock] we must still have sufficient
 	// history to recreate the trie at [endRootID].
-	historicalTrie, err := db.getTrieAtRootForRange(endRootID, start, largestKey)
+	historicalTrie, err := db.getTrieAtRootForRange(ctx, endRootID, start, largestKey)
 	if err != nil {
 		return nil, err
 	}
@@ -988,9 +1021,23 @@ func (db *merkleDB) commitView(ctx context.Context, trieToCommit *view) error {
 		return err
 	}
 
+	// The disk history is written atomically with the value nodes so that it
+	// always ends with the root of the values on disk.
+	var diskHistoryMetadata historyMetadata
+	if db.diskHistory != nil {
+		var err error
+		diskHistoryMetadata, err = db.diskHistory.record(valueNodeBatch, changes)
+		if err != nil {
+			return err
+		}
+	}
+
 	if err := db.commitValueChanges(ctx, valueNodeBatch); err != nil {
 		return err
 	}
+	if db.diskHistory != nil {
+		db.diskHistory.historyMetadata = diskHistoryMetadata
+	}
 
 	db.history.record(changes)
 
@@ -1267,6 +1314,7 @@ func (db *merkleDB) initializeRoot() error {
 // If [end] is Nothing, there's no upper bound on the range.
 // Assumes [db.commitLock] is read locked.
 func (db *merkleDB) getTrieAtRootForRange(
+	ctx context.Context,
 	rootID ids.ID,
 	start maybe.Maybe[[]byte],
 	end maybe.Maybe[[]byte],
@@ -1277,12 +1325,42 @@ func (db *merkleDB) getTrieAtRootForRange(
 	}
 
 	changeHistory, err := db.history.getChangesToGetToRoot(rootID, start, end)
+	if errors.Is(err, ErrInsufficientHistory) && db.diskHistory != nil {
+		return db.getTrieAtRootFromDisk(ctx, rootID)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return newViewWithChanges(db, changeHistory)
 }
 
+// Returns a view of the trie as it was when it had root [rootID] by reverting
+// the value changes stored in [db.diskHistory].
+// Assumes [db.commitLock] is read locked.
+func (db *merkleDB) getTrieAtRootFromDisk(ctx context.Context, rootID ids.ID) (Trie, error) {
+	values, err := db.diskHistory.getValuesToGetToRoot(rootID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The view isn't tracked by [db.childViews] because [db.commitLock]
+	// prevents the trie from changing while the view is used.
+	historicalView, err := newView(db, db, ViewChanges{
+		MapOps:       values,
+		ConsumeBytes: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := historicalView.applyValueChanges(ctx); err != nil {
+		return nil, err
+	}
+	if historicalView.changes.rootID != rootID {
+		return nil, fmt.Errorf("%w: expected root %s but got %s", errHistoryRootMismatch, rootID, historicalView.changes.rootID)
+	}
+	return historicalView, nil
+}
+
 // Returns all keys in range [start, end] that aren't in [keySet].
 // If [start] is Nothing, then the range has no lower bound.
 // If [end] is Nothing, then the range has no upper bound.
@@ -1371,6 +1449,9 @@ func (db *merkleDB) Clear() error {
 		values: map[Key]*change[maybe.Maybe[[]byte]]{},
 		nodes:  map[Key]*change[*node]{},
 	})
+	if db.diskHistory != nil {
+		return db.diskHistory.clear(db.rootID)
+	}
 	return nil
 }
 
@@ -184,36 +184,62 @@ func (th *trieHistory) getValueChanges(
 		changes, _ := th.history.Index(i)
 
 		// Add the changes from this commit to [combinedChanges].
-		for key, valueChange := range changes.values {
-			// The key is outside the range [start, end].
-			if (startKey.HasValue() && key.Less(startKey.Value())) ||
-				(end.HasValue() && key.Greater(endKey.Value())) {
-				continue
-			}
+		addValueChanges(combinedChanges, changedKeys, changes.changeSummary, startKey, endKey)
+	}
 
-			// A change to this key already exists in [combinedChanges]
-			// so update its before value with the earlier before value
-			if existing, ok := combinedChanges.values[key]; ok {
-				existing.after = valueChange.after
-				if existing.before.HasValue() == existing.after.HasValue() &&
-					bytes.Equal(existing.before.Value(), existing.after.Value()) {
-					// The change to this key is a no-op, so remove it from [combinedChanges].
-					delete(combinedChanges.values, key)
-					changedKeys.Remove(key)
-				}
-			} else {
-				combinedChanges.values[key] = &change[maybe.Maybe[[]byte]]{
-					before: valueChange.before,
-					after:  valueChange.after,
-				}
-				changedKeys.Add(key)
+	keepSmallestValueChanges(combinedChanges, changedKeys, maxLength)
+	return combinedChanges, nil
+}
+
+// addValueChanges adds the value changes in [changes] to keys in
+// [startKey, endKey] to [combinedChanges]. [changes] must have occurred after
+// all the changes that were previously added to [combinedChanges].
+// If [startKey] is Nothing, there's no lower bound on the range.
+// If [endKey] is Nothing, there's no upper bound on the range.
+func addValueChanges(
+	combinedChanges *changeSummary,
+	changedKeys set.Set[Key],
+	changes *changeSummary,
+	startKey maybe.Maybe[Key],
+	endKey maybe.Maybe[Key],
+) {
+	for key, valueChange := range changes.values {
+		// The key is outside the range [start, end].
+		if (startKey.HasValue() && key.Less(startKey.Value())) ||
+			(endKey.HasValue() && key.Greater(endKey.Value())) {
+			continue
+		}
+
+		// A change to this key already exists in [combinedChanges]
+		// so update its before value with the earlier before value
+		if existing, ok := combinedChanges.values[key]; ok {
+			existing.after = valueChange.after
+			if existing.before.HasValue() == existing.after.HasValue() &&
+				bytes.Equal(existing.before.Value(), existing.after.Value()) {
+				// The change to this key is a no-op, so remove it from [combinedChanges].
+				delete(combinedChanges.values, key)
+				changedKeys.Remove(key)
 			}
+		} else {
+			combinedChanges.values[key] = &change[maybe.Maybe[[]byte]]{
+				before: valueChange.before,
+				after:  valueChange.after,
+			}
+			changedKeys.Add(key)
 		}
 	}
+}
 
+// keepSmallestValueChanges removes all but the value changes of the smallest
+// [maxLength] keys from [combinedChanges].
+func keepSmallestValueChanges(
+	combinedChanges *changeSummary,
+	changedKeys set.Set[Key],
+	maxLength int,
+) {
 	// If we have <= [maxLength] elements, we're done.
 	if changedKeys.Len() <= maxLength {
-		return combinedChanges, nil
+		return
 	}
 
 	// Keep only the smallest [maxLength] items in [combinedChanges.values].
@@ -224,8 +250,6 @@ func (th *trieHistory) getValueChanges(
 		sortedChangedKeys = sortedChangedKeys[:len(sortedChangedKeys)-1]
 		delete(combinedChanges.values, greatestKey)
 	}
-
-	return combinedChanges, nil
 }
 
 // Returns the changes to go from the current trie state back to the requested [rootID]
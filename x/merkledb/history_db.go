@@ -0,0 +1,439 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/exp/maps"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+	"github.com/MetalBlockchain/metalgo/utils/set"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const historyMetadataLen = 3 * wrappers.LongLen
+
+var (
+	historyChangePrefix = []byte(string(historyPrefix) + "c")
+	historyRootPrefix   = []byte(string(historyPrefix) + "r")
+
+	errInvalidHistoryMetadata = errors.New("invalid history metadata")
+)
+
+// historyDB stores the value changes of previous trie states on disk.
+//
+// Unlike [trieHistory], which stores node changes, only value changes are
+// persisted. Tries at previous roots are re-created by applying the reverse
+// value changes to the current trie.
+//
+// Each change is tagged with a monotonic increasing insert number. The
+// following keys are stored:
+//
+//   - [historyChangePrefix] + insert number --> root ID + value changes
+//   - [historyRootPrefix] + root ID --> latest insert number resulting in root
+//   - [historyMetadataKey] --> oldest insert number + next insert number + size
+type historyDB struct {
+	baseDB database.Database
+
+	// Maximum number of changes to store.
+	maxLength uint64
+	// Maximum number of bytes of changes to store. If 0, there is no limit.
+	maxSize uint64
+
+	// Metadata of the changes on disk.
+	historyMetadata
+}
+
+// historyMetadata describes the changes stored by a [historyDB].
+type historyMetadata struct {
+	// Insert number of the oldest change.
+	oldest uint64
+	// Insert number that will be assigned to the next change.
+	next uint64
+	// Total number of bytes of the changes.
+	size uint64
+}
+
+func (m historyMetadata) bytes() []byte {
+	metadata := make([]byte, historyMetadataLen)
+	binary.BigEndian.PutUint64(metadata, m.oldest)
+	binary.BigEndian.PutUint64(metadata[wrappers.LongLen:], m.next)
+	binary.BigEndian.PutUint64(metadata[2*wrappers.LongLen:], m.size)
+	return metadata
+}
+
+// newHistoryDB returns the history stored in [baseDB]. If the stored history
+// doesn't end with [rootID], it is cleared and [rootID] is recorded as the
+// only root in the history.
+func newHistoryDB(
+	baseDB database.Database,
+	maxLength uint64,
+	maxSize uint64,
+	rootID ids.ID,
+) (*historyDB, error) {
+	h := &historyDB{
+		baseDB:    baseDB,
+		maxLength: maxLength,
+		maxSize:   maxSize,
+	}
+
+	metadata, err := baseDB.Get(historyMetadataKey)
+	switch err {
+	case nil:
+		if len(metadata) != historyMetadataLen {
+			return nil, fmt.Errorf("%w: length %d", errInvalidHistoryMetadata, len(metadata))
+		}
+		h.oldest = binary.BigEndian.Uint64(metadata)
+		h.next = binary.BigEndian.Uint64(metadata[wrappers.LongLen:])
+		h.size = binary.BigEndian.Uint64(metadata[2*wrappers.LongLen:])
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+
+	if h.next > h.oldest {
+		lastRootID, err := h.getRootID(h.next - 1)
+		if err != nil {
+			return nil, err
+		}
+		if lastRootID == rootID {
+			return h, nil
+		}
+	}
+
+	// The history doesn't end with the current root, for example because it
+	// was disabled while the trie was modified, so it can't be used.
+	return h, h.clear(rootID)
+}
+
+// clear deletes all changes and records [rootID] as the only root in the
+// history.
+func (h *historyDB) clear(rootID ids.ID) error {
+	if err := database.AtomicClearPrefix(h.baseDB, h.baseDB, historyPrefix); err != nil {
+		return err
+	}
+
+	// The changes were deleted from disk.
+	h.oldest = h.next
+	h.size = 0
+
+	batch := h.baseDB.NewBatch()
+	metadata, err := h.record(batch, &changeSummary{
+		rootID: rootID,
+		values: map[Key]*change[maybe.Maybe[[]byte]]{},
+	})
+	if err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	h.historyMetadata = metadata
+	return nil
+}
+
+// record writes the value changes in [changes] into [batch]. Once the history
+// exceeds its limits, the oldest changes are pruned.
+//
+// The metadata of the history after [batch] is written is returned. It must
+// only be assigned to [h.historyMetadata] once [batch] has been written.
+func (h *historyDB) record(batch database.KeyValueWriterDeleter, changes *changeSummary) (historyMetadata, error) {
+	metadata := h.historyMetadata
+	insertNumber := metadata.next
+	changeBytes := encodeHistoryChange(changes)
+	if err := batch.Put(historyChangeKey(insertNumber), changeBytes); err != nil {
+		return historyMetadata{}, err
+	}
+	if err := database.PutUInt64(batch, historyRootKey(changes.rootID), insertNumber); err != nil {
+		return historyMetadata{}, err
+	}
+	metadata.next++
+	metadata.size += uint64(len(changeBytes))
+
+	// Always keep the change that was just recorded.
+	for numChanges := metadata.next - metadata.oldest; numChanges > 1; numChanges-- {
+		if numChanges <= h.maxLength && (h.maxSize == 0 || metadata.size <= h.maxSize) {
+			break
+		}
+		if err := h.pruneOldest(batch, &metadata, changes.rootID); err != nil {
+			return historyMetadata{}, err
+		}
+	}
+	return metadata, batch.Put(historyMetadataKey, metadata.bytes())
+}
+
+// pruneOldest deletes the oldest change of [metadata] and updates [metadata]
+// accordingly. [newRootID] is the root of the change that is currently being
+// recorded.
+//
+// Assumes the oldest change has been written to disk.
+func (h *historyDB) pruneOldest(batch database.KeyValueWriterDeleter, metadata *historyMetadata, newRootID ids.ID) error {
+	oldest := metadata.oldest
+	changeKey := historyChangeKey(oldest)
+	changeBytes, err := h.baseDB.Get(changeKey)
+	if err != nil {
+		return err
+	}
+	rootID, err := ids.ToID(changeBytes[:min(ids.IDLen, len(changeBytes))])
+	if err != nil {
+		return err
+	}
+	if err := batch.Delete(changeKey); err != nil {
+		return err
+	}
+
+	// Only remove the root index if this was the latest change resulting in
+	// the root. If the root is being re-recorded, the index was already
+	// updated in [batch].
+	if rootID != newRootID {
+		rootKey := historyRootKey(rootID)
+		latestInsertNumber, err := database.GetUInt64(h.baseDB, rootKey)
+		if err != nil {
+			return err
+		}
+		if latestInsertNumber == oldest {
+			if err := batch.Delete(rootKey); err != nil {
+				return err
+			}
+		}
+	}
+
+	metadata.oldest++
+	metadata.size -= uint64(len(changeBytes))
+	return nil
+}
+
+// getValueChanges has the same semantics as [trieHistory.getValueChanges].
+func (h *historyDB) getValueChanges(
+	startRoot ids.ID,
+	endRoot ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	maxLength int,
+) (*changeSummary, error) {
+	if maxLength <= 0 {
+		return nil, fmt.Errorf("%w but was %d", ErrInvalidMaxLength, maxLength)
+	}
+
+	if startRoot == endRoot {
+		return newChangeSummary(maxLength), nil
+	}
+
+	endInsertNumber, err := h.getInsertNumber(endRoot)
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNoEndRoot, endRoot)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	startInsertNumber, err := h.getInsertNumber(startRoot)
+	if err == database.ErrNotFound {
+		return nil, fmt.Errorf("%w: start root %s not found", ErrInsufficientHistory, startRoot)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if startInsertNumber > endInsertNumber {
+		// The latest change resulting in [startRoot] happened after
+		// [endRoot]. Attempt to find a change resulting in [startRoot] before
+		// [endRoot].
+		found := false
+		for insertNumber := endInsertNumber; insertNumber > h.oldest; insertNumber-- {
+			rootID, err := h.getRootID(insertNumber - 1)
+			if err != nil {
+				return nil, err
+			}
+			if rootID == startRoot {
+				startInsertNumber = insertNumber - 1
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf(
+				"%w: start root %s not found before end root %s",
+				ErrInsufficientHistory, startRoot, endRoot,
+			)
+		}
+	}
+
+	var (
+		changedKeys     = set.Set[Key]{}
+		startKey        = maybe.Bind(start, ToKey)
+		endKey          = maybe.Bind(end, ToKey)
+		combinedChanges = newChangeSummary(maxLength)
+	)
+	err = h.iterateChanges(startInsertNumber+1, endInsertNumber, func(changes *changeSummary) error {
+		addValueChanges(combinedChanges, changedKeys, changes, startKey, endKey)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keepSmallestValueChanges(combinedChanges, changedKeys, maxLength)
+	return combinedChanges, nil
+}
+
+// getValuesToGetToRoot returns the values to apply to the current trie to
+// get back to the requested [rootID].
+//
+// Returns [ErrInsufficientHistory] if [rootID] isn't in the history.
+func (h *historyDB) getValuesToGetToRoot(rootID ids.ID) (map[string]maybe.Maybe[[]byte], error) {
+	insertNumber, err := h.getInsertNumber(rootID)
+	if err == database.ErrNotFound {
+		return nil, ErrInsufficientHistory
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The value of a key at [rootID] is the before value of the first change
+	// to the key after [rootID].
+	values := make(map[string]maybe.Maybe[[]byte])
+	err = h.iterateChanges(insertNumber+1, h.next-1, func(changes *changeSummary) error {
+		for key, valueChange := range changes.values {
+			if _, ok := values[key.value]; !ok {
+				values[key.value] = valueChange.before
+			}
+		}
+		return nil
+	})
+	return values, err
+}
+
+// iterateChanges calls [f] with each change in [start, end] in order of
+// increasing insert number.
+func (h *historyDB) iterateChanges(start uint64, end uint64, f func(*changeSummary) error) error {
+	if start > end {
+		return nil
+	}
+
+	it := h.baseDB.NewIteratorWithStartAndPrefix(historyChangeKey(start), historyChangePrefix)
+	defer it.Release()
+
+	for insertNumber := start; insertNumber <= end; insertNumber++ {
+		if !it.Next() {
+			if err := it.Error(); err != nil {
+				return err
+			}
+			return fmt.Errorf("%w: change %d not found", ErrInsufficientHistory, insertNumber)
+		}
+
+		changes, err := decodeHistoryChange(it.Value())
+		if err != nil {
+			return err
+		}
+		if err := f(changes); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// getInsertNumber returns the insert number of the latest change resulting in
+// [rootID].
+func (h *historyDB) getInsertNumber(rootID ids.ID) (uint64, error) {
+	return database.GetUInt64(h.baseDB, historyRootKey(rootID))
+}
+
+// getRootID returns the root ID resulting from the change with
+// [insertNumber].
+func (h *historyDB) getRootID(insertNumber uint64) (ids.ID, error) {
+	changeBytes, err := h.baseDB.Get(historyChangeKey(insertNumber))
+	if err != nil {
+		return ids.Empty, err
+	}
+	return ids.ToID(changeBytes[:min(ids.IDLen, len(changeBytes))])
+}
+
+func historyChangeKey(insertNumber uint64) []byte {
+	key := make([]byte, len(historyChangePrefix)+wrappers.LongLen)
+	copy(key, historyChangePrefix)
+	binary.BigEndian.PutUint64(key[len(historyChangePrefix):], insertNumber)
+	return key
+}
+
+func historyRootKey(rootID ids.ID) []byte {
+	key := make([]byte, len(historyRootPrefix)+ids.IDLen)
+	copy(key, historyRootPrefix)
+	copy(key[len(historyRootPrefix):], rootID[:])
+	return key
+}
+
+// encodeHistoryChange encodes the root ID and value changes of [changes].
+func encodeHistoryChange(changes *changeSummary) []byte {
+	keys := maps.Keys(changes.values)
+	utils.Sort(keys)
+
+	w := codecWriter{
+		b: make([]byte, 0, ids.IDLen+uintSize(uint64(len(keys)))),
+	}
+	w.ID(changes.rootID)
+	w.Uvarint(uint64(len(keys)))
+	for _, key := range keys {
+		valueChange := changes.values[key]
+		w.Key(key)
+		w.MaybeBytes(valueChange.before)
+		w.MaybeBytes(valueChange.after)
+	}
+	return w.b
+}
+
+func decodeHistoryChange(b []byte) (*changeSummary, error) {
+	r := codecReader{
+		b:    b,
+		copy: true,
+	}
+
+	rootID, err := r.ID()
+	if err != nil {
+		return nil, err
+	}
+	numChanges, err := r.Uvarint()
+	if err != nil {
+		return nil, err
+	}
+	// Each change is encoded with at least 3 bytes, which bounds the
+	// allocation below.
+	if numChanges > uint64(len(r.b)) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	changes := &changeSummary{
+		rootID: rootID,
+		values: make(map[Key]*change[maybe.Maybe[[]byte]], numChanges),
+	}
+	for i := uint64(0); i < numChanges; i++ {
+		key, err := r.Key()
+		if err != nil {
+			return nil, err
+		}
+		before, err := r.MaybeBytes()
+		if err != nil {
+			return nil, err
+		}
+		after, err := r.MaybeBytes()
+		if err != nil {
+			return nil, err
+		}
+		changes.values[key] = &change[maybe.Maybe[[]byte]]{
+			before: before,
+			after:  after,
+		}
+	}
+	if len(r.b) != 0 {
+		return nil, errExtraSpace
+	}
+	return changes, nil
+}
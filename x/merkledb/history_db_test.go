@@ -0,0 +1,248 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+)
+
+func TestHistoryDBEncoding(t *testing.T) {
+	require := require.New(t)
+
+	changes := &changeSummary{
+		rootID: ids.GenerateTestID(),
+		values: map[Key]*change[maybe.Maybe[[]byte]]{
+			ToKey([]byte{}): {
+				before: maybe.Nothing[[]byte](),
+				after:  maybe.Some([]byte{}),
+			},
+			ToKey([]byte("key1")): {
+				before: maybe.Some([]byte("before")),
+				after:  maybe.Nothing[[]byte](),
+			},
+			ToKey([]byte("key2")): {
+				before: maybe.Some([]byte("before")),
+				after:  maybe.Some([]byte("after")),
+			},
+		},
+	}
+
+	changeBytes := encodeHistoryChange(changes)
+	parsedChanges, err := decodeHistoryChange(changeBytes)
+	require.NoError(err)
+	require.Equal(changes, parsedChanges)
+
+	_, err = decodeHistoryChange(changeBytes[:len(changeBytes)-1])
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	_, err = decodeHistoryChange(append(changeBytes, 0))
+	require.ErrorIs(err, errExtraSpace)
+}
+
+// Test that change proofs and range proofs generated from the disk history
+// are identical to the proofs generated from the in-memory history, including
+// after restarts.
+func TestHistoryDBProofs(t *testing.T) {
+	require := require.New(t)
+
+	const numIters = 100
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	memoryConfig := newDefaultConfig()
+	memoryConfig.HistoryLength = numIters + 1
+	memoryDB, err := newDB(context.Background(), memdb.New(), memoryConfig)
+	require.NoError(err)
+
+	diskConfig := newDefaultConfig()
+	diskConfig.HistoryLength = 1
+	diskConfig.DiskHistoryLength = numIters + 1
+	baseDB := memdb.New()
+	diskDB, err := newDB(context.Background(), baseDB, diskConfig)
+	require.NoError(err)
+
+	roots := []ids.ID{memoryDB.getMerkleRoot()}
+	for i := 0; i < numIters; i++ {
+		ops := make([]database.BatchOp, 0, 3)
+		for j := 0; j < 3; j++ {
+			key := make([]byte, r.Intn(3)+1)
+			_, _ = r.Read(key)
+			value := make([]byte, r.Intn(4))
+			_, _ = r.Read(value)
+			ops = append(ops, database.BatchOp{
+				Key:    key,
+				Value:  value,
+				Delete: r.Intn(4) == 0,
+			})
+		}
+
+		for _, db := range []*merkleDB{memoryDB, diskDB} {
+			view, err := db.NewView(context.Background(), ViewChanges{BatchOps: ops})
+			require.NoError(err)
+			require.NoError(view.CommitToDB(context.Background()))
+		}
+		require.Equal(memoryDB.getMerkleRoot(), diskDB.getMerkleRoot())
+		roots = append(roots, memoryDB.getMerkleRoot())
+
+		// Periodically restart the disk backed database.
+		if i%10 == 0 {
+			require.NoError(diskDB.Close())
+			diskConfig.Reg = prometheus.NewRegistry()
+			diskDB, err = newDB(context.Background(), baseDB, diskConfig)
+			require.NoError(err)
+		}
+	}
+
+	for i := 0; i < 50; i++ {
+		var (
+			startRoot = roots[r.Intn(len(roots))]
+			endRoot   = roots[r.Intn(len(roots))]
+			start     = maybe.Nothing[[]byte]()
+			end       = maybe.Nothing[[]byte]()
+			maxLength = r.Intn(10) + 1
+		)
+		if r.Intn(2) == 0 {
+			start = maybe.Some([]byte{byte(r.Intn(256))})
+		}
+		if r.Intn(2) == 0 {
+			end = maybe.Some([]byte{byte(r.Intn(256))})
+		}
+
+		expectedRangeProof, expectedErr := memoryDB.GetRangeProofAtRoot(context.Background(), endRoot, start, end, maxLength)
+		rangeProof, err := diskDB.GetRangeProofAtRoot(context.Background(), endRoot, start, end, maxLength)
+		require.Equal(expectedErr, err)
+		require.Equal(expectedRangeProof, rangeProof)
+
+		expectedChangeProof, expectedErr := memoryDB.GetChangeProof(context.Background(), startRoot, endRoot, start, end, maxLength)
+		changeProof, err := diskDB.GetChangeProof(context.Background(), startRoot, endRoot, start, end, maxLength)
+		require.Equal(expectedErr, err)
+		require.Equal(expectedChangeProof, changeProof)
+	}
+}
+
+func TestHistoryDBPruning(t *testing.T) {
+	require := require.New(t)
+
+	config := newDefaultConfig()
+	config.HistoryLength = 1
+	config.DiskHistoryLength = 3
+	baseDB := memdb.New()
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	roots := []ids.ID{db.getMerkleRoot()}
+	for i := 0; i < 5; i++ {
+		require.NoError(db.Put([]byte{byte(i)}, []byte{byte(i)}))
+		roots = append(roots, db.getMerkleRoot())
+	}
+
+	// Only the last [config.DiskHistoryLength] roots are retained.
+	_, err = db.GetChangeProof(context.Background(), roots[2], roots[5], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+	_, err = db.GetRangeProofAtRoot(context.Background(), roots[2], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	changeProof, err := db.GetChangeProof(context.Background(), roots[3], roots[5], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+	require.Len(changeProof.KeyChanges, 2)
+
+	for _, root := range roots[:3] {
+		_, err := baseDB.Get(historyRootKey(root))
+		require.ErrorIs(err, database.ErrNotFound)
+	}
+	require.Equal(uint64(3), db.diskHistory.oldest)
+	require.Equal(uint64(6), db.diskHistory.next)
+
+	// Limiting the size of the history prunes more changes.
+	require.NoError(db.Close())
+	config.DiskHistorySize = uint(db.diskHistory.size - 1)
+	config.Reg = prometheus.NewRegistry()
+	db, err = newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	require.NoError(db.Put([]byte{5}, []byte{5}))
+	roots = append(roots, db.getMerkleRoot())
+
+	_, err = db.GetChangeProof(context.Background(), roots[4], roots[6], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	_, err = db.GetChangeProof(context.Background(), roots[5], roots[6], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.NoError(err)
+
+	// Clearing the database clears the history.
+	require.NoError(db.Clear())
+	_, err = db.GetRangeProofAtRoot(context.Background(), roots[5], maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+	require.Equal(db.diskHistory.oldest+1, db.diskHistory.next)
+}
+
+var errTestWriteFailed = errors.New("write failed")
+
+// failingBatchDB is a database whose batches fail to be written while [fail]
+// is set.
+type failingBatchDB struct {
+	database.Database
+	fail bool
+}
+
+func (db *failingBatchDB) NewBatch() database.Batch {
+	return &failingBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+type failingBatch struct {
+	database.Batch
+	db *failingBatchDB
+}
+
+func (b *failingBatch) Write() error {
+	if b.db.fail {
+		return errTestWriteFailed
+	}
+	return b.Batch.Write()
+}
+
+func TestHistoryDBFailedWrite(t *testing.T) {
+	require := require.New(t)
+
+	config := newDefaultConfig()
+	config.DiskHistoryLength = 2
+	baseDB := &failingBatchDB{
+		Database: memdb.New(),
+	}
+	db, err := newDB(context.Background(), baseDB, config)
+	require.NoError(err)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(db.Put([]byte{byte(i)}, []byte{byte(i)}))
+	}
+	expectedMetadata := db.diskHistory.historyMetadata
+
+	// If the changes fail to be written, the history isn't modified.
+	baseDB.fail = true
+	err = db.Put([]byte{3}, []byte{3})
+	require.ErrorIs(err, errTestWriteFailed)
+	require.Equal(expectedMetadata, db.diskHistory.historyMetadata)
+
+	onDiskMetadata, err := baseDB.Get(historyMetadataKey)
+	require.NoError(err)
+	require.Equal(expectedMetadata.bytes(), onDiskMetadata)
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+)
+
+const checkpointVersion byte = 0
+
+var (
+	checkpointKey = []byte("checkpoint")
+
+	errUnknownCheckpointVersion = errors.New("unknown checkpoint version")
+	errOverlappingCheckpoint    = errors.New("checkpoint contains overlapping ranges")
+)
+
+// checkpoint is the persisted progress of a sync.
+//
+// Every key range that isn't covered by [ranges] is assumed to not have been
+// synced yet.
+type checkpoint struct {
+	// The target root when the checkpoint was written.
+	targetRoot ids.ID
+	// Key ranges that have been synced to their [workItem.localRootID].
+	// Sorted by increasing start key and non-overlapping.
+	ranges []*workItem
+}
+
+// writeCheckpoint persists all the key ranges that have been synced to some
+// root. Ranges that are currently being processed are not included.
+//
+// Assumes [m.syncTargetLock] and [m.workLock] are held.
+func (m *Manager) writeCheckpoint() error {
+	ranges := m.processedWork.Items()
+	for _, item := range m.unprocessedWork.Items() {
+		// Items with an empty local root haven't been synced at all.
+		if item.localRootID != ids.Empty {
+			ranges = append(ranges, item)
+		}
+	}
+	slices.SortFunc(ranges, func(a, b *workItem) int {
+		return compareStart(a.start, b.start)
+	})
+
+	checkpointBytes, err := encodeCheckpoint(&checkpoint{
+		targetRoot: m.config.TargetRoot,
+		ranges:     ranges,
+	})
+	if err != nil {
+		return err
+	}
+	return m.config.CheckpointDB.Put(checkpointKey, checkpointBytes)
+}
+
+// getCheckpoint returns the persisted progress of a previous sync. If there
+// is no checkpoint, database.ErrNotFound is returned.
+func (m *Manager) getCheckpoint() (*checkpoint, error) {
+	checkpointBytes, err := m.config.CheckpointDB.Get(checkpointKey)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCheckpoint(checkpointBytes)
+}
+
+// resumeWork populates the work heaps from [c].
+//
+// Ranges synced to the current target root are marked as processed. Ranges
+// synced to a different root are queued to fetch change proofs. All other
+// ranges are queued to fetch range proofs.
+//
+// Assumes [m.workLock] is held.
+func (m *Manager) resumeWork(c *checkpoint) {
+	now := time.Now()
+	previousEnd := maybe.Nothing[[]byte]()
+	isFirst := true
+	for _, item := range c.ranges {
+		// Fill the gap between the previous range and this range.
+		if (isFirst && item.start.HasValue()) ||
+			(!isFirst && !maybe.Equal(previousEnd, item.start, bytes.Equal)) {
+			m.unprocessedWork.Insert(newWorkItem(ids.Empty, previousEnd, item.start, lowPriority, now))
+		}
+		isFirst = false
+		previousEnd = item.end
+
+		if item.localRootID == m.config.TargetRoot {
+			m.processedWork.MergeInsert(newWorkItem(item.localRootID, item.start, item.end, lowPriority, now))
+		} else {
+			m.unprocessedWork.Insert(newWorkItem(item.localRootID, item.start, item.end, highPriority, now))
+		}
+	}
+
+	// Fill the gap after the last range.
+	if isFirst || previousEnd.HasValue() {
+		m.unprocessedWork.Insert(newWorkItem(ids.Empty, previousEnd, maybe.Nothing[[]byte](), lowPriority, now))
+	}
+}
+
+func encodeCheckpoint(c *checkpoint) ([]byte, error) {
+	p := wrappers.Packer{
+		MaxSize: math.MaxInt32,
+	}
+	p.PackByte(checkpointVersion)
+	p.PackFixedBytes(c.targetRoot[:])
+	p.PackInt(uint32(len(c.ranges)))
+	for _, item := range c.ranges {
+		p.PackFixedBytes(item.localRootID[:])
+		packMaybeBytes(&p, item.start)
+		packMaybeBytes(&p, item.end)
+	}
+	return p.Bytes, p.Err
+}
+
+func decodeCheckpoint(b []byte) (*checkpoint, error) {
+	p := wrappers.Packer{
+		Bytes: b,
+	}
+	if version := p.UnpackByte(); p.Err == nil && version != checkpointVersion {
+		return nil, fmt.Errorf("%w: %d", errUnknownCheckpointVersion, version)
+	}
+
+	c := &checkpoint{}
+	copy(c.targetRoot[:], p.UnpackFixedBytes(ids.IDLen))
+	numRanges := p.UnpackInt()
+	for i := uint32(0); i < numRanges && p.Err == nil; i++ {
+		item := &workItem{}
+		copy(item.localRootID[:], p.UnpackFixedBytes(ids.IDLen))
+		item.start = unpackMaybeBytes(&p)
+		item.end = unpackMaybeBytes(&p)
+		c.ranges = append(c.ranges, item)
+	}
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	if p.Offset != len(b) {
+		return nil, wrappers.ErrInsufficientLength
+	}
+
+	// Ranges must be sorted and must not overlap.
+	for i := 1; i < len(c.ranges); i++ {
+		previous := c.ranges[i-1]
+		current := c.ranges[i]
+		if previous.end.IsNothing() || current.start.IsNothing() ||
+			bytes.Compare(previous.end.Value(), current.start.Value()) > 0 {
+			return nil, errOverlappingCheckpoint
+		}
+	}
+	return c, nil
+}
+
+func packMaybeBytes(p *wrappers.Packer, value maybe.Maybe[[]byte]) {
+	p.PackBool(value.HasValue())
+	if value.HasValue() {
+		p.PackBytes(value.Value())
+	}
+}
+
+func unpackMaybeBytes(p *wrappers.Packer) maybe.Maybe[[]byte] {
+	if !p.UnpackBool() {
+		return maybe.Nothing[[]byte]()
+	}
+	return maybe.Some(p.UnpackBytes())
+}
+
+// compareStart compares two range starts, where Nothing is considered to be
+// the smallest start.
+func compareStart(a, b maybe.Maybe[[]byte]) int {
+	switch {
+	case a.IsNothing() && b.IsNothing():
+		return 0
+	case a.IsNothing():
+		return -1
+	case b.IsNothing():
+		return 1
+	default:
+		return bytes.Compare(a.Value(), b.Value())
+	}
+}
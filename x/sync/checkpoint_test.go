@@ -0,0 +1,216 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MetalBlockchain/metalgo/database"
+	"github.com/MetalBlockchain/metalgo/database/memdb"
+	"github.com/MetalBlockchain/metalgo/ids"
+	"github.com/MetalBlockchain/metalgo/network/p2p/p2ptest"
+	"github.com/MetalBlockchain/metalgo/utils/logging"
+	"github.com/MetalBlockchain/metalgo/utils/maybe"
+	"github.com/MetalBlockchain/metalgo/utils/wrappers"
+	"github.com/MetalBlockchain/metalgo/x/merkledb"
+)
+
+func TestCheckpointEncoding(t *testing.T) {
+	require := require.New(t)
+
+	c := &checkpoint{
+		targetRoot: ids.GenerateTestID(),
+		ranges: []*workItem{
+			{
+				localRootID: ids.GenerateTestID(),
+				start:       maybe.Nothing[[]byte](),
+				end:         maybe.Some([]byte{1}),
+			},
+			{
+				localRootID: ids.GenerateTestID(),
+				start:       maybe.Some([]byte{1}),
+				end:         maybe.Some([]byte{2}),
+			},
+			{
+				localRootID: ids.GenerateTestID(),
+				start:       maybe.Some([]byte{3}),
+				end:         maybe.Nothing[[]byte](),
+			},
+		},
+	}
+
+	checkpointBytes, err := encodeCheckpoint(c)
+	require.NoError(err)
+	parsedCheckpoint, err := decodeCheckpoint(checkpointBytes)
+	require.NoError(err)
+	require.Equal(c, parsedCheckpoint)
+
+	_, err = decodeCheckpoint(checkpointBytes[:len(checkpointBytes)-1])
+	require.ErrorIs(err, wrappers.ErrInsufficientLength)
+
+	checkpointBytes[0] = checkpointVersion + 1
+	_, err = decodeCheckpoint(checkpointBytes)
+	require.ErrorIs(err, errUnknownCheckpointVersion)
+
+	c.ranges[1].start = maybe.Some([]byte{0})
+	checkpointBytes, err = encodeCheckpoint(c)
+	require.NoError(err)
+	_, err = decodeCheckpoint(checkpointBytes)
+	require.ErrorIs(err, errOverlappingCheckpoint)
+}
+
+func TestResumeWork(t *testing.T) {
+	var (
+		targetRoot = ids.GenerateTestID()
+		staleRoot  = ids.GenerateTestID()
+	)
+
+	type expectedItem struct {
+		rootID ids.ID
+		start  maybe.Maybe[[]byte]
+		end    maybe.Maybe[[]byte]
+	}
+	tests := []struct {
+		name                string
+		ranges              []*workItem
+		expectedProcessed   []expectedItem
+		expectedUnprocessed []expectedItem
+	}{
+		{
+			name: "empty checkpoint",
+			expectedUnprocessed: []expectedItem{
+				{ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte]()},
+			},
+		},
+		{
+			name: "gaps",
+			ranges: []*workItem{
+				{localRootID: targetRoot, start: maybe.Some([]byte{1}), end: maybe.Some([]byte{2})},
+				{localRootID: staleRoot, start: maybe.Some([]byte{3}), end: maybe.Some([]byte{4})},
+			},
+			expectedProcessed: []expectedItem{
+				{targetRoot, maybe.Some([]byte{1}), maybe.Some([]byte{2})},
+			},
+			expectedUnprocessed: []expectedItem{
+				{ids.Empty, maybe.Nothing[[]byte](), maybe.Some([]byte{1})},
+				{ids.Empty, maybe.Some([]byte{2}), maybe.Some([]byte{3})},
+				{staleRoot, maybe.Some([]byte{3}), maybe.Some([]byte{4})},
+				{ids.Empty, maybe.Some([]byte{4}), maybe.Nothing[[]byte]()},
+			},
+		},
+		{
+			name: "no gaps",
+			ranges: []*workItem{
+				{localRootID: targetRoot, start: maybe.Nothing[[]byte](), end: maybe.Some([]byte{1})},
+				{localRootID: targetRoot, start: maybe.Some([]byte{1}), end: maybe.Nothing[[]byte]()},
+			},
+			expectedProcessed: []expectedItem{
+				{targetRoot, maybe.Nothing[[]byte](), maybe.Nothing[[]byte]()},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			m := &Manager{
+				config: ManagerConfig{
+					TargetRoot: targetRoot,
+				},
+				unprocessedWork: newWorkHeap(),
+				processedWork:   newWorkHeap(),
+			}
+			m.resumeWork(&checkpoint{
+				targetRoot: staleRoot,
+				ranges:     tt.ranges,
+			})
+
+			requireItems := func(expected []expectedItem, items []*workItem) {
+				require.Len(items, len(expected))
+				for i, item := range items {
+					require.Equal(expected[i].rootID, item.localRootID)
+					require.Equal(expected[i].start, item.start)
+					require.Equal(expected[i].end, item.end)
+				}
+			}
+			requireItems(tt.expectedProcessed, m.processedWork.Items())
+			requireItems(tt.expectedUnprocessed, m.unprocessedWork.Items())
+		})
+	}
+}
+
+func TestSyncResumeFromCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+	dbToSync, err := generateTrie(t, r, 3*maxKeyValuesLimit)
+	require.NoError(err)
+	syncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+	checkpointDB := memdb.New()
+
+	ctx := context.Background()
+	newSyncer := func() *Manager {
+		syncer, err := NewManager(ManagerConfig{
+			DB:                    db,
+			RangeProofClient:      p2ptest.NewClient(t, ctx, NewGetRangeProofHandler(logging.NoLog{}, dbToSync), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()),
+			ChangeProofClient:     p2ptest.NewClient(t, ctx, NewGetChangeProofHandler(logging.NoLog{}, dbToSync), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()),
+			TargetRoot:            syncRoot,
+			SimultaneousWorkLimit: 5,
+			Log:                   logging.NoLog{},
+			BranchFactor:          merkledb.BranchFactor16,
+			CheckpointDB:          checkpointDB,
+		}, prometheus.NewRegistry())
+		require.NoError(err)
+		return syncer
+	}
+
+	syncer := newSyncer()
+	require.NoError(syncer.Start(context.Background()))
+
+	// Wait until some progress has been checkpointed before stopping the
+	// sync.
+	require.Eventually(
+		func() bool {
+			has, err := checkpointDB.Has(checkpointKey)
+			require.NoError(err)
+			return has
+		},
+		5*time.Second,
+		5*time.Millisecond,
+	)
+	syncer.Close()
+
+	// The checkpoint is retained after closing the syncer.
+	has, err := checkpointDB.Has(checkpointKey)
+	require.NoError(err)
+	require.True(has)
+
+	syncer = newSyncer()
+	require.NoError(syncer.Start(context.Background()))
+	require.NoError(syncer.Wait(context.Background()))
+
+	newRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(syncRoot, newRoot)
+
+	// The checkpoint is deleted once the sync completes.
+	_, err = checkpointDB.Get(checkpointKey)
+	require.ErrorIs(err, database.ErrNotFound)
+}
@@ -19,6 +19,7 @@ import (
 	"golang.org/x/exp/maps"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/MetalBlockchain/metalgo/database"
 	"github.com/MetalBlockchain/metalgo/ids"
 	"github.com/MetalBlockchain/metalgo/network/p2p"
 	"github.com/MetalBlockchain/metalgo/utils/logging"
@@ -152,6 +153,13 @@ type ManagerConfig struct {
 	StateSyncNodes        []ids.NodeID
 	// If not specified, [merkledb.DefaultHasher] will be used.
 	Hasher merkledb.Hasher
+	// If specified, the key ranges that have been synced are persisted to
+	// [CheckpointDB] so that [Manager.Start] can resume a previous sync. The
+	// checkpoint is deleted once the sync completes.
+	//
+	// [CheckpointDB] must only contain checkpoints of syncs into [DB]. If [DB]
+	// is modified outside of the Manager, [CheckpointDB] must be cleared.
+	CheckpointDB database.Database
 }
 
 func NewManager(config ManagerConfig, registerer prometheus.Registerer) (*Manager, error) {
@@ -203,9 +211,9 @@ func (m *Manager) Start(ctx context.Context) error {
 
 	m.config.Log.Info("starting sync", zap.Stringer("target root", m.config.TargetRoot))
 
-	// Add work item to fetch the entire key range.
-	// Note that this will be the first work item to be processed.
-	m.unprocessedWork.Insert(newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority, time.Now()))
+	if err := m.addInitialWork(); err != nil {
+		return err
+	}
 
 	m.syncing = true
 	ctx, m.cancelCtx = context.WithCancel(ctx)
@@ -214,6 +222,33 @@ func (m *Manager) Start(ctx context.Context) error {
 	return nil
 }
 
+// addInitialWork adds the work needed to sync the entire key range. If there
+// is a checkpoint of a previous sync, the sync is resumed from it.
+//
+// Assumes [m.workLock] is held.
+func (m *Manager) addInitialWork() error {
+	if m.config.CheckpointDB != nil {
+		checkpoint, err := m.getCheckpoint()
+		switch err {
+		case nil:
+			m.config.Log.Info("resuming sync",
+				zap.Stringer("previous target root", checkpoint.targetRoot),
+				zap.Int("numSyncedRanges", len(checkpoint.ranges)),
+			)
+			m.resumeWork(checkpoint)
+			return nil
+		case database.ErrNotFound:
+		default:
+			return err
+		}
+	}
+
+	// Add work item to fetch the entire key range.
+	// Note that this will be the first work item to be processed.
+	m.unprocessedWork.Insert(newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority, time.Now()))
+	return nil
+}
+
 // sync awaits signal on [m.unprocessedWorkCond], which indicates that there
 // is work to do or syncing completes.  If there is work, sync will dispatch a goroutine to do
 // the work.
@@ -239,6 +274,11 @@ func (m *Manager) sync(ctx context.Context) {
 			if m.processingWorkItems == 0 {
 				// There's no work to do, and there are no work items being processed
 				// which could cause work to be added, so we're done.
+				if m.config.CheckpointDB != nil && m.Error() == nil {
+					if err := m.config.CheckpointDB.Delete(checkpointKey); err != nil {
+						m.setError(err)
+					}
+				}
 				return // [m.workLock] released by defer.
 			}
 			// There's no work to do.
@@ -283,11 +323,32 @@ func (m *Manager) close() {
 }
 
 func (m *Manager) finishWorkItem() {
+	// [m.syncTargetLock] is required to checkpoint the target root and must
+	// be grabbed before [m.workLock].
+	m.syncTargetLock.RLock()
+	defer m.syncTargetLock.RUnlock()
+
 	m.workLock.Lock()
 	defer m.workLock.Unlock()
 
 	m.processingWorkItems--
 	m.unprocessedWorkCond.Signal()
+
+	if m.config.CheckpointDB == nil {
+		return
+	}
+
+	select {
+	case <-m.doneChan:
+		// Once closed, the work heaps are no longer updated, so they must not
+		// overwrite the checkpoint.
+		return
+	default:
+	}
+
+	if err := m.writeCheckpoint(); err != nil {
+		m.setError(err)
+	}
 }
 
 // Processes [item] by fetching a change or range proof.
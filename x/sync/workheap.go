@@ -154,6 +154,16 @@ func (wh *workHeap) remove(item *workItem) {
 	wh.sortedItems.Delete(item)
 }
 
+// Returns the items in the heap sorted by range start.
+func (wh *workHeap) Items() []*workItem {
+	items := make([]*workItem, 0, wh.Len())
+	wh.sortedItems.Ascend(func(item *workItem) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
 func (wh *workHeap) Len() int {
 	return wh.innerHeap.Len()
 }